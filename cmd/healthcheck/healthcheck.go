@@ -3,6 +3,7 @@ package healthcheck
 import (
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"os"
 	"time"
@@ -11,25 +12,38 @@ import (
 	"github.com/traefik/traefik/v3/pkg/config/static"
 )
 
+// Configuration wraps the static configuration and the healthcheck command parameters.
+type Configuration struct {
+	static.Configuration `export:"true"`
+
+	// Readiness calls the readiness endpoint instead of the ping one.
+	Readiness bool `description:"Calls Traefik /ready endpoint to check the readiness of Traefik." export:"true"`
+}
+
 // NewCmd builds a new HealthCheck command.
 func NewCmd(traefikConfiguration *static.Configuration, loaders []cli.ResourceLoader) *cli.Command {
+	hcConfiguration := &Configuration{Configuration: *traefikConfiguration}
+
 	return &cli.Command{
 		Name:          "healthcheck",
-		Description:   `Calls Traefik /ping endpoint (disabled by default) to check the health of Traefik.`,
-		Configuration: traefikConfiguration,
-		Run:           runCmd(traefikConfiguration),
+		Description:   `Calls Traefik /ping endpoint (disabled by default) to check the health of Traefik, or its /ready endpoint to check its readiness.`,
+		Configuration: hcConfiguration,
+		Run:           runCmd(hcConfiguration),
 		Resources:     loaders,
 	}
 }
 
-func runCmd(traefikConfiguration *static.Configuration) func(_ []string) error {
+func runCmd(hcConfiguration *Configuration) func(_ []string) error {
 	return func(_ []string) error {
-		traefikConfiguration.SetEffectiveConfiguration()
+		hcConfiguration.SetEffectiveConfiguration()
 
-		resp, errPing := Do(*traefikConfiguration)
-		if resp != nil {
-			resp.Body.Close()
+		method, path := http.MethodHead, "/ping"
+		if hcConfiguration.Readiness {
+			// The readiness status is written in the body of the response.
+			method, path = http.MethodGet, "/ready"
 		}
+
+		resp, errPing := do(hcConfiguration.Configuration, method, path)
 		if errPing != nil {
 			fmt.Printf("Error calling healthcheck: %s\n", errPing)
 			os.Exit(1)
@@ -37,8 +51,16 @@ func runCmd(traefikConfiguration *static.Configuration) func(_ []string) error {
 
 		if resp.StatusCode != http.StatusOK {
 			fmt.Printf("Bad healthcheck status: %s\n", resp.Status)
+
+			// The readiness endpoint describes what Traefik is still waiting for.
+			if body, err := io.ReadAll(resp.Body); err == nil && len(body) > 0 {
+				fmt.Print(string(body))
+			}
+			resp.Body.Close()
 			os.Exit(1)
 		}
+		resp.Body.Close()
+
 		fmt.Printf("OK: %s\n", resp.Request.URL)
 		os.Exit(0)
 		return nil
@@ -47,6 +69,10 @@ func runCmd(traefikConfiguration *static.Configuration) func(_ []string) error {
 
 // Do try to do a healthcheck.
 func Do(staticConfiguration static.Configuration) (*http.Response, error) {
+	return do(staticConfiguration, http.MethodHead, "/ping")
+}
+
+func do(staticConfiguration static.Configuration, method, path string) (*http.Response, error) {
 	if staticConfiguration.Ping == nil {
 		return nil, errors.New("please enable `ping` to use health check")
 	}
@@ -78,7 +104,10 @@ func Do(staticConfiguration static.Configuration) (*http.Response, error) {
 	// 	client.Transport = tr
 	// }
 
-	path := "/"
+	req, err := http.NewRequest(method, protocol+"://"+pingEntryPoint.GetAddress()+path, http.NoBody)
+	if err != nil {
+		return nil, err
+	}
 
-	return client.Head(protocol + "://" + pingEntryPoint.GetAddress() + path + "ping")
+	return client.Do(req)
 }
@@ -23,7 +23,10 @@ func init() {
 	zerolog.SetGlobalLevel(zerolog.ErrorLevel)
 }
 
-func setupLogger(ctx context.Context, staticConfiguration *static.Configuration) error {
+// logBufferSize is the number of log entries, and of provider errors, kept in memory for the support dump.
+const logBufferSize = 1000
+
+func setupLogger(ctx context.Context, staticConfiguration *static.Configuration, logBuffer *logs.Buffer) error {
 	// Validate that the experimental flag is set up at this point,
 	// rather than validating the static configuration before the setupLogger call.
 	// This ensures that validation messages are not logged using an un-configured logger.
@@ -35,6 +38,9 @@ func setupLogger(ctx context.Context, staticConfiguration *static.Configuration)
 	// configure log format
 	w := getLogWriter(staticConfiguration)
 
+	// keep the last log entries in memory, before they are formatted, for the support dump.
+	w = zerolog.MultiLevelWriter(w, logBuffer)
+
 	// configure log level
 	logLevel := getLogLevel(staticConfiguration)
 	zerolog.SetGlobalLevel(logLevel)
@@ -10,6 +10,7 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"path/filepath"
 	"slices"
 	"strings"
 	"syscall"
@@ -25,11 +26,13 @@ import (
 	"github.com/traefik/traefik/v3/cmd"
 	"github.com/traefik/traefik/v3/cmd/healthcheck"
 	cmdVersion "github.com/traefik/traefik/v3/cmd/version"
+	"github.com/traefik/traefik/v3/pkg/api"
 	tcli "github.com/traefik/traefik/v3/pkg/cli"
 	"github.com/traefik/traefik/v3/pkg/collector"
 	"github.com/traefik/traefik/v3/pkg/config/dynamic"
 	"github.com/traefik/traefik/v3/pkg/config/runtime"
 	"github.com/traefik/traefik/v3/pkg/config/static"
+	"github.com/traefik/traefik/v3/pkg/geoip"
 	"github.com/traefik/traefik/v3/pkg/middlewares/accesslog"
 	"github.com/traefik/traefik/v3/pkg/observability/logs"
 	"github.com/traefik/traefik/v3/pkg/observability/metrics"
@@ -93,7 +96,9 @@ func runCmd(staticConfiguration *static.Configuration) error {
 	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
 	defer cancel()
 
-	if err := setupLogger(ctx, staticConfiguration); err != nil {
+	logBuffer := logs.NewBuffer(logBufferSize)
+
+	if err := setupLogger(ctx, staticConfiguration, logBuffer); err != nil {
 		return fmt.Errorf("setting up logger: %w", err)
 	}
 
@@ -123,7 +128,7 @@ func runCmd(staticConfiguration *static.Configuration) error {
 
 	stats(staticConfiguration)
 
-	svr, err := setupServer(staticConfiguration)
+	svr, err := setupServer(staticConfiguration, logBuffer)
 	if err != nil {
 		return err
 	}
@@ -171,18 +176,34 @@ func runCmd(staticConfiguration *static.Configuration) error {
 	return nil
 }
 
-func setupServer(staticConfiguration *static.Configuration) (*server.Server, error) {
+func setupServer(staticConfiguration *static.Configuration, logBuffer *logs.Buffer) (*server.Server, error) {
 	providerAggregator := aggregator.NewProviderAggregator(*staticConfiguration.Providers)
 
 	ctx := context.Background()
 	routinesPool := safe.NewPool(ctx)
 
+	if staticConfiguration.Ping != nil {
+		providerAggregator.SetReadiness(staticConfiguration.Ping.Readiness())
+	}
+
 	// adds internal provider
 	err := providerAggregator.AddProvider(traefik.New(*staticConfiguration))
 	if err != nil {
 		return nil, err
 	}
 
+	// GeoIP
+
+	var geoIPResolver *geoip.Resolver
+	if staticConfiguration.GeoIP != nil {
+		geoIPResolver, err = geoip.NewResolver(*staticConfiguration.GeoIP)
+		if err != nil {
+			return nil, fmt.Errorf("unable to create GeoIP resolver: %w", err)
+		}
+
+		routinesPool.GoCtx(geoIPResolver.Run)
+	}
+
 	// ACME
 
 	tlsManager := traefiktls.NewManager(staticConfiguration.OCSP)
@@ -213,9 +234,14 @@ func setupServer(staticConfiguration *static.Configuration) (*server.Server, err
 		}
 	}
 	metricsRegistry := metrics.NewMultiRegistry(metricRegistries)
-	accessLog := setupAccessLog(ctx, staticConfiguration.AccessLog)
+	tlsManager.SetRevocationChecksCounter(metricsRegistry.TLSClientCertRevocationChecksCounter())
+	if staticConfiguration.Providers.File != nil {
+		staticConfiguration.Providers.File.SetErrorsGauge(metricsRegistry.ConfigFileErrorsGauge())
+	}
+	accessLog := setupAccessLog(ctx, staticConfiguration.AccessLog, geoIPResolver)
 	tracer, tracerCloser := setupTracing(ctx, staticConfiguration.Tracing)
-	observabilityMgr := middleware.NewObservabilityMgr(*staticConfiguration, metricsRegistry, semConvMetricRegistry, accessLog, tracer, tracerCloser)
+	mirrorComparisonLog := setupMirrorComparisonLog(staticConfiguration.MirrorComparisonLog)
+	observabilityMgr := middleware.NewObservabilityMgr(*staticConfiguration, metricsRegistry, semConvMetricRegistry, accessLog, tracer, tracerCloser, mirrorComparisonLog)
 
 	// Entrypoints
 
@@ -229,6 +255,8 @@ func setupServer(staticConfiguration *static.Configuration) (*server.Server, err
 		return nil, err
 	}
 
+	dynamicEntryPoints := server.NewDynamicEntryPoints(staticConfiguration.EntryPoints, staticConfiguration.HostResolver, metricsRegistry)
+
 	if staticConfiguration.API != nil {
 		version.DisableDashboardAd = staticConfiguration.API.DisableDashboardAd
 		version.DashboardName = staticConfiguration.API.DashboardName
@@ -301,25 +329,38 @@ func setupServer(staticConfiguration *static.Configuration) (*server.Server, err
 		proxyBuilder = proxy.NewSmartBuilder(transportManager, proxyBuilder, *staticConfiguration.Experimental.FastProxy)
 	}
 
+	// Watcher
+
+	watcher := server.NewConfigurationWatcher(
+		routinesPool,
+		providerAggregator,
+		getDefaultsEntrypoints(staticConfiguration),
+		"internal",
+	)
+
 	dialerManager := tcp.NewDialerManager(spiffeX509Source)
 	acmeHTTPHandler := getHTTPChallengeHandler(acmeProviders, httpChallengeProvider)
-	managerFactory := service.NewManagerFactory(*staticConfiguration, routinesPool, observabilityMgr, transportManager, proxyBuilder, acmeHTTPHandler)
+	supportDump := api.SupportDumpSources{
+		Logs:            logBuffer,
+		Certificates:    tlsManager.Certificates,
+		ProviderUpdates: watcher.LastUpdates,
+	}
+	managerFactory := service.NewManagerFactory(*staticConfiguration, routinesPool, observabilityMgr, transportManager, proxyBuilder, acmeHTTPHandler, supportDump)
 
 	// Router factory
 
-	routerFactory, err := server.NewRouterFactory(*staticConfiguration, managerFactory, tlsManager, observabilityMgr, pluginBuilder, dialerManager)
+	routerFactory, err := server.NewRouterFactory(*staticConfiguration, managerFactory, tlsManager, observabilityMgr, pluginBuilder, dialerManager, geoIPResolver)
 	if err != nil {
 		return nil, fmt.Errorf("creating router factory: %w", err)
 	}
 
-	// Watcher
-
-	watcher := server.NewConfigurationWatcher(
-		routinesPool,
-		providerAggregator,
-		getDefaultsEntrypoints(staticConfiguration),
-		"internal",
-	)
+	// Local plugins reload
+	if pluginBuilder != nil && hasLocalPlugins(staticConfiguration) {
+		err = pluginBuilder.WatchLocalPlugins(routinesPool, staticConfiguration.Experimental.LocalPlugins, watcher.Reload)
+		if err != nil {
+			pluginLogger.Err(err).Msg("Unable to watch local plugins")
+		}
+	}
 
 	// TLS
 	watcher.AddListener(func(conf dynamic.Configuration) {
@@ -346,10 +387,10 @@ func setupServer(staticConfiguration *static.Configuration) (*server.Server, err
 	})
 
 	// Switch router
-	watcher.AddListener(switchRouter(routerFactory, serverEntryPointsTCP, serverEntryPointsUDP))
+	watcher.AddListener(switchRouter(routerFactory, serverEntryPointsTCP, serverEntryPointsUDP, dynamicEntryPoints))
 
 	// Metrics
-	if metricsRegistry.IsEpEnabled() || metricsRegistry.IsRouterEnabled() || metricsRegistry.IsSvcEnabled() {
+	if metricsRegistry.IsEpEnabled() || metricsRegistry.IsRouterEnabled() || metricsRegistry.IsSvcEnabled() || metricsRegistry.IsMiddlewareEnabled() {
 		var eps []string
 		for key := range serverEntryPointsTCP {
 			eps = append(eps, key)
@@ -392,7 +433,14 @@ func setupServer(staticConfiguration *static.Configuration) (*server.Server, err
 		}
 	})
 
-	return server.NewServer(routinesPool, serverEntryPointsTCP, serverEntryPointsUDP, watcher, observabilityMgr), nil
+	// The applied listeners are called once all the configuration listeners are done,
+	// so a provider is only reported as ready once its routers have been built.
+	if staticConfiguration.Ping != nil {
+		readiness := staticConfiguration.Ping.Readiness()
+		watcher.AddAppliedListener(readiness.ConfigurationApplied)
+	}
+
+	return server.NewServer(routinesPool, serverEntryPointsTCP, serverEntryPointsUDP, dynamicEntryPoints, watcher, observabilityMgr), nil
 }
 
 func getHTTPChallengeHandler(acmeProviders []*acme.Provider, httpChallengeProvider http.Handler) http.Handler {
@@ -440,14 +488,18 @@ func getDefaultsEntrypoints(staticConfiguration *static.Configuration) []string
 	return defaultEntryPoints
 }
 
-func switchRouter(routerFactory *server.RouterFactory, serverEntryPointsTCP server.TCPEntryPoints, serverEntryPointsUDP server.UDPEntryPoints) func(conf dynamic.Configuration) {
+func switchRouter(routerFactory *server.RouterFactory, serverEntryPointsTCP server.TCPEntryPoints, serverEntryPointsUDP server.UDPEntryPoints, dynamicEntryPoints *server.DynamicEntryPoints) func(conf dynamic.Configuration) {
 	return func(conf dynamic.Configuration) {
 		rtConf := runtime.NewConfig(conf)
 
+		// The dynamic entry points are updated first, so that the routers are only built for the ones which are opened.
+		dynamicEntryPoints.Update(rtConf)
+
 		routers, udpRouters := routerFactory.CreateRouters(rtConf)
 
 		serverEntryPointsTCP.Switch(routers)
 		serverEntryPointsUDP.Switch(udpRouters)
+		dynamicEntryPoints.Switch(routers)
 	}
 }
 
@@ -590,7 +642,7 @@ func appendCertMetric(gauge gokitmetrics.Gauge, certificate *x509.Certificate) {
 	gauge.With(labels...).Set(notAfter)
 }
 
-func setupAccessLog(ctx context.Context, conf *otypes.AccessLog) accesslog.Accesslog {
+func setupAccessLog(ctx context.Context, conf *otypes.AccessLog, geoIPResolver *geoip.Resolver) accesslog.Accesslog {
 	if conf == nil {
 		return nil
 	}
@@ -601,6 +653,8 @@ func setupAccessLog(ctx context.Context, conf *otypes.AccessLog) accesslog.Acces
 		return nil
 	}
 
+	accessLoggerMiddleware.SetGeoIPResolver(geoIPResolver)
+
 	return accessLoggerMiddleware
 }
 
@@ -618,6 +672,25 @@ func setupTracing(ctx context.Context, conf *static.Tracing) (*tracing.Tracer, i
 	return tracer, closer
 }
 
+func setupMirrorComparisonLog(conf *otypes.MirrorComparisonLog) io.WriteCloser {
+	if conf == nil || conf.FilePath == "" {
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(conf.FilePath), 0o755); err != nil {
+		log.Warn().Err(err).Msg("Unable to create the mirror comparison log directory")
+		return nil
+	}
+
+	file, err := os.OpenFile(conf.FilePath, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0o664)
+	if err != nil {
+		log.Warn().Err(err).Msg("Unable to open the mirror comparison log file")
+		return nil
+	}
+
+	return file
+}
+
 func checkNewVersion(staticConfiguration *static.Configuration) {
 	logger := log.With().Logger()
 
@@ -0,0 +1,300 @@
+package auth
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Scopes granted to the API identities.
+const (
+	// ScopeRead grants access to the read-only endpoints, such as the dashboard.
+	ScopeRead = "read"
+	// ScopeWrite grants access to the endpoints changing the configuration or exposing the process internals,
+	// such as the REST provider, the support dump, and the debug endpoints. It implies ScopeRead.
+	ScopeWrite = "write"
+)
+
+// Config configures the authentication of the API and REST provider requests.
+type Config struct {
+	Tokens             []Token             `description:"Bearer tokens allowed to call the API." json:"tokens,omitempty" toml:"tokens,omitempty" yaml:"tokens,omitempty" export:"true"`
+	TokensFile         string              `description:"Path to a file of hashed bearer tokens, with one 'name:sha256:scopes' entry per line." json:"tokensFile,omitempty" toml:"tokensFile,omitempty" yaml:"tokensFile,omitempty" export:"true"`
+	ClientCertificates []ClientCertificate `description:"Client certificate identities allowed to call the API." json:"clientCertificates,omitempty" toml:"clientCertificates,omitempty" yaml:"clientCertificates,omitempty" export:"true"`
+}
+
+// Token is a bearer token allowed to call the API.
+type Token struct {
+	Name   string   `description:"Name identifying the token in the audit logs." json:"name,omitempty" toml:"name,omitempty" yaml:"name,omitempty" export:"true"`
+	Value  string   `description:"Value of the token." json:"value,omitempty" toml:"value,omitempty" yaml:"value,omitempty" loggable:"false"`
+	Scopes []string `description:"Scopes granted to the token (read, write)." json:"scopes,omitempty" toml:"scopes,omitempty" yaml:"scopes,omitempty" export:"true"`
+}
+
+// ClientCertificate is a client certificate identity allowed to call the API.
+type ClientCertificate struct {
+	Identity string   `description:"Common name, DNS SAN or URI SAN of the verified client certificate." json:"identity,omitempty" toml:"identity,omitempty" yaml:"identity,omitempty" export:"true"`
+	Scopes   []string `description:"Scopes granted to the identity (read, write)." json:"scopes,omitempty" toml:"scopes,omitempty" yaml:"scopes,omitempty" export:"true"`
+}
+
+// Identity is the authenticated caller of an API request.
+type Identity struct {
+	Name   string
+	Scopes []string
+}
+
+// HasScope reports whether the identity is granted the given scope.
+func (i Identity) HasScope(scope string) bool {
+	return slices.Contains(i.Scopes, scope) || scope == ScopeRead && slices.Contains(i.Scopes, ScopeWrite)
+}
+
+type contextKey struct{}
+
+// FromContext returns the identity authenticated for the request, if any.
+func FromContext(ctx context.Context) (Identity, bool) {
+	identity, ok := ctx.Value(contextKey{}).(Identity)
+	return identity, ok
+}
+
+type hashedToken struct {
+	hash     [sha256.Size]byte
+	identity Identity
+}
+
+// Authenticator authenticates the API requests with bearer tokens or client certificates.
+type Authenticator struct {
+	tokens             []hashedToken
+	clientCertificates map[string]Identity
+}
+
+// New creates an Authenticator from the given configuration.
+// An Authenticator without any token nor client certificate denies every request.
+func New(config *Config) (*Authenticator, error) {
+	a := &Authenticator{clientCertificates: make(map[string]Identity)}
+
+	for i, token := range config.Tokens {
+		if token.Name == "" {
+			return nil, fmt.Errorf("token %d: empty name", i)
+		}
+
+		if token.Value == "" {
+			return nil, fmt.Errorf("token %s: empty value", token.Name)
+		}
+
+		if err := validateScopes(token.Scopes); err != nil {
+			return nil, fmt.Errorf("token %s: %w", token.Name, err)
+		}
+
+		a.tokens = append(a.tokens, hashedToken{
+			hash:     sha256.Sum256([]byte(token.Value)),
+			identity: Identity{Name: token.Name, Scopes: token.Scopes},
+		})
+	}
+
+	if config.TokensFile != "" {
+		tokens, err := loadTokensFile(config.TokensFile)
+		if err != nil {
+			return nil, fmt.Errorf("loading tokens file %s: %w", config.TokensFile, err)
+		}
+
+		a.tokens = append(a.tokens, tokens...)
+	}
+
+	for _, cert := range config.ClientCertificates {
+		if cert.Identity == "" {
+			return nil, errors.New("client certificate: empty identity")
+		}
+
+		if err := validateScopes(cert.Scopes); err != nil {
+			return nil, fmt.Errorf("client certificate %s: %w", cert.Identity, err)
+		}
+
+		a.clientCertificates[cert.Identity] = Identity{Name: cert.Identity, Scopes: cert.Scopes}
+	}
+
+	return a, nil
+}
+
+// Wrap returns a handler which only lets through the authenticated requests granted the read scope.
+// The authenticated identity is available in the request context, see FromContext.
+func (a *Authenticator) Wrap(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		identity, err := a.authenticate(req)
+		if err != nil {
+			log.Debug().Err(err).Str("remoteAddr", req.RemoteAddr).Msg("API authentication failed")
+
+			rw.Header().Set("WWW-Authenticate", `Bearer realm="traefik"`)
+			http.Error(rw, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+			return
+		}
+
+		if !identity.HasScope(ScopeRead) {
+			http.Error(rw, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+			return
+		}
+
+		next.ServeHTTP(rw, req.WithContext(context.WithValue(req.Context(), contextKey{}, identity)))
+	})
+}
+
+func (a *Authenticator) authenticate(req *http.Request) (Identity, error) {
+	if header := req.Header.Get("Authorization"); header != "" {
+		scheme, token, ok := strings.Cut(header, " ")
+		if !ok || !strings.EqualFold(scheme, "Bearer") || token == "" {
+			return Identity{}, errors.New("unsupported authorization scheme")
+		}
+
+		hash := sha256.Sum256([]byte(token))
+
+		var identity Identity
+		var found bool
+		// All the tokens are compared, so that the response time does not depend on which one matches.
+		for _, t := range a.tokens {
+			if subtle.ConstantTimeCompare(hash[:], t.hash[:]) == 1 {
+				identity, found = t.identity, true
+			}
+		}
+
+		if !found {
+			return Identity{}, errors.New("invalid token")
+		}
+
+		return identity, nil
+	}
+
+	// Only the certificates verified against the client CAs of the TLS options are trusted.
+	if req.TLS != nil && len(req.TLS.VerifiedChains) > 0 && len(req.TLS.VerifiedChains[0]) > 0 {
+		cert := req.TLS.VerifiedChains[0][0]
+
+		names := append([]string{cert.Subject.CommonName}, cert.DNSNames...)
+		for _, uri := range cert.URIs {
+			names = append(names, uri.String())
+		}
+
+		for _, name := range names {
+			if identity, ok := a.clientCertificates[name]; ok && name != "" {
+				return identity, nil
+			}
+		}
+
+		return Identity{}, errors.New("unknown client certificate identity")
+	}
+
+	return Identity{}, errors.New("missing credentials")
+}
+
+// RequireWrite returns a handler which only lets through the requests granted the write scope,
+// and audit logs them.
+// When the API authentication is disabled, the requests are let through, and logged as anonymous.
+func RequireWrite(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		identity, authenticated := FromContext(req.Context())
+
+		// The audit logs are written regardless of the log level.
+		logger := log.Log().
+			Bool("audit", true).
+			Str("identity", identity.Name).
+			Bool("authenticated", authenticated).
+			Str("method", req.Method).
+			Str("path", req.URL.Path).
+			Str("remoteAddr", req.RemoteAddr)
+
+		if authenticated && !identity.HasScope(ScopeWrite) {
+			logger.Int("status", http.StatusForbidden).Msg("API write request denied")
+
+			http.Error(rw, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+			return
+		}
+
+		recorder := &statusRecorder{ResponseWriter: rw, status: http.StatusOK}
+		next.ServeHTTP(recorder, req)
+
+		logger.Int("status", recorder.status).Msg("API write request")
+	})
+}
+
+func validateScopes(scopes []string) error {
+	if len(scopes) == 0 {
+		return errors.New("no scope")
+	}
+
+	for _, scope := range scopes {
+		if scope != ScopeRead && scope != ScopeWrite {
+			return fmt.Errorf("unknown scope %q", scope)
+		}
+	}
+
+	return nil
+}
+
+// loadTokensFile loads the hashed tokens of the given file,
+// which contains one 'name:sha256:scopes' entry per line, where sha256 is the hex-encoded SHA-256 hash of the token,
+// and scopes is a comma-separated list of scopes.
+func loadTokensFile(filename string) ([]hashedToken, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var tokens []hashedToken
+
+	scanner := bufio.NewScanner(file)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		parts := strings.Split(line, ":")
+		if len(parts) != 3 || parts[0] == "" {
+			return nil, fmt.Errorf("line %d: invalid entry, expected 'name:sha256:scopes'", lineNumber)
+		}
+
+		hash, err := hex.DecodeString(parts[1])
+		if err != nil || len(hash) != sha256.Size {
+			return nil, fmt.Errorf("line %d: invalid SHA-256 hash", lineNumber)
+		}
+
+		scopes := strings.Split(parts[2], ",")
+		if err := validateScopes(scopes); err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNumber, err)
+		}
+
+		token := hashedToken{identity: Identity{Name: parts[0], Scopes: scopes}}
+		copy(token.hash[:], hash)
+
+		tokens = append(tokens, token)
+	}
+
+	return tokens, scanner.Err()
+}
+
+type statusRecorder struct {
+	http.ResponseWriter
+
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *statusRecorder) Flush() {
+	if flusher, ok := r.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (r *statusRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
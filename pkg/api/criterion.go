@@ -125,3 +125,16 @@ func getIntParam(request *http.Request, key string, defaultValue int) (int, erro
 	}
 	return value, nil
 }
+
+func getBoolParam(request *http.Request, key string) (bool, error) {
+	raw := request.URL.Query().Get(key)
+	if raw == "" {
+		return false, nil
+	}
+
+	value, err := strconv.ParseBool(raw)
+	if err != nil {
+		return false, fmt.Errorf("invalid request: %s: %s", key, raw)
+	}
+	return value, nil
+}
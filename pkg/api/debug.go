@@ -8,6 +8,7 @@ import (
 	"runtime"
 
 	"github.com/gorilla/mux"
+	"github.com/traefik/traefik/v3/pkg/api/auth"
 )
 
 func init() {
@@ -23,9 +24,10 @@ func goroutines() any {
 type DebugHandler struct{}
 
 // Append add debug routes on a router.
+// As they expose the process internals, they require the write scope.
 func (g DebugHandler) Append(router *mux.Router) {
 	router.Methods(http.MethodGet).Path("/debug/vars").
-		HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		Handler(auth.RequireWrite(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
 			w.Header().Set("Content-Type", "application/json; charset=utf-8")
 			fmt.Fprint(w, "{\n")
 			first := true
@@ -37,13 +39,13 @@ func (g DebugHandler) Append(router *mux.Router) {
 				fmt.Fprintf(w, "%q: %s", kv.Key, kv.Value)
 			})
 			fmt.Fprint(w, "\n}\n")
-		})
+		})))
 
 	runtime.SetBlockProfileRate(1)
 	runtime.SetMutexProfileFraction(5)
-	router.Methods(http.MethodGet).PathPrefix("/debug/pprof/cmdline").HandlerFunc(pprof.Cmdline)
-	router.Methods(http.MethodGet).PathPrefix("/debug/pprof/profile").HandlerFunc(pprof.Profile)
-	router.Methods(http.MethodGet).PathPrefix("/debug/pprof/symbol").HandlerFunc(pprof.Symbol)
-	router.Methods(http.MethodGet).PathPrefix("/debug/pprof/trace").HandlerFunc(pprof.Trace)
-	router.Methods(http.MethodGet).PathPrefix("/debug/pprof/").HandlerFunc(pprof.Index)
+	router.Methods(http.MethodGet).PathPrefix("/debug/pprof/cmdline").Handler(auth.RequireWrite(http.HandlerFunc(pprof.Cmdline)))
+	router.Methods(http.MethodGet).PathPrefix("/debug/pprof/profile").Handler(auth.RequireWrite(http.HandlerFunc(pprof.Profile)))
+	router.Methods(http.MethodGet).PathPrefix("/debug/pprof/symbol").Handler(auth.RequireWrite(http.HandlerFunc(pprof.Symbol)))
+	router.Methods(http.MethodGet).PathPrefix("/debug/pprof/trace").Handler(auth.RequireWrite(http.HandlerFunc(pprof.Trace)))
+	router.Methods(http.MethodGet).PathPrefix("/debug/pprof/").Handler(auth.RequireWrite(http.HandlerFunc(pprof.Index)))
 }
@@ -8,6 +8,7 @@ import (
 
 	"github.com/gorilla/mux"
 	"github.com/rs/zerolog/log"
+	"github.com/traefik/traefik/v3/pkg/api/auth"
 	"github.com/traefik/traefik/v3/pkg/config/dynamic"
 	"github.com/traefik/traefik/v3/pkg/config/runtime"
 	"github.com/traefik/traefik/v3/pkg/config/static"
@@ -50,6 +51,7 @@ type RunTimeRepresentation struct {
 	TCPServices    map[string]*tcpServiceInfoRepresentation `json:"tcpServices,omitempty"`
 	UDPRouters     map[string]*runtime.UDPRouterInfo        `json:"udpRouters,omitempty"`
 	UDPServices    map[string]*runtime.UDPServiceInfo       `json:"udpServices,omitempty"`
+	EntryPoints    map[string]*runtime.EntryPointInfo       `json:"entryPoints,omitempty"`
 }
 
 // Handler serves the configuration and status of Traefik on API endpoints.
@@ -58,12 +60,18 @@ type Handler struct {
 
 	// runtimeConfiguration is the data set used to create all the data representations exposed by the API.
 	runtimeConfiguration *runtime.Configuration
+
+	// supportDump provides the process state included on demand in the support dump.
+	supportDump SupportDumpSources
 }
 
 // NewBuilder returns a http.Handler builder based on runtime.Configuration.
-func NewBuilder(staticConfig static.Configuration) func(*runtime.Configuration) http.Handler {
+func NewBuilder(staticConfig static.Configuration, supportDump SupportDumpSources) func(*runtime.Configuration) http.Handler {
 	return func(configuration *runtime.Configuration) http.Handler {
-		return New(staticConfig, configuration).createRouter()
+		handler := New(staticConfig, configuration)
+		handler.supportDump = supportDump
+
+		return handler.createRouter()
 	}
 }
 
@@ -96,17 +104,21 @@ func (h Handler) createRouter() *mux.Router {
 	// Experimental endpoint
 	apiRouter.Methods(http.MethodGet).Path("/api/overview").HandlerFunc(h.getOverview)
 
-	apiRouter.Methods(http.MethodGet).Path("/api/support-dump").HandlerFunc(h.getSupportDump)
+	// The support dump exposes the whole configuration, and requires the write scope.
+	apiRouter.Methods(http.MethodGet).Path("/api/support-dump").Handler(auth.RequireWrite(http.HandlerFunc(h.getSupportDump)))
 
 	apiRouter.Methods(http.MethodGet).Path("/api/entrypoints").HandlerFunc(h.getEntryPoints)
 	apiRouter.Methods(http.MethodGet).Path("/api/entrypoints/{entryPointID}").HandlerFunc(h.getEntryPoint)
 
+	apiRouter.Methods(http.MethodGet).Path("/api/providers/file/errors").HandlerFunc(h.getFileProviderErrors)
+
 	apiRouter.Methods(http.MethodGet).Path("/api/http/routers").HandlerFunc(h.getRouters)
 	apiRouter.Methods(http.MethodGet).Path("/api/http/routers/{routerID}").HandlerFunc(h.getRouter)
 	apiRouter.Methods(http.MethodGet).Path("/api/http/services").HandlerFunc(h.getServices)
 	apiRouter.Methods(http.MethodGet).Path("/api/http/services/{serviceID}").HandlerFunc(h.getService)
 	apiRouter.Methods(http.MethodGet).Path("/api/http/middlewares").HandlerFunc(h.getMiddlewares)
 	apiRouter.Methods(http.MethodGet).Path("/api/http/middlewares/{middlewareID}").HandlerFunc(h.getMiddleware)
+	apiRouter.Methods(http.MethodPost).Path("/api/http/explain").HandlerFunc(h.explainHTTP)
 
 	apiRouter.Methods(http.MethodGet).Path("/api/tcp/routers").HandlerFunc(h.getTCPRouters)
 	apiRouter.Methods(http.MethodGet).Path("/api/tcp/routers/{routerID}").HandlerFunc(h.getTCPRouter)
@@ -114,6 +126,7 @@ func (h Handler) createRouter() *mux.Router {
 	apiRouter.Methods(http.MethodGet).Path("/api/tcp/services/{serviceID}").HandlerFunc(h.getTCPService)
 	apiRouter.Methods(http.MethodGet).Path("/api/tcp/middlewares").HandlerFunc(h.getTCPMiddlewares)
 	apiRouter.Methods(http.MethodGet).Path("/api/tcp/middlewares/{middlewareID}").HandlerFunc(h.getTCPMiddleware)
+	apiRouter.Methods(http.MethodPost).Path("/api/tcp/explain").HandlerFunc(h.explainTCP)
 
 	apiRouter.Methods(http.MethodGet).Path("/api/udp/routers").HandlerFunc(h.getUDPRouters)
 	apiRouter.Methods(http.MethodGet).Path("/api/udp/routers/{routerID}").HandlerFunc(h.getUDPRouter)
@@ -151,6 +164,7 @@ func (h Handler) getRuntimeConfiguration(rw http.ResponseWriter, request *http.R
 		TCPServices:    tcpSIRepr,
 		UDPRouters:     h.runtimeConfiguration.UDPRouters,
 		UDPServices:    h.runtimeConfiguration.UDPServices,
+		EntryPoints:    h.runtimeConfiguration.EntryPoints,
 	}
 
 	rw.Header().Set("Content-Type", "application/json")
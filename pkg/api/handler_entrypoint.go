@@ -10,6 +10,7 @@ import (
 
 	"github.com/gorilla/mux"
 	"github.com/rs/zerolog/log"
+	"github.com/traefik/traefik/v3/pkg/config/runtime"
 	"github.com/traefik/traefik/v3/pkg/config/static"
 )
 
@@ -17,10 +18,24 @@ type entryPointRepresentation struct {
 	*static.EntryPoint
 
 	Name string `json:"name,omitempty"`
+	// Dynamic reports whether the entry point is defined in the dynamic configuration.
+	Dynamic bool     `json:"dynamic,omitempty"`
+	Status  string   `json:"status,omitempty"`
+	Err     []string `json:"error,omitempty"`
+}
+
+func newDynamicEntryPointRepresentation(name string, info *runtime.EntryPointInfo) entryPointRepresentation {
+	return entryPointRepresentation{
+		EntryPoint: static.NewDynamicEntryPoint(info.EntryPoint),
+		Name:       name,
+		Dynamic:    true,
+		Status:     info.Status,
+		Err:        info.Err,
+	}
 }
 
 func (h Handler) getEntryPoints(rw http.ResponseWriter, request *http.Request) {
-	results := make([]entryPointRepresentation, 0, len(h.staticConfig.EntryPoints))
+	results := make([]entryPointRepresentation, 0, len(h.staticConfig.EntryPoints)+len(h.runtimeConfiguration.EntryPoints))
 
 	for name, ep := range h.staticConfig.EntryPoints {
 		results = append(results, entryPointRepresentation{
@@ -29,6 +44,15 @@ func (h Handler) getEntryPoints(rw http.ResponseWriter, request *http.Request) {
 		})
 	}
 
+	for name, info := range h.runtimeConfiguration.EntryPoints {
+		// The static entry points cannot be overridden.
+		if _, ok := h.staticConfig.EntryPoints[name]; ok {
+			continue
+		}
+
+		results = append(results, newDynamicEntryPointRepresentation(name, info))
+	}
+
 	sort.Slice(results, func(i, j int) bool {
 		return results[i].Name < results[j].Name
 	})
@@ -61,17 +85,19 @@ func (h Handler) getEntryPoint(rw http.ResponseWriter, request *http.Request) {
 
 	rw.Header().Set("Content-Type", "application/json")
 
-	ep, ok := h.staticConfig.EntryPoints[entryPointID]
-	if !ok {
+	var result entryPointRepresentation
+	if ep, ok := h.staticConfig.EntryPoints[entryPointID]; ok {
+		result = entryPointRepresentation{
+			EntryPoint: ep,
+			Name:       entryPointID,
+		}
+	} else if info, ok := h.runtimeConfiguration.EntryPoints[entryPointID]; ok {
+		result = newDynamicEntryPointRepresentation(entryPointID, info)
+	} else {
 		writeError(rw, fmt.Sprintf("entry point not found: %s", entryPointID), http.StatusNotFound)
 		return
 	}
 
-	result := entryPointRepresentation{
-		EntryPoint: ep,
-		Name:       entryPointID,
-	}
-
 	err = json.NewEncoder(rw).Encode(result)
 	if err != nil {
 		log.Ctx(request.Context()).Error().Err(err).Send()
@@ -13,6 +13,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
 	"github.com/traefik/traefik/v3/pkg/config/runtime"
 	"github.com/traefik/traefik/v3/pkg/config/static"
 )
@@ -28,6 +29,7 @@ func TestHandler_EntryPoints(t *testing.T) {
 		desc     string
 		path     string
 		conf     static.Configuration
+		rtConf   *runtime.Configuration
 		expected expected
 	}{
 		{
@@ -199,6 +201,58 @@ func TestHandler_EntryPoints(t *testing.T) {
 				statusCode: http.StatusNotFound,
 			},
 		},
+		{
+			desc: "all entry points, with dynamic ones",
+			path: "/api/entrypoints",
+			conf: static.Configuration{
+				Global: &static.Global{},
+				API:    &static.API{},
+				EntryPoints: map[string]*static.EntryPoint{
+					"web": {Address: ":80"},
+				},
+			},
+			rtConf: &runtime.Configuration{
+				EntryPoints: map[string]*runtime.EntryPointInfo{
+					"tenant": {
+						EntryPoint: &dynamic.EntryPoint{
+							Address: ":8000",
+							ProxyProtocol: &dynamic.EntryPointProxyProtocol{
+								TrustedIPs: []string{"192.168.1.1"},
+							},
+						},
+						Status: runtime.StatusEnabled,
+					},
+					"web": {
+						EntryPoint: &dynamic.EntryPoint{Address: ":8080"},
+						Status:     runtime.StatusDisabled,
+						Err:        []string{"entry point already defined in the static configuration"},
+					},
+				},
+			},
+			expected: expected{
+				statusCode: http.StatusOK,
+				nextPage:   "1",
+				jsonFile:   "testdata/entrypoints-dynamic.json",
+			},
+		},
+		{
+			desc: "one dynamic entry point by id",
+			path: "/api/entrypoints/tenant",
+			conf: static.Configuration{Global: &static.Global{}, API: &static.API{}},
+			rtConf: &runtime.Configuration{
+				EntryPoints: map[string]*runtime.EntryPointInfo{
+					"tenant": {
+						EntryPoint: &dynamic.EntryPoint{Address: ":8000"},
+						Status:     runtime.StatusDisabled,
+						Err:        []string{"building listener: error opening listener: listen tcp :8000: bind: address already in use"},
+					},
+				},
+			},
+			expected: expected{
+				statusCode: http.StatusOK,
+				jsonFile:   "testdata/entrypoint-dynamic.json",
+			},
+		},
 		{
 			desc: "one entry point by id, but no config",
 			path: "/api/entrypoints/foo",
@@ -213,7 +267,12 @@ func TestHandler_EntryPoints(t *testing.T) {
 		t.Run(test.desc, func(t *testing.T) {
 			t.Parallel()
 
-			handler := New(test.conf, &runtime.Configuration{})
+			rtConf := test.rtConf
+			if rtConf == nil {
+				rtConf = &runtime.Configuration{}
+			}
+
+			handler := New(test.conf, rtConf)
 			server := httptest.NewServer(handler.createRouter())
 
 			resp, err := http.DefaultClient.Get(server.URL + test.path)
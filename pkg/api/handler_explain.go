@@ -0,0 +1,182 @@
+package api
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+	"github.com/traefik/traefik/v3/pkg/config/runtime"
+	"github.com/traefik/traefik/v3/pkg/types"
+)
+
+// maxExplainRequestSize is the maximum size of an explain request body.
+const maxExplainRequestSize = 1 << 20
+
+// httpExplainRequest describes the synthetic HTTP request to route.
+type httpExplainRequest struct {
+	EntryPoint string            `json:"entryPoint"`
+	Method     string            `json:"method,omitempty"`
+	Host       string            `json:"host,omitempty"`
+	Path       string            `json:"path,omitempty"`
+	Headers    map[string]string `json:"headers,omitempty"`
+	ClientIP   string            `json:"clientIP,omitempty"`
+	TLS        bool              `json:"tls,omitempty"`
+	SNI        string            `json:"sni,omitempty"`
+}
+
+// tcpExplainRequest describes the synthetic TCP connection to route.
+type tcpExplainRequest struct {
+	EntryPoint string   `json:"entryPoint"`
+	SNI        string   `json:"sni,omitempty"`
+	ALPN       []string `json:"alpn,omitempty"`
+	ClientIP   string   `json:"clientIP,omitempty"`
+	TLS        bool     `json:"tls,omitempty"`
+}
+
+func (h Handler) explainHTTP(rw http.ResponseWriter, request *http.Request) {
+	var explainReq httpExplainRequest
+	if err := decodeExplainRequest(rw, request, &explainReq); err != nil {
+		writeError(rw, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	req, err := newExplainHTTPRequest(request.Context(), explainReq)
+	if err != nil {
+		writeError(rw, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	routing := h.runtimeConfiguration.Routing
+	if routing == nil {
+		writeError(rw, "routing not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	result, err := routing.ExplainHTTP(explainReq.EntryPoint, req)
+	if err != nil {
+		writeError(rw, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	writeExplainResult(rw, request, result)
+}
+
+// newExplainHTTPRequest creates the synthetic request, as received by the entry point.
+func newExplainHTTPRequest(ctx context.Context, explainReq httpExplainRequest) (*http.Request, error) {
+	if explainReq.Method == "" {
+		explainReq.Method = http.MethodGet
+	}
+
+	if explainReq.Path == "" {
+		explainReq.Path = "/"
+	}
+
+	if !strings.HasPrefix(explainReq.Path, "/") {
+		return nil, fmt.Errorf("path %q does not start with a '/'", explainReq.Path)
+	}
+
+	// The request URI is parsed as done by the HTTP server, which keeps the fragment in the path.
+	uri, err := url.ParseRequestURI(explainReq.Path)
+	if err != nil {
+		return nil, fmt.Errorf("invalid path %q: %w", explainReq.Path, err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, explainReq.Method, "/", http.NoBody)
+	if err != nil {
+		return nil, fmt.Errorf("invalid method %q: %w", explainReq.Method, err)
+	}
+
+	req.URL = uri
+	req.Host = explainReq.Host
+	req.RequestURI = explainReq.Path
+	for name, value := range explainReq.Headers {
+		req.Header.Set(name, value)
+	}
+
+	if explainReq.ClientIP != "" {
+		if net.ParseIP(explainReq.ClientIP) == nil {
+			return nil, fmt.Errorf("invalid client IP %q", explainReq.ClientIP)
+		}
+
+		req.RemoteAddr = net.JoinHostPort(explainReq.ClientIP, "0")
+	}
+
+	if explainReq.TLS || explainReq.SNI != "" {
+		serverName := explainReq.SNI
+		if serverName == "" {
+			serverName = types.CanonicalDomain(requestHost(explainReq.Host))
+		}
+
+		req.TLS = &tls.ConnectionState{ServerName: serverName}
+	}
+
+	return req, nil
+}
+
+func (h Handler) explainTCP(rw http.ResponseWriter, request *http.Request) {
+	var explainReq tcpExplainRequest
+	if err := decodeExplainRequest(rw, request, &explainReq); err != nil {
+		writeError(rw, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if explainReq.ClientIP != "" && net.ParseIP(explainReq.ClientIP) == nil {
+		writeError(rw, fmt.Sprintf("invalid client IP %q", explainReq.ClientIP), http.StatusBadRequest)
+		return
+	}
+
+	routing := h.runtimeConfiguration.Routing
+	if routing == nil {
+		writeError(rw, "routing not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	result, err := routing.ExplainTCP(explainReq.EntryPoint, runtime.TCPConnInfo{
+		ServerName: explainReq.SNI,
+		ALPN:       explainReq.ALPN,
+		ClientIP:   explainReq.ClientIP,
+		TLS:        explainReq.TLS || explainReq.SNI != "",
+	})
+	if err != nil {
+		writeError(rw, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	writeExplainResult(rw, request, result)
+}
+
+func decodeExplainRequest(rw http.ResponseWriter, request *http.Request, v any) error {
+	decoder := json.NewDecoder(http.MaxBytesReader(rw, request.Body, maxExplainRequestSize))
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(v); err != nil {
+		return fmt.Errorf("invalid explain request: %w", err)
+	}
+
+	return nil
+}
+
+func writeExplainResult(rw http.ResponseWriter, request *http.Request, result *runtime.RoutingExplanation) {
+	rw.Header().Set("Content-Type", "application/json")
+
+	err := json.NewEncoder(rw).Encode(result)
+	if err != nil {
+		log.Ctx(request.Context()).Error().Err(err).Send()
+		writeError(rw, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// requestHost returns the host part of a Host header value.
+func requestHost(host string) string {
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		return h
+	}
+
+	return host
+}
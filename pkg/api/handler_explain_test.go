@@ -0,0 +1,288 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/traefik/traefik/v3/pkg/config/runtime"
+	"github.com/traefik/traefik/v3/pkg/config/static"
+)
+
+type fakeRoutingExplainer struct {
+	explanation *runtime.RoutingExplanation
+	err         error
+
+	entryPoint string
+	req        *http.Request
+	conn       runtime.TCPConnInfo
+}
+
+func (f *fakeRoutingExplainer) ExplainHTTP(entryPointName string, req *http.Request) (*runtime.RoutingExplanation, error) {
+	f.entryPoint = entryPointName
+	f.req = req
+
+	return f.explanation, f.err
+}
+
+func (f *fakeRoutingExplainer) ExplainTCP(entryPointName string, conn runtime.TCPConnInfo) (*runtime.RoutingExplanation, error) {
+	f.entryPoint = entryPointName
+	f.conn = conn
+
+	return f.explanation, f.err
+}
+
+func TestHandler_ExplainHTTP(t *testing.T) {
+	explanation := &runtime.RoutingExplanation{
+		Router:      "api@file",
+		Middlewares: []string{"auth@file"},
+		Service:     "api@file",
+		Candidates: []runtime.RouteCandidate{
+			{
+				Name:           "beta@file",
+				Rule:           "Host(`example.com`) && Cookie(`group`, `beta`)",
+				Priority:       1000,
+				FailedMatchers: []string{"Cookie(`group`, `beta`)"},
+			},
+			{
+				Name:     "api@file",
+				Rule:     "Host(`example.com`) && PathPrefix(`/api`)",
+				Priority: 41,
+				Matched:  true,
+			},
+		},
+	}
+
+	testCases := []struct {
+		desc               string
+		body               string
+		noRouting          bool
+		explainErr         error
+		expectedStatusCode int
+		expectedMethod     string
+		expectedHost       string
+		expectedPath       string
+		expectedRemoteAddr string
+		expectedHeaders    http.Header
+		expectedServerName string
+		expectedTLS        bool
+	}{
+		{
+			desc:               "invalid body",
+			body:               `{"entryPoint":`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			desc:               "unknown field",
+			body:               `{"entryPoint":"web","foo":"bar"}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			desc:               "invalid client IP",
+			body:               `{"entryPoint":"web","host":"example.com","clientIP":"foo"}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			desc:               "invalid path",
+			body:               `{"entryPoint":"web","host":"example.com","path":"foo"}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			desc:               "routing not available",
+			body:               `{"entryPoint":"web","host":"example.com"}`,
+			noRouting:          true,
+			expectedStatusCode: http.StatusServiceUnavailable,
+		},
+		{
+			desc:               "explain error",
+			body:               `{"entryPoint":"foo","host":"example.com"}`,
+			explainErr:         errors.New(`entryPoint "foo" does not exist`),
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			desc:               "default request",
+			body:               `{"entryPoint":"web","host":"example.com"}`,
+			expectedStatusCode: http.StatusOK,
+			expectedMethod:     http.MethodGet,
+			expectedHost:       "example.com",
+			expectedPath:       "/",
+			expectedHeaders:    http.Header{},
+		},
+		{
+			desc:               "full request",
+			body:               `{"entryPoint":"web","method":"POST","host":"example.com:8443","path":"/api/users?id=1","headers":{"cookie":"group=beta"},"clientIP":"10.0.0.1","tls":true}`,
+			expectedStatusCode: http.StatusOK,
+			expectedMethod:     http.MethodPost,
+			expectedHost:       "example.com:8443",
+			expectedPath:       "/api/users",
+			expectedRemoteAddr: "10.0.0.1:0",
+			expectedHeaders:    http.Header{"Cookie": []string{"group=beta"}},
+			expectedServerName: "example.com",
+			expectedTLS:        true,
+		},
+		{
+			desc:               "SNI",
+			body:               `{"entryPoint":"web","host":"example.com","sni":"foo.com"}`,
+			expectedStatusCode: http.StatusOK,
+			expectedMethod:     http.MethodGet,
+			expectedHost:       "example.com",
+			expectedPath:       "/",
+			expectedHeaders:    http.Header{},
+			expectedServerName: "foo.com",
+			expectedTLS:        true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			explainer := &fakeRoutingExplainer{explanation: explanation, err: test.explainErr}
+
+			rtConf := &runtime.Configuration{}
+			if !test.noRouting {
+				rtConf.Routing = explainer
+			}
+
+			handler := New(static.Configuration{API: &static.API{}, Global: &static.Global{}}, rtConf)
+			server := httptest.NewServer(handler.createRouter())
+			t.Cleanup(server.Close)
+
+			resp, err := http.Post(server.URL+"/api/http/explain", "application/json", strings.NewReader(test.body))
+			require.NoError(t, err)
+			t.Cleanup(func() { _ = resp.Body.Close() })
+
+			require.Equal(t, test.expectedStatusCode, resp.StatusCode)
+			if test.expectedStatusCode != http.StatusOK {
+				return
+			}
+
+			var result runtime.RoutingExplanation
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+
+			assert.Equal(t, *explanation, result)
+
+			assert.Equal(t, "web", explainer.entryPoint)
+			require.NotNil(t, explainer.req)
+			assert.Equal(t, test.expectedMethod, explainer.req.Method)
+			assert.Equal(t, test.expectedHost, explainer.req.Host)
+			assert.Equal(t, test.expectedPath, explainer.req.URL.Path)
+			assert.Equal(t, test.expectedRemoteAddr, explainer.req.RemoteAddr)
+			assert.Equal(t, test.expectedHeaders, explainer.req.Header)
+
+			if !test.expectedTLS {
+				assert.Nil(t, explainer.req.TLS)
+				return
+			}
+
+			require.NotNil(t, explainer.req.TLS)
+			assert.Equal(t, test.expectedServerName, explainer.req.TLS.ServerName)
+		})
+	}
+}
+
+func TestHandler_ExplainTCP(t *testing.T) {
+	explanation := &runtime.RoutingExplanation{
+		Router:      "sni@file",
+		Middlewares: []string{"allowlist@file"},
+		Service:     "sni@file",
+		Candidates: []runtime.RouteCandidate{
+			{
+				Name:     "sni@file",
+				Rule:     "HostSNI(`example.com`) && ALPN(`h2`)",
+				Priority: 36,
+				Matched:  true,
+			},
+		},
+	}
+
+	testCases := []struct {
+		desc               string
+		body               string
+		noRouting          bool
+		explainErr         error
+		expectedStatusCode int
+		expectedConn       runtime.TCPConnInfo
+	}{
+		{
+			desc:               "invalid body",
+			body:               `{"entryPoint":`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			desc:               "invalid client IP",
+			body:               `{"entryPoint":"websecure","clientIP":"foo"}`,
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			desc:               "routing not available",
+			body:               `{"entryPoint":"websecure"}`,
+			noRouting:          true,
+			expectedStatusCode: http.StatusServiceUnavailable,
+		},
+		{
+			desc:               "explain error",
+			body:               `{"entryPoint":"foo"}`,
+			explainErr:         errors.New(`entryPoint "foo" does not exist`),
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		{
+			desc:               "TLS connection with SNI",
+			body:               `{"entryPoint":"websecure","sni":"example.com","alpn":["h2"],"clientIP":"192.168.1.1"}`,
+			expectedStatusCode: http.StatusOK,
+			expectedConn: runtime.TCPConnInfo{
+				ServerName: "example.com",
+				ALPN:       []string{"h2"},
+				ClientIP:   "192.168.1.1",
+				TLS:        true,
+			},
+		},
+		{
+			desc:               "non-TLS connection",
+			body:               `{"entryPoint":"websecure","clientIP":"192.168.1.1"}`,
+			expectedStatusCode: http.StatusOK,
+			expectedConn: runtime.TCPConnInfo{
+				ClientIP: "192.168.1.1",
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			explainer := &fakeRoutingExplainer{explanation: explanation, err: test.explainErr}
+
+			rtConf := &runtime.Configuration{}
+			if !test.noRouting {
+				rtConf.Routing = explainer
+			}
+
+			handler := New(static.Configuration{API: &static.API{}, Global: &static.Global{}}, rtConf)
+			server := httptest.NewServer(handler.createRouter())
+			t.Cleanup(server.Close)
+
+			resp, err := http.Post(server.URL+"/api/tcp/explain", "application/json", strings.NewReader(test.body))
+			require.NoError(t, err)
+			t.Cleanup(func() { _ = resp.Body.Close() })
+
+			require.Equal(t, test.expectedStatusCode, resp.StatusCode)
+			if test.expectedStatusCode != http.StatusOK {
+				return
+			}
+
+			var result runtime.RoutingExplanation
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+
+			assert.Equal(t, *explanation, result)
+
+			assert.Equal(t, "websecure", explainer.entryPoint)
+			assert.Equal(t, test.expectedConn, explainer.conn)
+		})
+	}
+}
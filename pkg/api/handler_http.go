@@ -36,19 +36,23 @@ func newRouterRepresentation(name string, rt *runtime.RouterInfo) routerRepresen
 type serviceRepresentation struct {
 	*runtime.ServiceInfo
 
-	Name         string            `json:"name,omitempty"`
-	Provider     string            `json:"provider,omitempty"`
-	Type         string            `json:"type,omitempty"`
-	ServerStatus map[string]string `json:"serverStatus,omitempty"`
+	Name            string                            `json:"name,omitempty"`
+	Provider        string                            `json:"provider,omitempty"`
+	Type            string                            `json:"type,omitempty"`
+	ServerStatus    map[string]string                 `json:"serverStatus,omitempty"`
+	ServerHealth    map[string]runtime.ServerHealth   `json:"serverHealth,omitempty"`
+	DrainingServers map[string]runtime.DrainingServer `json:"drainingServers,omitempty"`
 }
 
 func newServiceRepresentation(name string, si *runtime.ServiceInfo) serviceRepresentation {
 	return serviceRepresentation{
-		ServiceInfo:  si,
-		Name:         name,
-		Provider:     getProviderName(name),
-		Type:         strings.ToLower(extractType(si.Service)),
-		ServerStatus: si.GetAllStatus(),
+		ServiceInfo:     si,
+		Name:            name,
+		Provider:        getProviderName(name),
+		Type:            strings.ToLower(extractType(si.Service)),
+		ServerStatus:    si.GetAllStatus(),
+		ServerHealth:    si.GetAllHealth(),
+		DrainingServers: si.GetAllDraining(),
 	}
 }
 
@@ -0,0 +1,23 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/rs/zerolog/log"
+)
+
+func (h Handler) getFileProviderErrors(rw http.ResponseWriter, request *http.Request) {
+	rw.Header().Set("Content-Type", "application/json")
+
+	if h.staticConfig.Providers == nil || h.staticConfig.Providers.File == nil {
+		writeError(rw, "file provider not enabled", http.StatusNotFound)
+		return
+	}
+
+	err := json.NewEncoder(rw).Encode(h.staticConfig.Providers.File.Errors())
+	if err != nil {
+		log.Ctx(request.Context()).Error().Err(err).Send()
+		writeError(rw, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,83 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+	"github.com/traefik/traefik/v3/pkg/config/runtime"
+	"github.com/traefik/traefik/v3/pkg/config/static"
+	"github.com/traefik/traefik/v3/pkg/provider/file"
+	"github.com/traefik/traefik/v3/pkg/safe"
+)
+
+func TestHandler_FileProviderErrors(t *testing.T) {
+	tempDir := t.TempDir()
+
+	err := os.WriteFile(filepath.Join(tempDir, "foo.toml"), []byte("[http.routers.foo\n"), 0o644)
+	require.NoError(t, err)
+
+	fileProvider := &file.Provider{Directory: tempDir}
+
+	configChan := make(chan dynamic.Message, 1)
+	err = fileProvider.Provide(configChan, safe.NewPool(t.Context()))
+	require.NoError(t, err)
+
+	testCases := []struct {
+		desc           string
+		providers      *static.Providers
+		expectedStatus int
+		expectedFiles  []string
+	}{
+		{
+			desc:           "file provider not enabled",
+			providers:      &static.Providers{},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			desc:           "file with an error",
+			providers:      &static.Providers{File: fileProvider},
+			expectedStatus: http.StatusOK,
+			expectedFiles:  []string{"foo.toml"},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			conf := static.Configuration{API: &static.API{}, Providers: test.providers}
+			handler := New(conf, &runtime.Configuration{})
+			server := httptest.NewServer(handler.createRouter())
+			t.Cleanup(server.Close)
+
+			resp, err := http.DefaultClient.Get(server.URL + "/api/providers/file/errors")
+			require.NoError(t, err)
+			defer resp.Body.Close()
+
+			require.Equal(t, test.expectedStatus, resp.StatusCode)
+			if test.expectedStatus != http.StatusOK {
+				return
+			}
+
+			assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+
+			var fileErrors []file.FileError
+			err = json.NewDecoder(resp.Body).Decode(&fileErrors)
+			require.NoError(t, err)
+
+			var files []string
+			for _, fileError := range fileErrors {
+				files = append(files, fileError.File)
+				assert.NotEmpty(t, fileError.Error)
+			}
+			assert.Equal(t, test.expectedFiles, files)
+		})
+	}
+}
@@ -2,17 +2,70 @@ package api
 
 import (
 	"archive/tar"
+	"bytes"
+	"cmp"
 	"compress/gzip"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"runtime/pprof"
+	"slices"
 	"time"
 
 	"github.com/rs/zerolog/log"
+	"github.com/traefik/traefik/v3/pkg/config/runtime"
+	"github.com/traefik/traefik/v3/pkg/observability/logs"
 	"github.com/traefik/traefik/v3/pkg/redactor"
+	traefiktls "github.com/traefik/traefik/v3/pkg/tls"
 	"github.com/traefik/traefik/v3/pkg/version"
 )
 
+const (
+	defaultCPUProfileSeconds = 5
+	maxCPUProfileSeconds     = 30
+)
+
+// SupportDumpSources provides the process state included on demand in the support dump.
+type SupportDumpSources struct {
+	// Logs keeps the last log entries, and the last errors reported by the providers.
+	Logs *logs.Buffer
+	// Certificates returns the inventory of the TLS certificates.
+	Certificates func() []traefiktls.CertificateInfo
+	// ProviderUpdates returns the last time each provider sent a new configuration.
+	ProviderUpdates func() map[string]time.Time
+}
+
+type supportDumpFile struct {
+	name    string
+	content []byte
+}
+
+type healthDump struct {
+	HTTP []serverHealthDump `json:"http,omitempty" export:"true"`
+	TCP  []serverHealthDump `json:"tcp,omitempty" export:"true"`
+}
+
+type serverHealthDump struct {
+	Service string                `json:"service" export:"true"`
+	Server  string                `json:"server" export:"true"`
+	Status  string                `json:"status,omitempty" export:"true"`
+	Health  *runtime.ServerHealth `json:"health,omitempty" export:"true"`
+}
+
+type certificatesDump struct {
+	Certificates []traefiktls.CertificateInfo `json:"certificates,omitempty" export:"true"`
+}
+
+type logsDump struct {
+	Entries []logs.Entry `json:"entries,omitempty" export:"true"`
+}
+
+type providersDump struct {
+	LastUpdates map[string]time.Time `json:"lastUpdates,omitempty" export:"true"`
+	Errors      []logs.Entry         `json:"errors,omitempty" export:"true"`
+}
+
 func (h Handler) getSupportDump(rw http.ResponseWriter, req *http.Request) {
 	logger := log.Ctx(req.Context())
 
@@ -45,6 +98,23 @@ func (h Handler) getSupportDump(rw http.ResponseWriter, req *http.Request) {
 		return
 	}
 
+	files := []supportDumpFile{
+		{name: "version.json", content: tVersion},
+		{name: "static-config.json", content: []byte(staticConfig)},
+		{name: "runtime-config.json", content: runtimeConfig},
+	}
+
+	// The optional sections are gathered before writing the archive,
+	// so that an error can still be reported with the right status code.
+	sections, statusCode, err := h.getSupportDumpSections(req)
+	if err != nil {
+		logger.Error().Err(err).Msg("Unable to gather support dump sections")
+		writeError(rw, err.Error(), statusCode)
+		return
+	}
+
+	files = append(files, sections...)
+
 	rw.Header().Set("Content-Type", "application/gzip")
 	rw.Header().Set("Content-Disposition", "attachment; filename=support-dump.tar.gz")
 
@@ -56,24 +126,205 @@ func (h Handler) getSupportDump(rw http.ResponseWriter, req *http.Request) {
 	tw := tar.NewWriter(gw)
 	defer tw.Close()
 
-	// Add configuration files to the archive.
-	if err := addFile(tw, "version.json", tVersion); err != nil {
-		logger.Error().Err(err).Msg("Unable to archive version file")
-		writeError(rw, err.Error(), http.StatusInternalServerError)
-		return
+	// Add files to the archive.
+	for _, file := range files {
+		if err := addFile(tw, file.name, file.content); err != nil {
+			logger.Error().Err(err).Str("file", file.name).Msg("Unable to archive file")
+			writeError(rw, err.Error(), http.StatusInternalServerError)
+			return
+		}
 	}
+}
 
-	if err := addFile(tw, "static-config.json", []byte(staticConfig)); err != nil {
-		logger.Error().Err(err).Msg("Unable to archive static configuration")
-		writeError(rw, err.Error(), http.StatusInternalServerError)
-		return
+// getSupportDumpSections returns the optional sections of the support dump selected by the query parameters,
+// along with the status code to use when an error occurs.
+func (h Handler) getSupportDumpSections(req *http.Request) ([]supportDumpFile, int, error) {
+	var files []supportDumpFile
+
+	withProfiles, err := getBoolParam(req, "profiles")
+	if err != nil {
+		return nil, http.StatusBadRequest, err
 	}
 
-	if err := addFile(tw, "runtime-config.json", runtimeConfig); err != nil {
-		logger.Error().Err(err).Msg("Unable to archive runtime configuration")
-		writeError(rw, err.Error(), http.StatusInternalServerError)
-		return
+	if withProfiles {
+		cpuProfileSeconds, err := getIntParam(req, "cpuProfileSeconds", defaultCPUProfileSeconds)
+		if err != nil {
+			return nil, http.StatusBadRequest, err
+		}
+
+		if cpuProfileSeconds > maxCPUProfileSeconds {
+			return nil, http.StatusBadRequest, fmt.Errorf("invalid request: cpuProfileSeconds: %d exceeds %d", cpuProfileSeconds, maxCPUProfileSeconds)
+		}
+
+		profiles, err := getProfiles(req, time.Duration(cpuProfileSeconds)*time.Second)
+		if err != nil {
+			return nil, http.StatusInternalServerError, err
+		}
+
+		files = append(files, profiles...)
+	}
+
+	logsCount, err := getIntParam(req, "logs", 0)
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+
+	if logsCount > 0 {
+		if h.supportDump.Logs == nil {
+			return nil, http.StatusNotFound, errors.New("logs not available")
+		}
+
+		file, err := anonymizedFile("logs.json", logsDump{Entries: h.supportDump.Logs.Entries(logsCount)})
+		if err != nil {
+			return nil, http.StatusInternalServerError, err
+		}
+
+		files = append(files, file)
+	}
+
+	withHealth, err := getBoolParam(req, "health")
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+
+	if withHealth {
+		file, err := anonymizedFile("health.json", h.getHealthDump())
+		if err != nil {
+			return nil, http.StatusInternalServerError, err
+		}
+
+		files = append(files, file)
+	}
+
+	withCertificates, err := getBoolParam(req, "certificates")
+	if err != nil {
+		return nil, http.StatusBadRequest, err
 	}
+
+	if withCertificates {
+		if h.supportDump.Certificates == nil {
+			return nil, http.StatusNotFound, errors.New("certificates not available")
+		}
+
+		file, err := anonymizedFile("certificates.json", certificatesDump{Certificates: h.supportDump.Certificates()})
+		if err != nil {
+			return nil, http.StatusInternalServerError, err
+		}
+
+		files = append(files, file)
+	}
+
+	withProviders, err := getBoolParam(req, "providers")
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+
+	if withProviders {
+		if h.supportDump.ProviderUpdates == nil || h.supportDump.Logs == nil {
+			return nil, http.StatusNotFound, errors.New("providers state not available")
+		}
+
+		file, err := anonymizedFile("providers.json", providersDump{
+			LastUpdates: h.supportDump.ProviderUpdates(),
+			Errors:      h.supportDump.Logs.ProviderErrors(),
+		})
+		if err != nil {
+			return nil, http.StatusInternalServerError, err
+		}
+
+		files = append(files, file)
+	}
+
+	return files, http.StatusOK, nil
+}
+
+func (h Handler) getHealthDump() healthDump {
+	var dump healthDump
+
+	for name, service := range h.runtimeConfiguration.Services {
+		dump.HTTP = append(dump.HTTP, newServerHealthDumps(name, service.GetAllStatus(), service.GetAllHealth())...)
+	}
+
+	for name, service := range h.runtimeConfiguration.TCPServices {
+		dump.TCP = append(dump.TCP, newServerHealthDumps(name, service.GetAllStatus(), service.GetAllHealth())...)
+	}
+
+	sortServerHealthDumps(dump.HTTP)
+	sortServerHealthDumps(dump.TCP)
+
+	return dump
+}
+
+func newServerHealthDumps(serviceName string, statuses map[string]string, healths map[string]runtime.ServerHealth) []serverHealthDump {
+	var dumps []serverHealthDump
+
+	for server, status := range statuses {
+		dump := serverHealthDump{Service: serviceName, Server: server, Status: status}
+		if health, ok := healths[server]; ok {
+			dump.Health = &health
+		}
+
+		dumps = append(dumps, dump)
+	}
+
+	for server, health := range healths {
+		if _, ok := statuses[server]; !ok {
+			dumps = append(dumps, serverHealthDump{Service: serviceName, Server: server, Health: &health})
+		}
+	}
+
+	return dumps
+}
+
+func sortServerHealthDumps(dumps []serverHealthDump) {
+	slices.SortFunc(dumps, func(a, b serverHealthDump) int {
+		return cmp.Or(cmp.Compare(a.Service, b.Service), cmp.Compare(a.Server, b.Server))
+	})
+}
+
+// getProfiles returns the goroutine and heap profiles, and a CPU profile collected during the given duration.
+func getProfiles(req *http.Request, cpuProfileDuration time.Duration) ([]supportDumpFile, error) {
+	var files []supportDumpFile
+
+	for _, name := range []string{"goroutine", "heap"} {
+		var buf bytes.Buffer
+		if err := pprof.Lookup(name).WriteTo(&buf, 0); err != nil {
+			return nil, fmt.Errorf("writing %s profile: %w", name, err)
+		}
+
+		files = append(files, supportDumpFile{name: "profiles/" + name + ".pprof", content: buf.Bytes()})
+	}
+
+	var buf bytes.Buffer
+	if err := pprof.StartCPUProfile(&buf); err != nil {
+		return nil, fmt.Errorf("starting CPU profile: %w", err)
+	}
+
+	timer := time.NewTimer(cpuProfileDuration)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+	case <-req.Context().Done():
+	}
+
+	pprof.StopCPUProfile()
+
+	if err := req.Context().Err(); err != nil {
+		return nil, fmt.Errorf("collecting CPU profile: %w", err)
+	}
+
+	return append(files, supportDumpFile{name: "profiles/cpu.pprof", content: buf.Bytes()}), nil
+}
+
+// anonymizedFile returns a support dump file holding the given content, redacted by the redactor.
+func anonymizedFile(name string, content any) (supportDumpFile, error) {
+	data, err := redactor.Anonymize(content)
+	if err != nil {
+		return supportDumpFile{}, fmt.Errorf("anonymizing %s: %w", name, err)
+	}
+
+	return supportDumpFile{name: name, content: []byte(data)}, nil
 }
 
 func addFile(tw *tar.Writer, name string, content []byte) error {
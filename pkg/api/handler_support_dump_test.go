@@ -8,21 +8,26 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
+	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/traefik/traefik/v3/pkg/config/dynamic"
 	"github.com/traefik/traefik/v3/pkg/config/runtime"
 	"github.com/traefik/traefik/v3/pkg/config/static"
+	"github.com/traefik/traefik/v3/pkg/observability/logs"
+	traefiktls "github.com/traefik/traefik/v3/pkg/tls"
 )
 
 func TestHandler_SupportDump(t *testing.T) {
 	testCases := []struct {
-		desc       string
-		path       string
-		confStatic static.Configuration
-		confDyn    runtime.Configuration
-		validate   func(t *testing.T, files map[string][]byte)
+		desc        string
+		path        string
+		confStatic  static.Configuration
+		confDyn     runtime.Configuration
+		supportDump SupportDumpSources
+		validate    func(t *testing.T, files map[string][]byte)
 	}{
 		{
 			desc:       "empty configurations",
@@ -83,6 +88,51 @@ func TestHandler_SupportDump(t *testing.T) {
 				assert.Contains(t, string(files["runtime-config.json"]), `"test-service"`)
 			},
 		},
+		{
+			desc:       "with profiles",
+			path:       "/api/support-dump?profiles=true&cpuProfileSeconds=1",
+			confStatic: static.Configuration{API: &static.API{}, Global: &static.Global{}},
+			validate: func(t *testing.T, files map[string][]byte) {
+				t.Helper()
+
+				assert.NotEmpty(t, files["profiles/goroutine.pprof"])
+				assert.NotEmpty(t, files["profiles/heap.pprof"])
+				assert.NotEmpty(t, files["profiles/cpu.pprof"])
+			},
+		},
+		{
+			desc:       "with logs, health, certificates and providers",
+			path:       "/api/support-dump?logs=1&health=true&certificates=true&providers=true",
+			confStatic: static.Configuration{API: &static.API{}, Global: &static.Global{}},
+			confDyn: runtime.Configuration{
+				Services: map[string]*runtime.ServiceInfo{
+					"test-service": newServiceInfoWithHealth("http://127.0.0.1:8080"),
+				},
+			},
+			supportDump: SupportDumpSources{
+				Logs: newLogBuffer(),
+				Certificates: func() []traefiktls.CertificateInfo {
+					return []traefiktls.CertificateInfo{{
+						Store:      "default",
+						CommonName: "example.com",
+						SANs:       []string{"example.com"},
+						NotBefore:  time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC),
+						NotAfter:   time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC),
+					}}
+				},
+				ProviderUpdates: func() map[string]time.Time {
+					return map[string]time.Time{"docker": time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)}
+				},
+			},
+			validate: func(t *testing.T, files map[string][]byte) {
+				t.Helper()
+
+				assert.JSONEq(t, `{"entries":[{"level":"warn","message":"Unable to reach xxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxx"}]}`, string(files["logs.json"]))
+				assert.JSONEq(t, `{"http":[{"service":"test-service","server":"xxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxx","status":"DOWN","health":{"lastCheck":"2025-01-01T00:00:00Z","lastCheckLatency":"0s","statusCode":503,"error":"received error status code: 503","consecutiveFailures":1}}]}`, string(files["health.json"]))
+				assert.JSONEq(t, `{"certificates":[{"store":"default","commonName":"xxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxx","sans":["xxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxx"],"notBefore":"2025-01-01T00:00:00Z","notAfter":"2026-01-01T00:00:00Z"}]}`, string(files["certificates.json"]))
+				assert.JSONEq(t, `{"lastUpdates":{"docker":"2025-01-01T00:00:00Z"},"errors":[{"level":"error","message":"Provider error","error":"dial tcp: lookup xxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxx no such host","providerName":"docker"}]}`, string(files["providers.json"]))
+			},
+		},
 	}
 
 	for _, test := range testCases {
@@ -90,6 +140,7 @@ func TestHandler_SupportDump(t *testing.T) {
 			t.Parallel()
 
 			handler := New(test.confStatic, &test.confDyn)
+			handler.supportDump = test.supportDump
 			server := httptest.NewServer(handler.createRouter())
 
 			resp, err := http.DefaultClient.Get(server.URL + test.path)
@@ -108,6 +159,85 @@ func TestHandler_SupportDump(t *testing.T) {
 	}
 }
 
+func TestHandler_SupportDump_errors(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		path     string
+		expected int
+	}{
+		{
+			desc:     "invalid boolean parameter",
+			path:     "/api/support-dump?health=foo",
+			expected: http.StatusBadRequest,
+		},
+		{
+			desc:     "invalid logs parameter",
+			path:     "/api/support-dump?logs=foo",
+			expected: http.StatusBadRequest,
+		},
+		{
+			desc:     "too long CPU profile",
+			path:     "/api/support-dump?profiles=true&cpuProfileSeconds=31",
+			expected: http.StatusBadRequest,
+		},
+		{
+			desc:     "logs not available",
+			path:     "/api/support-dump?logs=10",
+			expected: http.StatusNotFound,
+		},
+		{
+			desc:     "certificates not available",
+			path:     "/api/support-dump?certificates=true",
+			expected: http.StatusNotFound,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			handler := New(static.Configuration{API: &static.API{}, Global: &static.Global{}}, &runtime.Configuration{})
+			server := httptest.NewServer(handler.createRouter())
+
+			resp, err := http.DefaultClient.Get(server.URL + test.path)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, resp.StatusCode)
+		})
+	}
+}
+
+func newServiceInfoWithHealth(server string) *runtime.ServiceInfo {
+	serviceInfo := &runtime.ServiceInfo{
+		Service: &dynamic.Service{
+			LoadBalancer: &dynamic.ServersLoadBalancer{
+				Servers: []dynamic.Server{{URL: server}},
+			},
+		},
+		Status: runtime.StatusEnabled,
+	}
+
+	serviceInfo.UpdateServerStatus(server, "DOWN")
+	serviceInfo.RecordHealthCheck(server, runtime.HealthCheckResult{
+		Time:       time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC),
+		StatusCode: http.StatusServiceUnavailable,
+		Err:        errors.New("received error status code: 503"),
+	})
+
+	return serviceInfo
+}
+
+func newLogBuffer() *logs.Buffer {
+	buffer := logs.NewBuffer(10)
+
+	logger := zerolog.New(buffer)
+	logger.Error().Str(logs.ProviderName, "docker").Err(errors.New("dial tcp: lookup docker.example.com: no such host")).Msg("Provider error")
+	logger.Debug().RawJSON("config", []byte(`{"password":"secret"}`)).Msg("Configuration received")
+	logger.Warn().Msg("Unable to reach https://backend.example.com")
+
+	return buffer
+}
+
 // extractTarGz reads a tar.gz archive and returns a map of filename to contents
 func extractTarGz(r io.Reader) (map[string][]byte, error) {
 	files := make(map[string][]byte)
@@ -31,19 +31,23 @@ func newTCPRouterRepresentation(name string, rt *runtime.TCPRouterInfo) tcpRoute
 type tcpServiceRepresentation struct {
 	*runtime.TCPServiceInfo
 
-	Name         string            `json:"name,omitempty"`
-	Provider     string            `json:"provider,omitempty"`
-	Type         string            `json:"type,omitempty"`
-	ServerStatus map[string]string `json:"serverStatus,omitempty"`
+	Name            string                            `json:"name,omitempty"`
+	Provider        string                            `json:"provider,omitempty"`
+	Type            string                            `json:"type,omitempty"`
+	ServerStatus    map[string]string                 `json:"serverStatus,omitempty"`
+	ServerHealth    map[string]runtime.ServerHealth   `json:"serverHealth,omitempty"`
+	DrainingServers map[string]runtime.DrainingServer `json:"drainingServers,omitempty"`
 }
 
 func newTCPServiceRepresentation(name string, si *runtime.TCPServiceInfo) tcpServiceRepresentation {
 	return tcpServiceRepresentation{
-		TCPServiceInfo: si,
-		Name:           name,
-		Provider:       getProviderName(name),
-		Type:           strings.ToLower(extractType(si.TCPService)),
-		ServerStatus:   si.GetAllStatus(),
+		TCPServiceInfo:  si,
+		Name:            name,
+		Provider:        getProviderName(name),
+		Type:            strings.ToLower(extractType(si.TCPService)),
+		ServerStatus:    si.GetAllStatus(),
+		ServerHealth:    si.GetAllHealth(),
+		DrainingServers: si.GetAllDraining(),
 	}
 }
 
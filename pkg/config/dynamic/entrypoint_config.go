@@ -0,0 +1,82 @@
+package dynamic
+
+import (
+	"time"
+
+	ptypes "github.com/traefik/paerser/types"
+)
+
+// +k8s:deepcopy-gen=true
+
+// EntryPoint holds the configuration of an entry point opened at runtime.
+// Contrary to the entry points of the static configuration, it only supports TCP.
+type EntryPoint struct {
+	Address          string                      `json:"address,omitempty" toml:"address,omitempty" yaml:"address,omitempty" export:"true"`
+	Transport        *EntryPointTransport        `json:"transport,omitempty" toml:"transport,omitempty" yaml:"transport,omitempty" export:"true"`
+	ProxyProtocol    *EntryPointProxyProtocol    `json:"proxyProtocol,omitempty" toml:"proxyProtocol,omitempty" yaml:"proxyProtocol,omitempty" label:"allowEmpty" file:"allowEmpty" kv:"allowEmpty" export:"true"`
+	ForwardedHeaders *EntryPointForwardedHeaders `json:"forwardedHeaders,omitempty" toml:"forwardedHeaders,omitempty" yaml:"forwardedHeaders,omitempty" export:"true"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// EntryPointTransport configures communication between clients and Traefik.
+type EntryPointTransport struct {
+	LifeCycle            *EntryPointLifeCycle          `json:"lifeCycle,omitempty" toml:"lifeCycle,omitempty" yaml:"lifeCycle,omitempty" export:"true"`
+	RespondingTimeouts   *EntryPointRespondingTimeouts `json:"respondingTimeouts,omitempty" toml:"respondingTimeouts,omitempty" yaml:"respondingTimeouts,omitempty" export:"true"`
+	KeepAliveMaxTime     ptypes.Duration               `json:"keepAliveMaxTime,omitempty" toml:"keepAliveMaxTime,omitempty" yaml:"keepAliveMaxTime,omitempty" export:"true"`
+	KeepAliveMaxRequests int                           `json:"keepAliveMaxRequests,omitempty" toml:"keepAliveMaxRequests,omitempty" yaml:"keepAliveMaxRequests,omitempty" export:"true"`
+}
+
+// SetDefaults sets the default values for an EntryPointTransport.
+func (t *EntryPointTransport) SetDefaults() {
+	t.LifeCycle = &EntryPointLifeCycle{}
+	t.LifeCycle.SetDefaults()
+	t.RespondingTimeouts = &EntryPointRespondingTimeouts{}
+	t.RespondingTimeouts.SetDefaults()
+}
+
+// +k8s:deepcopy-gen=true
+
+// EntryPointLifeCycle contains configurations relevant to the lifecycle (such as the shutdown phase) of an entry point.
+// As the listener is closed as soon as the entry point is removed, there is no grace period to keep accepting requests.
+type EntryPointLifeCycle struct {
+	GraceTimeOut ptypes.Duration `json:"graceTimeOut,omitempty" toml:"graceTimeOut,omitempty" yaml:"graceTimeOut,omitempty" export:"true"`
+}
+
+// SetDefaults sets the default values for an EntryPointLifeCycle.
+func (l *EntryPointLifeCycle) SetDefaults() {
+	l.GraceTimeOut = ptypes.Duration(10 * time.Second)
+}
+
+// +k8s:deepcopy-gen=true
+
+// EntryPointRespondingTimeouts contains timeout configurations for incoming requests to the entry point.
+type EntryPointRespondingTimeouts struct {
+	ReadTimeout  ptypes.Duration `json:"readTimeout,omitempty" toml:"readTimeout,omitempty" yaml:"readTimeout,omitempty" export:"true"`
+	WriteTimeout ptypes.Duration `json:"writeTimeout,omitempty" toml:"writeTimeout,omitempty" yaml:"writeTimeout,omitempty" export:"true"`
+	IdleTimeout  ptypes.Duration `json:"idleTimeout,omitempty" toml:"idleTimeout,omitempty" yaml:"idleTimeout,omitempty" export:"true"`
+}
+
+// SetDefaults sets the default values for an EntryPointRespondingTimeouts.
+func (r *EntryPointRespondingTimeouts) SetDefaults() {
+	r.ReadTimeout = ptypes.Duration(60 * time.Second)
+	r.IdleTimeout = ptypes.Duration(180 * time.Second)
+}
+
+// +k8s:deepcopy-gen=true
+
+// EntryPointProxyProtocol contains the Proxy-Protocol configuration of an entry point.
+type EntryPointProxyProtocol struct {
+	Insecure   bool     `json:"insecure,omitempty" toml:"insecure,omitempty" yaml:"insecure,omitempty" export:"true"`
+	TrustedIPs []string `json:"trustedIPs,omitempty" toml:"trustedIPs,omitempty" yaml:"trustedIPs,omitempty"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// EntryPointForwardedHeaders configures which client forwarding headers are trusted by an entry point.
+type EntryPointForwardedHeaders struct {
+	Insecure               bool     `json:"insecure,omitempty" toml:"insecure,omitempty" yaml:"insecure,omitempty" export:"true"`
+	TrustedIPs             []string `json:"trustedIPs,omitempty" toml:"trustedIPs,omitempty" yaml:"trustedIPs,omitempty"`
+	Connection             []string `json:"connection,omitempty" toml:"connection,omitempty" yaml:"connection,omitempty"`
+	NotAppendXForwardedFor bool     `json:"notAppendXForwardedFor,omitempty" toml:"notAppendXForwardedFor,omitempty" yaml:"notAppendXForwardedFor,omitempty" export:"true"`
+}
@@ -25,10 +25,17 @@ const (
 	// DefaultFlushInterval is the default value for the ResponseForwarding flush interval.
 	DefaultFlushInterval = ptypes.Duration(100 * time.Millisecond)
 
+	// DefaultHTTP3FallbackDuration is the default value for the ServersTransport HTTP/3 fallback duration.
+	DefaultHTTP3FallbackDuration = ptypes.Duration(time.Minute)
+
 	// MirroringDefaultMirrorBody is the Mirroring.MirrorBody option default value.
 	MirroringDefaultMirrorBody = true
 	// MirroringDefaultMaxBodySize is the Mirroring.MaxBodySize option default value.
 	MirroringDefaultMaxBodySize int64 = -1
+	// MirroringCompareDefaultMaxBodySize is the Mirroring.Compare.MaxBodySize option default value.
+	MirroringCompareDefaultMaxBodySize int64 = 1024 * 1024
+	// MirroringCompareDefaultDiffsPercent is the Mirroring.Compare.DiffsPercent option default value.
+	MirroringCompareDefaultDiffsPercent = 100
 	// FailoverErrorsDefaultMaxRequestBodyBytes is the Failover.Errors.MaxBodySize option default value.
 	FailoverErrorsDefaultMaxRequestBodyBytes int64 = -1
 )
@@ -67,6 +74,7 @@ type Service struct {
 	Weighted            *WeightedRoundRobin  `json:"weighted,omitempty" toml:"weighted,omitempty" yaml:"weighted,omitempty" label:"-" export:"true"`
 	Mirroring           *Mirroring           `json:"mirroring,omitempty" toml:"mirroring,omitempty" yaml:"mirroring,omitempty" label:"-" export:"true"`
 	Failover            *Failover            `json:"failover,omitempty" toml:"failover,omitempty" yaml:"failover,omitempty" label:"-" export:"true"`
+	FileServer          *FileServer          `json:"fileServer,omitempty" toml:"fileServer,omitempty" yaml:"fileServer,omitempty" label:"-" export:"true"`
 }
 
 // Merge merges another Service into this one.
@@ -180,11 +188,12 @@ func (r *RouterObservabilityConfig) SetDefaults() {
 
 // Mirroring holds the Mirroring configuration.
 type Mirroring struct {
-	Service     string          `json:"service,omitempty" toml:"service,omitempty" yaml:"service,omitempty" export:"true"`
-	MirrorBody  *bool           `json:"mirrorBody,omitempty" toml:"mirrorBody,omitempty" yaml:"mirrorBody,omitempty" export:"true"`
-	MaxBodySize *int64          `json:"maxBodySize,omitempty" toml:"maxBodySize,omitempty" yaml:"maxBodySize,omitempty" export:"true"`
-	Mirrors     []MirrorService `json:"mirrors,omitempty" toml:"mirrors,omitempty" yaml:"mirrors,omitempty" export:"true"`
-	HealthCheck *HealthCheck    `json:"healthCheck,omitempty" toml:"healthCheck,omitempty" yaml:"healthCheck,omitempty" label:"allowEmpty" file:"allowEmpty" kv:"allowEmpty" export:"true"`
+	Service     string            `json:"service,omitempty" toml:"service,omitempty" yaml:"service,omitempty" export:"true"`
+	MirrorBody  *bool             `json:"mirrorBody,omitempty" toml:"mirrorBody,omitempty" yaml:"mirrorBody,omitempty" export:"true"`
+	MaxBodySize *int64            `json:"maxBodySize,omitempty" toml:"maxBodySize,omitempty" yaml:"maxBodySize,omitempty" export:"true"`
+	Mirrors     []MirrorService   `json:"mirrors,omitempty" toml:"mirrors,omitempty" yaml:"mirrors,omitempty" export:"true"`
+	HealthCheck *HealthCheck      `json:"healthCheck,omitempty" toml:"healthCheck,omitempty" yaml:"healthCheck,omitempty" label:"allowEmpty" file:"allowEmpty" kv:"allowEmpty" export:"true"`
+	Compare     *MirroringCompare `json:"compare,omitempty" toml:"compare,omitempty" yaml:"compare,omitempty" label:"allowEmpty" file:"allowEmpty" kv:"allowEmpty" export:"true"`
 }
 
 // SetDefaults Default values for a WRRService.
@@ -197,6 +206,32 @@ func (m *Mirroring) SetDefaults() {
 
 // +k8s:deepcopy-gen=true
 
+// MirroringCompare holds the configuration of the comparison of the mirror responses with the main service response.
+type MirroringCompare struct {
+	// Headers defines the names of the response headers to compare.
+	// When empty, all the response headers are compared, except the Date header.
+	Headers []string `json:"headers,omitempty" toml:"headers,omitempty" yaml:"headers,omitempty" export:"true"`
+	// IgnoreHeaders defines the names of the response headers to ignore.
+	IgnoreHeaders []string `json:"ignoreHeaders,omitempty" toml:"ignoreHeaders,omitempty" yaml:"ignoreHeaders,omitempty" export:"true"`
+	// IgnoreJSONPaths defines the paths of the fields to ignore in the JSON response bodies (e.g. meta.requestId or items.*.updatedAt).
+	IgnoreJSONPaths []string `json:"ignoreJSONPaths,omitempty" toml:"ignoreJSONPaths,omitempty" yaml:"ignoreJSONPaths,omitempty" export:"true"`
+	// MaxBodySize defines the maximum size in bytes of the response bodies to compare.
+	// The bodies are not compared when one of them is larger, and -1 means no limit.
+	MaxBodySize *int64 `json:"maxBodySize,omitempty" toml:"maxBodySize,omitempty" yaml:"maxBodySize,omitempty" export:"true"`
+	// DiffsPercent defines the percentage of the mismatches whose differences are logged.
+	DiffsPercent *int `json:"diffsPercent,omitempty" toml:"diffsPercent,omitempty" yaml:"diffsPercent,omitempty" export:"true"`
+}
+
+// SetDefaults sets the default values.
+func (m *MirroringCompare) SetDefaults() {
+	defaultMaxBodySize := MirroringCompareDefaultMaxBodySize
+	m.MaxBodySize = &defaultMaxBodySize
+	defaultDiffsPercent := MirroringCompareDefaultDiffsPercent
+	m.DiffsPercent = &defaultDiffsPercent
+}
+
+// +k8s:deepcopy-gen=true
+
 // Failover holds the Failover configuration.
 type Failover struct {
 	Service     string         `json:"service,omitempty" toml:"service,omitempty" yaml:"service,omitempty" export:"true"`
@@ -207,6 +242,29 @@ type Failover struct {
 
 // +k8s:deepcopy-gen=true
 
+// FileServer serves static files from a local directory.
+type FileServer struct {
+	// Root defines the path of the directory to serve the files from.
+	Root string `json:"root,omitempty" toml:"root,omitempty" yaml:"root,omitempty"`
+	// IndexFiles defines the names of the files served when a directory is requested, in order of preference.
+	IndexFiles []string `json:"indexFiles,omitempty" toml:"indexFiles,omitempty" yaml:"indexFiles,omitempty" export:"true"`
+	// SPAFallback enables the single-page application mode,
+	// where the requests for files which do not exist are answered with the index file of the root directory.
+	SPAFallback bool `json:"spaFallback,omitempty" toml:"spaFallback,omitempty" yaml:"spaFallback,omitempty" export:"true"`
+	// Precompressed enables serving the precompressed variants of the files (.br, .zst, .gz),
+	// according to the encodings accepted by the client.
+	Precompressed bool `json:"precompressed,omitempty" toml:"precompressed,omitempty" yaml:"precompressed,omitempty" export:"true"`
+	// Browse enables the listing of the directories without index file.
+	Browse bool `json:"browse,omitempty" toml:"browse,omitempty" yaml:"browse,omitempty" export:"true"`
+}
+
+// SetDefaults sets the default values.
+func (f *FileServer) SetDefaults() {
+	f.IndexFiles = []string{"index.html"}
+}
+
+// +k8s:deepcopy-gen=true
+
 // FailoverError holds errors configuration.
 type FailoverError struct {
 	MaxRequestBodyBytes *int64   `json:"maxRequestBodyBytes,omitempty" toml:"maxRequestBodyBytes,omitempty" yaml:"maxRequestBodyBytes,omitempty" export:"true"`
@@ -371,9 +429,15 @@ type ServersLoadBalancer struct {
 	HealthCheck *ServerHealthCheck `json:"healthCheck,omitempty" toml:"healthCheck,omitempty" yaml:"healthCheck,omitempty" export:"true"`
 	// PassiveHealthCheck enables passive health checks for children servers of this load-balancer.
 	PassiveHealthCheck *PassiveServerHealthCheck `json:"passiveHealthCheck,omitempty" toml:"passiveHealthCheck,omitempty" yaml:"passiveHealthCheck,omitempty" export:"true"`
-	PassHostHeader     *bool                     `json:"passHostHeader" toml:"passHostHeader" yaml:"passHostHeader" export:"true"`
-	ResponseForwarding *ResponseForwarding       `json:"responseForwarding,omitempty" toml:"responseForwarding,omitempty" yaml:"responseForwarding,omitempty" export:"true"`
-	ServersTransport   string                    `json:"serversTransport,omitempty" toml:"serversTransport,omitempty" yaml:"serversTransport,omitempty" export:"true"`
+	// CircuitBreaker attaches a circuit breaker to each server of this load-balancer.
+	// A server whose breaker is open is taken out of the load-balancing.
+	CircuitBreaker     *ServerCircuitBreaker `json:"circuitBreaker,omitempty" toml:"circuitBreaker,omitempty" yaml:"circuitBreaker,omitempty" export:"true"`
+	PassHostHeader     *bool                 `json:"passHostHeader" toml:"passHostHeader" yaml:"passHostHeader" export:"true"`
+	ResponseForwarding *ResponseForwarding   `json:"responseForwarding,omitempty" toml:"responseForwarding,omitempty" yaml:"responseForwarding,omitempty" export:"true"`
+	ServersTransport   string                `json:"serversTransport,omitempty" toml:"serversTransport,omitempty" yaml:"serversTransport,omitempty" export:"true"`
+	// DrainTimeout defines how long the upgraded connections (e.g. WebSocket) to a server which is removed from the configuration,
+	// or marked unhealthy, are kept before being closed. A zero value disables the connection draining.
+	DrainTimeout ptypes.Duration `json:"drainTimeout,omitempty" toml:"drainTimeout,omitempty" yaml:"drainTimeout,omitempty" export:"true"`
 }
 
 // Merge merges the other load balancer into this one.
@@ -495,6 +559,28 @@ func (p *PassiveServerHealthCheck) SetDefaults() {
 
 // +k8s:deepcopy-gen=true
 
+// ServerCircuitBreaker holds the configuration of the circuit breakers attached to the servers of a load-balancer.
+type ServerCircuitBreaker struct {
+	// Expression is the condition that opens the breaker of a server, evaluated on the server metrics.
+	Expression string `json:"expression,omitempty" toml:"expression,omitempty" yaml:"expression,omitempty" export:"true"`
+	// CheckPeriod is the interval between successive checks of the expression.
+	CheckPeriod ptypes.Duration `json:"checkPeriod,omitempty" toml:"checkPeriod,omitempty" yaml:"checkPeriod,omitempty" export:"true"`
+	// FallbackDuration is the duration for which a server is taken out of the load-balancing once its breaker is open.
+	FallbackDuration ptypes.Duration `json:"fallbackDuration,omitempty" toml:"fallbackDuration,omitempty" yaml:"fallbackDuration,omitempty" export:"true"`
+	// TrialRequests is the number of requests which must succeed, once the fallback duration is over (half-open state),
+	// to close the breaker. A failed trial request opens the breaker again.
+	TrialRequests int `json:"trialRequests,omitempty" toml:"trialRequests,omitempty" yaml:"trialRequests,omitempty" export:"true"`
+}
+
+// SetDefaults sets the default values on a ServerCircuitBreaker.
+func (c *ServerCircuitBreaker) SetDefaults() {
+	c.CheckPeriod = ptypes.Duration(100 * time.Millisecond)
+	c.FallbackDuration = ptypes.Duration(10 * time.Second)
+	c.TrialRequests = 1
+}
+
+// +k8s:deepcopy-gen=true
+
 // HealthCheck controls healthcheck awareness and propagation at the services level.
 type HealthCheck struct{}
 
@@ -519,6 +605,22 @@ type ServersTransport struct {
 	MaxIdleConns        int                     `description:"MaxIdleConns controls the maximum number of idle (keep-alive) connections across all hosts. Zero means no limit." json:"maxIdleConns,omitempty" toml:"maxIdleConns,omitempty" yaml:"maxIdleConns,omitempty" export:"true"`
 	MaxConnsPerHost     int                     `description:"MaxConnsPerHost optionally limits the total number of connections per host, including connections in the dialing, active, and idle states. On limit violation, dials will block. Zero means no limit." json:"maxConnsPerHost,omitempty" toml:"maxConnsPerHost,omitempty" yaml:"maxConnsPerHost,omitempty" export:"true"`
 	IdleConnTimeout     ptypes.Duration         `description:"The maximum amount of time an idle (keep-alive) connection will remain idle before closing itself. Zero means no limit." json:"idleConnTimeout,omitempty" toml:"idleConnTimeout,omitempty" yaml:"idleConnTimeout,omitempty" export:"true"`
+	HTTP3               *ServersTransportHTTP3  `description:"Enables HTTP/3 (QUIC) for the connections with the HTTPS backend servers." json:"http3,omitempty" toml:"http3,omitempty" yaml:"http3,omitempty" label:"allowEmpty" file:"allowEmpty" kv:"allowEmpty" export:"true"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// ServersTransportHTTP3 holds the HTTP/3 configuration of a ServersTransport.
+type ServersTransportHTTP3 struct {
+	Enable0RTT          bool            `description:"Enables sending the idempotent requests (GET and HEAD without body) as 0-RTT data on resumed connections." json:"enable0RTT,omitempty" toml:"enable0RTT,omitempty" yaml:"enable0RTT,omitempty" export:"true"`
+	ConnectionMigration bool            `description:"Migrates the connections to a new network path when the local address used to reach the backend server changes." json:"connectionMigration,omitempty" toml:"connectionMigration,omitempty" yaml:"connectionMigration,omitempty" export:"true"`
+	DisableTCPFallback  bool            `description:"Disables the fallback to TCP (HTTP/2 or HTTP/1.1) when a QUIC connection cannot be established." json:"disableTCPFallback,omitempty" toml:"disableTCPFallback,omitempty" yaml:"disableTCPFallback,omitempty" export:"true"`
+	FallbackDuration    ptypes.Duration `description:"Duration during which a backend server is contacted over TCP after a QUIC connection failure." json:"fallbackDuration,omitempty" toml:"fallbackDuration,omitempty" yaml:"fallbackDuration,omitempty" export:"true"`
+}
+
+// SetDefaults sets the default values.
+func (s *ServersTransportHTTP3) SetDefaults() {
+	s.FallbackDuration = DefaultHTTP3FallbackDuration
 }
 
 // +k8s:deepcopy-gen=true
@@ -47,6 +47,9 @@ type Middleware struct {
 	Retry             *Retry             `json:"retry,omitempty" toml:"retry,omitempty" yaml:"retry,omitempty" export:"true"`
 	ContentType       *ContentType       `json:"contentType,omitempty" toml:"contentType,omitempty" yaml:"contentType,omitempty" label:"allowEmpty" file:"allowEmpty" kv:"allowEmpty" export:"true"`
 	GrpcWeb           *GrpcWeb           `json:"grpcWeb,omitempty" toml:"grpcWeb,omitempty" yaml:"grpcWeb,omitempty" export:"true"`
+	FaultInjection    *FaultInjection    `json:"faultInjection,omitempty" toml:"faultInjection,omitempty" yaml:"faultInjection,omitempty" export:"true"`
+	RequestID         *RequestID         `json:"requestID,omitempty" toml:"requestID,omitempty" yaml:"requestID,omitempty" label:"allowEmpty" file:"allowEmpty" kv:"allowEmpty" export:"true"`
+	GeoBlock          *GeoBlock          `json:"geoBlock,omitempty" toml:"geoBlock,omitempty" yaml:"geoBlock,omitempty" export:"true"`
 
 	Plugin map[string]PluginConf `json:"plugin,omitempty" toml:"plugin,omitempty" yaml:"plugin,omitempty" export:"true"`
 
@@ -278,6 +281,53 @@ type ErrorPage struct {
 
 // +k8s:deepcopy-gen=true
 
+// FaultInjection holds the fault injection middleware configuration.
+// This middleware injects faults (delays, aborts, connection resets and bandwidth limits) into a percentage of the requests,
+// to test the resilience of the clients and services.
+type FaultInjection struct {
+	// Percentage defines the percentage of the matching requests into which the faults are injected.
+	// Default: 100.
+	Percentage int `json:"percentage,omitempty" toml:"percentage,omitempty" yaml:"percentage,omitempty" export:"true"`
+	// Headers restricts the fault injection to the requests having all the given headers, with the given values.
+	// An empty value matches any value of the header.
+	Headers map[string]string `json:"headers,omitempty" toml:"headers,omitempty" yaml:"headers,omitempty" export:"true"`
+	// Delay defines the delay added to the requests before they are forwarded.
+	Delay *FaultDelay `json:"delay,omitempty" toml:"delay,omitempty" yaml:"delay,omitempty" export:"true"`
+	// Abort defines the response returned in place of forwarding the requests.
+	Abort *FaultAbort `json:"abort,omitempty" toml:"abort,omitempty" yaml:"abort,omitempty" export:"true"`
+	// ResetConnection defines whether the client connection is reset in place of forwarding the requests.
+	ResetConnection bool `json:"resetConnection,omitempty" toml:"resetConnection,omitempty" yaml:"resetConnection,omitempty" export:"true"`
+	// ResponseBandwidth defines the maximum bandwidth of the responses, in bytes per second.
+	ResponseBandwidth int64 `json:"responseBandwidth,omitempty" toml:"responseBandwidth,omitempty" yaml:"responseBandwidth,omitempty" export:"true"`
+}
+
+// SetDefaults sets the default values on a FaultInjection.
+func (f *FaultInjection) SetDefaults() {
+	f.Percentage = 100
+}
+
+// +k8s:deepcopy-gen=true
+
+// FaultDelay holds the fault injection delay configuration.
+type FaultDelay struct {
+	// Duration defines the fixed delay added to the requests.
+	Duration ptypes.Duration `json:"duration,omitempty" toml:"duration,omitempty" yaml:"duration,omitempty" export:"true"`
+	// Jitter defines the maximum random delay added to the fixed delay.
+	Jitter ptypes.Duration `json:"jitter,omitempty" toml:"jitter,omitempty" yaml:"jitter,omitempty" export:"true"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// FaultAbort holds the fault injection abort configuration.
+type FaultAbort struct {
+	// StatusCode defines the HTTP status code of the abort response.
+	StatusCode int `json:"statusCode,omitempty" toml:"statusCode,omitempty" yaml:"statusCode,omitempty" export:"true"`
+	// GRPCStatusCode defines the gRPC status code of the abort response to the gRPC requests.
+	GRPCStatusCode int `json:"grpcStatusCode,omitempty" toml:"grpcStatusCode,omitempty" yaml:"grpcStatusCode,omitempty" export:"true"`
+}
+
+// +k8s:deepcopy-gen=true
+
 // ForwardAuth holds the forward auth middleware configuration.
 // This middleware delegates the request authentication to a Service.
 // More info: https://doc.traefik.io/traefik/v3.6/middlewares/http/forwardauth/
@@ -316,6 +366,13 @@ type ForwardAuth struct {
 	// Interpolate activates variable interpolation for Address and AuthSigninURL config options.
 	// Currently, this is only used by the NGINX provider to support variable substitution.
 	Interpolate bool `json:"interpolate,omitempty" toml:"-" yaml:"-" label:"-" file:"-" kv:"-" export:"true"`
+	// GRPC defines whether to call the authentication server with the gRPC external authorization API,
+	// compatible with the Envoy ext_authz Authorization/Check API, instead of HTTP.
+	// When enabled, Address is the gRPC target of the authorization server, such as authz.example.com:9191.
+	GRPC bool `json:"grpc,omitempty" toml:"grpc,omitempty" yaml:"grpc,omitempty" export:"true"`
+	// Cache defines the configuration of the authentication decisions cache.
+	// When set, the responses of the authentication server are reused for the requests sharing the same cache key.
+	Cache *ForwardAuthCache `json:"cache,omitempty" toml:"cache,omitempty" yaml:"cache,omitempty" label:"allowEmpty" file:"allowEmpty" kv:"allowEmpty" export:"true"`
 }
 
 func (f *ForwardAuth) SetDefaults() {
@@ -325,6 +382,47 @@ func (f *ForwardAuth) SetDefaults() {
 
 // +k8s:deepcopy-gen=true
 
+// ForwardAuthCache holds the ForwardAuth decisions cache configuration.
+type ForwardAuthCache struct {
+	// Key defines the request parts the cache key is computed from.
+	Key ForwardAuthCacheKey `json:"key,omitempty" toml:"key,omitempty" yaml:"key,omitempty" export:"true"`
+	// TTL defines how long the allow decisions are kept, unless the authentication server response has a Cache-Control header.
+	// Default value is 60s.
+	TTL ptypes.Duration `json:"ttl,omitempty" toml:"ttl,omitempty" yaml:"ttl,omitempty" export:"true"`
+	// NegativeTTL defines how long the deny decisions are kept, unless the authentication server response has a Cache-Control header.
+	// Default value is 0s, meaning that the deny decisions are not cached.
+	NegativeTTL ptypes.Duration `json:"negativeTTL,omitempty" toml:"negativeTTL,omitempty" yaml:"negativeTTL,omitempty" export:"true"`
+	// MaxEntries defines the maximum number of decisions kept in memory.
+	// Default value is 10000.
+	MaxEntries int `json:"maxEntries,omitempty" toml:"maxEntries,omitempty" yaml:"maxEntries,omitempty" export:"true"`
+	// Redis stores the decisions in Redis, to share them across the Traefik instances.
+	// When set, MaxEntries is not used.
+	Redis *Redis `json:"redis,omitempty" toml:"redis,omitempty" yaml:"redis,omitempty" export:"true"`
+}
+
+// SetDefaults sets the default values on a ForwardAuthCache.
+func (c *ForwardAuthCache) SetDefaults() {
+	c.TTL = ptypes.Duration(60 * time.Second)
+	c.MaxEntries = 10000
+}
+
+// +k8s:deepcopy-gen=true
+
+// ForwardAuthCacheKey defines the request parts the ForwardAuth cache key is computed from.
+type ForwardAuthCacheKey struct {
+	// Headers defines the request headers part of the cache key, such as Authorization.
+	Headers []string `json:"headers,omitempty" toml:"headers,omitempty" yaml:"headers,omitempty" export:"true"`
+	// Cookies defines the request cookies part of the cache key.
+	Cookies []string `json:"cookies,omitempty" toml:"cookies,omitempty" yaml:"cookies,omitempty" export:"true"`
+	// Method defines whether the request method is part of the cache key.
+	Method bool `json:"method,omitempty" toml:"method,omitempty" yaml:"method,omitempty" export:"true"`
+	// PathSegments defines the number of leading request path segments part of the cache key.
+	// Default value is 0, meaning that the path is not part of the cache key.
+	PathSegments int `json:"pathSegments,omitempty" toml:"pathSegments,omitempty" yaml:"pathSegments,omitempty" export:"true"`
+}
+
+// +k8s:deepcopy-gen=true
+
 // ClientTLS holds TLS specific configurations as client
 // CA, Cert and Key can be either path or file contents.
 // TODO: remove this struct when CAOptional option will be removed.
@@ -339,6 +437,30 @@ type ClientTLS struct {
 
 // +k8s:deepcopy-gen=true
 
+// GeoBlock holds the GeoBlock middleware configuration.
+// This middleware allows or denies the requests based on the country and the autonomous system of the client IP,
+// resolved with the GeoIP databases of the static configuration.
+type GeoBlock struct {
+	// AllowedCountries defines the ISO 3166-1 alpha-2 codes of the allowed countries.
+	AllowedCountries []string `json:"allowedCountries,omitempty" toml:"allowedCountries,omitempty" yaml:"allowedCountries,omitempty"`
+	// DeniedCountries defines the ISO 3166-1 alpha-2 codes of the denied countries.
+	DeniedCountries []string `json:"deniedCountries,omitempty" toml:"deniedCountries,omitempty" yaml:"deniedCountries,omitempty"`
+	// AllowedASNs defines the numbers of the allowed autonomous systems.
+	AllowedASNs []int `json:"allowedASNs,omitempty" toml:"allowedASNs,omitempty" yaml:"allowedASNs,omitempty"`
+	// DeniedASNs defines the numbers of the denied autonomous systems.
+	DeniedASNs []int `json:"deniedASNs,omitempty" toml:"deniedASNs,omitempty" yaml:"deniedASNs,omitempty"`
+	// DenyUnknown defines whether the requests from the IPs having neither a known country nor a known autonomous system are denied.
+	DenyUnknown bool `json:"denyUnknown,omitempty" toml:"denyUnknown,omitempty" yaml:"denyUnknown,omitempty" export:"true"`
+	// IPStrategy defines how the client IP is resolved.
+	// If not set, the ipStrategy of the GeoIP static configuration is used.
+	IPStrategy *IPStrategy `json:"ipStrategy,omitempty" toml:"ipStrategy,omitempty" yaml:"ipStrategy,omitempty" label:"allowEmpty" file:"allowEmpty" kv:"allowEmpty" export:"true"`
+	// RejectStatusCode defines the HTTP status code used for refused requests.
+	// If not set, the default is 403 (Forbidden).
+	RejectStatusCode int `json:"rejectStatusCode,omitempty" toml:"rejectStatusCode,omitempty" yaml:"rejectStatusCode,omitempty" label:"allowEmpty" file:"allowEmpty" kv:"allowEmpty" export:"true"`
+}
+
+// +k8s:deepcopy-gen=true
+
 // Headers holds the headers middleware configuration.
 // This middleware manages the requests and responses headers.
 // More info: https://doc.traefik.io/traefik/v3.6/middlewares/http/headers/#customrequestheaders
@@ -475,11 +597,11 @@ func (h *Headers) HasSecureHeadersDefined() bool {
 type IPStrategy struct {
 	// Depth tells Traefik to use the X-Forwarded-For header and take the IP located at the depth position (starting from the right).
 	// +kubebuilder:validation:Minimum=0
-	Depth int `json:"depth,omitempty" toml:"depth,omitempty" yaml:"depth,omitempty" export:"true"`
+	Depth int `description:"Uses the X-Forwarded-For header and takes the IP located at the depth position (starting from the right)." json:"depth,omitempty" toml:"depth,omitempty" yaml:"depth,omitempty" export:"true"`
 	// ExcludedIPs configures Traefik to scan the X-Forwarded-For header and select the first IP not in the list.
-	ExcludedIPs []string `json:"excludedIPs,omitempty" toml:"excludedIPs,omitempty" yaml:"excludedIPs,omitempty"`
+	ExcludedIPs []string `description:"Scans the X-Forwarded-For header and selects the first IP not in the list." json:"excludedIPs,omitempty" toml:"excludedIPs,omitempty" yaml:"excludedIPs,omitempty"`
 	// IPv6Subnet configures Traefik to consider all IPv6 addresses from the defined subnet as originating from the same IP. Applies to RemoteAddrStrategy and DepthStrategy.
-	IPv6Subnet *int `json:"ipv6Subnet,omitempty" toml:"ipv6Subnet,omitempty" yaml:"ipv6Subnet,omitempty"`
+	IPv6Subnet *int `description:"Considers all the IPv6 addresses from the defined subnet as originating from the same IP." json:"ipv6Subnet,omitempty" toml:"ipv6Subnet,omitempty" yaml:"ipv6Subnet,omitempty"`
 	// TODO(mpl): I think we should make RemoteAddr an explicit field. For one thing, it would yield better documentation.
 }
 
@@ -746,6 +868,29 @@ type ReplacePathRegex struct {
 
 // +k8s:deepcopy-gen=true
 
+// RequestID holds the request ID middleware configuration.
+// This middleware propagates the ID of the requests, or generates one, to correlate the Traefik logs and traces with the ones of the services.
+type RequestID struct {
+	// HeaderName defines the name of the header holding the request ID, in the requests and in the responses.
+	// Default: X-Request-Id.
+	HeaderName string `json:"headerName,omitempty" toml:"headerName,omitempty" yaml:"headerName,omitempty" export:"true"`
+	// Generator defines the format of the generated request IDs: uuidv7 or ulid.
+	// Default: uuidv7.
+	Generator string `json:"generator,omitempty" toml:"generator,omitempty" yaml:"generator,omitempty" export:"true"`
+	// FromTraceID defines whether the trace ID of the traced requests is used as request ID, instead of a generated one.
+	FromTraceID bool `json:"fromTraceID,omitempty" toml:"fromTraceID,omitempty" yaml:"fromTraceID,omitempty" export:"true"`
+	// IgnoreIncoming defines whether the request ID sent by the client is replaced with a new one.
+	IgnoreIncoming bool `json:"ignoreIncoming,omitempty" toml:"ignoreIncoming,omitempty" yaml:"ignoreIncoming,omitempty" export:"true"`
+}
+
+// SetDefaults sets the default values on a RequestID.
+func (r *RequestID) SetDefaults() {
+	r.HeaderName = "X-Request-Id"
+	r.Generator = "uuidv7"
+}
+
+// +k8s:deepcopy-gen=true
+
 // Retry holds the retry middleware configuration.
 // This middleware reissues requests a given number of times to a backend server if that server does not reply.
 // As soon as the server answers, the middleware stops retrying, regardless of the response status.
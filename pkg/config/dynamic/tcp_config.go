@@ -111,6 +111,9 @@ type TCPServersLoadBalancer struct {
 	// Deprecated: use ServersTransport to configure the TerminationDelay instead.
 	TerminationDelay *int                  `json:"terminationDelay,omitempty" toml:"terminationDelay,omitempty" yaml:"terminationDelay,omitempty" export:"true"`
 	HealthCheck      *TCPServerHealthCheck `json:"healthCheck,omitempty" toml:"healthCheck,omitempty" yaml:"healthCheck,omitempty" label:"allowEmpty" file:"allowEmpty" kv:"allowEmpty" export:"true"`
+	// DrainTimeout defines how long the connections to a server which is removed from the configuration,
+	// or marked unhealthy, are kept before being closed. A zero value disables the connection draining.
+	DrainTimeout ptypes.Duration `json:"drainTimeout,omitempty" toml:"drainTimeout,omitempty" yaml:"drainTimeout,omitempty" export:"true"`
 }
 
 // Merge merges the other load balancer into this one.
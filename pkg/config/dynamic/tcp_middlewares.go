@@ -9,6 +9,8 @@ type TCPMiddleware struct {
 	IPWhiteList *TCPIPWhiteList `json:"ipWhiteList,omitempty" toml:"ipWhiteList,omitempty" yaml:"ipWhiteList,omitempty" export:"true"`
 	IPAllowList *TCPIPAllowList `json:"ipAllowList,omitempty" toml:"ipAllowList,omitempty" yaml:"ipAllowList,omitempty" export:"true"`
 
+	Plugin map[string]PluginConf `json:"plugin,omitempty" toml:"plugin,omitempty" yaml:"plugin,omitempty" export:"true"`
+
 	// Anyone extension
 	Anyone map[string]any `json:"anyone,omitempty" toml:"anyone,omitempty" yaml:"anyone,omitempty" export:"true"`
 }
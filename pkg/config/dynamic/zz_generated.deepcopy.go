@@ -223,6 +223,22 @@ func (in *Configuration) DeepCopyInto(out *Configuration) {
 		*out = new(TLSConfiguration)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.EntryPoints != nil {
+		in, out := &in.EntryPoints, &out.EntryPoints
+		*out = make(map[string]*EntryPoint, len(*in))
+		for key, val := range *in {
+			var outVal *EntryPoint
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				inVal := (*in)[key]
+				in, out := &inVal, &outVal
+				*out = new(EntryPoint)
+				(*in).DeepCopyInto(*out)
+			}
+			(*out)[key] = outVal
+		}
+	}
 	return
 }
 
@@ -345,6 +361,142 @@ func (in *EncodedCharacters) DeepCopy() *EncodedCharacters {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EntryPoint) DeepCopyInto(out *EntryPoint) {
+	*out = *in
+	if in.Transport != nil {
+		in, out := &in.Transport, &out.Transport
+		*out = new(EntryPointTransport)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ProxyProtocol != nil {
+		in, out := &in.ProxyProtocol, &out.ProxyProtocol
+		*out = new(EntryPointProxyProtocol)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ForwardedHeaders != nil {
+		in, out := &in.ForwardedHeaders, &out.ForwardedHeaders
+		*out = new(EntryPointForwardedHeaders)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EntryPoint.
+func (in *EntryPoint) DeepCopy() *EntryPoint {
+	if in == nil {
+		return nil
+	}
+	out := new(EntryPoint)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EntryPointForwardedHeaders) DeepCopyInto(out *EntryPointForwardedHeaders) {
+	*out = *in
+	if in.TrustedIPs != nil {
+		in, out := &in.TrustedIPs, &out.TrustedIPs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Connection != nil {
+		in, out := &in.Connection, &out.Connection
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EntryPointForwardedHeaders.
+func (in *EntryPointForwardedHeaders) DeepCopy() *EntryPointForwardedHeaders {
+	if in == nil {
+		return nil
+	}
+	out := new(EntryPointForwardedHeaders)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EntryPointLifeCycle) DeepCopyInto(out *EntryPointLifeCycle) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EntryPointLifeCycle.
+func (in *EntryPointLifeCycle) DeepCopy() *EntryPointLifeCycle {
+	if in == nil {
+		return nil
+	}
+	out := new(EntryPointLifeCycle)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EntryPointProxyProtocol) DeepCopyInto(out *EntryPointProxyProtocol) {
+	*out = *in
+	if in.TrustedIPs != nil {
+		in, out := &in.TrustedIPs, &out.TrustedIPs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EntryPointProxyProtocol.
+func (in *EntryPointProxyProtocol) DeepCopy() *EntryPointProxyProtocol {
+	if in == nil {
+		return nil
+	}
+	out := new(EntryPointProxyProtocol)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EntryPointRespondingTimeouts) DeepCopyInto(out *EntryPointRespondingTimeouts) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EntryPointRespondingTimeouts.
+func (in *EntryPointRespondingTimeouts) DeepCopy() *EntryPointRespondingTimeouts {
+	if in == nil {
+		return nil
+	}
+	out := new(EntryPointRespondingTimeouts)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EntryPointTransport) DeepCopyInto(out *EntryPointTransport) {
+	*out = *in
+	if in.LifeCycle != nil {
+		in, out := &in.LifeCycle, &out.LifeCycle
+		*out = new(EntryPointLifeCycle)
+		**out = **in
+	}
+	if in.RespondingTimeouts != nil {
+		in, out := &in.RespondingTimeouts, &out.RespondingTimeouts
+		*out = new(EntryPointRespondingTimeouts)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EntryPointTransport.
+func (in *EntryPointTransport) DeepCopy() *EntryPointTransport {
+	if in == nil {
+		return nil
+	}
+	out := new(EntryPointTransport)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ErrorPage) DeepCopyInto(out *ErrorPage) {
 	*out = *in
@@ -444,6 +596,92 @@ func (in *FailoverError) DeepCopy() *FailoverError {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FaultAbort) DeepCopyInto(out *FaultAbort) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FaultAbort.
+func (in *FaultAbort) DeepCopy() *FaultAbort {
+	if in == nil {
+		return nil
+	}
+	out := new(FaultAbort)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FaultDelay) DeepCopyInto(out *FaultDelay) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FaultDelay.
+func (in *FaultDelay) DeepCopy() *FaultDelay {
+	if in == nil {
+		return nil
+	}
+	out := new(FaultDelay)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FaultInjection) DeepCopyInto(out *FaultInjection) {
+	*out = *in
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Delay != nil {
+		in, out := &in.Delay, &out.Delay
+		*out = new(FaultDelay)
+		**out = **in
+	}
+	if in.Abort != nil {
+		in, out := &in.Abort, &out.Abort
+		*out = new(FaultAbort)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FaultInjection.
+func (in *FaultInjection) DeepCopy() *FaultInjection {
+	if in == nil {
+		return nil
+	}
+	out := new(FaultInjection)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FileServer) DeepCopyInto(out *FileServer) {
+	*out = *in
+	if in.IndexFiles != nil {
+		in, out := &in.IndexFiles, &out.IndexFiles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FileServer.
+func (in *FileServer) DeepCopy() *FileServer {
+	if in == nil {
+		return nil
+	}
+	out := new(FileServer)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ForwardAuth) DeepCopyInto(out *ForwardAuth) {
 	*out = *in
@@ -477,6 +715,11 @@ func (in *ForwardAuth) DeepCopyInto(out *ForwardAuth) {
 		*out = new(int64)
 		**out = **in
 	}
+	if in.Cache != nil {
+		in, out := &in.Cache, &out.Cache
+		*out = new(ForwardAuthCache)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -490,6 +733,54 @@ func (in *ForwardAuth) DeepCopy() *ForwardAuth {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ForwardAuthCache) DeepCopyInto(out *ForwardAuthCache) {
+	*out = *in
+	in.Key.DeepCopyInto(&out.Key)
+	if in.Redis != nil {
+		in, out := &in.Redis, &out.Redis
+		*out = new(Redis)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ForwardAuthCache.
+func (in *ForwardAuthCache) DeepCopy() *ForwardAuthCache {
+	if in == nil {
+		return nil
+	}
+	out := new(ForwardAuthCache)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ForwardAuthCacheKey) DeepCopyInto(out *ForwardAuthCacheKey) {
+	*out = *in
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Cookies != nil {
+		in, out := &in.Cookies, &out.Cookies
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ForwardAuthCacheKey.
+func (in *ForwardAuthCacheKey) DeepCopy() *ForwardAuthCacheKey {
+	if in == nil {
+		return nil
+	}
+	out := new(ForwardAuthCacheKey)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ForwardingTimeouts) DeepCopyInto(out *ForwardingTimeouts) {
 	*out = *in
@@ -522,6 +813,47 @@ func (in *GRPCStatus) DeepCopy() *GRPCStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GeoBlock) DeepCopyInto(out *GeoBlock) {
+	*out = *in
+	if in.AllowedCountries != nil {
+		in, out := &in.AllowedCountries, &out.AllowedCountries
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DeniedCountries != nil {
+		in, out := &in.DeniedCountries, &out.DeniedCountries
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowedASNs != nil {
+		in, out := &in.AllowedASNs, &out.AllowedASNs
+		*out = make([]int, len(*in))
+		copy(*out, *in)
+	}
+	if in.DeniedASNs != nil {
+		in, out := &in.DeniedASNs, &out.DeniedASNs
+		*out = make([]int, len(*in))
+		copy(*out, *in)
+	}
+	if in.IPStrategy != nil {
+		in, out := &in.IPStrategy, &out.IPStrategy
+		*out = new(IPStrategy)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GeoBlock.
+func (in *GeoBlock) DeepCopy() *GeoBlock {
+	if in == nil {
+		return nil
+	}
+	out := new(GeoBlock)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GrpcWeb) DeepCopyInto(out *GrpcWeb) {
 	*out = *in
@@ -1080,6 +1412,21 @@ func (in *Middleware) DeepCopyInto(out *Middleware) {
 		*out = new(GrpcWeb)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.FaultInjection != nil {
+		in, out := &in.FaultInjection, &out.FaultInjection
+		*out = new(FaultInjection)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RequestID != nil {
+		in, out := &in.RequestID, &out.RequestID
+		*out = new(RequestID)
+		**out = **in
+	}
+	if in.GeoBlock != nil {
+		in, out := &in.GeoBlock, &out.GeoBlock
+		*out = new(GeoBlock)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Plugin != nil {
 		in, out := &in.Plugin, &out.Plugin
 		*out = make(map[string]PluginConf, len(*in))
@@ -1164,6 +1511,11 @@ func (in *Mirroring) DeepCopyInto(out *Mirroring) {
 		*out = new(HealthCheck)
 		**out = **in
 	}
+	if in.Compare != nil {
+		in, out := &in.Compare, &out.Compare
+		*out = new(MirroringCompare)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1177,6 +1529,47 @@ func (in *Mirroring) DeepCopy() *Mirroring {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MirroringCompare) DeepCopyInto(out *MirroringCompare) {
+	*out = *in
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.IgnoreHeaders != nil {
+		in, out := &in.IgnoreHeaders, &out.IgnoreHeaders
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.IgnoreJSONPaths != nil {
+		in, out := &in.IgnoreJSONPaths, &out.IgnoreJSONPaths
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MaxBodySize != nil {
+		in, out := &in.MaxBodySize, &out.MaxBodySize
+		*out = new(int64)
+		**out = **in
+	}
+	if in.DiffsPercent != nil {
+		in, out := &in.DiffsPercent, &out.DiffsPercent
+		*out = new(int)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MirroringCompare.
+func (in *MirroringCompare) DeepCopy() *MirroringCompare {
+	if in == nil {
+		return nil
+	}
+	out := new(MirroringCompare)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Model) DeepCopyInto(out *Model) {
 	*out = *in
@@ -1439,6 +1832,22 @@ func (in *RequestRedirect) DeepCopy() *RequestRedirect {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RequestID) DeepCopyInto(out *RequestID) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RequestID.
+func (in *RequestID) DeepCopy() *RequestID {
+	if in == nil {
+		return nil
+	}
+	out := new(RequestID)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ResponseForwarding) DeepCopyInto(out *ResponseForwarding) {
 	*out = *in
@@ -1642,6 +2051,22 @@ func (in *Server) DeepCopy() *Server {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServerCircuitBreaker) DeepCopyInto(out *ServerCircuitBreaker) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServerCircuitBreaker.
+func (in *ServerCircuitBreaker) DeepCopy() *ServerCircuitBreaker {
+	if in == nil {
+		return nil
+	}
+	out := new(ServerCircuitBreaker)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ServerHealthCheck) DeepCopyInto(out *ServerHealthCheck) {
 	*out = *in
@@ -1700,6 +2125,11 @@ func (in *ServersLoadBalancer) DeepCopyInto(out *ServersLoadBalancer) {
 		*out = new(PassiveServerHealthCheck)
 		**out = **in
 	}
+	if in.CircuitBreaker != nil {
+		in, out := &in.CircuitBreaker, &out.CircuitBreaker
+		*out = new(ServerCircuitBreaker)
+		**out = **in
+	}
 	if in.PassHostHeader != nil {
 		in, out := &in.PassHostHeader, &out.PassHostHeader
 		*out = new(bool)
@@ -1751,6 +2181,11 @@ func (in *ServersTransport) DeepCopyInto(out *ServersTransport) {
 		*out = new(Spiffe)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.HTTP3 != nil {
+		in, out := &in.HTTP3, &out.HTTP3
+		*out = new(ServersTransportHTTP3)
+		**out = **in
+	}
 	return
 }
 
@@ -1764,6 +2199,22 @@ func (in *ServersTransport) DeepCopy() *ServersTransport {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServersTransportHTTP3) DeepCopyInto(out *ServersTransportHTTP3) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServersTransportHTTP3.
+func (in *ServersTransportHTTP3) DeepCopy() *ServersTransportHTTP3 {
+	if in == nil {
+		return nil
+	}
+	out := new(ServersTransportHTTP3)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Service) DeepCopyInto(out *Service) {
 	*out = *in
@@ -1797,6 +2248,11 @@ func (in *Service) DeepCopyInto(out *Service) {
 		*out = new(Failover)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.FileServer != nil {
+		in, out := &in.FileServer, &out.FileServer
+		*out = new(FileServer)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -2087,6 +2543,13 @@ func (in *TCPMiddleware) DeepCopyInto(out *TCPMiddleware) {
 		*out = new(TCPIPAllowList)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Plugin != nil {
+		in, out := &in.Plugin, &out.Plugin
+		*out = make(map[string]PluginConf, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
 	return
 }
 
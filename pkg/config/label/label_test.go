@@ -52,6 +52,7 @@ func TestDecodeConfiguration(t *testing.T) {
 		"traefik.http.middlewares.Middleware7.forwardauth.tls.key":                                 "foobar",
 		"traefik.http.middlewares.Middleware7.forwardauth.trustforwardheader":                      "true",
 		"traefik.http.middlewares.Middleware7.forwardauth.forwardbody":                             "true",
+		"traefik.http.middlewares.Middleware7.forwardauth.grpc":                                    "true",
 		"traefik.http.middlewares.Middleware7.forwardauth.maxbodysize":                             "42",
 		"traefik.http.middlewares.Middleware7.forwardauth.preserveRequestMethod":                   "true",
 		"traefik.http.middlewares.Middleware7.forwardauth.maxresponsebodysize":                     "42",
@@ -178,6 +179,7 @@ func TestDecodeConfiguration(t *testing.T) {
 		"traefik.http.services.Service0.loadbalancer.healthcheck.timeout":              "1s",
 		"traefik.http.services.Service0.loadbalancer.healthcheck.followredirects":      "true",
 		"traefik.http.services.Service0.loadbalancer.passhostheader":                   "true",
+		"traefik.http.services.Service0.loadbalancer.draintimeout":                     "42s",
 		"traefik.http.services.Service0.loadbalancer.responseforwarding.flushinterval": "1s",
 		"traefik.http.services.Service0.loadbalancer.strategy":                         "foobar",
 		"traefik.http.services.Service0.loadbalancer.server.url":                       "foobar",
@@ -203,6 +205,7 @@ func TestDecodeConfiguration(t *testing.T) {
 		"traefik.http.services.Service1.loadbalancer.healthcheck.timeout":              "1s",
 		"traefik.http.services.Service1.loadbalancer.healthcheck.followredirects":      "true",
 		"traefik.http.services.Service1.loadbalancer.passhostheader":                   "true",
+		"traefik.http.services.Service1.loadbalancer.draintimeout":                     "42s",
 		"traefik.http.services.Service1.loadbalancer.responseforwarding.flushinterval": "1s",
 		"traefik.http.services.Service1.loadbalancer.strategy":                         "foobar",
 		"traefik.http.services.Service1.loadbalancer.server.url":                       "foobar",
@@ -229,10 +232,12 @@ func TestDecodeConfiguration(t *testing.T) {
 		"traefik.tcp.routers.Router1.tls.passthrough":                      "false",
 		"traefik.tcp.services.Service0.loadbalancer.server.Port":           "42",
 		"traefik.tcp.services.Service0.loadbalancer.TerminationDelay":      "42",
+		"traefik.tcp.services.Service0.loadbalancer.DrainTimeout":          "42s",
 		"traefik.tcp.services.Service0.loadbalancer.proxyProtocol.version": "42",
 		"traefik.tcp.services.Service0.loadbalancer.serversTransport":      "foo",
 		"traefik.tcp.services.Service1.loadbalancer.server.Port":           "42",
 		"traefik.tcp.services.Service1.loadbalancer.TerminationDelay":      "42",
+		"traefik.tcp.services.Service1.loadbalancer.DrainTimeout":          "42s",
 		"traefik.tcp.services.Service1.loadbalancer.proxyProtocol":         "true",
 		"traefik.tcp.services.Service1.loadbalancer.serversTransport":      "foo",
 
@@ -304,6 +309,7 @@ func TestDecodeConfiguration(t *testing.T) {
 						TerminationDelay: pointer(42),
 						ProxyProtocol:    &dynamic.ProxyProtocol{Version: 42},
 						ServersTransport: "foo",
+						DrainTimeout:     ptypes.Duration(42 * time.Second),
 					},
 				},
 				"Service1": {
@@ -316,6 +322,7 @@ func TestDecodeConfiguration(t *testing.T) {
 						TerminationDelay: pointer(42),
 						ProxyProtocol:    &dynamic.ProxyProtocol{Version: 2},
 						ServersTransport: "foo",
+						DrainTimeout:     ptypes.Duration(42 * time.Second),
 					},
 				},
 			},
@@ -599,6 +606,7 @@ func TestDecodeConfiguration(t *testing.T) {
 						MaxBodySize:           pointer(int64(42)),
 						PreserveRequestMethod: true,
 						MaxResponseBodySize:   pointer[int64](42),
+						GRPC:                  true,
 					},
 				},
 				"Middleware8": {
@@ -736,6 +744,7 @@ func TestDecodeConfiguration(t *testing.T) {
 							FlushInterval: ptypes.Duration(time.Second),
 						},
 						ServersTransport: "foobar",
+						DrainTimeout:     ptypes.Duration(42 * time.Second),
 					},
 				},
 				"Service1": {
@@ -771,6 +780,7 @@ func TestDecodeConfiguration(t *testing.T) {
 							FlushInterval: ptypes.Duration(time.Second),
 						},
 						ServersTransport: "foobar",
+						DrainTimeout:     ptypes.Duration(42 * time.Second),
 					},
 				},
 			},
@@ -848,6 +858,7 @@ func TestEncodeConfiguration(t *testing.T) {
 						},
 						ServersTransport: "foo",
 						TerminationDelay: pointer(42),
+						DrainTimeout:     ptypes.Duration(42 * time.Second),
 					},
 				},
 				"Service1": {
@@ -859,6 +870,7 @@ func TestEncodeConfiguration(t *testing.T) {
 						},
 						ServersTransport: "foo",
 						TerminationDelay: pointer(42),
+						DrainTimeout:     ptypes.Duration(42 * time.Second),
 					},
 				},
 			},
@@ -1159,6 +1171,7 @@ func TestEncodeConfiguration(t *testing.T) {
 						MaxBodySize:           pointer(int64(42)),
 						PreserveRequestMethod: true,
 						MaxResponseBodySize:   pointer[int64](42),
+						GRPC:                  true,
 					},
 				},
 				"Middleware8": {
@@ -1285,6 +1298,7 @@ func TestEncodeConfiguration(t *testing.T) {
 							FlushInterval: ptypes.Duration(time.Second),
 						},
 						ServersTransport: "foobar",
+						DrainTimeout:     ptypes.Duration(42 * time.Second),
 					},
 				},
 				"Service1": {
@@ -1318,6 +1332,7 @@ func TestEncodeConfiguration(t *testing.T) {
 							FlushInterval: ptypes.Duration(time.Second),
 						},
 						ServersTransport: "foobar",
+						DrainTimeout:     ptypes.Duration(42 * time.Second),
 					},
 				},
 			},
@@ -1370,6 +1385,7 @@ func TestEncodeConfiguration(t *testing.T) {
 		"traefik.HTTP.Middlewares.Middleware7.ForwardAuth.AuthResponseHeaders":                     "foobar, fiibar",
 		"traefik.HTTP.Middlewares.Middleware7.ForwardAuth.AuthRequestHeaders":                      "foobar, fiibar",
 		"traefik.HTTP.Middlewares.Middleware7.ForwardAuth.ForwardBody":                             "true",
+		"traefik.HTTP.Middlewares.Middleware7.ForwardAuth.GRPC":                                    "true",
 		"traefik.HTTP.Middlewares.Middleware7.ForwardAuth.MaxBodySize":                             "42",
 		"traefik.HTTP.Middlewares.Middleware7.ForwardAuth.TLS.CA":                                  "foobar",
 		"traefik.HTTP.Middlewares.Middleware7.ForwardAuth.TLS.CAOptional":                          "true",
@@ -1508,6 +1524,7 @@ func TestEncodeConfiguration(t *testing.T) {
 		"traefik.HTTP.Services.Service0.LoadBalancer.HealthCheck.Port":                 "42",
 		"traefik.HTTP.Services.Service0.LoadBalancer.HealthCheck.Scheme":               "foobar",
 		"traefik.HTTP.Services.Service0.LoadBalancer.HealthCheck.Timeout":              "1000000000",
+		"traefik.HTTP.Services.Service0.LoadBalancer.DrainTimeout":                     "42000000000",
 		"traefik.HTTP.Services.Service0.LoadBalancer.PassHostHeader":                   "true",
 		"traefik.HTTP.Services.Service0.LoadBalancer.ResponseForwarding.FlushInterval": "1000000000",
 		"traefik.HTTP.Services.Service0.LoadBalancer.Strategy":                         "foobar",
@@ -1533,6 +1550,7 @@ func TestEncodeConfiguration(t *testing.T) {
 		"traefik.HTTP.Services.Service1.LoadBalancer.HealthCheck.Port":                 "42",
 		"traefik.HTTP.Services.Service1.LoadBalancer.HealthCheck.Scheme":               "foobar",
 		"traefik.HTTP.Services.Service1.LoadBalancer.HealthCheck.Timeout":              "1000000000",
+		"traefik.HTTP.Services.Service1.LoadBalancer.DrainTimeout":                     "42000000000",
 		"traefik.HTTP.Services.Service1.LoadBalancer.PassHostHeader":                   "true",
 		"traefik.HTTP.Services.Service1.LoadBalancer.ResponseForwarding.FlushInterval": "1000000000",
 		"traefik.HTTP.Services.Service1.LoadBalancer.Strategy":                         "foobar",
@@ -1558,10 +1576,12 @@ func TestEncodeConfiguration(t *testing.T) {
 		"traefik.TCP.Routers.Router1.TLS.Options":                     "foo",
 		"traefik.TCP.Services.Service0.LoadBalancer.server.Port":      "42",
 		"traefik.TCP.Services.Service0.LoadBalancer.server.TLS":       "false",
+		"traefik.TCP.Services.Service0.LoadBalancer.DrainTimeout":     "42000000000",
 		"traefik.TCP.Services.Service0.LoadBalancer.ServersTransport": "foo",
 		"traefik.TCP.Services.Service0.LoadBalancer.TerminationDelay": "42",
 		"traefik.TCP.Services.Service1.LoadBalancer.server.Port":      "42",
 		"traefik.TCP.Services.Service1.LoadBalancer.server.TLS":       "false",
+		"traefik.TCP.Services.Service1.LoadBalancer.DrainTimeout":     "42000000000",
 		"traefik.TCP.Services.Service1.LoadBalancer.ServersTransport": "foo",
 		"traefik.TCP.Services.Service1.LoadBalancer.TerminationDelay": "42",
 
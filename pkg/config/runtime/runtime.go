@@ -33,11 +33,15 @@ type Configuration struct {
 	TCPServices    map[string]*TCPServiceInfo    `json:"tcpServices,omitempty"`
 	UDPRouters     map[string]*UDPRouterInfo     `json:"udpRouters,omitempty"`
 	UDPServices    map[string]*UDPServiceInfo    `json:"udpServices,omitempty"`
+	EntryPoints    map[string]*EntryPointInfo    `json:"entryPoints,omitempty"`
+
+	// Routing explains the routing of the routers built from the configuration, once they are built.
+	Routing RoutingExplainer `json:"-"`
 }
 
 // NewConfig returns a Configuration initialized with the given conf. It never returns nil.
 func NewConfig(conf dynamic.Configuration) *Configuration {
-	if conf.HTTP == nil && conf.TCP == nil && conf.UDP == nil {
+	if conf.HTTP == nil && conf.TCP == nil && conf.UDP == nil && conf.EntryPoints == nil {
 		return &Configuration{}
 	}
 
@@ -110,6 +114,13 @@ func NewConfig(conf dynamic.Configuration) *Configuration {
 		}
 	}
 
+	if len(conf.EntryPoints) > 0 {
+		runtimeConfig.EntryPoints = make(map[string]*EntryPointInfo, len(conf.EntryPoints))
+		for k, v := range conf.EntryPoints {
+			runtimeConfig.EntryPoints[k] = &EntryPointInfo{EntryPoint: v, Status: StatusEnabled}
+		}
+	}
+
 	return runtimeConfig
 }
 
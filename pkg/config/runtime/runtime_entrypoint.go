@@ -0,0 +1,26 @@
+package runtime
+
+import (
+	"slices"
+
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+)
+
+// EntryPointInfo holds information about an entry point defined in the dynamic configuration.
+type EntryPointInfo struct {
+	*dynamic.EntryPoint // dynamic configuration
+
+	// Err contains all the errors that occurred while opening the entry point.
+	Err    []string `json:"error,omitempty"`
+	Status string   `json:"status,omitempty"`
+}
+
+// AddError adds err to e.Err, if it does not already exist.
+// The entry point is marked as disabled, as it cannot be served.
+func (e *EntryPointInfo) AddError(err error) {
+	if !slices.Contains(e.Err, err.Error()) {
+		e.Err = append(e.Err, err.Error())
+	}
+
+	e.Status = StatusDisabled
+}
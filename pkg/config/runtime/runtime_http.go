@@ -152,6 +152,8 @@ type ServiceInfo struct {
 
 	serverStatusMu sync.RWMutex
 	serverStatus   map[string]string // keyed by server URL
+	serverHealth   serverHealths     // keyed by server URL
+	serverDraining drainingServers   // keyed by server URL
 }
 
 // AddError adds err to s.Err, if it does not already exist.
@@ -182,9 +184,90 @@ func (s *ServiceInfo) UpdateServerStatus(server, status string) {
 	if s.serverStatus == nil {
 		s.serverStatus = make(map[string]string)
 	}
+
+	if previous, ok := s.serverStatus[server]; ok && previous != status {
+		if s.serverHealth == nil {
+			s.serverHealth = make(serverHealths)
+		}
+		s.serverHealth.recordTransition(server, status)
+	}
+
 	s.serverStatus[server] = status
 }
 
+// RecordHealthCheck records the result of an active health check of the server.
+// It is the responsibility of the caller to check that s is not nil.
+func (s *ServiceInfo) RecordHealthCheck(server string, result HealthCheckResult) {
+	s.serverStatusMu.Lock()
+	defer s.serverStatusMu.Unlock()
+
+	if s.serverHealth == nil {
+		s.serverHealth = make(serverHealths)
+	}
+	s.serverHealth.recordCheck(server, result)
+}
+
+// RecordPassiveFailures records the number of failed requests to the server within the passive health check failure window,
+// along with the error describing the last failure, if any.
+// It is the responsibility of the caller to check that s is not nil.
+func (s *ServiceInfo) RecordPassiveFailures(server string, failures int, err string) {
+	s.serverStatusMu.Lock()
+	defer s.serverStatusMu.Unlock()
+
+	if s.serverHealth == nil {
+		s.serverHealth = make(serverHealths)
+	}
+	s.serverHealth.recordPassiveFailures(server, failures, err)
+}
+
+// RecordCircuitBreakerState records the state of the circuit breaker of the server,
+// along with the error describing why the breaker opened, if any.
+// It is the responsibility of the caller to check that s is not nil.
+func (s *ServiceInfo) RecordCircuitBreakerState(server, state, err string) {
+	s.serverStatusMu.Lock()
+	defer s.serverStatusMu.Unlock()
+
+	if s.serverHealth == nil {
+		s.serverHealth = make(serverHealths)
+	}
+	s.serverHealth.recordCircuitBreakerState(server, state, err)
+}
+
+// UpdateDrainingServer sets the draining details of the server, or removes them when draining is nil.
+// It is the responsibility of the caller to check that s is not nil.
+func (s *ServiceInfo) UpdateDrainingServer(server string, draining *DrainingServer) {
+	s.serverStatusMu.Lock()
+	defer s.serverStatusMu.Unlock()
+
+	if draining == nil {
+		delete(s.serverDraining, server)
+		return
+	}
+
+	if s.serverDraining == nil {
+		s.serverDraining = make(drainingServers)
+	}
+	s.serverDraining[server] = *draining
+}
+
+// GetAllDraining returns the draining details of all the draining servers in ServiceInfo.
+// It is the responsibility of the caller to check that s is not nil.
+func (s *ServiceInfo) GetAllDraining() map[string]DrainingServer {
+	s.serverStatusMu.RLock()
+	defer s.serverStatusMu.RUnlock()
+
+	return s.serverDraining.clone()
+}
+
+// GetAllHealth returns the health details of all the servers in ServiceInfo.
+// It is the responsibility of the caller to check that s is not nil.
+func (s *ServiceInfo) GetAllHealth() map[string]ServerHealth {
+	s.serverStatusMu.RLock()
+	defer s.serverStatusMu.RUnlock()
+
+	return s.serverHealth.clone()
+}
+
 // GetAllStatus returns all the statuses of all the servers in ServiceInfo.
 // It is the responsibility of the caller to check that s is not nil.
 func (s *ServiceInfo) GetAllStatus() map[string]string {
@@ -0,0 +1,38 @@
+package runtime
+
+import "net/http"
+
+// RoutingExplainer explains how the routers built from the configuration route the requests and the connections.
+type RoutingExplainer interface {
+	// ExplainHTTP explains how the request, received by the given entry point, is routed.
+	ExplainHTTP(entryPointName string, req *http.Request) (*RoutingExplanation, error)
+	// ExplainTCP explains how the connection, received by the given entry point, is routed.
+	ExplainTCP(entryPointName string, conn TCPConnInfo) (*RoutingExplanation, error)
+}
+
+// TCPConnInfo describes a TCP connection to route.
+type TCPConnInfo struct {
+	ServerName string
+	ALPN       []string
+	ClientIP   string
+	TLS        bool
+}
+
+// RoutingExplanation describes how a request or a connection is routed.
+type RoutingExplanation struct {
+	Router      string   `json:"router,omitempty"`
+	Middlewares []string `json:"middlewares,omitempty"`
+	Service     string   `json:"service,omitempty"`
+	// Candidates are the routers evaluated, in the evaluation order.
+	Candidates []RouteCandidate `json:"candidates"`
+}
+
+// RouteCandidate is a router evaluated while routing a request or a connection.
+type RouteCandidate struct {
+	Name           string   `json:"name"`
+	Parent         string   `json:"parent,omitempty"`
+	Rule           string   `json:"rule"`
+	Priority       int      `json:"priority"`
+	Matched        bool     `json:"matched"`
+	FailedMatchers []string `json:"failedMatchers,omitempty"`
+}
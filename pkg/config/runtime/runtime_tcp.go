@@ -92,6 +92,8 @@ type TCPServiceInfo struct {
 
 	serverStatusMu sync.RWMutex
 	serverStatus   map[string]string // keyed by server address
+	serverHealth   serverHealths     // keyed by server address
+	serverDraining drainingServers   // keyed by server address
 }
 
 // AddError adds err to s.Err, if it does not already exist.
@@ -121,9 +123,64 @@ func (s *TCPServiceInfo) UpdateServerStatus(server, status string) {
 	if s.serverStatus == nil {
 		s.serverStatus = make(map[string]string)
 	}
+
+	if previous, ok := s.serverStatus[server]; ok && previous != status {
+		if s.serverHealth == nil {
+			s.serverHealth = make(serverHealths)
+		}
+		s.serverHealth.recordTransition(server, status)
+	}
+
 	s.serverStatus[server] = status
 }
 
+// RecordHealthCheck records the result of an active health check of the server.
+// It is the responsibility of the caller to check that s is not nil.
+func (s *TCPServiceInfo) RecordHealthCheck(server string, result HealthCheckResult) {
+	s.serverStatusMu.Lock()
+	defer s.serverStatusMu.Unlock()
+
+	if s.serverHealth == nil {
+		s.serverHealth = make(serverHealths)
+	}
+	s.serverHealth.recordCheck(server, result)
+}
+
+// UpdateDrainingServer sets the draining details of the server, or removes them when draining is nil.
+// It is the responsibility of the caller to check that s is not nil.
+func (s *TCPServiceInfo) UpdateDrainingServer(server string, draining *DrainingServer) {
+	s.serverStatusMu.Lock()
+	defer s.serverStatusMu.Unlock()
+
+	if draining == nil {
+		delete(s.serverDraining, server)
+		return
+	}
+
+	if s.serverDraining == nil {
+		s.serverDraining = make(drainingServers)
+	}
+	s.serverDraining[server] = *draining
+}
+
+// GetAllDraining returns the draining details of all the draining servers in TCPServiceInfo.
+// It is the responsibility of the caller to check that s is not nil.
+func (s *TCPServiceInfo) GetAllDraining() map[string]DrainingServer {
+	s.serverStatusMu.RLock()
+	defer s.serverStatusMu.RUnlock()
+
+	return s.serverDraining.clone()
+}
+
+// GetAllHealth returns the health details of all the servers in TCPServiceInfo.
+// It is the responsibility of the caller to check that s is not nil.
+func (s *TCPServiceInfo) GetAllHealth() map[string]ServerHealth {
+	s.serverStatusMu.RLock()
+	defer s.serverStatusMu.RUnlock()
+
+	return s.serverHealth.clone()
+}
+
 // GetAllStatus returns all the statuses of all the servers in TCPServiceInfo.
 func (s *TCPServiceInfo) GetAllStatus() map[string]string {
 	s.serverStatusMu.RLock()
@@ -0,0 +1,166 @@
+package runtime
+
+import (
+	"maps"
+	"slices"
+	"time"
+)
+
+// maxServerTransitions is the number of status transitions kept per server.
+const maxServerTransitions = 10
+
+// ServerHealth holds the health details of a service server.
+type ServerHealth struct {
+	// LastCheck is the time of the last active health check.
+	LastCheck *time.Time `json:"lastCheck,omitempty" export:"true"`
+	// LastCheckLatency is the duration of the last active health check.
+	LastCheckLatency string `json:"lastCheckLatency,omitempty" export:"true"`
+	// StatusCode is the HTTP status code received during the last active health check.
+	StatusCode int `json:"statusCode,omitempty" export:"true"`
+	// GRPCStatus is the gRPC serving status received during the last active health check.
+	GRPCStatus string `json:"grpcStatus,omitempty" export:"true"`
+	// Error is the error message of the last failed health check, active or passive.
+	Error string `json:"error,omitempty" export:"true"`
+
+	ConsecutiveSuccesses int `json:"consecutiveSuccesses,omitempty" export:"true"`
+	ConsecutiveFailures  int `json:"consecutiveFailures,omitempty" export:"true"`
+	// PassiveFailures is the number of failed requests within the passive health check failure window.
+	PassiveFailures int `json:"passiveFailures,omitempty" export:"true"`
+	// CircuitBreaker is the state of the circuit breaker of the server, if any.
+	CircuitBreaker string `json:"circuitBreaker,omitempty" export:"true"`
+	// circuitBreakerError is the reason why the circuit breaker opened, while it is open.
+	circuitBreakerError string
+
+	// Transitions holds the latest status transitions of the server, the most recent being the last.
+	Transitions []ServerTransition `json:"transitions,omitempty" export:"true"`
+}
+
+// ServerTransition describes a change of the status of a server.
+type ServerTransition struct {
+	Status string    `json:"status" export:"true"`
+	Time   time.Time `json:"time" export:"true"`
+	Reason string    `json:"reason,omitempty" export:"true"`
+}
+
+// HealthCheckResult is the outcome of an active health check of a server.
+type HealthCheckResult struct {
+	Time       time.Time
+	Latency    time.Duration
+	StatusCode int
+	GRPCStatus string
+	Err        error
+}
+
+// serverHealths holds the health details of servers, keyed by server URL or address.
+// It is not safe for concurrent use.
+type serverHealths map[string]*ServerHealth
+
+func (h serverHealths) get(server string) *ServerHealth {
+	health, ok := h[server]
+	if !ok {
+		health = &ServerHealth{}
+		h[server] = health
+	}
+
+	return health
+}
+
+func (h serverHealths) recordCheck(server string, result HealthCheckResult) {
+	health := h.get(server)
+
+	checkTime := result.Time
+	health.LastCheck = &checkTime
+	health.LastCheckLatency = result.Latency.String()
+	health.StatusCode = result.StatusCode
+	health.GRPCStatus = result.GRPCStatus
+
+	if result.Err != nil {
+		health.Error = result.Err.Error()
+		health.ConsecutiveFailures++
+		health.ConsecutiveSuccesses = 0
+		return
+	}
+
+	health.Error = ""
+	health.ConsecutiveSuccesses++
+	health.ConsecutiveFailures = 0
+}
+
+func (h serverHealths) recordPassiveFailures(server string, failures int, err string) {
+	health := h.get(server)
+
+	health.PassiveFailures = failures
+	if err != "" {
+		health.Error = err
+	}
+}
+
+func (h serverHealths) recordCircuitBreakerState(server, state, err string) {
+	health := h.get(server)
+
+	health.CircuitBreaker = state
+	if err != "" {
+		health.Error = err
+		health.circuitBreakerError = err
+		return
+	}
+
+	// The breaker is not open anymore, so its error does not describe the server,
+	// unless another error was recorded since.
+	if health.circuitBreakerError != "" && health.Error == health.circuitBreakerError {
+		health.Error = ""
+	}
+	health.circuitBreakerError = ""
+}
+
+func (h serverHealths) recordTransition(server, status string) {
+	health := h.get(server)
+
+	transition := ServerTransition{
+		Status: status,
+		Time:   time.Now(),
+	}
+	if status != StatusUp {
+		transition.Reason = health.Error
+	}
+
+	health.Transitions = append(health.Transitions, transition)
+	if len(health.Transitions) > maxServerTransitions {
+		health.Transitions = health.Transitions[len(health.Transitions)-maxServerTransitions:]
+	}
+}
+
+func (h serverHealths) clone() map[string]ServerHealth {
+	if len(h) == 0 {
+		return nil
+	}
+
+	healths := make(map[string]ServerHealth, len(h))
+	for server, health := range h {
+		healthCopy := *health
+		healthCopy.Transitions = slices.Clone(health.Transitions)
+		healths[server] = healthCopy
+	}
+
+	return healths
+}
+
+// DrainingServer describes a server whose connections are being drained,
+// because it was removed from the configuration or marked unhealthy.
+type DrainingServer struct {
+	Since       time.Time `json:"since"`
+	Deadline    time.Time `json:"deadline"`
+	Connections int       `json:"connections"`
+}
+
+// drainingServers holds the draining servers, keyed by server URL or address.
+// It is not safe for concurrent use.
+type drainingServers map[string]DrainingServer
+
+func (d drainingServers) clone() map[string]DrainingServer {
+	if len(d) == 0 {
+		return nil
+	}
+
+	return maps.Clone(d)
+}
@@ -0,0 +1,145 @@
+package runtime
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServiceInfo_RecordHealthCheck(t *testing.T) {
+	si := &ServiceInfo{}
+	assert.Nil(t, si.GetAllHealth())
+
+	now := time.Now()
+	si.UpdateServerStatus("http://127.0.0.1", StatusUp)
+	si.RecordHealthCheck("http://127.0.0.1", HealthCheckResult{Time: now, Latency: 10 * time.Millisecond, StatusCode: 200})
+	si.RecordHealthCheck("http://127.0.0.1", HealthCheckResult{Time: now, Latency: 12 * time.Millisecond, StatusCode: 200})
+
+	health := si.GetAllHealth()["http://127.0.0.1"]
+	require.NotNil(t, health.LastCheck)
+	assert.Equal(t, now, *health.LastCheck)
+	assert.Equal(t, "12ms", health.LastCheckLatency)
+	assert.Equal(t, 200, health.StatusCode)
+	assert.Equal(t, 2, health.ConsecutiveSuccesses)
+	assert.Zero(t, health.ConsecutiveFailures)
+	assert.Empty(t, health.Transitions)
+
+	si.RecordHealthCheck("http://127.0.0.1", HealthCheckResult{Time: now, StatusCode: 503, Err: errors.New("received error status code: 503")})
+	si.UpdateServerStatus("http://127.0.0.1", StatusDown)
+
+	health = si.GetAllHealth()["http://127.0.0.1"]
+	assert.Equal(t, "received error status code: 503", health.Error)
+	assert.Zero(t, health.ConsecutiveSuccesses)
+	assert.Equal(t, 1, health.ConsecutiveFailures)
+	require.Len(t, health.Transitions, 1)
+	assert.Equal(t, StatusDown, health.Transitions[0].Status)
+	assert.Equal(t, "received error status code: 503", health.Transitions[0].Reason)
+
+	si.RecordHealthCheck("http://127.0.0.1", HealthCheckResult{Time: now, StatusCode: 200})
+	si.UpdateServerStatus("http://127.0.0.1", StatusUp)
+
+	health = si.GetAllHealth()["http://127.0.0.1"]
+	assert.Empty(t, health.Error)
+	require.Len(t, health.Transitions, 2)
+	assert.Equal(t, StatusUp, health.Transitions[1].Status)
+	assert.Empty(t, health.Transitions[1].Reason)
+}
+
+func TestServiceInfo_RecordPassiveFailures(t *testing.T) {
+	si := &ServiceInfo{}
+
+	si.UpdateServerStatus("http://127.0.0.1", StatusUp)
+	si.RecordPassiveFailures("http://127.0.0.1", 3, "backend not reached")
+	si.UpdateServerStatus("http://127.0.0.1", StatusDown)
+
+	health := si.GetAllHealth()["http://127.0.0.1"]
+	assert.Equal(t, 3, health.PassiveFailures)
+	assert.Equal(t, "backend not reached", health.Error)
+	require.Len(t, health.Transitions, 1)
+	assert.Equal(t, "backend not reached", health.Transitions[0].Reason)
+}
+
+func TestServiceInfo_RecordCircuitBreakerState(t *testing.T) {
+	si := &ServiceInfo{}
+
+	si.UpdateServerStatus("http://127.0.0.1", StatusUp)
+	si.RecordCircuitBreakerState("http://127.0.0.1", "open", "circuit breaker open: NetworkErrorRatio() > 0.5")
+	si.UpdateServerStatus("http://127.0.0.1", StatusDown)
+
+	health := si.GetAllHealth()["http://127.0.0.1"]
+	assert.Equal(t, "open", health.CircuitBreaker)
+	require.Len(t, health.Transitions, 1)
+	assert.Equal(t, "circuit breaker open: NetworkErrorRatio() > 0.5", health.Transitions[0].Reason)
+
+	si.RecordCircuitBreakerState("http://127.0.0.1", "halfOpen", "")
+
+	health = si.GetAllHealth()["http://127.0.0.1"]
+	assert.Equal(t, "halfOpen", health.CircuitBreaker)
+	assert.Empty(t, health.Error)
+}
+
+func TestServiceInfo_RecordCircuitBreakerState_closed(t *testing.T) {
+	si := &ServiceInfo{}
+
+	si.UpdateServerStatus("http://127.0.0.1", StatusUp)
+	si.RecordCircuitBreakerState("http://127.0.0.1", "open", "circuit breaker open: NetworkErrorRatio() > 0.5")
+	si.UpdateServerStatus("http://127.0.0.1", StatusDown)
+
+	health := si.GetAllHealth()["http://127.0.0.1"]
+	assert.Equal(t, "open", health.CircuitBreaker)
+	assert.Equal(t, "circuit breaker open: NetworkErrorRatio() > 0.5", health.Error)
+
+	si.RecordCircuitBreakerState("http://127.0.0.1", "closed", "")
+	si.UpdateServerStatus("http://127.0.0.1", StatusUp)
+
+	health = si.GetAllHealth()["http://127.0.0.1"]
+	assert.Equal(t, "closed", health.CircuitBreaker)
+	assert.Empty(t, health.Error)
+
+	// An error recorded while the breaker is open is kept when the breaker closes.
+	si.RecordCircuitBreakerState("http://127.0.0.1", "open", "circuit breaker open: NetworkErrorRatio() > 0.5")
+	si.RecordPassiveFailures("http://127.0.0.1", 1, "backend not reached")
+	si.RecordCircuitBreakerState("http://127.0.0.1", "closed", "")
+
+	health = si.GetAllHealth()["http://127.0.0.1"]
+	assert.Equal(t, "backend not reached", health.Error)
+}
+
+func TestServiceInfo_transitionsHistory(t *testing.T) {
+	si := &ServiceInfo{}
+
+	si.UpdateServerStatus("http://127.0.0.1", StatusUp)
+	for i := range 2 * maxServerTransitions {
+		status := StatusDown
+		if i%2 == 1 {
+			status = StatusUp
+		}
+		si.UpdateServerStatus("http://127.0.0.1", status)
+	}
+
+	health := si.GetAllHealth()["http://127.0.0.1"]
+	require.Len(t, health.Transitions, maxServerTransitions)
+	assert.Equal(t, StatusUp, health.Transitions[maxServerTransitions-1].Status)
+
+	// The returned health details must not be affected by later changes.
+	si.UpdateServerStatus("http://127.0.0.1", StatusDown)
+	assert.Equal(t, StatusUp, health.Transitions[maxServerTransitions-1].Status)
+}
+
+func TestTCPServiceInfo_RecordHealthCheck(t *testing.T) {
+	si := &TCPServiceInfo{}
+
+	si.UpdateServerStatus("127.0.0.1:8080", StatusUp)
+	si.RecordHealthCheck("127.0.0.1:8080", HealthCheckResult{Time: time.Now(), Err: errors.New("connection refused")})
+	si.UpdateServerStatus("127.0.0.1:8080", StatusDown)
+
+	health := si.GetAllHealth()["127.0.0.1:8080"]
+	assert.Equal(t, "connection refused", health.Error)
+	assert.Equal(t, 1, health.ConsecutiveFailures)
+	require.Len(t, health.Transitions, 1)
+	assert.Equal(t, StatusDown, health.Transitions[0].Status)
+	assert.Equal(t, "connection refused", health.Transitions[0].Reason)
+}
@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	ptypes "github.com/traefik/paerser/types"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
 	otypes "github.com/traefik/traefik/v3/pkg/observability/types"
 	"github.com/traefik/traefik/v3/pkg/types"
 	"k8s.io/utils/ptr"
@@ -64,6 +65,48 @@ func (ep *EntryPoint) SetDefaults() {
 	ep.HTTP2.SetDefaults()
 }
 
+// NewDynamicEntryPoint returns the entry point configuration matching the given entry point of the dynamic configuration.
+// The options which cannot be set in the dynamic configuration keep their default value.
+func NewDynamicEntryPoint(config *dynamic.EntryPoint) *EntryPoint {
+	ep := &EntryPoint{Address: config.Address}
+	ep.SetDefaults()
+
+	if t := config.Transport; t != nil {
+		ep.Transport.KeepAliveMaxTime = t.KeepAliveMaxTime
+		ep.Transport.KeepAliveMaxRequests = t.KeepAliveMaxRequests
+
+		if t.LifeCycle != nil {
+			ep.Transport.LifeCycle = &LifeCycle{GraceTimeOut: t.LifeCycle.GraceTimeOut}
+		}
+
+		if t.RespondingTimeouts != nil {
+			ep.Transport.RespondingTimeouts = &RespondingTimeouts{
+				ReadTimeout:  t.RespondingTimeouts.ReadTimeout,
+				WriteTimeout: t.RespondingTimeouts.WriteTimeout,
+				IdleTimeout:  t.RespondingTimeouts.IdleTimeout,
+			}
+		}
+	}
+
+	if config.ProxyProtocol != nil {
+		ep.ProxyProtocol = &ProxyProtocol{
+			Insecure:   config.ProxyProtocol.Insecure,
+			TrustedIPs: config.ProxyProtocol.TrustedIPs,
+		}
+	}
+
+	if config.ForwardedHeaders != nil {
+		ep.ForwardedHeaders = &ForwardedHeaders{
+			Insecure:               config.ForwardedHeaders.Insecure,
+			TrustedIPs:             config.ForwardedHeaders.TrustedIPs,
+			Connection:             config.ForwardedHeaders.Connection,
+			NotAppendXForwardedFor: config.ForwardedHeaders.NotAppendXForwardedFor,
+		}
+	}
+
+	return ep
+}
+
 // HTTPConfig is the HTTP configuration of an entry point.
 type HTTPConfig struct {
 	Redirections          *Redirections      `description:"Set of redirection" json:"redirections,omitempty" toml:"redirections,omitempty" yaml:"redirections,omitempty" export:"true"`
@@ -0,0 +1,270 @@
+package geoip
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/oschwald/maxminddb-golang"
+	"github.com/rs/zerolog/log"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+	"github.com/traefik/traefik/v3/pkg/ip"
+)
+
+// reloadDelay is the delay during which the changes of a database file are gathered before reloading it,
+// as the database updates are usually made of several file system events.
+const reloadDelay = time.Second
+
+// Config holds the GeoIP databases configuration.
+type Config struct {
+	CountryDatabase string              `description:"Path to the MaxMind DB file (.mmdb) used to resolve the country of the client IPs." json:"countryDatabase,omitempty" toml:"countryDatabase,omitempty" yaml:"countryDatabase,omitempty"`
+	ASNDatabase     string              `description:"Path to the MaxMind DB file (.mmdb) used to resolve the autonomous system of the client IPs." json:"asnDatabase,omitempty" toml:"asnDatabase,omitempty" yaml:"asnDatabase,omitempty"`
+	IPStrategy      *dynamic.IPStrategy `description:"Strategy used to resolve the client IP of the HTTP requests, for the rule matchers and the access logs." json:"ipStrategy,omitempty" toml:"ipStrategy,omitempty" yaml:"ipStrategy,omitempty" label:"allowEmpty" file:"allowEmpty" export:"true"`
+}
+
+// Record holds the GeoIP data of an IP address.
+type Record struct {
+	// Country is the ISO 3166-1 alpha-2 code of the country, if any.
+	Country string
+	// ASN is the autonomous system number, if any.
+	ASN uint
+	// ASOrganization is the autonomous system organization, if any.
+	ASOrganization string
+}
+
+// Resolver resolves the GeoIP data of IP addresses from MaxMind DB files,
+// which are reloaded when they change.
+type Resolver struct {
+	country  database
+	asn      database
+	strategy ip.Strategy
+}
+
+// NewResolver creates a Resolver, loading the configured databases.
+func NewResolver(config Config) (*Resolver, error) {
+	if config.CountryDatabase == "" && config.ASNDatabase == "" {
+		return nil, errors.New("no GeoIP database configured")
+	}
+
+	strategy, err := config.IPStrategy.Get()
+	if err != nil {
+		return nil, fmt.Errorf("GeoIP IP strategy: %w", err)
+	}
+
+	r := &Resolver{
+		country:  database{path: config.CountryDatabase},
+		asn:      database{path: config.ASNDatabase},
+		strategy: strategy,
+	}
+
+	for _, db := range r.databases() {
+		if err := db.load(); err != nil {
+			return nil, err
+		}
+	}
+
+	return r, nil
+}
+
+// HasCountry tells whether a country database is configured.
+func (r *Resolver) HasCountry() bool {
+	return r.country.path != ""
+}
+
+// HasASN tells whether an ASN database is configured.
+func (r *Resolver) HasASN() bool {
+	return r.asn.path != ""
+}
+
+// Lookup returns the GeoIP data of the IP address.
+// The lookup errors are logged, and result in an empty record.
+func (r *Resolver) Lookup(ip net.IP) Record {
+	record := Record{Country: r.Country(ip)}
+	record.ASN, record.ASOrganization = r.AutonomousSystem(ip)
+
+	return record
+}
+
+// LookupString parses the IP address and returns its GeoIP data.
+// An empty record is returned for invalid IP addresses.
+func (r *Resolver) LookupString(ip string) Record {
+	return r.Lookup(net.ParseIP(strings.TrimSpace(ip)))
+}
+
+// Country returns the ISO 3166-1 alpha-2 code of the country of the IP address,
+// falling back to its registered country, or an empty string if unknown.
+func (r *Resolver) Country(ip net.IP) string {
+	reader := r.country.reader.Load()
+	if reader == nil || ip == nil {
+		return ""
+	}
+
+	var record struct {
+		Country struct {
+			ISOCode string `maxminddb:"iso_code"`
+		} `maxminddb:"country"`
+		RegisteredCountry struct {
+			ISOCode string `maxminddb:"iso_code"`
+		} `maxminddb:"registered_country"`
+	}
+
+	if err := reader.Lookup(ip, &record); err != nil {
+		log.Debug().Err(err).Str("ip", ip.String()).Msg("Error while looking up the country")
+		return ""
+	}
+
+	if record.Country.ISOCode != "" {
+		return record.Country.ISOCode
+	}
+
+	return record.RegisteredCountry.ISOCode
+}
+
+// AutonomousSystem returns the number and organization of the autonomous system of the IP address,
+// or zero values if unknown.
+func (r *Resolver) AutonomousSystem(ip net.IP) (uint, string) {
+	reader := r.asn.reader.Load()
+	if reader == nil || ip == nil {
+		return 0, ""
+	}
+
+	var record struct {
+		Number       uint   `maxminddb:"autonomous_system_number"`
+		Organization string `maxminddb:"autonomous_system_organization"`
+	}
+
+	if err := reader.Lookup(ip, &record); err != nil {
+		log.Debug().Err(err).Str("ip", ip.String()).Msg("Error while looking up the autonomous system")
+		return 0, ""
+	}
+
+	return record.Number, record.Organization
+}
+
+// ParseASN parses an autonomous system number, with or without the AS prefix (e.g. AS64500 or 64500).
+func ParseASN(value string) (uint, error) {
+	value = strings.TrimSpace(value)
+	if len(value) > 2 && strings.EqualFold(value[:2], "AS") {
+		value = value[2:]
+	}
+
+	asn, err := strconv.ParseUint(value, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid autonomous system number %q", value)
+	}
+
+	return uint(asn), nil
+}
+
+// ClientIP returns the client IP of the request, resolved with the configured IP strategy.
+func (r *Resolver) ClientIP(req *http.Request) string {
+	return r.strategy.GetIP(req)
+}
+
+// Run watches the database files, and reloads them when they change, until the context is done.
+// A database which cannot be reloaded is kept as is.
+func (r *Resolver) Run(ctx context.Context) {
+	logger := log.Ctx(ctx).With().Str("component", "geoip").Logger()
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		logger.Error().Err(err).Msg("Error while creating the GeoIP databases watcher")
+		return
+	}
+	defer func() { _ = watcher.Close() }()
+
+	// The directories are watched, rather than the files,
+	// to keep watching the databases replaced by a rename.
+	for _, db := range r.databases() {
+		if err := watcher.Add(filepath.Dir(db.path)); err != nil {
+			logger.Error().Err(err).Str("path", db.path).Msg("Error while watching the GeoIP database")
+		}
+	}
+
+	timer := time.NewTimer(reloadDelay)
+	timer.Stop()
+
+	changed := make(map[*database]struct{})
+	for {
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+
+		case evt, ok := <-watcher.Events:
+			if !ok {
+				return
+			}
+
+			for _, db := range r.databases() {
+				if filepath.Clean(evt.Name) == filepath.Clean(db.path) {
+					changed[db] = struct{}{}
+					timer.Reset(reloadDelay)
+				}
+			}
+
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return
+			}
+
+			logger.Error().Err(err).Msg("GeoIP databases watcher error")
+
+		case <-timer.C:
+			for db := range changed {
+				if err := db.load(); err != nil {
+					logger.Error().Err(err).Msg("Error while reloading the GeoIP database, keeping the previous one")
+					continue
+				}
+
+				logger.Info().Str("path", db.path).Msg("GeoIP database reloaded")
+			}
+
+			clear(changed)
+		}
+	}
+}
+
+// databases returns the configured databases.
+func (r *Resolver) databases() []*database {
+	var dbs []*database
+	for _, db := range []*database{&r.country, &r.asn} {
+		if db.path != "" {
+			dbs = append(dbs, db)
+		}
+	}
+
+	return dbs
+}
+
+type database struct {
+	path   string
+	reader atomic.Pointer[maxminddb.Reader]
+}
+
+// load reads the database file in memory, rather than mapping it,
+// as the previous reader may still be in use while the file is replaced.
+func (d *database) load() error {
+	buf, err := os.ReadFile(d.path)
+	if err != nil {
+		return fmt.Errorf("reading GeoIP database %s: %w", d.path, err)
+	}
+
+	reader, err := maxminddb.FromBytes(buf)
+	if err != nil {
+		return fmt.Errorf("loading GeoIP database %s: %w", d.path, err)
+	}
+
+	d.reader.Store(reader)
+
+	return nil
+}
@@ -0,0 +1,185 @@
+package geoip
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/maxmind/mmdbwriter/mmdbtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/traefik/traefik/v3/pkg/geoip/geoiptest"
+)
+
+func TestResolver_Lookup(t *testing.T) {
+	dir := t.TempDir()
+
+	countryDB := filepath.Join(dir, "country.mmdb")
+	geoiptest.WriteDatabase(t, countryDB, map[string]mmdbtype.Map{
+		"1.2.3.0/24": {
+			"country":            mmdbtype.Map{"iso_code": mmdbtype.String("FR"), "names": mmdbtype.Map{"en": mmdbtype.String("France")}},
+			"registered_country": mmdbtype.Map{"iso_code": mmdbtype.String("DE")},
+		},
+		"5.6.0.0/16": {
+			"registered_country": mmdbtype.Map{"iso_code": mmdbtype.String("US")},
+		},
+		"2001:db8::/32": {
+			"country": mmdbtype.Map{"iso_code": mmdbtype.String("JP")},
+		},
+	})
+
+	asnDB := filepath.Join(dir, "asn.mmdb")
+	geoiptest.WriteDatabase(t, asnDB, map[string]mmdbtype.Map{
+		"1.2.0.0/16": {
+			"autonomous_system_number":       mmdbtype.Uint32(64500),
+			"autonomous_system_organization": mmdbtype.String("Example Org"),
+		},
+	})
+
+	resolver, err := NewResolver(Config{CountryDatabase: countryDB, ASNDatabase: asnDB})
+	require.NoError(t, err)
+
+	assert.True(t, resolver.HasCountry())
+	assert.True(t, resolver.HasASN())
+
+	testCases := []struct {
+		desc     string
+		ip       string
+		expected Record
+	}{
+		{
+			desc:     "country and ASN",
+			ip:       "1.2.3.4",
+			expected: Record{Country: "FR", ASN: 64500, ASOrganization: "Example Org"},
+		},
+		{
+			desc:     "ASN only",
+			ip:       "1.2.4.4",
+			expected: Record{ASN: 64500, ASOrganization: "Example Org"},
+		},
+		{
+			desc:     "registered country",
+			ip:       "5.6.7.8",
+			expected: Record{Country: "US"},
+		},
+		{
+			desc:     "IPv6",
+			ip:       "2001:db8::1",
+			expected: Record{Country: "JP"},
+		},
+		{
+			desc: "unknown IP",
+			ip:   "9.9.9.9",
+		},
+		{
+			desc: "invalid IP",
+			ip:   "foo",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.expected, resolver.LookupString(test.ip))
+		})
+	}
+}
+
+func TestNewResolver_errors(t *testing.T) {
+	dir := t.TempDir()
+
+	invalidDB := filepath.Join(dir, "invalid.mmdb")
+	require.NoError(t, os.WriteFile(invalidDB, []byte("foo"), 0o600))
+
+	testCases := []struct {
+		desc   string
+		config Config
+	}{
+		{
+			desc: "no database",
+		},
+		{
+			desc:   "missing database",
+			config: Config{CountryDatabase: filepath.Join(dir, "missing.mmdb")},
+		},
+		{
+			desc:   "invalid database",
+			config: Config{ASNDatabase: invalidDB},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := NewResolver(test.config)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestParseASN(t *testing.T) {
+	testCases := []struct {
+		value     string
+		expected  uint
+		expectErr bool
+	}{
+		{value: "64500", expected: 64500},
+		{value: "AS64500", expected: 64500},
+		{value: "as64500", expected: 64500},
+		{value: "AS", expectErr: true},
+		{value: "foo", expectErr: true},
+		{value: "-1", expectErr: true},
+		{value: "4294967296", expectErr: true},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.value, func(t *testing.T) {
+			t.Parallel()
+
+			asn, err := ParseASN(test.value)
+			if test.expectErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, asn)
+		})
+	}
+}
+
+func TestResolver_Run(t *testing.T) {
+	dir := t.TempDir()
+
+	countryDB := filepath.Join(dir, "country.mmdb")
+	geoiptest.WriteDatabase(t, countryDB, map[string]mmdbtype.Map{
+		"1.2.3.0/24": {"country": mmdbtype.Map{"iso_code": mmdbtype.String("FR")}},
+	})
+
+	resolver, err := NewResolver(Config{CountryDatabase: countryDB})
+	require.NoError(t, err)
+
+	go resolver.Run(t.Context())
+
+	// Gives some time to the watcher to start.
+	time.Sleep(100 * time.Millisecond)
+
+	// An invalid database is not loaded.
+	require.NoError(t, os.WriteFile(countryDB, []byte("foo"), 0o600))
+	time.Sleep(2 * reloadDelay)
+	assert.Equal(t, "FR", resolver.LookupString("1.2.3.4").Country)
+
+	// The database is replaced by a rename.
+	tmpDB := filepath.Join(dir, "country.mmdb.tmp")
+	geoiptest.WriteDatabase(t, tmpDB, map[string]mmdbtype.Map{
+		"1.2.3.0/24": {"country": mmdbtype.Map{"iso_code": mmdbtype.String("BE")}},
+	})
+	require.NoError(t, os.Rename(tmpDB, countryDB))
+
+	assert.Eventually(t, func() bool {
+		return resolver.LookupString("1.2.3.4").Country == "BE"
+	}, 5*time.Second, 50*time.Millisecond)
+}
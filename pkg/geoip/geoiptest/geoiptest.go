@@ -0,0 +1,35 @@
+// Package geoiptest provides utilities to test the GeoIP lookups.
+package geoiptest
+
+import (
+	"net"
+	"os"
+	"testing"
+
+	"github.com/maxmind/mmdbwriter"
+	"github.com/maxmind/mmdbwriter/mmdbtype"
+	"github.com/stretchr/testify/require"
+)
+
+// WriteDatabase writes a MaxMind DB holding the given data by network (CIDR).
+// The networks must not overlap, and can be reserved networks (e.g. private or loopback ones).
+func WriteDatabase(t *testing.T, path string, networks map[string]mmdbtype.Map) {
+	t.Helper()
+
+	writer, err := mmdbwriter.New(mmdbwriter.Options{DatabaseType: "Test", IncludeReservedNetworks: true})
+	require.NoError(t, err)
+
+	for cidr, data := range networks {
+		_, network, err := net.ParseCIDR(cidr)
+		require.NoError(t, err)
+
+		require.NoError(t, writer.Insert(network, data))
+	}
+
+	file, err := os.Create(path)
+	require.NoError(t, err)
+
+	_, err = writer.WriteTo(file)
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+}
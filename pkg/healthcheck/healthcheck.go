@@ -173,7 +173,8 @@ func (shc *ServiceHealthChecker) healthcheck(ctx context.Context, targets chan t
 				up := true
 				serverUpMetricValue := float64(1)
 
-				if err := shc.executeHealthCheck(ctx, shc.config, target.targetURL); err != nil {
+				result := shc.executeHealthCheck(ctx, shc.config, target.targetURL)
+				if err := result.Err; err != nil {
 					// The context is canceled when the dynamic configuration is refreshed.
 					if errors.Is(err, context.Canceled) {
 						return
@@ -199,6 +200,7 @@ func (shc *ServiceHealthChecker) healthcheck(ctx context.Context, targets chan t
 					shc.unhealthyTargets <- target
 				}
 
+				shc.info.RecordHealthCheck(target.targetURL.String(), result)
 				shc.info.UpdateServerStatus(target.targetURL.String(), statusStr)
 
 				shc.metrics.ServiceServerUpGauge().
@@ -209,40 +211,48 @@ func (shc *ServiceHealthChecker) healthcheck(ctx context.Context, targets chan t
 	}
 }
 
-func (shc *ServiceHealthChecker) executeHealthCheck(ctx context.Context, config *dynamic.ServerHealthCheck, target *url.URL) error {
-	ctx, cancel := context.WithDeadline(ctx, time.Now().Add(shc.timeout))
+func (shc *ServiceHealthChecker) executeHealthCheck(ctx context.Context, config *dynamic.ServerHealthCheck, target *url.URL) runtime.HealthCheckResult {
+	result := runtime.HealthCheckResult{Time: time.Now()}
+
+	ctx, cancel := context.WithDeadline(ctx, result.Time.Add(shc.timeout))
 	defer cancel()
 
 	if config.Mode == modeGRPC {
-		return shc.checkHealthGRPC(ctx, target)
+		result.GRPCStatus, result.Err = shc.checkHealthGRPC(ctx, target)
+	} else {
+		result.StatusCode, result.Err = shc.checkHealthHTTP(ctx, target)
 	}
-	return shc.checkHealthHTTP(ctx, target)
+
+	result.Latency = time.Since(result.Time)
+
+	return result
 }
 
-// checkHealthHTTP returns an error with a meaningful description if the health check failed.
+// checkHealthHTTP returns the received status code,
+// and an error with a meaningful description if the health check failed.
 // Dedicated to HTTP servers.
-func (shc *ServiceHealthChecker) checkHealthHTTP(ctx context.Context, target *url.URL) error {
+func (shc *ServiceHealthChecker) checkHealthHTTP(ctx context.Context, target *url.URL) (int, error) {
 	req, err := shc.newRequest(ctx, target)
 	if err != nil {
-		return fmt.Errorf("create HTTP request: %w", err)
+		return 0, fmt.Errorf("create HTTP request: %w", err)
 	}
 
 	resp, err := shc.client.Do(req)
 	if err != nil {
-		return fmt.Errorf("HTTP request failed: %w", err)
+		return 0, fmt.Errorf("HTTP request failed: %w", err)
 	}
 
 	defer resp.Body.Close()
 
 	if shc.config.Status == 0 && (resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusBadRequest) {
-		return fmt.Errorf("received error status code: %v", resp.StatusCode)
+		return resp.StatusCode, fmt.Errorf("received error status code: %v", resp.StatusCode)
 	}
 
 	if shc.config.Status != 0 && shc.config.Status != resp.StatusCode {
-		return fmt.Errorf("received error status code: %v expected status code: %v", resp.StatusCode, shc.config.Status)
+		return resp.StatusCode, fmt.Errorf("received error status code: %v expected status code: %v", resp.StatusCode, shc.config.Status)
 	}
 
-	return nil
+	return resp.StatusCode, nil
 }
 
 func (shc *ServiceHealthChecker) newRequest(ctx context.Context, target *url.URL) (*http.Request, error) {
@@ -284,12 +294,13 @@ func (shc *ServiceHealthChecker) newRequest(ctx context.Context, target *url.URL
 	return req, nil
 }
 
-// checkHealthGRPC returns an error with a meaningful description if the health check failed.
+// checkHealthGRPC returns the received gRPC serving status, or the gRPC error code,
+// and an error with a meaningful description if the health check failed.
 // Dedicated to gRPC servers implementing gRPC Health Checking Protocol v1.
-func (shc *ServiceHealthChecker) checkHealthGRPC(ctx context.Context, serverURL *url.URL) error {
+func (shc *ServiceHealthChecker) checkHealthGRPC(ctx context.Context, serverURL *url.URL) (string, error) {
 	u, err := serverURL.Parse(shc.config.Path)
 	if err != nil {
-		return fmt.Errorf("failed to parse server URL: %w", err)
+		return "", fmt.Errorf("failed to parse server URL: %w", err)
 	}
 
 	port := u.Port()
@@ -308,38 +319,40 @@ func (shc *ServiceHealthChecker) checkHealthGRPC(ctx context.Context, serverURL
 	conn, err := grpc.DialContext(ctx, serverAddr, opts...)
 	if err != nil {
 		if errors.Is(err, context.DeadlineExceeded) {
-			return fmt.Errorf("fail to connect to %s within %s: %w", serverAddr, shc.config.Timeout, err)
+			return "", fmt.Errorf("fail to connect to %s within %s: %w", serverAddr, shc.config.Timeout, err)
 		}
-		return fmt.Errorf("fail to connect to %s: %w", serverAddr, err)
+		return "", fmt.Errorf("fail to connect to %s: %w", serverAddr, err)
 	}
 	defer func() { _ = conn.Close() }()
 
 	resp, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
 	if err != nil {
-		if stat, ok := status.FromError(err); ok {
+		stat, ok := status.FromError(err)
+		if ok {
 			switch stat.Code() {
 			case codes.Unimplemented:
-				return fmt.Errorf("gRPC server does not implement the health protocol: %w", err)
+				return stat.Code().String(), fmt.Errorf("gRPC server does not implement the health protocol: %w", err)
 			case codes.DeadlineExceeded:
-				return fmt.Errorf("gRPC health check timeout: %w", err)
+				return stat.Code().String(), fmt.Errorf("gRPC health check timeout: %w", err)
 			case codes.Canceled:
-				return context.Canceled
+				return stat.Code().String(), context.Canceled
 			}
 		}
 
-		return fmt.Errorf("gRPC health check failed: %w", err)
+		return stat.Code().String(), fmt.Errorf("gRPC health check failed: %w", err)
 	}
 
 	if resp.GetStatus() != healthpb.HealthCheckResponse_SERVING {
-		return fmt.Errorf("received gRPC status code: %v", resp.GetStatus())
+		return resp.GetStatus().String(), fmt.Errorf("received gRPC status code: %v", resp.GetStatus())
 	}
 
-	return nil
+	return resp.GetStatus().String(), nil
 }
 
 type PassiveServiceHealthChecker struct {
 	serviceName string
 	balancer    StatusSetter
+	info        *runtime.ServiceInfo
 	metrics     metricsHealthCheck
 
 	maxFailedAttempts    int
@@ -353,10 +366,11 @@ type PassiveServiceHealthChecker struct {
 	timers      sync.Map
 }
 
-func NewPassiveHealthChecker(serviceName string, balancer StatusSetter, maxFailedAttempts int, failureWindow ptypes.Duration, hasActiveHealthCheck bool, metrics metricsHealthCheck) *PassiveServiceHealthChecker {
+func NewPassiveHealthChecker(serviceName string, balancer StatusSetter, info *runtime.ServiceInfo, maxFailedAttempts int, failureWindow ptypes.Duration, hasActiveHealthCheck bool, metrics metricsHealthCheck) *PassiveServiceHealthChecker {
 	return &PassiveServiceHealthChecker{
 		serviceName:          serviceName,
 		balancer:             balancer,
+		info:                 info,
 		failures:             make(map[string][]time.Time),
 		maxFailedAttempts:    maxFailedAttempts,
 		failureWindow:        failureWindow,
@@ -386,8 +400,13 @@ func (p *PassiveServiceHealthChecker) WrapHandler(ctx context.Context, next http
 
 		if backendCalled && codeCatcher.statusCode < http.StatusInternalServerError {
 			p.failuresMu.Lock()
+			hadFailures := len(p.failures[targetURL]) > 0
 			p.failures[targetURL] = nil
 			p.failuresMu.Unlock()
+
+			if hadFailures {
+				p.recordFailures(targetURL, 0, "")
+			}
 			return
 		}
 
@@ -395,7 +414,15 @@ func (p *PassiveServiceHealthChecker) WrapHandler(ctx context.Context, next http
 		p.failures[targetURL] = append(p.failures[targetURL], time.Now())
 		p.failuresMu.Unlock()
 
-		if p.healthy(targetURL) {
+		healthy, failures := p.healthy(targetURL)
+
+		reason := "backend not reached"
+		if backendCalled {
+			reason = fmt.Sprintf("received error status code: %d", codeCatcher.statusCode)
+		}
+		p.recordFailures(targetURL, failures, "passive health check: "+reason)
+
+		if healthy {
 			return
 		}
 
@@ -409,6 +436,9 @@ func (p *PassiveServiceHealthChecker) WrapHandler(ctx context.Context, next http
 
 			p.balancer.SetStatus(ctx, targetURL, false)
 			p.metrics.ServiceServerUpGauge().With("service", p.serviceName, "url", targetURL).Set(0)
+			if p.info != nil {
+				p.info.UpdateServerStatus(targetURL, runtime.StatusDown)
+			}
 
 			// If the service has an active health check, the passive health checker should not reset the status.
 			// The active health check will handle the status updates.
@@ -429,6 +459,9 @@ func (p *PassiveServiceHealthChecker) WrapHandler(ctx context.Context, next http
 
 					p.balancer.SetStatus(ctx, targetURL, true)
 					p.metrics.ServiceServerUpGauge().With("service", p.serviceName, "url", targetURL).Set(1)
+					if p.info != nil {
+						p.info.UpdateServerStatus(targetURL, runtime.StatusUp)
+					}
 				}
 			}()
 
@@ -437,7 +470,16 @@ func (p *PassiveServiceHealthChecker) WrapHandler(ctx context.Context, next http
 	})
 }
 
-func (p *PassiveServiceHealthChecker) healthy(targetURL string) bool {
+func (p *PassiveServiceHealthChecker) recordFailures(targetURL string, failures int, err string) {
+	if p.info == nil {
+		return
+	}
+
+	p.info.RecordPassiveFailures(targetURL, failures, err)
+}
+
+// healthy returns whether the target is healthy, along with the number of failures within the failure window.
+func (p *PassiveServiceHealthChecker) healthy(targetURL string) (bool, int) {
 	windowStart := time.Now().Add(-time.Duration(p.failureWindow))
 
 	p.failuresMu.Lock()
@@ -453,7 +495,8 @@ func (p *PassiveServiceHealthChecker) healthy(targetURL string) bool {
 	}
 
 	// Check if failures exceed maxFailedAttempts.
-	return len(p.failures[targetURL]) < p.maxFailedAttempts
+	count := len(p.failures[targetURL])
+	return count < p.maxFailedAttempts, count
 }
 
 type codeCatcher struct {
@@ -134,7 +134,10 @@ func (thc *ServiceTCPHealthChecker) healthcheck(ctx context.Context, targets cha
 
 				up := true
 
-				if err := thc.executeHealthCheck(ctx, thc.config, target); err != nil {
+				checkTime := time.Now()
+				err := thc.executeHealthCheck(ctx, thc.config, target)
+				result := runtime.HealthCheckResult{Time: checkTime, Latency: time.Since(checkTime), Err: err}
+				if err != nil {
 					// The context is canceled when the dynamic configuration is refreshed.
 					if errors.Is(err, context.Canceled) {
 						return
@@ -159,6 +162,7 @@ func (thc *ServiceTCPHealthChecker) healthcheck(ctx context.Context, targets cha
 					thc.unhealthyTargets <- target
 				}
 
+				thc.info.RecordHealthCheck(target.Address, result)
 				thc.info.UpdateServerStatus(target.Address, statusStr)
 
 				// TODO: add a TCP server up metric (like for HTTP).
@@ -303,7 +303,7 @@ func TestServiceHealthChecker_checkHealthHTTP_NotFollowingRedirects(t *testing.T
 	}
 	healthChecker := NewServiceHealthChecker(ctx, nil, config, nil, nil, http.DefaultTransport, nil, "")
 
-	err := healthChecker.checkHealthHTTP(ctx, testhelpers.MustParseURL(server.URL))
+	_, err := healthChecker.checkHealthHTTP(ctx, testhelpers.MustParseURL(server.URL))
 	require.NoError(t, err)
 
 	assert.False(t, redirectServerCalled, "HTTP redirect must not be followed")
@@ -28,6 +28,10 @@ const (
 	ClientHost = "ClientHost"
 	// ClientPort is the map key used for the remote TCP port from which the client request was received.
 	ClientPort = "ClientPort"
+	// ClientCountry is the map key used for the ISO country code of the client IP, resolved with the GeoIP country database.
+	ClientCountry = "ClientCountry"
+	// ClientASN is the map key used for the autonomous system number of the client IP, resolved with the GeoIP ASN database.
+	ClientASN = "ClientASN"
 	// ClientUsername is the map key used for the username provided in the URL, if present.
 	ClientUsername = "ClientUsername"
 	// RequestAddr is the map key used for the HTTP Host header (usually IP:port). This is treated as not a header by the Go API.
@@ -70,6 +74,10 @@ const (
 	Overhead = "Overhead"
 	// RetryAttempts is the map key used for the amount of attempts the request was retried.
 	RetryAttempts = "RetryAttempts"
+	// FaultInjected is the map key used for the faults injected into the request by the FaultInjection middleware.
+	FaultInjected = "FaultInjected"
+	// RequestID is the map key used for the ID of the request set by the RequestID middleware.
+	RequestID = "RequestID"
 
 	// TLSVersion is the version of TLS used in the request.
 	TLSVersion = "TLSVersion"
@@ -118,11 +126,15 @@ func init() {
 	}
 	allCoreKeys[ServiceAddr] = struct{}{}
 	allCoreKeys[ClientAddr] = struct{}{}
+	allCoreKeys[ClientCountry] = struct{}{}
+	allCoreKeys[ClientASN] = struct{}{}
 	allCoreKeys[RequestAddr] = struct{}{}
 	allCoreKeys[GzipRatio] = struct{}{}
 	allCoreKeys[StartLocal] = struct{}{}
 	allCoreKeys[Overhead] = struct{}{}
 	allCoreKeys[RetryAttempts] = struct{}{}
+	allCoreKeys[FaultInjected] = struct{}{}
+	allCoreKeys[RequestID] = struct{}{}
 	allCoreKeys[TLSVersion] = struct{}{}
 	allCoreKeys[TLSCipher] = struct{}{}
 	allCoreKeys[TLSClientSubject] = struct{}{}
@@ -19,6 +19,7 @@ import (
 	"github.com/rs/zerolog/log"
 	"github.com/sirupsen/logrus"
 	ptypes "github.com/traefik/paerser/types"
+	"github.com/traefik/traefik/v3/pkg/geoip"
 	"github.com/traefik/traefik/v3/pkg/middlewares/capture"
 	"github.com/traefik/traefik/v3/pkg/middlewares/observability"
 	"github.com/traefik/traefik/v3/pkg/observability/logs"
@@ -71,6 +72,8 @@ type Accesslog interface {
 	Rotate() error
 	// AliceConstructor returns an alice.Constructor that wraps the Handler (conditionally) in a middleware chain.
 	AliceConstructor() alice.Constructor
+	// SetGeoIPResolver sets the resolver used to log the GeoIP data of the client IPs.
+	SetGeoIPResolver(resolver *geoip.Resolver)
 }
 
 // Handler will write each request and its response to the access log.
@@ -82,6 +85,7 @@ type Handler struct {
 	httpCodeRanges types.HTTPCodeRanges
 	logHandlerChan chan handlerParams
 	wg             sync.WaitGroup
+	geoIPResolver  *geoip.Resolver
 }
 
 // NewHandler creates a new Handler.
@@ -188,6 +192,12 @@ func NewHandlerWithFormatWriter(ctx context.Context, config *otypes.AccessLog, w
 	return logHandler, nil
 }
 
+// SetGeoIPResolver sets the resolver used to log the GeoIP data of the client IPs.
+// It must be called before the handler serves requests.
+func (h *Handler) SetGeoIPResolver(resolver *geoip.Resolver) {
+	h.geoIPResolver = resolver
+}
+
 // AliceConstructor returns an alice.Constructor that wraps the Handler (conditionally) in a middleware chain.
 func (h *Handler) AliceConstructor() alice.Constructor {
 	return func(next http.Handler) (http.Handler, error) {
@@ -268,6 +278,16 @@ func (h *Handler) ServeHTTP(rw http.ResponseWriter, req *http.Request, next http
 		core[ClientHost] = forwardedFor
 	}
 
+	if resolver := h.geoIPResolver; resolver != nil {
+		clientIP := net.ParseIP(resolver.ClientIP(req))
+		if country := resolver.Country(clientIP); country != "" {
+			core[ClientCountry] = country
+		}
+		if asn, _ := resolver.AutonomousSystem(clientIP); asn != 0 {
+			core[ClientASN] = asn
+		}
+	}
+
 	ctx := req.Context()
 	capt, err := capture.FromContext(ctx)
 	if err != nil {
@@ -3,6 +3,7 @@ package auth
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
 	"errors"
 	"fmt"
 	"io"
@@ -13,10 +14,13 @@ import (
 	"strings"
 	"time"
 
+	authv3 "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
+	"github.com/rs/zerolog"
 	"github.com/traefik/traefik/v3/pkg/config/dynamic"
 	"github.com/traefik/traefik/v3/pkg/middlewares"
 	"github.com/traefik/traefik/v3/pkg/middlewares/accesslog"
 	"github.com/traefik/traefik/v3/pkg/middlewares/ingressnginx"
+	mmetrics "github.com/traefik/traefik/v3/pkg/middlewares/metrics"
 	"github.com/traefik/traefik/v3/pkg/middlewares/observability"
 	"github.com/traefik/traefik/v3/pkg/observability/tracing"
 	"github.com/traefik/traefik/v3/pkg/proxy/httputil"
@@ -28,6 +32,9 @@ import (
 
 const typeNameForward = "ForwardAuth"
 
+// forwardAuthTimeout is the timeout of the calls to the authentication server.
+const forwardAuthTimeout = 30 * time.Second
+
 const (
 	xForwardedURI    = "X-Forwarded-Uri"
 	xForwardedMethod = "X-Forwarded-Method"
@@ -65,6 +72,9 @@ type forwardAuth struct {
 	preserveRequestMethod    bool
 	authSigninURL            string
 	interpolate              bool
+	outcomes                 *mmetrics.OutcomeRecorder
+	cache                    *decisionCache
+	authzClient              authv3.AuthorizationClient
 }
 
 // NewForward creates a forward auth middleware.
@@ -92,6 +102,7 @@ func NewForward(ctx context.Context, next http.Handler, config dynamic.ForwardAu
 		preserveRequestMethod:    config.PreserveRequestMethod,
 		authSigninURL:            config.AuthSigninURL,
 		interpolate:              config.Interpolate,
+		outcomes:                 mmetrics.NewOutcomeRecorder(ctx, name, typeNameForward),
 	}
 
 	if config.MaxBodySize != nil {
@@ -112,22 +123,25 @@ func NewForward(ctx context.Context, next http.Handler, config dynamic.ForwardAu
 		CheckRedirect: func(r *http.Request, via []*http.Request) error {
 			return http.ErrUseLastResponse
 		},
-		Timeout: 30 * time.Second,
+		Timeout: forwardAuthTimeout,
 	}
 
+	var clientTLS *types.ClientTLS
+	var tlsConfig *tls.Config
 	if config.TLS != nil {
 		if config.TLS.CAOptional != nil {
 			logger.Warn().Msg("CAOptional option is deprecated, TLS client authentication is a server side option, please remove any usage of this option.")
 		}
 
-		clientTLS := &types.ClientTLS{
+		clientTLS = &types.ClientTLS{
 			CA:                 config.TLS.CA,
 			Cert:               config.TLS.Cert,
 			Key:                config.TLS.Key,
 			InsecureSkipVerify: config.TLS.InsecureSkipVerify,
 		}
 
-		tlsConfig, err := clientTLS.CreateTLSConfig(ctx)
+		var err error
+		tlsConfig, err = clientTLS.CreateTLSConfig(ctx)
 		if err != nil {
 			return nil, fmt.Errorf("unable to create client TLS configuration: %w", err)
 		}
@@ -145,6 +159,32 @@ func NewForward(ctx context.Context, next http.Handler, config dynamic.ForwardAu
 		fa.authResponseHeadersRegex = re
 	}
 
+	if config.GRPC {
+		authzClient, err := newAuthorizationClient(ctx, config.Address, clientTLS, tlsConfig)
+		if err != nil {
+			return nil, fmt.Errorf("creating gRPC authorization client: %w", err)
+		}
+		fa.authzClient = authzClient
+	}
+
+	if config.Cache != nil {
+		// The request body is not part of the cache key.
+		if config.ForwardBody {
+			return nil, errors.New("cache cannot be used with forwardBody")
+		}
+
+		// The gRPC authorization responses mutate the request, and cannot be replayed as HTTP responses.
+		if config.GRPC {
+			return nil, errors.New("cache cannot be used with grpc")
+		}
+
+		cache, err := newDecisionCache(ctx, name, *config.Cache)
+		if err != nil {
+			return nil, fmt.Errorf("creating decisions cache: %w", err)
+		}
+		fa.cache = cache
+	}
+
 	return fa, nil
 }
 
@@ -155,6 +195,11 @@ func (fa *forwardAuth) GetTracingInformation() (string, string) {
 func (fa *forwardAuth) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
 	logger := middlewares.GetLogger(req.Context(), fa.name, typeNameForward)
 
+	if fa.authzClient != nil {
+		fa.serveGRPC(rw, req, logger)
+		return
+	}
+
 	address := fa.address
 	if fa.interpolate {
 		address = ingressnginx.ReplaceVariables(address, req)
@@ -174,6 +219,18 @@ func (fa *forwardAuth) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
 		return
 	}
 
+	var cacheKey string
+	if fa.cache != nil {
+		cacheKey = fa.cache.keyOf(req, address)
+
+		if cached, ok := fa.cache.get(req.Context(), logger, cacheKey); ok {
+			logger.Debug().Msg("Using cached authentication decision")
+
+			fa.handleAuthResponse(rw, req, logger, address, cached.response(forwardReq), cached.Body, nil, nil)
+			return
+		}
+	}
+
 	if fa.forwardBody {
 		bodyBytes, err := fa.readBodyBytes(req)
 		if errors.Is(err, errBodyTooLarge) {
@@ -217,6 +274,7 @@ func (fa *forwardAuth) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
 	if forwardErr != nil {
 		logger.Error().Err(forwardErr).Msgf("Error calling %s", address)
 		observability.SetStatusErrorf(req.Context(), "Error calling %s. Cause: %s", address, forwardErr)
+		fa.outcomes.Record(req, mmetrics.OutcomeError)
 
 		statusCode := http.StatusInternalServerError
 		if errors.Is(forwardErr, context.Canceled) {
@@ -250,6 +308,16 @@ func (fa *forwardAuth) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
 		forwardSpan.End()
 	}
 
+	if fa.cache != nil {
+		fa.cache.set(req.Context(), logger, cacheKey, forwardResponse, body)
+	}
+
+	fa.handleAuthResponse(rw, req, logger, address, forwardResponse, body, tracer, forwardSpan)
+}
+
+// handleAuthResponse applies the decision of the authentication server response,
+// either by forwarding the request to the next handler, or by replying with the denial.
+func (fa *forwardAuth) handleAuthResponse(rw http.ResponseWriter, req *http.Request, logger *zerolog.Logger, address string, forwardResponse *http.Response, body []byte, tracer *tracing.Tracer, forwardSpan trace.Span) {
 	if fa.headerField != "" {
 		if elems := forwardResponse.Header[http.CanonicalHeaderKey(fa.headerField)]; len(elems) > 0 {
 			logData := accesslog.GetLogData(req)
@@ -262,25 +330,10 @@ func (fa *forwardAuth) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
 	// If auth server returns 401 and AuthSigninURL is configured, redirect to signin URL.
 	if fa.authSigninURL != "" && forwardResponse.StatusCode == http.StatusUnauthorized {
 		logger.Debug().Msgf("Redirecting to signin URL: %s", fa.authSigninURL)
-
-		signinURL := fa.authSigninURL
-		if fa.interpolate {
-			// If the signin URL doesn't contain "rd=" parameter,
-			// add it with the original request URL to match the NGINX behavior.
-			if !strings.Contains(signinURL, "rd=") {
-				suffix := "rd=$scheme://$host$escaped_request_uri"
-				if !strings.Contains(signinURL, "?") {
-					signinURL += "?" + suffix
-				} else {
-					signinURL += "&" + suffix
-				}
-			}
-
-			signinURL = ingressnginx.ReplaceVariables(signinURL, req)
-		}
+		fa.outcomes.Record(req, mmetrics.OutcomeDenied)
 
 		tracer.CaptureResponse(forwardSpan, forwardResponse.Header, http.StatusFound, trace.SpanKindClient)
-		http.Redirect(rw, req, signinURL, http.StatusFound)
+		http.Redirect(rw, req, fa.signinURL(req), http.StatusFound)
 		return
 	}
 
@@ -288,6 +341,7 @@ func (fa *forwardAuth) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
 	// didn't return a response within the range of [200, 300).
 	if forwardResponse.StatusCode < http.StatusOK || forwardResponse.StatusCode >= http.StatusMultipleChoices {
 		logger.Debug().Msgf("Remote error %s. StatusCode: %d", address, forwardResponse.StatusCode)
+		fa.outcomes.Record(req, mmetrics.OutcomeDenied)
 
 		utils.CopyHeaders(rw.Header(), forwardResponse.Header)
 		utils.RemoveHeaders(rw.Header(), hopHeaders...)
@@ -338,6 +392,7 @@ func (fa *forwardAuth) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
 	}
 
 	tracer.CaptureResponse(forwardSpan, forwardResponse.Header, forwardResponse.StatusCode, trace.SpanKindClient)
+	fa.outcomes.Record(req, mmetrics.OutcomeAllowed)
 
 	req.RequestURI = req.URL.RequestURI()
 
@@ -350,6 +405,27 @@ func (fa *forwardAuth) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
 	fa.next.ServeHTTP(middlewares.NewResponseModifier(rw, req, fa.buildModifier(authCookies)), req)
 }
 
+// signinURL returns the URL to redirect to when the authentication server returns 401 Unauthorized.
+func (fa *forwardAuth) signinURL(req *http.Request) string {
+	signinURL := fa.authSigninURL
+	if !fa.interpolate {
+		return signinURL
+	}
+
+	// If the signin URL doesn't contain "rd=" parameter,
+	// add it with the original request URL to match the NGINX behavior.
+	if !strings.Contains(signinURL, "rd=") {
+		suffix := "rd=$scheme://$host$escaped_request_uri"
+		if !strings.Contains(signinURL, "?") {
+			signinURL += "?" + suffix
+		} else {
+			signinURL += "&" + suffix
+		}
+	}
+
+	return ingressnginx.ReplaceVariables(signinURL, req)
+}
+
 func (fa *forwardAuth) redirectURL(forwardResponse *http.Response) (*url.URL, error) {
 	if !fa.preserveLocationHeader {
 		return forwardResponse.Location()
@@ -0,0 +1,333 @@
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mailgun/ttlmap"
+	"github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+)
+
+const forwardCachePrefix = "forwardauth:"
+
+// decision is an authentication server response kept by the decisions cache.
+type decision struct {
+	StatusCode int         `json:"statusCode"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       []byte      `json:"body,omitempty"`
+}
+
+// response returns the decision as a response to the given authentication request.
+func (d *decision) response(forwardReq *http.Request) *http.Response {
+	return &http.Response{
+		StatusCode: d.StatusCode,
+		Header:     d.Header,
+		Request:    forwardReq,
+	}
+}
+
+// decisionStore stores the authentication decisions.
+type decisionStore interface {
+	get(ctx context.Context, key string) (*decision, bool, error)
+	set(ctx context.Context, key string, value *decision, ttl time.Duration) error
+}
+
+// redisClient is the subset of the Redis client used by the decisions cache.
+type redisClient interface {
+	Get(ctx context.Context, key string) *redis.StringCmd
+	Set(ctx context.Context, key string, value any, expiration time.Duration) *redis.StatusCmd
+}
+
+// decisionCache caches the authentication server responses, keyed by the configured request parts.
+type decisionCache struct {
+	prefix      string
+	key         dynamic.ForwardAuthCacheKey
+	ttl         time.Duration
+	negativeTTL time.Duration
+	store       decisionStore
+}
+
+func newDecisionCache(ctx context.Context, name string, config dynamic.ForwardAuthCache) (*decisionCache, error) {
+	if len(config.Key.Headers) == 0 && len(config.Key.Cookies) == 0 && !config.Key.Method && config.Key.PathSegments <= 0 {
+		return nil, errors.New("at least one request part must be defined in the cache key")
+	}
+
+	cache := &decisionCache{
+		prefix:      forwardCachePrefix + name + ":",
+		key:         config.Key,
+		ttl:         time.Duration(config.TTL),
+		negativeTTL: time.Duration(config.NegativeTTL),
+	}
+
+	if config.Redis != nil {
+		client, err := newRedisClient(ctx, config.Redis)
+		if err != nil {
+			return nil, fmt.Errorf("creating Redis client: %w", err)
+		}
+
+		cache.store = &redisDecisionStore{client: client}
+		return cache, nil
+	}
+
+	maxEntries := config.MaxEntries
+	if maxEntries <= 0 {
+		maxEntries = 10000
+	}
+
+	entries, err := ttlmap.NewConcurrent(maxEntries)
+	if err != nil {
+		return nil, fmt.Errorf("creating ttlmap: %w", err)
+	}
+
+	cache.store = &memoryDecisionStore{entries: entries}
+	return cache, nil
+}
+
+// keyOf returns the cache key of the given request, sent to the given authentication server address.
+func (c *decisionCache) keyOf(req *http.Request, address string) string {
+	h := sha256.New()
+
+	write := func(values ...string) {
+		for _, value := range values {
+			h.Write([]byte(strconv.Itoa(len(value))))
+			h.Write([]byte{':'})
+			h.Write([]byte(value))
+		}
+	}
+
+	write(address)
+
+	for _, name := range c.key.Headers {
+		write(name)
+		write(req.Header.Values(name)...)
+	}
+
+	for _, name := range c.key.Cookies {
+		write(name)
+		if cookie, err := req.Cookie(name); err == nil {
+			write(cookie.Value)
+		}
+	}
+
+	if c.key.Method {
+		write(req.Method)
+	}
+
+	if c.key.PathSegments > 0 {
+		write(pathPrefix(req.URL.Path, c.key.PathSegments))
+	}
+
+	return c.prefix + hex.EncodeToString(h.Sum(nil))
+}
+
+func (c *decisionCache) get(ctx context.Context, logger *zerolog.Logger, key string) (*decision, bool) {
+	value, ok, err := c.store.get(ctx, key)
+	if err != nil {
+		logger.Debug().Err(err).Msg("Error while reading the authentication decisions cache")
+		return nil, false
+	}
+
+	return value, ok
+}
+
+// set caches the given authentication server response, if it is cacheable.
+func (c *decisionCache) set(ctx context.Context, logger *zerolog.Logger, key string, res *http.Response, body []byte) {
+	// A response setting cookies is specific to the client, and must not be shared.
+	if len(res.Header.Values("Set-Cookie")) > 0 {
+		return
+	}
+
+	var ttl time.Duration
+	switch {
+	case res.StatusCode >= http.StatusOK && res.StatusCode < http.StatusMultipleChoices:
+		ttl = c.ttl
+	case res.StatusCode >= http.StatusMultipleChoices && res.StatusCode < http.StatusInternalServerError:
+		ttl = c.negativeTTL
+	default:
+		// Informational and server error responses are not authentication decisions.
+		return
+	}
+
+	if maxAge, ok := cacheControlTTL(res.Header); ok {
+		ttl = maxAge
+	}
+
+	if ttl <= 0 {
+		return
+	}
+
+	value := &decision{
+		StatusCode: res.StatusCode,
+		Header:     res.Header.Clone(),
+		Body:       body,
+	}
+
+	if err := c.store.set(ctx, key, value, ttl); err != nil {
+		logger.Debug().Err(err).Msg("Error while writing the authentication decisions cache")
+	}
+}
+
+// cacheControlTTL returns the TTL defined by the Cache-Control header of the authentication server response.
+// A zero TTL is returned when the response must not be cached.
+func cacheControlTTL(header http.Header) (time.Duration, bool) {
+	values := header.Values("Cache-Control")
+	if len(values) == 0 {
+		return 0, false
+	}
+
+	var maxAge, sharedMaxAge string
+	for _, value := range values {
+		for directive := range strings.SplitSeq(value, ",") {
+			name, arg, _ := strings.Cut(strings.TrimSpace(directive), "=")
+			switch strings.ToLower(name) {
+			case "no-store", "no-cache", "private":
+				return 0, true
+			case "max-age":
+				maxAge = strings.Trim(arg, `"`)
+			case "s-maxage":
+				sharedMaxAge = strings.Trim(arg, `"`)
+			}
+		}
+	}
+
+	// The s-maxage directive takes precedence, as the decisions cache is shared between the clients.
+	for _, arg := range []string{sharedMaxAge, maxAge} {
+		if arg == "" {
+			continue
+		}
+
+		seconds, err := strconv.Atoi(arg)
+		if err != nil || seconds < 0 {
+			return 0, true
+		}
+
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	return 0, false
+}
+
+// pathPrefix returns the first segments of the given path.
+func pathPrefix(path string, segments int) string {
+	var prefix strings.Builder
+	for segment := range strings.SplitSeq(strings.TrimPrefix(path, "/"), "/") {
+		if segments == 0 {
+			break
+		}
+
+		prefix.WriteString("/")
+		prefix.WriteString(segment)
+		segments--
+	}
+
+	return prefix.String()
+}
+
+type memoryDecisionStore struct {
+	entries *ttlmap.TtlMap
+}
+
+func (s *memoryDecisionStore) get(_ context.Context, key string) (*decision, bool, error) {
+	value, ok := s.entries.Get(key)
+	if !ok {
+		return nil, false, nil
+	}
+
+	return value.(*decision), true, nil
+}
+
+func (s *memoryDecisionStore) set(_ context.Context, key string, value *decision, ttl time.Duration) error {
+	// The ttlmap has a precision of one second.
+	ttlSeconds := int(ttl.Seconds())
+	if ttlSeconds < 1 {
+		return nil
+	}
+
+	return s.entries.Set(key, value, ttlSeconds)
+}
+
+type redisDecisionStore struct {
+	client redisClient
+}
+
+func (s *redisDecisionStore) get(ctx context.Context, key string) (*decision, bool, error) {
+	data, err := s.client.Get(ctx, key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("getting decision: %w", err)
+	}
+
+	var value decision
+	if err := json.Unmarshal(data, &value); err != nil {
+		return nil, false, fmt.Errorf("unmarshaling decision: %w", err)
+	}
+
+	return &value, true, nil
+}
+
+func (s *redisDecisionStore) set(ctx context.Context, key string, value *decision, ttl time.Duration) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("marshaling decision: %w", err)
+	}
+
+	if err := s.client.Set(ctx, key, data, ttl).Err(); err != nil {
+		return fmt.Errorf("setting decision: %w", err)
+	}
+
+	return nil
+}
+
+func newRedisClient(ctx context.Context, config *dynamic.Redis) (redisClient, error) {
+	options := &redis.UniversalOptions{
+		Addrs:          config.Endpoints,
+		Username:       config.Username,
+		Password:       config.Password,
+		DB:             config.DB,
+		PoolSize:       config.PoolSize,
+		MinIdleConns:   config.MinIdleConns,
+		MaxActiveConns: config.MaxActiveConns,
+	}
+
+	if config.DialTimeout != nil && *config.DialTimeout > 0 {
+		options.DialTimeout = time.Duration(*config.DialTimeout)
+	}
+
+	if config.ReadTimeout != nil {
+		if *config.ReadTimeout > 0 {
+			options.ReadTimeout = time.Duration(*config.ReadTimeout)
+		} else {
+			options.ReadTimeout = -1
+		}
+	}
+
+	if config.WriteTimeout != nil {
+		if *config.WriteTimeout > 0 {
+			options.WriteTimeout = time.Duration(*config.WriteTimeout)
+		} else {
+			options.WriteTimeout = -1
+		}
+	}
+
+	if config.TLS != nil {
+		var err error
+		options.TLSConfig, err = config.TLS.CreateTLSConfig(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("creating TLS config: %w", err)
+		}
+	}
+
+	return redis.NewUniversalClient(options), nil
+}
@@ -0,0 +1,295 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ptypes "github.com/traefik/paerser/types"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+	"github.com/traefik/traefik/v3/pkg/testhelpers"
+)
+
+func TestForwardAuthCache(t *testing.T) {
+	testCases := []struct {
+		desc          string
+		cache         dynamic.ForwardAuthCache
+		authStatus    int
+		authHeader    http.Header
+		requests      []http.Header
+		expectedCalls int32
+	}{
+		{
+			desc: "allow decision is cached per key",
+			cache: dynamic.ForwardAuthCache{
+				Key: dynamic.ForwardAuthCacheKey{Headers: []string{"Authorization"}},
+				TTL: ptypes.Duration(time.Minute),
+			},
+			authStatus:    http.StatusOK,
+			requests:      []http.Header{{"Authorization": {"a"}}, {"Authorization": {"a"}}, {"Authorization": {"b"}}},
+			expectedCalls: 2,
+		},
+		{
+			desc: "deny decision is not cached without negative TTL",
+			cache: dynamic.ForwardAuthCache{
+				Key: dynamic.ForwardAuthCacheKey{Headers: []string{"Authorization"}},
+				TTL: ptypes.Duration(time.Minute),
+			},
+			authStatus:    http.StatusForbidden,
+			requests:      []http.Header{{"Authorization": {"a"}}, {"Authorization": {"a"}}},
+			expectedCalls: 2,
+		},
+		{
+			desc: "deny decision is cached with negative TTL",
+			cache: dynamic.ForwardAuthCache{
+				Key:         dynamic.ForwardAuthCacheKey{Headers: []string{"Authorization"}},
+				TTL:         ptypes.Duration(time.Minute),
+				NegativeTTL: ptypes.Duration(time.Minute),
+			},
+			authStatus:    http.StatusForbidden,
+			requests:      []http.Header{{"Authorization": {"a"}}, {"Authorization": {"a"}}},
+			expectedCalls: 1,
+		},
+		{
+			desc: "server error is not cached",
+			cache: dynamic.ForwardAuthCache{
+				Key:         dynamic.ForwardAuthCacheKey{Headers: []string{"Authorization"}},
+				TTL:         ptypes.Duration(time.Minute),
+				NegativeTTL: ptypes.Duration(time.Minute),
+			},
+			authStatus:    http.StatusServiceUnavailable,
+			requests:      []http.Header{{"Authorization": {"a"}}, {"Authorization": {"a"}}},
+			expectedCalls: 2,
+		},
+		{
+			desc: "Cache-Control no-store is not cached",
+			cache: dynamic.ForwardAuthCache{
+				Key: dynamic.ForwardAuthCacheKey{Headers: []string{"Authorization"}},
+				TTL: ptypes.Duration(time.Minute),
+			},
+			authStatus:    http.StatusOK,
+			authHeader:    http.Header{"Cache-Control": {"no-store"}},
+			requests:      []http.Header{{"Authorization": {"a"}}, {"Authorization": {"a"}}},
+			expectedCalls: 2,
+		},
+		{
+			desc: "Cache-Control max-age overrides the TTL",
+			cache: dynamic.ForwardAuthCache{
+				Key: dynamic.ForwardAuthCacheKey{Headers: []string{"Authorization"}},
+			},
+			authStatus:    http.StatusOK,
+			authHeader:    http.Header{"Cache-Control": {"max-age=60"}},
+			requests:      []http.Header{{"Authorization": {"a"}}, {"Authorization": {"a"}}},
+			expectedCalls: 1,
+		},
+		{
+			desc: "response setting cookies is not cached",
+			cache: dynamic.ForwardAuthCache{
+				Key: dynamic.ForwardAuthCacheKey{Headers: []string{"Authorization"}},
+				TTL: ptypes.Duration(time.Minute),
+			},
+			authStatus:    http.StatusOK,
+			authHeader:    http.Header{"Set-Cookie": {"session=foo"}},
+			requests:      []http.Header{{"Authorization": {"a"}}, {"Authorization": {"a"}}},
+			expectedCalls: 2,
+		},
+		{
+			desc: "cookie key",
+			cache: dynamic.ForwardAuthCache{
+				Key: dynamic.ForwardAuthCacheKey{Cookies: []string{"session"}},
+				TTL: ptypes.Duration(time.Minute),
+			},
+			authStatus:    http.StatusOK,
+			requests:      []http.Header{{"Cookie": {"session=a; other=a"}}, {"Cookie": {"session=a; other=b"}}, {"Cookie": {"session=b"}}},
+			expectedCalls: 2,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			var calls atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls.Add(1)
+
+				for name, values := range test.authHeader {
+					w.Header()[name] = values
+				}
+				w.Header().Set("X-Auth-User", "user")
+				w.WriteHeader(test.authStatus)
+				fmt.Fprint(w, "auth")
+			}))
+			t.Cleanup(server.Close)
+
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "user", r.Header.Get("X-Auth-User"))
+				fmt.Fprint(w, "traefik")
+			})
+
+			cache := test.cache
+			middleware, err := NewForward(t.Context(), next, dynamic.ForwardAuth{
+				Address:             server.URL,
+				AuthResponseHeaders: []string{"X-Auth-User"},
+				Cache:               &cache,
+			}, "authTest")
+			require.NoError(t, err)
+
+			ts := httptest.NewServer(middleware)
+			t.Cleanup(ts.Close)
+
+			for _, header := range test.requests {
+				req := testhelpers.MustNewRequest(http.MethodGet, ts.URL, nil)
+				req.Header = header
+
+				res, err := http.DefaultClient.Do(req)
+				require.NoError(t, err)
+
+				body, err := io.ReadAll(res.Body)
+				require.NoError(t, err)
+				require.NoError(t, res.Body.Close())
+
+				assert.Equal(t, test.authStatus, res.StatusCode)
+				if test.authStatus == http.StatusOK {
+					assert.Equal(t, "traefik", string(body))
+				} else {
+					assert.Equal(t, "auth", string(body))
+				}
+			}
+
+			assert.Equal(t, test.expectedCalls, calls.Load())
+		})
+	}
+}
+
+func TestForwardAuthCache_config(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+
+	_, err := NewForward(t.Context(), next, dynamic.ForwardAuth{
+		Address: "http://localhost",
+		Cache:   &dynamic.ForwardAuthCache{TTL: ptypes.Duration(time.Minute)},
+	}, "authTest")
+	assert.Error(t, err)
+
+	_, err = NewForward(t.Context(), next, dynamic.ForwardAuth{
+		Address:     "http://localhost",
+		ForwardBody: true,
+		Cache: &dynamic.ForwardAuthCache{
+			Key: dynamic.ForwardAuthCacheKey{Method: true},
+			TTL: ptypes.Duration(time.Minute),
+		},
+	}, "authTest")
+	assert.Error(t, err)
+}
+
+func Test_decisionCache_keyOf(t *testing.T) {
+	cache := &decisionCache{
+		prefix: "forwardauth:test:",
+		key:    dynamic.ForwardAuthCacheKey{Method: true, PathSegments: 2},
+	}
+
+	keyOf := func(method, target string) string {
+		return cache.keyOf(httptest.NewRequest(method, target, nil), "http://auth")
+	}
+
+	assert.Equal(t, keyOf(http.MethodGet, "/api/v1/foo"), keyOf(http.MethodGet, "/api/v1/bar"))
+	assert.NotEqual(t, keyOf(http.MethodGet, "/api/v1/foo"), keyOf(http.MethodGet, "/api/v2/foo"))
+	assert.NotEqual(t, keyOf(http.MethodGet, "/api/v1/foo"), keyOf(http.MethodPost, "/api/v1/foo"))
+	assert.Contains(t, keyOf(http.MethodGet, "/"), "forwardauth:test:")
+}
+
+func Test_cacheControlTTL(t *testing.T) {
+	testCases := []struct {
+		header      string
+		expectedTTL time.Duration
+		expectedOK  bool
+	}{
+		{header: ""},
+		{header: "public", expectedOK: false},
+		{header: "max-age=30", expectedTTL: 30 * time.Second, expectedOK: true},
+		{header: "max-age=30, s-maxage=10", expectedTTL: 10 * time.Second, expectedOK: true},
+		{header: "max-age=0", expectedOK: true},
+		{header: "max-age=foo", expectedOK: true},
+		{header: "no-cache, max-age=30", expectedOK: true},
+		{header: "private", expectedOK: true},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.header, func(t *testing.T) {
+			t.Parallel()
+
+			header := http.Header{}
+			if test.header != "" {
+				header.Set("Cache-Control", test.header)
+			}
+
+			ttl, ok := cacheControlTTL(header)
+			assert.Equal(t, test.expectedOK, ok)
+			assert.Equal(t, test.expectedTTL, ttl)
+		})
+	}
+}
+
+func Test_pathPrefix(t *testing.T) {
+	assert.Equal(t, "/api", pathPrefix("/api/v1/foo", 1))
+	assert.Equal(t, "/api/v1", pathPrefix("/api/v1/foo", 2))
+	assert.Equal(t, "/api/v1/foo", pathPrefix("/api/v1/foo", 5))
+	assert.Equal(t, "/", pathPrefix("/", 1))
+}
+
+func Test_redisDecisionStore(t *testing.T) {
+	client := &mockRedisClient{values: map[string]string{}}
+	cache := &decisionCache{
+		prefix: "forwardauth:test:",
+		key:    dynamic.ForwardAuthCacheKey{Headers: []string{"Authorization"}},
+		ttl:    time.Minute,
+		store:  &redisDecisionStore{client: client},
+	}
+
+	logger := log.Logger
+	res := &http.Response{StatusCode: http.StatusOK, Header: http.Header{"X-Auth-User": {"user"}}}
+	cache.set(t.Context(), &logger, "key", res, []byte("body"))
+
+	assert.Equal(t, time.Minute, client.expirations["key"])
+
+	cached, ok := cache.get(t.Context(), &logger, "key")
+	require.True(t, ok)
+	assert.Equal(t, &decision{StatusCode: http.StatusOK, Header: http.Header{"X-Auth-User": {"user"}}, Body: []byte("body")}, cached)
+
+	_, ok = cache.get(t.Context(), &logger, "unknown")
+	assert.False(t, ok)
+}
+
+type mockRedisClient struct {
+	values      map[string]string
+	expirations map[string]time.Duration
+}
+
+func (m *mockRedisClient) Get(ctx context.Context, key string) *redis.StringCmd {
+	value, ok := m.values[key]
+	if !ok {
+		return redis.NewStringResult("", redis.Nil)
+	}
+
+	return redis.NewStringResult(value, nil)
+}
+
+func (m *mockRedisClient) Set(ctx context.Context, key string, value any, expiration time.Duration) *redis.StatusCmd {
+	m.values[key] = string(value.([]byte))
+
+	if m.expirations == nil {
+		m.expirations = map[string]time.Duration{}
+	}
+	m.expirations[key] = expiration
+
+	return redis.NewStatusResult("OK", nil)
+}
@@ -0,0 +1,503 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	authv3 "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"github.com/traefik/traefik/v3/pkg/middlewares"
+	"github.com/traefik/traefik/v3/pkg/middlewares/accesslog"
+	mmetrics "github.com/traefik/traefik/v3/pkg/middlewares/metrics"
+	"github.com/traefik/traefik/v3/pkg/middlewares/observability"
+	"github.com/traefik/traefik/v3/pkg/observability/tracing"
+	"github.com/traefik/traefik/v3/pkg/proxy/httputil"
+	"github.com/traefik/traefik/v3/pkg/types"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// authorizationConns holds the connections to the gRPC authorization servers.
+// As the middlewares are created again on each configuration reload,
+// the connections are shared with the middlewares of the next configuration, and closed once they are not used anymore.
+var authorizationConns = newAuthorizationConnPool(forwardAuthTimeout)
+
+// authorizationConnKey identifies a connection to a gRPC authorization server.
+type authorizationConnKey struct {
+	address string
+	// tls is the digest of the TLS configuration, computed from the content of the CA, certificate, and key,
+	// so that a connection is not reused once these files are updated.
+	tls string
+}
+
+// newAuthorizationConnKey returns the key of the connection to the given address with the given TLS configuration.
+func newAuthorizationConnKey(address string, clientTLS *types.ClientTLS) (authorizationConnKey, error) {
+	key := authorizationConnKey{address: address}
+	if clientTLS == nil {
+		return key, nil
+	}
+
+	hash := sha256.New()
+	for _, value := range []string{clientTLS.CA, clientTLS.Cert, clientTLS.Key} {
+		content, err := types.FileOrContent(value).Read()
+		if err != nil {
+			return authorizationConnKey{}, err
+		}
+
+		_, _ = fmt.Fprintf(hash, "%d:", len(content))
+		_, _ = hash.Write(content)
+	}
+	_, _ = fmt.Fprintf(hash, "%t", clientTLS.InsecureSkipVerify)
+
+	key.tls = hex.EncodeToString(hash.Sum(nil))
+
+	return key, nil
+}
+
+// authorizationConn is a connection of the pool, with the number of middlewares using it.
+type authorizationConn struct {
+	*grpc.ClientConn
+
+	refs       int
+	closeTimer *time.Timer
+}
+
+// authorizationConnPool is a pool of connections to the gRPC authorization servers,
+// keyed by address and TLS configuration.
+type authorizationConnPool struct {
+	// closeDelay is how long an unused connection is kept open,
+	// to let the in-flight checks end and the middlewares of the next configuration reuse it.
+	closeDelay time.Duration
+
+	mu    sync.Mutex
+	conns map[authorizationConnKey]*authorizationConn
+}
+
+func newAuthorizationConnPool(closeDelay time.Duration) *authorizationConnPool {
+	return &authorizationConnPool{
+		closeDelay: closeDelay,
+		conns:      make(map[authorizationConnKey]*authorizationConn),
+	}
+}
+
+// acquire returns the connection to the given authorization server, creating it if needed.
+// The connection is secured with the given TLS configuration, if any.
+// It is released when the given context is done, and closed after the close delay if it is not acquired again.
+func (p *authorizationConnPool) acquire(ctx context.Context, key authorizationConnKey, tlsConfig *tls.Config) (*grpc.ClientConn, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	conn, ok := p.conns[key]
+	if !ok {
+		creds := insecure.NewCredentials()
+		if tlsConfig != nil {
+			creds = credentials.NewTLS(tlsConfig)
+		}
+
+		clientConn, err := grpc.NewClient(key.address, grpc.WithTransportCredentials(creds))
+		if err != nil {
+			return nil, err
+		}
+
+		conn = &authorizationConn{ClientConn: clientConn}
+		p.conns[key] = conn
+	}
+
+	if conn.closeTimer != nil {
+		conn.closeTimer.Stop()
+		conn.closeTimer = nil
+	}
+	conn.refs++
+
+	context.AfterFunc(ctx, func() {
+		p.release(key, conn)
+	})
+
+	return conn.ClientConn, nil
+}
+
+// release releases the given connection, and schedules its closing if it is not used anymore.
+func (p *authorizationConnPool) release(key authorizationConnKey, conn *authorizationConn) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	conn.refs--
+	if conn.refs > 0 {
+		return
+	}
+
+	var closeTimer *time.Timer
+	closeTimer = time.AfterFunc(p.closeDelay, func() {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+
+		// The connection has been acquired again since this closing was scheduled.
+		if conn.closeTimer != closeTimer {
+			return
+		}
+
+		delete(p.conns, key)
+
+		if err := conn.Close(); err != nil {
+			log.Error().Err(err).Str("address", key.address).Msg("Error while closing the gRPC authorization connection")
+		}
+	})
+	conn.closeTimer = closeTimer
+}
+
+// newAuthorizationClient returns a client of the Envoy ext_authz Authorization service,
+// using the shared connection to the given address with the given TLS configuration.
+// The connection is released when the given context is done.
+func newAuthorizationClient(ctx context.Context, address string, clientTLS *types.ClientTLS, tlsConfig *tls.Config) (authv3.AuthorizationClient, error) {
+	key, err := newAuthorizationConnKey(address, clientTLS)
+	if err != nil {
+		return nil, fmt.Errorf("reading TLS configuration: %w", err)
+	}
+
+	conn, err := authorizationConns.acquire(ctx, key, tlsConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return authv3.NewAuthorizationClient(conn), nil
+}
+
+// serveGRPC checks the request with the gRPC authorization server,
+// and applies the returned decision.
+func (fa *forwardAuth) serveGRPC(rw http.ResponseWriter, req *http.Request, logger *zerolog.Logger) {
+	checkReq, err := fa.newCheckRequest(req)
+	if errors.Is(err, errBodyTooLarge) {
+		logger.Debug().Msgf("Request body is too large, maxBodySize: %d", fa.maxBodySize)
+
+		observability.SetStatusErrorf(req.Context(), "Request body is too large, maxBodySize: %d", fa.maxBodySize)
+		rw.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	if err != nil {
+		logger.Debug().Err(err).Msg("Error while building the check request")
+
+		observability.SetStatusErrorf(req.Context(), "Error while building the check request: %s", err)
+		rw.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), forwardAuthTimeout)
+	defer cancel()
+
+	var checkSpan trace.Span
+	var tracer *tracing.Tracer
+	if tracer = tracing.TracerFromContext(req.Context()); tracer != nil && observability.TracingEnabled(req.Context()) {
+		ctx, checkSpan = tracer.Start(ctx, "AuthRequest", trace.WithSpanKind(trace.SpanKindClient))
+		defer checkSpan.End()
+
+		md := metadata.MD{}
+		otel.GetTextMapPropagator().Inject(ctx, metadataCarrier(md))
+		ctx = metadata.NewOutgoingContext(ctx, md)
+	}
+
+	checkRes, err := fa.authzClient.Check(ctx, checkReq)
+	if err != nil {
+		logger.Error().Err(err).Msgf("Error calling %s", fa.address)
+		observability.SetStatusErrorf(req.Context(), "Error calling %s. Cause: %s", fa.address, err)
+		fa.outcomes.Record(req, mmetrics.OutcomeError)
+
+		statusCode := http.StatusInternalServerError
+		if status.Code(err) == codes.Canceled || errors.Is(req.Context().Err(), context.Canceled) {
+			statusCode = httputil.StatusClientClosedRequest
+		}
+
+		rw.WriteHeader(statusCode)
+		return
+	}
+
+	if codes.Code(checkRes.GetStatus().GetCode()) != codes.OK {
+		fa.denyGRPC(rw, req, logger, checkRes.GetDeniedResponse(), tracer, checkSpan)
+		return
+	}
+
+	okResponse := checkRes.GetOkResponse()
+
+	if fa.headerField != "" {
+		for _, option := range okResponse.GetHeaders() {
+			if strings.EqualFold(option.GetHeader().GetKey(), fa.headerField) {
+				if logData := accesslog.GetLogData(req); logData != nil {
+					logData.Core[accesslog.ClientUsername] = headerValue(option.GetHeader())
+				}
+				break
+			}
+		}
+	}
+
+	applyHeaderOptions(req.Header, okResponse.GetHeaders())
+
+	for _, name := range okResponse.GetHeadersToRemove() {
+		// The pseudo headers and the Host header cannot be removed.
+		if strings.HasPrefix(name, ":") || strings.EqualFold(name, "Host") {
+			continue
+		}
+		req.Header.Del(name)
+	}
+
+	if len(okResponse.GetQueryParametersToSet()) > 0 || len(okResponse.GetQueryParametersToRemove()) > 0 {
+		query := req.URL.Query()
+		for _, name := range okResponse.GetQueryParametersToRemove() {
+			query.Del(name)
+		}
+		for _, param := range okResponse.GetQueryParametersToSet() {
+			query.Set(param.GetKey(), param.GetValue())
+		}
+		req.URL.RawQuery = query.Encode()
+	}
+
+	tracer.CaptureResponse(checkSpan, nil, http.StatusOK, trace.SpanKindClient)
+	fa.outcomes.Record(req, mmetrics.OutcomeAllowed)
+
+	req.RequestURI = req.URL.RequestURI()
+
+	responseHeaders := okResponse.GetResponseHeadersToAdd()
+	if len(responseHeaders) == 0 {
+		fa.next.ServeHTTP(rw, req)
+		return
+	}
+
+	fa.next.ServeHTTP(middlewares.NewResponseModifier(rw, req, func(res *http.Response) error {
+		applyHeaderOptions(res.Header, responseHeaders)
+		return nil
+	}), req)
+}
+
+// denyGRPC replies with the denied response of the gRPC authorization server.
+func (fa *forwardAuth) denyGRPC(rw http.ResponseWriter, req *http.Request, logger *zerolog.Logger, denied *authv3.DeniedHttpResponse, tracer *tracing.Tracer, checkSpan trace.Span) {
+	fa.outcomes.Record(req, mmetrics.OutcomeDenied)
+
+	// As Envoy, a denied response without status is a 403 Forbidden.
+	statusCode := http.StatusForbidden
+	if code := denied.GetStatus().GetCode(); code != 0 {
+		statusCode = int(code)
+	}
+
+	if fa.authSigninURL != "" && statusCode == http.StatusUnauthorized {
+		logger.Debug().Msgf("Redirecting to signin URL: %s", fa.authSigninURL)
+
+		tracer.CaptureResponse(checkSpan, nil, http.StatusFound, trace.SpanKindClient)
+		http.Redirect(rw, req, fa.signinURL(req), http.StatusFound)
+		return
+	}
+
+	logger.Debug().Msgf("Remote error %s. StatusCode: %d", fa.address, statusCode)
+
+	applyHeaderOptions(rw.Header(), denied.GetHeaders())
+
+	tracer.CaptureResponse(checkSpan, rw.Header(), statusCode, trace.SpanKindClient)
+	rw.WriteHeader(statusCode)
+
+	if _, err := rw.Write([]byte(denied.GetBody())); err != nil {
+		logger.Error().Err(err).Send()
+	}
+}
+
+// newCheckRequest returns the ext_authz check request holding the attributes of the given request.
+func (fa *forwardAuth) newCheckRequest(req *http.Request) (*authv3.CheckRequest, error) {
+	// The headers sent to the gRPC authorization server are the ones sent to an HTTP authentication server.
+	forwardReq := &http.Request{Header: make(http.Header)}
+	writeHeader(req, forwardReq, fa.trustForwardHeader, fa.authRequestHeaders)
+
+	headers := make(map[string]string, len(forwardReq.Header))
+	for name, values := range forwardReq.Header {
+		value := strings.Join(values, ",")
+		if value == "" {
+			continue
+		}
+		headers[strings.ToLower(name)] = value
+	}
+
+	scheme := "http"
+	if req.TLS != nil {
+		scheme = "https"
+	}
+
+	httpReq := &authv3.AttributeContext_HttpRequest{
+		Id:       req.Header.Get("X-Request-Id"),
+		Method:   req.Method,
+		Headers:  headers,
+		Path:     req.URL.RequestURI(),
+		Host:     req.Host,
+		Scheme:   scheme,
+		Size:     req.ContentLength,
+		Protocol: req.Proto,
+	}
+
+	if fa.forwardBody {
+		body, err := fa.readBodyBytes(req)
+		if err != nil {
+			return nil, err
+		}
+
+		// body is nil when the request has no body.
+		if body != nil {
+			req.Body = io.NopCloser(bytes.NewReader(body))
+
+			if utf8.Valid(body) {
+				httpReq.Body = string(body)
+			} else {
+				httpReq.RawBody = body
+			}
+		}
+	}
+
+	attributes := &authv3.AttributeContext{
+		Source: &authv3.AttributeContext_Peer{
+			Address: socketAddress(req.RemoteAddr),
+		},
+		Request: &authv3.AttributeContext_Request{
+			Time: timestamppb.Now(),
+			Http: httpReq,
+		},
+	}
+
+	if localAddr, ok := req.Context().Value(http.LocalAddrContextKey).(net.Addr); ok {
+		attributes.Destination = &authv3.AttributeContext_Peer{
+			Address: socketAddress(localAddr.String()),
+		}
+	}
+
+	if req.TLS != nil {
+		attributes.TlsSession = &authv3.AttributeContext_TLSSession{Sni: req.TLS.ServerName}
+
+		if len(req.TLS.PeerCertificates) > 0 {
+			cert := req.TLS.PeerCertificates[0]
+			attributes.Source.Principal = principal(cert)
+
+			// As Envoy, the peer certificate is sent URL encoded, in PEM format.
+			certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})
+			attributes.Source.Certificate = strings.ReplaceAll(url.QueryEscape(string(certPEM)), "+", "%20")
+		}
+	}
+
+	return &authv3.CheckRequest{Attributes: attributes}, nil
+}
+
+// socketAddress returns the Envoy socket address of the given host:port address.
+func socketAddress(address string) *corev3.Address {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return &corev3.Address{Address: &corev3.Address_SocketAddress{
+			SocketAddress: &corev3.SocketAddress{Address: address},
+		}}
+	}
+
+	socketAddr := &corev3.SocketAddress{Address: host}
+	if portValue, err := strconv.ParseUint(port, 10, 32); err == nil {
+		socketAddr.PortSpecifier = &corev3.SocketAddress_PortValue{PortValue: uint32(portValue)}
+	}
+
+	return &corev3.Address{Address: &corev3.Address_SocketAddress{SocketAddress: socketAddr}}
+}
+
+// principal returns the identity of the peer certificate,
+// which is, as for Envoy, the first URI SAN, the first DNS SAN, or the subject.
+func principal(cert *x509.Certificate) string {
+	if len(cert.URIs) > 0 {
+		return cert.URIs[0].String()
+	}
+
+	if len(cert.DNSNames) > 0 {
+		return cert.DNSNames[0]
+	}
+
+	return cert.Subject.String()
+}
+
+// applyHeaderOptions applies the header mutations returned by the gRPC authorization server.
+// As documented by the ext_authz API, the append field defaults to false,
+// so a header without append field nor append action overrides the existing values.
+func applyHeaderOptions(header http.Header, options []*corev3.HeaderValueOption) {
+	for _, option := range options {
+		name := option.GetHeader().GetKey()
+		if name == "" || strings.HasPrefix(name, ":") {
+			continue
+		}
+
+		value := headerValue(option.GetHeader())
+
+		if appendValue := option.GetAppend(); appendValue != nil {
+			if appendValue.GetValue() {
+				header.Add(name, value)
+			} else {
+				header.Set(name, value)
+			}
+			continue
+		}
+
+		switch option.GetAppendAction() {
+		case corev3.HeaderValueOption_ADD_IF_ABSENT:
+			if len(header.Values(name)) == 0 {
+				header.Set(name, value)
+			}
+		case corev3.HeaderValueOption_OVERWRITE_IF_EXISTS:
+			if len(header.Values(name)) > 0 {
+				header.Set(name, value)
+			}
+		default:
+			header.Set(name, value)
+		}
+	}
+}
+
+func headerValue(header *corev3.HeaderValue) string {
+	if header.GetValue() != "" {
+		return header.GetValue()
+	}
+
+	return string(header.GetRawValue())
+}
+
+// metadataCarrier adapts the gRPC metadata to a propagation.TextMapCarrier.
+type metadataCarrier metadata.MD
+
+func (c metadataCarrier) Get(key string) string {
+	values := metadata.MD(c).Get(key)
+	if len(values) == 0 {
+		return ""
+	}
+
+	return values[0]
+}
+
+func (c metadataCarrier) Set(key, value string) {
+	metadata.MD(c).Set(key, value)
+}
+
+func (c metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for key := range c {
+		keys = append(keys, key)
+	}
+
+	return keys
+}
@@ -0,0 +1,355 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	authv3 "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
+	typev3 "github.com/envoyproxy/go-control-plane/envoy/type/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+	"github.com/traefik/traefik/v3/pkg/testhelpers"
+	"github.com/traefik/traefik/v3/pkg/types"
+	rpcstatus "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestForwardAuthGRPC(t *testing.T) {
+	testCases := []struct {
+		desc               string
+		config             dynamic.ForwardAuth
+		check              func(t *testing.T, req *authv3.CheckRequest) *authv3.CheckResponse
+		requestBody        string
+		expectedStatusCode int
+		expectedBody       string
+		expectedHeader     http.Header
+	}{
+		{
+			desc: "allowed with header mutations",
+			check: func(t *testing.T, req *authv3.CheckRequest) *authv3.CheckResponse {
+				t.Helper()
+
+				httpReq := req.GetAttributes().GetRequest().GetHttp()
+				assert.Equal(t, http.MethodGet, httpReq.GetMethod())
+				assert.Equal(t, "/foo?bar=baz", httpReq.GetPath())
+				assert.Equal(t, "http", httpReq.GetScheme())
+				assert.Equal(t, "Bearer token", httpReq.GetHeaders()["authorization"])
+				assert.Equal(t, "127.0.0.1", req.GetAttributes().GetSource().GetAddress().GetSocketAddress().GetAddress())
+				assert.Empty(t, httpReq.GetBody())
+
+				return &authv3.CheckResponse{
+					Status: &rpcstatus.Status{Code: int32(codes.OK)},
+					HttpResponse: &authv3.CheckResponse_OkResponse{OkResponse: &authv3.OkHttpResponse{
+						Headers: []*corev3.HeaderValueOption{
+							{Header: &corev3.HeaderValue{Key: "X-Auth-User", Value: "user"}},
+							{Header: &corev3.HeaderValue{Key: "X-Existing", Value: "added"}, Append: wrapperspb.Bool(true)},
+							{Header: &corev3.HeaderValue{Key: "X-Existing", Value: "ignored"}, AppendAction: corev3.HeaderValueOption_ADD_IF_ABSENT},
+						},
+						HeadersToRemove:         []string{"Authorization"},
+						QueryParametersToSet:    []*corev3.QueryParameter{{Key: "user", Value: "user"}},
+						QueryParametersToRemove: []string{"bar"},
+						ResponseHeadersToAdd: []*corev3.HeaderValueOption{
+							{Header: &corev3.HeaderValue{Key: "X-Auth-Response", Value: "value"}},
+						},
+					}},
+				}
+			},
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       "[user] [existing added] [] user=user",
+			expectedHeader:     http.Header{"X-Auth-Response": {"value"}},
+		},
+		{
+			desc:   "allowed with body",
+			config: dynamic.ForwardAuth{ForwardBody: true},
+			check: func(t *testing.T, req *authv3.CheckRequest) *authv3.CheckResponse {
+				t.Helper()
+
+				assert.Equal(t, "request body", req.GetAttributes().GetRequest().GetHttp().GetBody())
+
+				return &authv3.CheckResponse{Status: &rpcstatus.Status{Code: int32(codes.OK)}}
+			},
+			requestBody:        "request body",
+			expectedStatusCode: http.StatusOK,
+			expectedBody:       "[] [existing] [Bearer token] bar=baz request body",
+		},
+		{
+			desc: "denied with response",
+			check: func(t *testing.T, req *authv3.CheckRequest) *authv3.CheckResponse {
+				t.Helper()
+
+				return &authv3.CheckResponse{
+					Status: &rpcstatus.Status{Code: int32(codes.PermissionDenied)},
+					HttpResponse: &authv3.CheckResponse_DeniedResponse{DeniedResponse: &authv3.DeniedHttpResponse{
+						Status:  &typev3.HttpStatus{Code: typev3.StatusCode_Unauthorized},
+						Headers: []*corev3.HeaderValueOption{{Header: &corev3.HeaderValue{Key: "Www-Authenticate", Value: "Bearer"}}},
+						Body:    "denied",
+					}},
+				}
+			},
+			expectedStatusCode: http.StatusUnauthorized,
+			expectedBody:       "denied",
+			expectedHeader:     http.Header{"Www-Authenticate": {"Bearer"}},
+		},
+		{
+			desc: "denied without response",
+			check: func(t *testing.T, req *authv3.CheckRequest) *authv3.CheckResponse {
+				t.Helper()
+
+				return &authv3.CheckResponse{Status: &rpcstatus.Status{Code: int32(codes.PermissionDenied)}}
+			},
+			expectedStatusCode: http.StatusForbidden,
+		},
+		{
+			desc:   "denied with signin URL",
+			config: dynamic.ForwardAuth{AuthSigninURL: "https://example.com/signin"},
+			check: func(t *testing.T, req *authv3.CheckRequest) *authv3.CheckResponse {
+				t.Helper()
+
+				return &authv3.CheckResponse{
+					Status: &rpcstatus.Status{Code: int32(codes.Unauthenticated)},
+					HttpResponse: &authv3.CheckResponse_DeniedResponse{DeniedResponse: &authv3.DeniedHttpResponse{
+						Status: &typev3.HttpStatus{Code: typev3.StatusCode_Unauthorized},
+					}},
+				}
+			},
+			expectedStatusCode: http.StatusFound,
+			expectedBody:       "<a href=\"https://example.com/signin\">Found</a>.\n\n",
+			expectedHeader:     http.Header{"Location": {"https://example.com/signin"}},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			address := startAuthorizationServer(t, authorizationServerFunc(func(req *authv3.CheckRequest) *authv3.CheckResponse {
+				return test.check(t, req)
+			}))
+
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, err := io.ReadAll(r.Body)
+				require.NoError(t, err)
+
+				output := fmt.Sprintf("%s %s %s %s", r.Header.Values("X-Auth-User"), r.Header.Values("X-Existing"), r.Header.Values("Authorization"), r.URL.RawQuery)
+				if len(body) > 0 {
+					output += " " + string(body)
+				}
+
+				fmt.Fprint(w, output)
+			})
+
+			config := test.config
+			config.Address = address
+			config.GRPC = true
+
+			middleware, err := NewForward(t.Context(), next, config, "authTest")
+			require.NoError(t, err)
+
+			ts := httptest.NewServer(middleware)
+			t.Cleanup(ts.Close)
+
+			client := &http.Client{
+				CheckRedirect: func(r *http.Request, via []*http.Request) error {
+					return http.ErrUseLastResponse
+				},
+			}
+
+			req := testhelpers.MustNewRequest(http.MethodGet, ts.URL+"/foo?bar=baz", strings.NewReader(test.requestBody))
+			req.Header.Set("Authorization", "Bearer token")
+			req.Header.Set("X-Existing", "existing")
+
+			res, err := client.Do(req)
+			require.NoError(t, err)
+
+			body, err := io.ReadAll(res.Body)
+			require.NoError(t, err)
+			require.NoError(t, res.Body.Close())
+
+			assert.Equal(t, test.expectedStatusCode, res.StatusCode)
+			assert.Equal(t, test.expectedBody, string(body))
+			for name, values := range test.expectedHeader {
+				assert.Equal(t, values, res.Header.Values(name))
+			}
+		})
+	}
+}
+
+func TestForwardAuthGRPC_unavailable(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("next handler should not be called")
+	})
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	address := listener.Addr().String()
+	require.NoError(t, listener.Close())
+
+	middleware, err := NewForward(t.Context(), next, dynamic.ForwardAuth{Address: address, GRPC: true}, "authTest")
+	require.NoError(t, err)
+
+	rw := httptest.NewRecorder()
+	middleware.ServeHTTP(rw, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	assert.Equal(t, http.StatusInternalServerError, rw.Code)
+}
+
+func TestForwardAuthGRPC_sharedConnection(t *testing.T) {
+	address := startAuthorizationServer(t, authorizationServerFunc(func(req *authv3.CheckRequest) *authv3.CheckResponse {
+		return &authv3.CheckResponse{Status: &rpcstatus.Status{Code: int32(codes.OK)}}
+	}))
+
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+
+	// The middlewares are created again on each configuration reload.
+	for range 3 {
+		_, err := NewForward(t.Context(), next, dynamic.ForwardAuth{Address: address, GRPC: true}, "authTest")
+		require.NoError(t, err)
+	}
+
+	_, err := NewForward(t.Context(), next, dynamic.ForwardAuth{
+		Address: address,
+		GRPC:    true,
+		TLS:     &dynamic.ClientTLS{InsecureSkipVerify: true},
+	}, "authTest")
+	require.NoError(t, err)
+
+	authorizationConns.mu.Lock()
+	defer authorizationConns.mu.Unlock()
+
+	var conns int
+	for key := range authorizationConns.conns {
+		if key.address == address {
+			conns++
+		}
+	}
+
+	assert.Equal(t, 2, conns)
+}
+
+func TestAuthorizationConnPool_release(t *testing.T) {
+	pool := newAuthorizationConnPool(0)
+	key := authorizationConnKey{address: "127.0.0.1:9000"}
+
+	// The middlewares of the current configuration.
+	currentCtx, cancelCurrent := context.WithCancel(t.Context())
+
+	conn, err := pool.acquire(currentCtx, key, nil)
+	require.NoError(t, err)
+
+	// The middlewares of the next configuration.
+	nextCtx, cancelNext := context.WithCancel(t.Context())
+
+	nextConn, err := pool.acquire(nextCtx, key, nil)
+	require.NoError(t, err)
+	assert.Same(t, conn, nextConn)
+
+	cancelCurrent()
+
+	assert.Never(t, func() bool {
+		return conn.GetState() == connectivity.Shutdown
+	}, 100*time.Millisecond, 10*time.Millisecond)
+
+	cancelNext()
+
+	assert.Eventually(t, func() bool {
+		return conn.GetState() == connectivity.Shutdown
+	}, time.Second, 10*time.Millisecond)
+
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+
+	assert.Empty(t, pool.conns)
+}
+
+func TestAuthorizationConnPool_acquireReleased(t *testing.T) {
+	pool := newAuthorizationConnPool(time.Hour)
+	key := authorizationConnKey{address: "127.0.0.1:9000"}
+
+	ctx, cancel := context.WithCancel(t.Context())
+
+	conn, err := pool.acquire(ctx, key, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	cancel()
+
+	assert.Eventually(t, func() bool {
+		pool.mu.Lock()
+		defer pool.mu.Unlock()
+
+		return pool.conns[key].closeTimer != nil
+	}, time.Second, 10*time.Millisecond)
+
+	// The connection is reused by the middlewares created before its closing.
+	reusedConn, err := pool.acquire(t.Context(), key, nil)
+	require.NoError(t, err)
+	assert.Same(t, conn, reusedConn)
+
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+
+	assert.Nil(t, pool.conns[key].closeTimer)
+	assert.Equal(t, 1, pool.conns[key].refs)
+}
+
+func TestNewAuthorizationConnKey(t *testing.T) {
+	caPath := filepath.Join(t.TempDir(), "ca.pem")
+	require.NoError(t, os.WriteFile(caPath, []byte("foo"), 0o600))
+
+	clientTLS := &types.ClientTLS{CA: caPath}
+
+	key, err := newAuthorizationConnKey("127.0.0.1:9000", clientTLS)
+	require.NoError(t, err)
+
+	sameKey, err := newAuthorizationConnKey("127.0.0.1:9000", clientTLS)
+	require.NoError(t, err)
+	assert.Equal(t, key, sameKey)
+
+	noTLSKey, err := newAuthorizationConnKey("127.0.0.1:9000", nil)
+	require.NoError(t, err)
+	assert.NotEqual(t, key, noTLSKey)
+
+	// The CA file is updated in place.
+	require.NoError(t, os.WriteFile(caPath, []byte("bar"), 0o600))
+
+	updatedKey, err := newAuthorizationConnKey("127.0.0.1:9000", clientTLS)
+	require.NoError(t, err)
+	assert.NotEqual(t, key, updatedKey)
+}
+
+type authorizationServerFunc func(req *authv3.CheckRequest) *authv3.CheckResponse
+
+func (f authorizationServerFunc) Check(_ context.Context, req *authv3.CheckRequest) (*authv3.CheckResponse, error) {
+	return f(req), nil
+}
+
+func startAuthorizationServer(t *testing.T, server authv3.AuthorizationServer) string {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	grpcServer := grpc.NewServer()
+	authv3.RegisterAuthorizationServer(grpcServer, server)
+
+	go func() {
+		_ = grpcServer.Serve(listener)
+	}()
+	t.Cleanup(grpcServer.Stop)
+
+	return listener.Addr().String()
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/rs/zerolog/log"
 	"github.com/traefik/traefik/v3/pkg/config/dynamic"
 	"github.com/traefik/traefik/v3/pkg/middlewares"
+	mmetrics "github.com/traefik/traefik/v3/pkg/middlewares/metrics"
 	"github.com/traefik/traefik/v3/pkg/middlewares/observability"
 	"github.com/traefik/traefik/v3/pkg/observability/logs"
 	"github.com/vulcand/oxy/v2/cbreaker"
@@ -31,8 +32,20 @@ func New(ctx context.Context, next http.Handler, confCircuitBreaker dynamic.Circ
 
 	responseCode := confCircuitBreaker.ResponseCode
 
+	outcomes := mmetrics.NewOutcomeRecorder(ctx, name, typeName)
+	if outcomes != nil {
+		outcomes.SetCircuitBreakerState(mmetrics.CircuitBreakerClosed)
+
+		allowed := next
+		next = http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			outcomes.Record(req, mmetrics.OutcomeAllowed)
+			allowed.ServeHTTP(rw, req)
+		})
+	}
+
 	cbOpts := []cbreaker.Option{
 		cbreaker.Fallback(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			outcomes.Record(req, mmetrics.OutcomeRejected)
 			observability.SetStatusErrorf(req.Context(), "blocked by circuit-breaker (%q)", expression)
 			rw.WriteHeader(responseCode)
 
@@ -42,6 +55,8 @@ func New(ctx context.Context, next http.Handler, confCircuitBreaker dynamic.Circ
 		})),
 		cbreaker.Logger(logs.NewOxyWrapper(*logger)),
 		cbreaker.Verbose(logger.GetLevel() == zerolog.TraceLevel),
+		cbreaker.OnTripped(stateSideEffect{outcomes: outcomes, state: mmetrics.CircuitBreakerOpen}),
+		cbreaker.OnStandby(stateSideEffect{outcomes: outcomes, state: mmetrics.CircuitBreakerClosed}),
 	}
 
 	if confCircuitBreaker.CheckPeriod > 0 {
@@ -74,3 +89,14 @@ func (c *circuitBreaker) GetTracingInformation() (string, string) {
 func (c *circuitBreaker) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
 	c.circuitBreaker.ServeHTTP(rw, req)
 }
+
+// stateSideEffect reports the circuit breaker state when the circuit breaker enters it.
+type stateSideEffect struct {
+	outcomes *mmetrics.OutcomeRecorder
+	state    float64
+}
+
+func (s stateSideEffect) Exec() error {
+	s.outcomes.SetCircuitBreakerState(s.state)
+	return nil
+}
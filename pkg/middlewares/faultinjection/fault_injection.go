@@ -0,0 +1,291 @@
+package faultinjection
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+	"github.com/traefik/traefik/v3/pkg/middlewares"
+	"github.com/traefik/traefik/v3/pkg/middlewares/accesslog"
+	"golang.org/x/time/rate"
+)
+
+const typeName = "FaultInjection"
+
+// maxBandwidthBurst is the maximum number of bytes written at once when the response bandwidth is limited.
+const maxBandwidthBurst = 32 * 1024
+
+// faultInjection is a middleware injecting faults into a percentage of the requests.
+type faultInjection struct {
+	next http.Handler
+	name string
+
+	percentage        int
+	headers           map[string]string
+	delay             time.Duration
+	jitter            time.Duration
+	abort             *dynamic.FaultAbort
+	resetConnection   bool
+	responseBandwidth int64
+}
+
+// New creates a fault injection middleware.
+func New(ctx context.Context, next http.Handler, config dynamic.FaultInjection, name string) (http.Handler, error) {
+	middlewares.GetLogger(ctx, name, typeName).Debug().Msg("Creating middleware")
+
+	if config.Percentage < 0 || config.Percentage > 100 {
+		return nil, fmt.Errorf("percentage must be between 0 and 100, got %d", config.Percentage)
+	}
+
+	if config.Abort != nil && config.ResetConnection {
+		return nil, errors.New("abort and resetConnection cannot be both configured")
+	}
+
+	if config.Delay == nil && config.Abort == nil && !config.ResetConnection && config.ResponseBandwidth == 0 {
+		return nil, errors.New("at least one of delay, abort, resetConnection or responseBandwidth must be configured")
+	}
+
+	if config.ResponseBandwidth < 0 {
+		return nil, fmt.Errorf("responseBandwidth must be positive, got %d", config.ResponseBandwidth)
+	}
+
+	f := &faultInjection{
+		next:              next,
+		name:              name,
+		percentage:        config.Percentage,
+		abort:             config.Abort,
+		resetConnection:   config.ResetConnection,
+		responseBandwidth: config.ResponseBandwidth,
+	}
+
+	if config.Delay != nil {
+		if config.Delay.Duration < 0 || config.Delay.Jitter < 0 {
+			return nil, errors.New("delay duration and jitter must be positive")
+		}
+
+		f.delay = time.Duration(config.Delay.Duration)
+		f.jitter = time.Duration(config.Delay.Jitter)
+	}
+
+	if abort := config.Abort; abort != nil {
+		if abort.StatusCode == 0 && abort.GRPCStatusCode == 0 {
+			return nil, errors.New("abort statusCode or grpcStatusCode must be configured")
+		}
+
+		if abort.StatusCode != 0 && (abort.StatusCode < 100 || abort.StatusCode > 599) {
+			return nil, fmt.Errorf("invalid abort statusCode %d", abort.StatusCode)
+		}
+
+		// The OK (0) status code is not a fault.
+		if abort.GRPCStatusCode < 0 || abort.GRPCStatusCode > 16 {
+			return nil, fmt.Errorf("invalid abort grpcStatusCode %d", abort.GRPCStatusCode)
+		}
+	}
+
+	if len(config.Headers) > 0 {
+		f.headers = make(map[string]string, len(config.Headers))
+		for name, value := range config.Headers {
+			f.headers[http.CanonicalHeaderKey(name)] = value
+		}
+	}
+
+	return f, nil
+}
+
+func (f *faultInjection) GetTracingInformation() (string, string) {
+	return f.name, typeName
+}
+
+func (f *faultInjection) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	if !f.matches(req) {
+		f.next.ServeHTTP(rw, req)
+		return
+	}
+
+	var faults []string
+	defer func() {
+		if logData := accesslog.GetLogData(req); logData != nil && len(faults) > 0 {
+			logData.Core[accesslog.FaultInjected] = strings.Join(faults, ",")
+		}
+	}()
+
+	if f.delay > 0 || f.jitter > 0 {
+		delay := f.delay
+		if f.jitter > 0 {
+			delay += rand.N(f.jitter)
+		}
+		faults = append(faults, "delay="+delay.String())
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+	}
+
+	if f.abort != nil {
+		if f.writeAbort(rw, req) {
+			faults = append(faults, "abort")
+			return
+		}
+	}
+
+	if f.resetConnection {
+		faults = append(faults, "reset")
+		resetConnection(rw)
+		return
+	}
+
+	if f.responseBandwidth > 0 {
+		faults = append(faults, "bandwidth="+strconv.FormatInt(f.responseBandwidth, 10))
+		rw = newThrottledResponseWriter(req.Context(), rw, f.responseBandwidth)
+	}
+
+	f.next.ServeHTTP(rw, req)
+}
+
+// matches tells whether faults must be injected into the request.
+func (f *faultInjection) matches(req *http.Request) bool {
+	for name, value := range f.headers {
+		values, ok := req.Header[name]
+		if !ok {
+			return false
+		}
+
+		if value != "" && !slices.Contains(values, value) {
+			return false
+		}
+	}
+
+	return f.percentage >= 100 || rand.IntN(100) < f.percentage
+}
+
+// writeAbort writes the abort response, and tells whether the request has been aborted.
+// The gRPC requests are aborted with the gRPC status code, if any, the other requests with the HTTP status code, if any.
+func (f *faultInjection) writeAbort(rw http.ResponseWriter, req *http.Request) bool {
+	if f.abort.GRPCStatusCode != 0 && isGRPC(req) {
+		// Trailers-Only response, as described in the gRPC over HTTP/2 specification.
+		rw.Header().Set("Content-Type", req.Header.Get("Content-Type"))
+		rw.Header().Set("Grpc-Status", strconv.Itoa(f.abort.GRPCStatusCode))
+		rw.Header().Set("Grpc-Message", "fault injected")
+		rw.WriteHeader(http.StatusOK)
+
+		return true
+	}
+
+	if f.abort.StatusCode == 0 {
+		return false
+	}
+
+	http.Error(rw, http.StatusText(f.abort.StatusCode), f.abort.StatusCode)
+
+	return true
+}
+
+func isGRPC(req *http.Request) bool {
+	return strings.HasPrefix(req.Header.Get("Content-Type"), "application/grpc")
+}
+
+// resetConnection resets the client connection, without writing any response.
+func resetConnection(rw http.ResponseWriter) {
+	conn, _, err := http.NewResponseController(rw).Hijack()
+	if err != nil {
+		// The connections of the HTTP/2 and HTTP/3 requests, which are shared with other requests, cannot be hijacked:
+		// aborting the handler resets the request stream instead.
+		panic(http.ErrAbortHandler)
+	}
+
+	// Discarding the unsent data on close makes the connection send a TCP RST instead of a FIN.
+	if tcpConn := underlyingTCPConn(conn); tcpConn != nil {
+		_ = tcpConn.SetLinger(0)
+	}
+
+	_ = conn.Close()
+}
+
+// underlyingTCPConn returns the TCP connection wrapped by the given connection, if any.
+func underlyingTCPConn(conn net.Conn) *net.TCPConn {
+	for conn != nil {
+		switch c := conn.(type) {
+		case *net.TCPConn:
+			return c
+		case interface{ NetConn() net.Conn }:
+			conn = c.NetConn()
+		case interface{ Raw() net.Conn }:
+			conn = c.Raw()
+		default:
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// throttledResponseWriter limits the bandwidth of the response body.
+type throttledResponseWriter struct {
+	http.ResponseWriter
+
+	ctx     context.Context
+	limiter *rate.Limiter
+}
+
+func newThrottledResponseWriter(ctx context.Context, rw http.ResponseWriter, bandwidth int64) *throttledResponseWriter {
+	burst := int(min(bandwidth, maxBandwidthBurst))
+
+	return &throttledResponseWriter{
+		ResponseWriter: rw,
+		ctx:            ctx,
+		limiter:        rate.NewLimiter(rate.Limit(bandwidth), burst),
+	}
+}
+
+func (t *throttledResponseWriter) Write(p []byte) (int, error) {
+	var written int
+	for len(p) > 0 {
+		chunk := p[:min(len(p), t.limiter.Burst())]
+
+		if err := t.limiter.WaitN(t.ctx, len(chunk)); err != nil {
+			return written, err
+		}
+
+		n, err := t.ResponseWriter.Write(chunk)
+		written += n
+		if err != nil {
+			return written, err
+		}
+
+		// The written chunks are flushed to make the bandwidth limit observable by the client.
+		if flusher, ok := t.ResponseWriter.(http.Flusher); ok {
+			flusher.Flush()
+		}
+
+		p = p[len(chunk):]
+	}
+
+	return written, nil
+}
+
+func (t *throttledResponseWriter) Flush() {
+	if flusher, ok := t.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (t *throttledResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	if h, ok := t.ResponseWriter.(http.Hijacker); ok {
+		return h.Hijack()
+	}
+
+	return nil, nil, fmt.Errorf("not a hijacker: %T", t.ResponseWriter)
+}
@@ -0,0 +1,254 @@
+package faultinjection
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ptypes "github.com/traefik/paerser/types"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+	"github.com/traefik/traefik/v3/pkg/middlewares/accesslog"
+)
+
+func TestNew_validation(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		config    dynamic.FaultInjection
+		expectErr bool
+	}{
+		{
+			desc:   "abort",
+			config: dynamic.FaultInjection{Percentage: 100, Abort: &dynamic.FaultAbort{StatusCode: http.StatusServiceUnavailable}},
+		},
+		{
+			desc:      "no fault",
+			config:    dynamic.FaultInjection{Percentage: 100},
+			expectErr: true,
+		},
+		{
+			desc:      "invalid percentage",
+			config:    dynamic.FaultInjection{Percentage: 101, ResetConnection: true},
+			expectErr: true,
+		},
+		{
+			desc:      "abort and reset",
+			config:    dynamic.FaultInjection{Percentage: 100, Abort: &dynamic.FaultAbort{StatusCode: http.StatusServiceUnavailable}, ResetConnection: true},
+			expectErr: true,
+		},
+		{
+			desc:      "abort without status code",
+			config:    dynamic.FaultInjection{Percentage: 100, Abort: &dynamic.FaultAbort{}},
+			expectErr: true,
+		},
+		{
+			desc:      "invalid abort status code",
+			config:    dynamic.FaultInjection{Percentage: 100, Abort: &dynamic.FaultAbort{StatusCode: 42}},
+			expectErr: true,
+		},
+		{
+			desc:      "invalid abort gRPC status code",
+			config:    dynamic.FaultInjection{Percentage: 100, Abort: &dynamic.FaultAbort{GRPCStatusCode: 17}},
+			expectErr: true,
+		},
+		{
+			desc:      "negative delay",
+			config:    dynamic.FaultInjection{Percentage: 100, Delay: &dynamic.FaultDelay{Duration: ptypes.Duration(-time.Second)}},
+			expectErr: true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := New(t.Context(), http.NotFoundHandler(), test.config, "faultInjection")
+			if test.expectErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestFaultInjection(t *testing.T) {
+	testCases := []struct {
+		desc           string
+		config         dynamic.FaultInjection
+		headers        map[string]string
+		expectedStatus int
+		expectedHeader http.Header
+		expectedFaults string
+	}{
+		{
+			desc:           "abort",
+			config:         dynamic.FaultInjection{Percentage: 100, Abort: &dynamic.FaultAbort{StatusCode: http.StatusServiceUnavailable}},
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedFaults: "abort",
+		},
+		{
+			desc:           "no matching percentage",
+			config:         dynamic.FaultInjection{Percentage: 0, Abort: &dynamic.FaultAbort{StatusCode: http.StatusServiceUnavailable}},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			desc: "matching headers",
+			config: dynamic.FaultInjection{
+				Percentage: 100,
+				Headers:    map[string]string{"x-chaos": "enabled", "X-Test": ""},
+				Abort:      &dynamic.FaultAbort{StatusCode: http.StatusServiceUnavailable},
+			},
+			headers:        map[string]string{"X-Chaos": "enabled", "X-Test": "foo"},
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedFaults: "abort",
+		},
+		{
+			desc: "missing header",
+			config: dynamic.FaultInjection{
+				Percentage: 100,
+				Headers:    map[string]string{"X-Chaos": "enabled", "X-Test": ""},
+				Abort:      &dynamic.FaultAbort{StatusCode: http.StatusServiceUnavailable},
+			},
+			headers:        map[string]string{"X-Chaos": "enabled"},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			desc: "non matching header value",
+			config: dynamic.FaultInjection{
+				Percentage: 100,
+				Headers:    map[string]string{"X-Chaos": "enabled"},
+				Abort:      &dynamic.FaultAbort{StatusCode: http.StatusServiceUnavailable},
+			},
+			headers:        map[string]string{"X-Chaos": "disabled"},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			desc:           "gRPC abort",
+			config:         dynamic.FaultInjection{Percentage: 100, Abort: &dynamic.FaultAbort{StatusCode: http.StatusServiceUnavailable, GRPCStatusCode: 14}},
+			headers:        map[string]string{"Content-Type": "application/grpc+proto"},
+			expectedStatus: http.StatusOK,
+			expectedHeader: http.Header{
+				"Content-Type": {"application/grpc+proto"},
+				"Grpc-Status":  {"14"},
+				"Grpc-Message": {"fault injected"},
+			},
+			expectedFaults: "abort",
+		},
+		{
+			desc:           "gRPC abort of a non gRPC request",
+			config:         dynamic.FaultInjection{Percentage: 100, Abort: &dynamic.FaultAbort{GRPCStatusCode: 14}},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			desc: "delay and abort",
+			config: dynamic.FaultInjection{
+				Percentage: 100,
+				Delay:      &dynamic.FaultDelay{Duration: ptypes.Duration(10 * time.Millisecond)},
+				Abort:      &dynamic.FaultAbort{StatusCode: http.StatusGatewayTimeout},
+			},
+			expectedStatus: http.StatusGatewayTimeout,
+			expectedFaults: "delay=10ms,abort",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			next := http.HandlerFunc(func(rw http.ResponseWriter, _ *http.Request) {
+				rw.WriteHeader(http.StatusOK)
+			})
+
+			handler, err := New(t.Context(), next, test.config, "faultInjection")
+			require.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+			for name, value := range test.headers {
+				req.Header.Set(name, value)
+			}
+
+			logData := &accesslog.LogData{Core: accesslog.CoreLogData{}}
+			req = req.WithContext(context.WithValue(req.Context(), accesslog.DataTableKey, logData))
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			assert.Equal(t, test.expectedStatus, rec.Code)
+			for name, values := range test.expectedHeader {
+				assert.Equal(t, values, rec.Header().Values(name))
+			}
+
+			if test.expectedFaults == "" {
+				assert.NotContains(t, logData.Core, accesslog.FaultInjected)
+				return
+			}
+			assert.Equal(t, test.expectedFaults, logData.Core[accesslog.FaultInjected])
+		})
+	}
+}
+
+func TestFaultInjection_delay(t *testing.T) {
+	handler, err := New(t.Context(), http.HandlerFunc(func(rw http.ResponseWriter, _ *http.Request) {
+		rw.WriteHeader(http.StatusOK)
+	}), dynamic.FaultInjection{
+		Percentage: 100,
+		Delay: &dynamic.FaultDelay{
+			Duration: ptypes.Duration(50 * time.Millisecond),
+			Jitter:   ptypes.Duration(50 * time.Millisecond),
+		},
+	}, "faultInjection")
+	require.NoError(t, err)
+
+	start := time.Now()
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", http.NoBody))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+}
+
+func TestFaultInjection_resetConnection(t *testing.T) {
+	handler, err := New(t.Context(), http.HandlerFunc(func(rw http.ResponseWriter, _ *http.Request) {
+		rw.WriteHeader(http.StatusOK)
+	}), dynamic.FaultInjection{Percentage: 100, ResetConnection: true}, "faultInjection")
+	require.NoError(t, err)
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	res, err := http.Get(server.URL)
+	require.Error(t, err)
+	assert.Nil(t, res)
+}
+
+func TestFaultInjection_responseBandwidth(t *testing.T) {
+	body := bytes.Repeat([]byte("a"), 3000)
+
+	handler, err := New(t.Context(), http.HandlerFunc(func(rw http.ResponseWriter, _ *http.Request) {
+		_, _ = rw.Write(body)
+	}), dynamic.FaultInjection{Percentage: 100, ResponseBandwidth: 1000}, "faultInjection")
+	require.NoError(t, err)
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	start := time.Now()
+
+	res, err := http.Get(server.URL)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = res.Body.Close() })
+
+	got, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+
+	assert.Equal(t, strings.Repeat("a", 3000), string(got))
+	// The first 1000 bytes are sent right away, the remaining ones at 1000 bytes per second.
+	assert.GreaterOrEqual(t, time.Since(start), 2*time.Second-100*time.Millisecond)
+}
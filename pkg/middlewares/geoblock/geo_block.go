@@ -0,0 +1,213 @@
+package geoblock
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+	"github.com/traefik/traefik/v3/pkg/geoip"
+	"github.com/traefik/traefik/v3/pkg/ip"
+	"github.com/traefik/traefik/v3/pkg/middlewares"
+	mmetrics "github.com/traefik/traefik/v3/pkg/middlewares/metrics"
+	"github.com/traefik/traefik/v3/pkg/middlewares/observability"
+)
+
+const typeName = "GeoBlock"
+
+// geoBlock is a middleware allowing or denying the requests based on the GeoIP data of the client IP.
+type geoBlock struct {
+	next             http.Handler
+	name             string
+	resolver         *geoip.Resolver
+	strategy         ip.Strategy
+	allowedCountries []string
+	deniedCountries  []string
+	allowedASNs      []uint
+	deniedASNs       []uint
+	denyUnknown      bool
+	rejectStatusCode int
+	outcomes         *mmetrics.OutcomeRecorder
+}
+
+// New creates a GeoBlock middleware, resolving the GeoIP data of the client IPs with the given resolver.
+func New(ctx context.Context, next http.Handler, resolver *geoip.Resolver, config dynamic.GeoBlock, name string) (http.Handler, error) {
+	middlewares.GetLogger(ctx, name, typeName).Debug().Msg("Creating middleware")
+
+	if resolver == nil {
+		return nil, errors.New("GeoIP is not configured")
+	}
+
+	if len(config.AllowedCountries) == 0 && len(config.DeniedCountries) == 0 &&
+		len(config.AllowedASNs) == 0 && len(config.DeniedASNs) == 0 && !config.DenyUnknown {
+		return nil, errors.New("no allowed or denied countries and autonomous systems")
+	}
+
+	if (len(config.AllowedCountries) > 0 || len(config.DeniedCountries) > 0) && !resolver.HasCountry() {
+		return nil, errors.New("the GeoIP country database is not configured")
+	}
+
+	if (len(config.AllowedASNs) > 0 || len(config.DeniedASNs) > 0) && !resolver.HasASN() {
+		return nil, errors.New("the GeoIP ASN database is not configured")
+	}
+
+	rejectStatusCode := config.RejectStatusCode
+	// If RejectStatusCode is not given, default to Forbidden (403).
+	if rejectStatusCode == 0 {
+		rejectStatusCode = http.StatusForbidden
+	} else if http.StatusText(rejectStatusCode) == "" {
+		return nil, fmt.Errorf("invalid HTTP status code %d", rejectStatusCode)
+	}
+
+	allowedCountries, err := parseCountries(config.AllowedCountries)
+	if err != nil {
+		return nil, fmt.Errorf("allowedCountries: %w", err)
+	}
+
+	deniedCountries, err := parseCountries(config.DeniedCountries)
+	if err != nil {
+		return nil, fmt.Errorf("deniedCountries: %w", err)
+	}
+
+	allowedASNs, err := parseASNs(config.AllowedASNs)
+	if err != nil {
+		return nil, fmt.Errorf("allowedASNs: %w", err)
+	}
+
+	deniedASNs, err := parseASNs(config.DeniedASNs)
+	if err != nil {
+		return nil, fmt.Errorf("deniedASNs: %w", err)
+	}
+
+	var strategy ip.Strategy
+	if config.IPStrategy != nil {
+		strategy, err = config.IPStrategy.Get()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &geoBlock{
+		next:             next,
+		name:             name,
+		resolver:         resolver,
+		strategy:         strategy,
+		allowedCountries: allowedCountries,
+		deniedCountries:  deniedCountries,
+		allowedASNs:      allowedASNs,
+		deniedASNs:       deniedASNs,
+		denyUnknown:      config.DenyUnknown,
+		rejectStatusCode: rejectStatusCode,
+		outcomes:         mmetrics.NewOutcomeRecorder(ctx, name, typeName),
+	}, nil
+}
+
+func (g *geoBlock) GetTracingInformation() (string, string) {
+	return g.name, typeName
+}
+
+func (g *geoBlock) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	logger := middlewares.GetLogger(req.Context(), g.name, typeName)
+	ctx := logger.WithContext(req.Context())
+
+	var clientIP string
+	if g.strategy != nil {
+		clientIP = g.strategy.GetIP(req)
+	} else {
+		clientIP = g.resolver.ClientIP(req)
+	}
+
+	if err := g.check(net.ParseIP(clientIP)); err != nil {
+		logger.Debug().Msgf("Rejecting IP %s: %v", clientIP, err)
+		observability.SetStatusErrorf(req.Context(), "Rejecting IP %s: %v", clientIP, err)
+		g.outcomes.Record(req, mmetrics.OutcomeBlocked)
+		reject(ctx, g.rejectStatusCode, rw)
+		return
+	}
+
+	logger.Debug().Msgf("Accepting IP %s", clientIP)
+	g.outcomes.Record(req, mmetrics.OutcomeAllowed)
+
+	g.next.ServeHTTP(rw, req)
+}
+
+// check returns an error if the IP is not allowed.
+// The denied countries and autonomous systems take precedence over the allowed ones.
+func (g *geoBlock) check(clientIP net.IP) error {
+	var country string
+	if len(g.allowedCountries) > 0 || len(g.deniedCountries) > 0 || g.denyUnknown {
+		country = g.resolver.Country(clientIP)
+	}
+
+	var asn uint
+	if len(g.allowedASNs) > 0 || len(g.deniedASNs) > 0 || g.denyUnknown {
+		asn, _ = g.resolver.AutonomousSystem(clientIP)
+	}
+
+	// An unknown IP never matches the allow lists, so it is denied as soon as one is configured.
+	if country == "" && asn == 0 && g.denyUnknown {
+		return errors.New("unknown country and autonomous system")
+	}
+
+	if country != "" && slices.Contains(g.deniedCountries, country) {
+		return fmt.Errorf("denied country %s", country)
+	}
+
+	if asn != 0 && slices.Contains(g.deniedASNs, asn) {
+		return fmt.Errorf("denied autonomous system AS%d", asn)
+	}
+
+	if len(g.allowedCountries) == 0 && len(g.allowedASNs) == 0 {
+		return nil
+	}
+
+	if country != "" && slices.Contains(g.allowedCountries, country) {
+		return nil
+	}
+
+	if asn != 0 && slices.Contains(g.allowedASNs, asn) {
+		return nil
+	}
+
+	return fmt.Errorf("country %q and autonomous system AS%d not allowed", country, asn)
+}
+
+func parseCountries(countries []string) ([]string, error) {
+	var codes []string
+	for _, country := range countries {
+		code := strings.ToUpper(strings.TrimSpace(country))
+		if len(code) != 2 {
+			return nil, fmt.Errorf("invalid country %q, an ISO 3166-1 alpha-2 country code is expected", country)
+		}
+
+		codes = append(codes, code)
+	}
+
+	return codes, nil
+}
+
+func parseASNs(asns []int) ([]uint, error) {
+	var numbers []uint
+	for _, asn := range asns {
+		if asn <= 0 || asn > 1<<32-1 {
+			return nil, fmt.Errorf("invalid autonomous system number %d", asn)
+		}
+
+		numbers = append(numbers, uint(asn))
+	}
+
+	return numbers, nil
+}
+
+func reject(ctx context.Context, statusCode int, rw http.ResponseWriter) {
+	rw.WriteHeader(statusCode)
+	_, err := rw.Write([]byte(http.StatusText(statusCode)))
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Send()
+	}
+}
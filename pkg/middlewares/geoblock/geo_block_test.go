@@ -0,0 +1,218 @@
+package geoblock
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/maxmind/mmdbwriter/mmdbtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+	"github.com/traefik/traefik/v3/pkg/geoip"
+	"github.com/traefik/traefik/v3/pkg/geoip/geoiptest"
+)
+
+func setupResolver(t *testing.T) *geoip.Resolver {
+	t.Helper()
+
+	dir := t.TempDir()
+
+	countryDB := filepath.Join(dir, "country.mmdb")
+	geoiptest.WriteDatabase(t, countryDB, map[string]mmdbtype.Map{
+		"20.20.20.0/24": {"country": mmdbtype.Map{"iso_code": mmdbtype.String("FR")}},
+		"30.30.30.0/24": {"country": mmdbtype.Map{"iso_code": mmdbtype.String("US")}},
+	})
+
+	asnDB := filepath.Join(dir, "asn.mmdb")
+	geoiptest.WriteDatabase(t, asnDB, map[string]mmdbtype.Map{
+		"20.20.0.0/16": {"autonomous_system_number": mmdbtype.Uint32(64500)},
+		"40.40.0.0/16": {"autonomous_system_number": mmdbtype.Uint32(64501)},
+	})
+
+	resolver, err := geoip.NewResolver(geoip.Config{CountryDatabase: countryDB, ASNDatabase: asnDB})
+	require.NoError(t, err)
+
+	return resolver
+}
+
+func TestNew(t *testing.T) {
+	resolver := setupResolver(t)
+
+	testCases := []struct {
+		desc          string
+		config        dynamic.GeoBlock
+		expectedError bool
+	}{
+		{
+			desc:   "valid configuration",
+			config: dynamic.GeoBlock{AllowedCountries: []string{"fr"}, DeniedASNs: []int{64500}},
+		},
+		{
+			desc:   "deny unknown only",
+			config: dynamic.GeoBlock{DenyUnknown: true},
+		},
+		{
+			desc:          "empty configuration",
+			config:        dynamic.GeoBlock{},
+			expectedError: true,
+		},
+		{
+			desc:          "invalid country",
+			config:        dynamic.GeoBlock{DeniedCountries: []string{"France"}},
+			expectedError: true,
+		},
+		{
+			desc:          "invalid ASN",
+			config:        dynamic.GeoBlock{AllowedASNs: []int{-1}},
+			expectedError: true,
+		},
+		{
+			desc:          "invalid HTTP status code",
+			config:        dynamic.GeoBlock{AllowedCountries: []string{"FR"}, RejectStatusCode: 600},
+			expectedError: true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+			handler, err := New(t.Context(), next, resolver, test.config, "traefikTest")
+
+			if test.expectedError {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.NotNil(t, handler)
+		})
+	}
+}
+
+func TestNew_notConfigured(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+
+	_, err := New(t.Context(), next, nil, dynamic.GeoBlock{AllowedCountries: []string{"FR"}}, "traefikTest")
+	assert.Error(t, err)
+}
+
+func TestGeoBlock_ServeHTTP(t *testing.T) {
+	resolver := setupResolver(t)
+
+	testCases := []struct {
+		desc       string
+		config     dynamic.GeoBlock
+		remoteAddr string
+		xff        string
+		expected   int
+	}{
+		{
+			desc:       "allowed country",
+			config:     dynamic.GeoBlock{AllowedCountries: []string{"FR"}},
+			remoteAddr: "20.20.20.20:1234",
+			expected:   http.StatusOK,
+		},
+		{
+			desc:       "not allowed country",
+			config:     dynamic.GeoBlock{AllowedCountries: []string{"FR"}},
+			remoteAddr: "30.30.30.30:1234",
+			expected:   http.StatusForbidden,
+		},
+		{
+			desc:       "denied country",
+			config:     dynamic.GeoBlock{DeniedCountries: []string{"us"}},
+			remoteAddr: "30.30.30.30:1234",
+			expected:   http.StatusForbidden,
+		},
+		{
+			desc:       "not denied country",
+			config:     dynamic.GeoBlock{DeniedCountries: []string{"US"}},
+			remoteAddr: "20.20.20.20:1234",
+			expected:   http.StatusOK,
+		},
+		{
+			desc:       "allowed ASN",
+			config:     dynamic.GeoBlock{AllowedCountries: []string{"US"}, AllowedASNs: []int{64501}},
+			remoteAddr: "40.40.40.40:1234",
+			expected:   http.StatusOK,
+		},
+		{
+			desc:       "denied ASN takes precedence over allowed country",
+			config:     dynamic.GeoBlock{AllowedCountries: []string{"FR"}, DeniedASNs: []int{64500}},
+			remoteAddr: "20.20.20.20:1234",
+			expected:   http.StatusForbidden,
+		},
+		{
+			desc:       "unknown IP with allowed countries",
+			config:     dynamic.GeoBlock{AllowedCountries: []string{"FR"}},
+			remoteAddr: "10.10.10.10:1234",
+			expected:   http.StatusForbidden,
+		},
+		{
+			desc:       "unknown IP with allowed ASNs",
+			config:     dynamic.GeoBlock{AllowedASNs: []int{64501}},
+			remoteAddr: "10.10.10.10:1234",
+			expected:   http.StatusForbidden,
+		},
+		{
+			desc:       "unknown IP with deny list",
+			config:     dynamic.GeoBlock{DeniedCountries: []string{"US"}, DeniedASNs: []int{64500}},
+			remoteAddr: "10.10.10.10:1234",
+			expected:   http.StatusOK,
+		},
+		{
+			desc:       "denied unknown IP",
+			config:     dynamic.GeoBlock{AllowedCountries: []string{"FR"}, DenyUnknown: true},
+			remoteAddr: "10.10.10.10:1234",
+			expected:   http.StatusForbidden,
+		},
+		{
+			desc:       "known IP with deny unknown",
+			config:     dynamic.GeoBlock{DenyUnknown: true},
+			remoteAddr: "40.40.40.40:1234",
+			expected:   http.StatusOK,
+		},
+		{
+			desc: "client IP from IP strategy",
+			config: dynamic.GeoBlock{
+				AllowedCountries: []string{"FR"},
+				IPStrategy:       &dynamic.IPStrategy{Depth: 1},
+			},
+			remoteAddr: "10.10.10.10:1234",
+			xff:        "30.30.30.30, 20.20.20.20",
+			expected:   http.StatusOK,
+		},
+		{
+			desc:       "custom reject status code",
+			config:     dynamic.GeoBlock{DeniedCountries: []string{"FR"}, RejectStatusCode: http.StatusNotFound},
+			remoteAddr: "20.20.20.20:1234",
+			expected:   http.StatusNotFound,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+			handler, err := New(t.Context(), next, resolver, test.config, "traefikTest")
+			require.NoError(t, err)
+
+			recorder := httptest.NewRecorder()
+
+			req := httptest.NewRequest(http.MethodGet, "http://10.10.10.10", nil)
+			req.RemoteAddr = test.remoteAddr
+			if test.xff != "" {
+				req.Header.Set("X-Forwarded-For", test.xff)
+			}
+
+			handler.ServeHTTP(recorder, req)
+
+			assert.Equal(t, test.expected, recorder.Code)
+		})
+	}
+}
@@ -2,12 +2,14 @@ package inflightreq
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 
 	"github.com/rs/zerolog"
 	"github.com/traefik/traefik/v3/pkg/config/dynamic"
 	"github.com/traefik/traefik/v3/pkg/middlewares"
+	mmetrics "github.com/traefik/traefik/v3/pkg/middlewares/metrics"
 	"github.com/traefik/traefik/v3/pkg/observability/logs"
 	"github.com/vulcand/oxy/v2/connlimit"
 )
@@ -17,8 +19,9 @@ const (
 )
 
 type inFlightReq struct {
-	handler http.Handler
-	name    string
+	handler  http.Handler
+	name     string
+	outcomes *mmetrics.OutcomeRecorder
 }
 
 // New creates a max request middleware.
@@ -42,14 +45,20 @@ func New(ctx context.Context, next http.Handler, config dynamic.InFlightReq, nam
 		return nil, fmt.Errorf("error creating requests limiter: %w", err)
 	}
 
+	outcomes := mmetrics.NewOutcomeRecorder(ctx, name, typeName)
+	if outcomes != nil {
+		next = recordAllowed(next, outcomes)
+	}
+
 	handler, err := connlimit.New(next, sourceMatcher, config.Amount,
 		connlimit.Logger(logs.NewOxyWrapper(*logger)),
-		connlimit.Verbose(logger.GetLevel() == zerolog.TraceLevel))
+		connlimit.Verbose(logger.GetLevel() == zerolog.TraceLevel),
+		connlimit.ErrorHandler(&shedErrorHandler{outcomes: outcomes}))
 	if err != nil {
 		return nil, fmt.Errorf("error creating connection limit: %w", err)
 	}
 
-	return &inFlightReq{handler: handler, name: name}, nil
+	return &inFlightReq{handler: handler, name: name, outcomes: outcomes}, nil
 }
 
 func (i *inFlightReq) GetTracingInformation() (string, string) {
@@ -59,3 +68,25 @@ func (i *inFlightReq) GetTracingInformation() (string, string) {
 func (i *inFlightReq) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
 	i.handler.ServeHTTP(rw, req)
 }
+
+func recordAllowed(next http.Handler, outcomes *mmetrics.OutcomeRecorder) http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		outcomes.Record(req, mmetrics.OutcomeAllowed)
+		next.ServeHTTP(rw, req)
+	})
+}
+
+// shedErrorHandler records the requests shed by the connection limiter,
+// before delegating the response to the default connlimit error handler.
+type shedErrorHandler struct {
+	outcomes *mmetrics.OutcomeRecorder
+}
+
+func (h *shedErrorHandler) ServeHTTP(rw http.ResponseWriter, req *http.Request, err error) {
+	var maxConnErr *connlimit.MaxConnError
+	if errors.As(err, &maxConnErr) {
+		h.outcomes.Record(req, mmetrics.OutcomeShed)
+	}
+
+	(&connlimit.ConnErrHandler{}).ServeHTTP(rw, req, err)
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/traefik/traefik/v3/pkg/config/dynamic"
 	"github.com/traefik/traefik/v3/pkg/ip"
 	"github.com/traefik/traefik/v3/pkg/middlewares"
+	mmetrics "github.com/traefik/traefik/v3/pkg/middlewares/metrics"
 	"github.com/traefik/traefik/v3/pkg/middlewares/observability"
 )
 
@@ -24,6 +25,7 @@ type ipAllowLister struct {
 	strategy         ip.Strategy
 	name             string
 	rejectStatusCode int
+	outcomes         *mmetrics.OutcomeRecorder
 }
 
 // New builds a new IPAllowLister given a list of CIDR-Strings to allow.
@@ -61,6 +63,7 @@ func New(ctx context.Context, next http.Handler, config dynamic.IPAllowList, nam
 		next:             next,
 		name:             name,
 		rejectStatusCode: rejectStatusCode,
+		outcomes:         mmetrics.NewOutcomeRecorder(ctx, name, typeName),
 	}, nil
 }
 
@@ -77,10 +80,12 @@ func (al *ipAllowLister) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
 	if err != nil {
 		logger.Debug().Msgf("Rejecting IP %s: %v", clientIP, err)
 		observability.SetStatusErrorf(req.Context(), "Rejecting IP %s: %v", clientIP, err)
+		al.outcomes.Record(req, mmetrics.OutcomeBlocked)
 		reject(ctx, al.rejectStatusCode, rw)
 		return
 	}
 	logger.Debug().Msgf("Accepting IP %s", clientIP)
+	al.outcomes.Record(req, mmetrics.OutcomeAllowed)
 
 	al.next.ServeHTTP(rw, req)
 }
@@ -0,0 +1,92 @@
+package metrics
+
+import (
+	"context"
+	"net/http"
+
+	gokitmetrics "github.com/go-kit/kit/metrics"
+	"github.com/traefik/traefik/v3/pkg/middlewares/observability"
+	"github.com/traefik/traefik/v3/pkg/middlewares/retry"
+	"github.com/traefik/traefik/v3/pkg/observability/metrics"
+)
+
+// Outcomes of the decisions taken by middlewares.
+const (
+	OutcomeAllowed  = "allowed"
+	OutcomeRejected = "rejected"
+	OutcomeDenied   = "denied"
+	OutcomeBlocked  = "blocked"
+	OutcomeShed     = "shed"
+	OutcomeRetried  = "retried"
+	OutcomeError    = "error"
+)
+
+// Circuit breaker states, as reported by the circuit breaker state gauge.
+const (
+	CircuitBreakerClosed float64 = 0
+	CircuitBreakerOpen   float64 = 1
+)
+
+type outcomeRegistryKey struct{}
+
+// WithOutcomeRegistry returns a copy of the given context holding the registry
+// used by the middlewares created with this context to record their decisions.
+func WithOutcomeRegistry(ctx context.Context, registry metrics.Registry) context.Context {
+	if registry == nil {
+		return ctx
+	}
+
+	return context.WithValue(ctx, outcomeRegistryKey{}, registry)
+}
+
+// OutcomeRecorder records the decisions taken by a middleware.
+// A nil OutcomeRecorder is valid and records nothing.
+type OutcomeRecorder struct {
+	outcomesCounter gokitmetrics.Counter
+	stateGauge      gokitmetrics.Gauge
+	labels          []string
+}
+
+// NewOutcomeRecorder returns the OutcomeRecorder for the given middleware,
+// or nil if middleware metrics are not enabled in the context registry.
+func NewOutcomeRecorder(ctx context.Context, middlewareName, middlewareType string) *OutcomeRecorder {
+	registry, ok := ctx.Value(outcomeRegistryKey{}).(metrics.Registry)
+	if !ok || !registry.IsMiddlewareEnabled() {
+		return nil
+	}
+
+	return &OutcomeRecorder{
+		outcomesCounter: registry.MiddlewareOutcomesCounter(),
+		stateGauge:      registry.MiddlewareCircuitBreakerStateGauge(),
+		labels:          []string{"middleware", middlewareName, "type", middlewareType},
+	}
+}
+
+// Record counts the given outcome for a request, if metrics are enabled for it.
+func (r *OutcomeRecorder) Record(req *http.Request, outcome string) {
+	if r == nil || r.outcomesCounter == nil || !observability.MetricsEnabled(req.Context()) {
+		return
+	}
+
+	labels := make([]string, 0, len(r.labels)+2)
+	labels = append(labels, r.labels...)
+	labels = append(labels, "outcome", outcome)
+
+	r.outcomesCounter.With(labels...).Add(1)
+}
+
+// SetCircuitBreakerState reports the current state of a circuit breaker.
+func (r *OutcomeRecorder) SetCircuitBreakerState(state float64) {
+	if r == nil || r.stateGauge == nil {
+		return
+	}
+
+	r.stateGauge.With(r.labels...).Set(state)
+}
+
+// Retried implements retry.Listener by recording a retried outcome.
+func (r *OutcomeRecorder) Retried(req *http.Request, _ int) {
+	r.Record(req, OutcomeRetried)
+}
+
+var _ retry.Listener = (*OutcomeRecorder)(nil)
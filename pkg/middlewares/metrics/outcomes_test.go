@@ -0,0 +1,116 @@
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	gokitmetrics "github.com/go-kit/kit/metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/traefik/traefik/v3/pkg/middlewares/observability"
+	"github.com/traefik/traefik/v3/pkg/observability/metrics"
+	"github.com/traefik/traefik/v3/pkg/testhelpers"
+)
+
+type outcomesRegistry struct {
+	metrics.Registry
+
+	enabled bool
+	counter *CollectingCounter
+	gauge   *testhelpers.CollectingGauge
+}
+
+func (r *outcomesRegistry) IsMiddlewareEnabled() bool {
+	return r.enabled
+}
+
+func (r *outcomesRegistry) MiddlewareOutcomesCounter() gokitmetrics.Counter {
+	return r.counter
+}
+
+func (r *outcomesRegistry) MiddlewareCircuitBreakerStateGauge() gokitmetrics.Gauge {
+	return r.gauge
+}
+
+func TestOutcomeRecorder(t *testing.T) {
+	testCases := []struct {
+		desc           string
+		enabled        bool
+		metricsEnabled bool
+		expectedValue  float64
+	}{
+		{
+			desc:           "middleware metrics disabled",
+			metricsEnabled: true,
+		},
+		{
+			desc:    "metrics disabled for the request",
+			enabled: true,
+		},
+		{
+			desc:           "outcome recorded",
+			enabled:        true,
+			metricsEnabled: true,
+			expectedValue:  1,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			registry := &outcomesRegistry{
+				Registry: metrics.NewVoidRegistry(),
+				enabled:  test.enabled,
+				counter:  &CollectingCounter{},
+				gauge:    &testhelpers.CollectingGauge{},
+			}
+
+			recorder := NewOutcomeRecorder(WithOutcomeRegistry(context.Background(), registry), "foo@file", "RateLimiter")
+			if !test.enabled {
+				require.Nil(t, recorder)
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req = req.WithContext(observability.WithObservability(req.Context(), observability.Observability{MetricsEnabled: test.metricsEnabled}))
+
+			recorder.Record(req, OutcomeRejected)
+
+			assert.InDelta(t, test.expectedValue, registry.counter.CounterValue, 0)
+			if test.expectedValue > 0 {
+				assert.Equal(t, []string{"middleware", "foo@file", "type", "RateLimiter", "outcome", OutcomeRejected}, registry.counter.LastLabelValues)
+			}
+		})
+	}
+}
+
+func TestOutcomeRecorder_SetCircuitBreakerState(t *testing.T) {
+	registry := &outcomesRegistry{
+		Registry: metrics.NewVoidRegistry(),
+		enabled:  true,
+		counter:  &CollectingCounter{},
+		gauge:    &testhelpers.CollectingGauge{},
+	}
+
+	recorder := NewOutcomeRecorder(WithOutcomeRegistry(context.Background(), registry), "cb@file", "CircuitBreaker")
+	require.NotNil(t, recorder)
+
+	recorder.SetCircuitBreakerState(CircuitBreakerOpen)
+
+	assert.InDelta(t, CircuitBreakerOpen, registry.gauge.GaugeValue, 0)
+	assert.Equal(t, []string{"middleware", "cb@file", "type", "CircuitBreaker"}, registry.gauge.LastLabelValues)
+}
+
+func TestOutcomeRecorder_nil(t *testing.T) {
+	var recorder *OutcomeRecorder
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+
+	assert.NotPanics(t, func() {
+		recorder.Record(req, OutcomeAllowed)
+		recorder.Retried(req, 2)
+		recorder.SetCircuitBreakerState(CircuitBreakerClosed)
+	})
+}
@@ -0,0 +1,144 @@
+package observability
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"strconv"
+	"sync/atomic"
+
+	"github.com/traefik/traefik/v3/pkg/middlewares"
+	"github.com/traefik/traefik/v3/pkg/observability/logs"
+	"github.com/traefik/traefik/v3/pkg/observability/tracing"
+	"github.com/traefik/traefik/v3/pkg/tcp"
+	traefiktls "github.com/traefik/traefik/v3/pkg/tls"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	tcpRouterTypeName = "TracingTCPRouter"
+)
+
+type tcpRouterTracing struct {
+	tracer  *tracing.Tracer
+	router  string
+	service string
+	next    tcp.Handler
+}
+
+// NewTCPRouter creates a new tracing handler that starts a server span for each connection handled by a TCP router.
+// It returns the next handler as is when the tracer is nil.
+func NewTCPRouter(ctx context.Context, tracer *tracing.Tracer, router, service string, next tcp.Handler) tcp.Handler {
+	if tracer == nil {
+		return next
+	}
+
+	middlewares.GetLogger(ctx, "tracing", tcpRouterTypeName).
+		Debug().Str(logs.RouterName, router).Str(logs.ServiceName, service).Msg("Added TCP tracing middleware")
+
+	return &tcpRouterTracing{
+		tracer:  tracer,
+		router:  router,
+		service: service,
+		next:    next,
+	}
+}
+
+func (t *tcpRouterTracing) ServeTCP(conn tcp.WriteCloser) {
+	tracingCtx, span := t.tracer.Start(tcp.ContextOf(conn), "TCP Router", trace.WithSpanKind(trace.SpanKindServer))
+	defer span.End()
+
+	tConn := &tracedConn{WriteCloser: conn, ctx: tracingCtx}
+
+	vars := tcp.ContextVars(tConn, map[string]string{tcp.TraceID: span.SpanContext().TraceID().String()})
+
+	span.SetAttributes(
+		semconv.NetworkTransportTCP,
+		attribute.String("traefik.router.name", t.router),
+		attribute.String("traefik.service.name", t.service),
+	)
+	setAddressAttributes(span, conn.RemoteAddr().String(), semconv.ClientAddress, semconv.ClientPort)
+
+	if source := vars[tcp.ProxyProtocolSource]; source != "" {
+		span.SetAttributes(attribute.String("traefik.proxy_protocol.source", source))
+	}
+	if sni := vars[tcp.RequestTLSSNI]; sni != "" {
+		span.SetAttributes(attribute.String("tls.client.server_name", sni))
+	}
+	if alpn := vars[tcp.RequestTLSALPN]; alpn != "" {
+		span.SetAttributes(attribute.String("tls.client.supported_protocols", alpn))
+	}
+
+	t.next.ServeTCP(tConn)
+
+	// The variables below are only known once the connection has been handled.
+	vars = tcp.ContextVars(tConn)
+
+	if tc, ok := conn.(*tls.Conn); ok {
+		if state := tc.ConnectionState(); state.HandshakeComplete {
+			span.SetAttributes(semconv.TLSProtocolVersion(traefiktls.GetVersion(&state)))
+		}
+	}
+	if addr := vars[tcp.ServiceAddr]; addr != "" {
+		setAddressAttributes(span, addr, semconv.ServerAddress, semconv.ServerPort)
+	}
+
+	span.SetAttributes(
+		attribute.Int64("traefik.tcp.received_bytes", tConn.received.Load()),
+		attribute.Int64("traefik.tcp.sent_bytes", tConn.sent.Load()),
+	)
+
+	switch status := vars[tcp.Status]; status {
+	case "":
+	case "Y":
+		span.SetAttributes(attribute.String("traefik.tcp.close_reason", "closed"))
+	default:
+		span.SetAttributes(attribute.String("traefik.tcp.close_reason", status))
+		if vars[tcp.ProxyServerAddr] == "" {
+			// The connection to the backend could not be established.
+			span.SetStatus(codes.Error, status)
+		}
+	}
+}
+
+func setAddressAttributes(span trace.Span, address string, host func(string) attribute.KeyValue, port func(int) attribute.KeyValue) {
+	h, p, err := net.SplitHostPort(address)
+	if err != nil {
+		return
+	}
+
+	span.SetAttributes(host(h))
+	if portNum, err := strconv.Atoi(p); err == nil {
+		span.SetAttributes(port(portNum))
+	}
+}
+
+// tracedConn is a tcp.WriteCloser carrying the tracing context of its connection,
+// and counting the bytes going through it.
+type tracedConn struct {
+	tcp.WriteCloser
+
+	ctx      context.Context
+	received atomic.Int64
+	sent     atomic.Int64
+}
+
+// Context returns the tracing context of the connection.
+func (c *tracedConn) Context() context.Context {
+	return c.ctx
+}
+
+func (c *tracedConn) Read(p []byte) (int, error) {
+	n, err := c.WriteCloser.Read(p)
+	c.received.Add(int64(n))
+	return n, err
+}
+
+func (c *tracedConn) Write(p []byte) (int, error) {
+	n, err := c.WriteCloser.Write(p)
+	c.sent.Add(int64(n))
+	return n, err
+}
@@ -0,0 +1,95 @@
+package observability
+
+import (
+	"io"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/traefik/traefik/v3/pkg/observability/tracing"
+	"github.com/traefik/traefik/v3/pkg/tcp"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+func TestNewTCPRouter(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = ln.Close() })
+
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		_, _ = conn.Write([]byte("ping"))
+		_, _ = io.ReadFull(conn, make([]byte, 2))
+	}()
+
+	clientConn, err := net.Dial("tcp", ln.Addr().String())
+	require.NoError(t, err)
+
+	conn := tcp.NewNextConn(clientConn.(*net.TCPConn))
+	defer conn.Close()
+
+	tcp.ContextVars(conn, map[string]string{
+		tcp.RequestTLSSNI:  "foo.localhost",
+		tcp.RequestTLSALPN: "h2,http/1.1",
+	})
+
+	next := tcp.HandlerFunc(func(conn tcp.WriteCloser) {
+		_, err := io.ReadFull(conn, make([]byte, 4))
+		require.NoError(t, err)
+
+		_, err = conn.Write([]byte("ok"))
+		require.NoError(t, err)
+
+		tcp.ContextVars(conn, map[string]string{
+			tcp.ServiceAddr:     "10.0.0.1:5432",
+			tcp.ProxyServerAddr: "10.0.0.1:5432",
+			tcp.Status:          "Y",
+		})
+	})
+
+	mTracer := &mockTracer{}
+	tracer := tracing.NewTracer(mTracer, nil, nil, nil)
+
+	handler := NewTCPRouter(t.Context(), tracer, "myRouter", "myService", next)
+	handler.ServeTCP(conn)
+
+	require.Len(t, mTracer.spans, 1)
+
+	span := mTracer.spans[0]
+	assert.Equal(t, "TCP Router", span.name)
+
+	expected := []attribute.KeyValue{
+		attribute.String("span.kind", "server"),
+		attribute.String("network.transport", "tcp"),
+		attribute.String("traefik.router.name", "myRouter"),
+		attribute.String("traefik.service.name", "myService"),
+		attribute.String("tls.client.server_name", "foo.localhost"),
+		attribute.String("tls.client.supported_protocols", "h2,http/1.1"),
+		attribute.String("server.address", "10.0.0.1"),
+		attribute.Int("server.port", 5432),
+		attribute.Int64("traefik.tcp.received_bytes", 4),
+		attribute.Int64("traefik.tcp.sent_bytes", 2),
+		attribute.String("traefik.tcp.close_reason", "closed"),
+	}
+	for _, attr := range expected {
+		assert.Contains(t, span.attributes, attr)
+	}
+
+	assert.Equal(t, span.SpanContext().TraceID().String(), tcp.ContextVars(conn)[tcp.TraceID])
+}
+
+func TestNewTCPRouter_noTracer(t *testing.T) {
+	next := tcp.HandlerFunc(func(conn tcp.WriteCloser) {})
+
+	handler := NewTCPRouter(t.Context(), nil, "myRouter", "myService", next)
+
+	assert.NotNil(t, handler)
+	_, ok := handler.(*tcpRouterTracing)
+	assert.False(t, ok)
+}
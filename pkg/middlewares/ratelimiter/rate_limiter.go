@@ -12,6 +12,7 @@ import (
 	"github.com/rs/zerolog/log"
 	"github.com/traefik/traefik/v3/pkg/config/dynamic"
 	"github.com/traefik/traefik/v3/pkg/middlewares"
+	mmetrics "github.com/traefik/traefik/v3/pkg/middlewares/metrics"
 	"github.com/traefik/traefik/v3/pkg/middlewares/observability"
 	"github.com/vulcand/oxy/v2/utils"
 	"golang.org/x/time/rate"
@@ -37,6 +38,7 @@ type rateLimiter struct {
 	sourceMatcher utils.SourceExtractor
 	next          http.Handler
 	logger        *zerolog.Logger
+	outcomes      *mmetrics.OutcomeRecorder
 
 	limiter limiter
 }
@@ -120,6 +122,7 @@ func New(ctx context.Context, next http.Handler, config dynamic.RateLimit, name
 		next:          next,
 		sourceMatcher: sourceMatcher,
 		limiter:       limiter,
+		outcomes:      mmetrics.NewOutcomeRecorder(ctx, name, typeName),
 	}, nil
 }
 
@@ -156,12 +159,14 @@ func (rl *rateLimiter) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
 	}
 
 	if delay == nil {
+		rl.outcomes.Record(req, mmetrics.OutcomeRejected)
 		observability.SetStatusErrorf(ctx, "No bursty traffic allowed")
 		http.Error(rw, "No bursty traffic allowed", http.StatusTooManyRequests)
 		return
 	}
 
 	if *delay > rl.maxDelay {
+		rl.outcomes.Record(req, mmetrics.OutcomeRejected)
 		rl.serveDelayError(ctx, rw, *delay)
 		return
 	}
@@ -175,6 +180,7 @@ func (rl *rateLimiter) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
 	case <-time.After(*delay):
 	}
 
+	rl.outcomes.Record(req, mmetrics.OutcomeAllowed)
 	rl.next.ServeHTTP(rw, req)
 }
 
@@ -38,6 +38,15 @@ func (re *recovery) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
 
 func recoverFunc(rw recoveryResponseWriter, req *http.Request) {
 	if err := recover(); err != nil {
+		// With HTTP/2 and HTTP/3, aborting the handler resets the request stream, without affecting the connection,
+		// which is what is expected from a handler aborted before writing the response (e.g. by the FaultInjection middleware).
+		//nolint:errorlint // false-positive because err is an interface.
+		if err == http.ErrAbortHandler && req.ProtoMajor >= 2 {
+			middlewares.GetLogger(req.Context(), middlewareName, typeName).Debug().
+				Msgf("Request has been aborted [%s - %s]: %v", req.RemoteAddr, req.URL, err)
+			panic(err)
+		}
+
 		defer rw.finalizeResponse()
 
 		logger := middlewares.GetLogger(req.Context(), middlewareName, typeName)
@@ -63,3 +63,20 @@ func TestRecoverHandler(t *testing.T) {
 		})
 	}
 }
+
+func TestRecoverHandler_abortHTTP2(t *testing.T) {
+	recovery, err := New(t.Context(), http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		panic(http.ErrAbortHandler)
+	}))
+	require.NoError(t, err)
+
+	server := httptest.NewUnstartedServer(recovery)
+	server.EnableHTTP2 = true
+	server.StartTLS()
+	t.Cleanup(server.Close)
+
+	// The request stream is reset, instead of receiving an error response.
+	res, err := server.Client().Get(server.URL)
+	require.Nil(t, res)
+	assert.ErrorContains(t, err, "stream error")
+}
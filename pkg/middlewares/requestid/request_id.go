@@ -0,0 +1,167 @@
+package requestid
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+	"github.com/traefik/traefik/v3/pkg/middlewares"
+	"github.com/traefik/traefik/v3/pkg/middlewares/accesslog"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const typeName = "RequestID"
+
+const (
+	generatorUUIDv7 = "uuidv7"
+	generatorULID   = "ulid"
+)
+
+// maxIncomingIDLength is the maximum length of the request IDs sent by the clients.
+const maxIncomingIDLength = 128
+
+// requestIDAttribute is the span attribute holding the request ID.
+const requestIDAttribute = "http.request.id"
+
+// requestID is a middleware propagating the ID of the request to the service, the response, the access logs and the traces.
+type requestID struct {
+	next           http.Handler
+	name           string
+	headerName     string
+	generate       func() (string, error)
+	fromTraceID    bool
+	ignoreIncoming bool
+}
+
+// New creates a request ID middleware.
+func New(ctx context.Context, next http.Handler, config dynamic.RequestID, name string) (http.Handler, error) {
+	middlewares.GetLogger(ctx, name, typeName).Debug().Msg("Creating middleware")
+
+	if config.HeaderName == "" {
+		return nil, errors.New("empty header name")
+	}
+
+	r := &requestID{
+		next:           next,
+		name:           name,
+		headerName:     http.CanonicalHeaderKey(config.HeaderName),
+		fromTraceID:    config.FromTraceID,
+		ignoreIncoming: config.IgnoreIncoming,
+	}
+
+	switch config.Generator {
+	case generatorUUIDv7, "":
+		r.generate = newUUIDv7
+	case generatorULID:
+		r.generate = newULID
+	default:
+		return nil, fmt.Errorf("unknown request ID generator %q", config.Generator)
+	}
+
+	return r, nil
+}
+
+func (r *requestID) GetTracingInformation() (string, string) {
+	return r.name, typeName
+}
+
+func (r *requestID) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	logger := middlewares.GetLogger(req.Context(), r.name, typeName)
+
+	id, err := r.getID(req)
+	if err != nil {
+		logger.Error().Err(err).Msg("Error while generating request ID")
+		r.next.ServeHTTP(rw, req)
+		return
+	}
+
+	req.Header.Set(r.headerName, id)
+
+	if logData := accesslog.GetLogData(req); logData != nil {
+		logData.Core[accesslog.RequestID] = id
+	}
+
+	trace.SpanFromContext(req.Context()).SetAttributes(attribute.String(requestIDAttribute, id))
+
+	r.next.ServeHTTP(middlewares.NewResponseModifier(rw, req, func(res *http.Response) error {
+		// Overrides the value echoed by the service, if any, to avoid duplicated headers.
+		res.Header.Set(r.headerName, id)
+		return nil
+	}), req)
+}
+
+// getID returns the ID of the request, sent by the client, derived from the trace ID, or generated.
+func (r *requestID) getID(req *http.Request) (string, error) {
+	if !r.ignoreIncoming {
+		if id := req.Header.Get(r.headerName); isValidID(id) {
+			return id, nil
+		}
+	}
+
+	if r.fromTraceID {
+		if spanCtx := trace.SpanContextFromContext(req.Context()); spanCtx.HasTraceID() {
+			return spanCtx.TraceID().String(), nil
+		}
+	}
+
+	return r.generate()
+}
+
+// isValidID tells whether the request ID sent by the client can be trusted to be written in the logs and headers.
+func isValidID(id string) bool {
+	if id == "" || len(id) > maxIncomingIDLength {
+		return false
+	}
+
+	for _, c := range []byte(id) {
+		// Only the visible ASCII characters are allowed.
+		if c <= ' ' || c > '~' {
+			return false
+		}
+	}
+
+	return true
+}
+
+func newUUIDv7() (string, error) {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return "", err
+	}
+
+	return id.String(), nil
+}
+
+// crockfordAlphabet is the Crockford's Base32 alphabet used to encode the ULIDs.
+const crockfordAlphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// newULID generates a ULID (https://github.com/ulid/spec),
+// made of a 48 bits timestamp in milliseconds followed by 80 random bits.
+func newULID() (string, error) {
+	var data [16]byte
+
+	binary.BigEndian.PutUint64(data[:8], uint64(time.Now().UnixMilli())<<16)
+	if _, err := rand.Read(data[6:]); err != nil {
+		return "", err
+	}
+
+	hi := binary.BigEndian.Uint64(data[:8])
+	lo := binary.BigEndian.Uint64(data[8:])
+
+	// The 128 bits are encoded, from the least significant ones, into 26 characters of 5 bits.
+	var encoded [26]byte
+	for i := len(encoded) - 1; i >= 0; i-- {
+		encoded[i] = crockfordAlphabet[lo&0x1f]
+		lo = lo>>5 | hi<<59
+		hi >>= 5
+	}
+
+	return string(encoded[:]), nil
+}
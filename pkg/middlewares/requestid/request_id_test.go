@@ -0,0 +1,188 @@
+package requestid
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+	"github.com/traefik/traefik/v3/pkg/middlewares/accesslog"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestNew(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		config    dynamic.RequestID
+		expectErr bool
+	}{
+		{
+			desc:   "uuidv7",
+			config: dynamic.RequestID{HeaderName: "X-Request-Id", Generator: "uuidv7"},
+		},
+		{
+			desc:   "ulid",
+			config: dynamic.RequestID{HeaderName: "X-Request-Id", Generator: "ulid"},
+		},
+		{
+			desc:      "unknown generator",
+			config:    dynamic.RequestID{HeaderName: "X-Request-Id", Generator: "foo"},
+			expectErr: true,
+		},
+		{
+			desc:      "empty header name",
+			config:    dynamic.RequestID{Generator: "uuidv7"},
+			expectErr: true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := New(t.Context(), http.NotFoundHandler(), test.config, "requestID")
+			if test.expectErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestRequestID(t *testing.T) {
+	traceID := trace.TraceID{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f, 0x10}
+
+	testCases := []struct {
+		desc       string
+		config     dynamic.RequestID
+		incomingID string
+		traced     bool
+		expectedID string
+		assertID   func(t *testing.T, id string)
+	}{
+		{
+			desc:   "generated uuidv7",
+			config: dynamic.RequestID{HeaderName: "X-Request-Id", Generator: "uuidv7"},
+			assertID: func(t *testing.T, id string) {
+				t.Helper()
+
+				parsed, err := uuid.Parse(id)
+				require.NoError(t, err)
+				assert.Equal(t, uuid.Version(7), parsed.Version())
+			},
+		},
+		{
+			desc:   "generated ulid",
+			config: dynamic.RequestID{HeaderName: "X-Request-Id", Generator: "ulid"},
+			assertID: func(t *testing.T, id string) {
+				t.Helper()
+
+				assert.Len(t, id, 26)
+				assert.Empty(t, strings.Trim(id, crockfordAlphabet))
+			},
+		},
+		{
+			desc:       "incoming ID",
+			config:     dynamic.RequestID{HeaderName: "X-Correlation-Id", Generator: "uuidv7"},
+			incomingID: "foo-bar",
+			expectedID: "foo-bar",
+		},
+		{
+			desc:       "ignored incoming ID",
+			config:     dynamic.RequestID{HeaderName: "X-Request-Id", Generator: "uuidv7", FromTraceID: true, IgnoreIncoming: true},
+			incomingID: "foo-bar",
+			traced:     true,
+			expectedID: traceID.String(),
+		},
+		{
+			desc:       "invalid incoming ID",
+			config:     dynamic.RequestID{HeaderName: "X-Request-Id", Generator: "uuidv7", FromTraceID: true},
+			incomingID: "foo\tbar",
+			traced:     true,
+			expectedID: traceID.String(),
+		},
+		{
+			desc:       "trace ID",
+			config:     dynamic.RequestID{HeaderName: "X-Request-Id", Generator: "uuidv7", FromTraceID: true},
+			traced:     true,
+			expectedID: traceID.String(),
+		},
+		{
+			desc:   "trace ID of an untraced request",
+			config: dynamic.RequestID{HeaderName: "X-Request-Id", Generator: "uuidv7", FromTraceID: true},
+			assertID: func(t *testing.T, id string) {
+				t.Helper()
+
+				_, err := uuid.Parse(id)
+				require.NoError(t, err)
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			var forwardedID string
+			next := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				forwardedID = req.Header.Get(test.config.HeaderName)
+
+				// The ID echoed by the service must not be duplicated.
+				rw.Header().Set(test.config.HeaderName, forwardedID)
+				rw.WriteHeader(http.StatusOK)
+			})
+
+			handler, err := New(t.Context(), next, test.config, "requestID")
+			require.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+			if test.incomingID != "" {
+				req.Header.Set(test.config.HeaderName, test.incomingID)
+			}
+
+			ctx := req.Context()
+			if test.traced {
+				ctx = trace.ContextWithSpanContext(ctx, trace.NewSpanContext(trace.SpanContextConfig{
+					TraceID: traceID,
+					SpanID:  trace.SpanID{0x01},
+				}))
+			}
+
+			logData := &accesslog.LogData{Core: accesslog.CoreLogData{}}
+			req = req.WithContext(context.WithValue(ctx, accesslog.DataTableKey, logData))
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			require.NotEmpty(t, forwardedID)
+			assert.Equal(t, []string{forwardedID}, rec.Header().Values(test.config.HeaderName))
+			assert.Equal(t, forwardedID, logData.Core[accesslog.RequestID])
+
+			if test.assertID != nil {
+				test.assertID(t, forwardedID)
+				return
+			}
+			assert.Equal(t, test.expectedID, forwardedID)
+		})
+	}
+}
+
+func TestNewULID(t *testing.T) {
+	first, err := newULID()
+	require.NoError(t, err)
+
+	second, err := newULID()
+	require.NoError(t, err)
+
+	assert.NotEqual(t, first, second)
+	// The first 10 characters encode the timestamp: the ULIDs are sortable.
+	assert.LessOrEqual(t, first[:10], second[:10])
+	// A 128 bits value starts with a character encoding at most 3 bits.
+	assert.LessOrEqual(t, first[0], byte('7'))
+}
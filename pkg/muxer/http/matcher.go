@@ -1,32 +1,48 @@
 package http
 
 import (
+	"errors"
 	"fmt"
+	"net"
 	"net/http"
 	"regexp"
 	"slices"
+	"strconv"
 	"strings"
 	"unicode/utf8"
 
 	"github.com/rs/zerolog/log"
+	"github.com/traefik/traefik/v3/pkg/geoip"
 	"github.com/traefik/traefik/v3/pkg/ip"
 	"github.com/traefik/traefik/v3/pkg/middlewares/requestdecorator"
 )
 
 var httpFuncs = matcherBuilderFuncs{
-	"ClientIP":     expectNParameters(clientIP, 1),
-	"Method":       expectNParameters(method, 1),
-	"Host":         expectNParameters(host, 1),
-	"HostRegexp":   expectNParameters(hostRegexp, 1),
-	"Path":         expectNParameters(path, 1),
-	"PathRegexp":   expectNParameters(pathRegexp, 1),
-	"PathPrefix":   expectNParameters(pathPrefix, 1),
-	"Header":       expectNParameters(header, 2),
-	"HeaderRegexp": expectNParameters(headerRegexp, 2),
-	"Query":        expectNParameters(query, 1, 2),
-	"QueryRegexp":  expectNParameters(queryRegexp, 1, 2),
+	"ClientIP":          expectNParameters(clientIP, 1),
+	"ClientCountry":     expectNParameters(clientCountry(nil), 1),
+	"ClientASN":         expectNParameters(clientASN(nil), 1),
+	"ClientCertSubject": expectNParameters(clientCertSubject, 1),
+	"Method":            expectNParameters(method, 1),
+	"Host":              expectNParameters(host, 1),
+	"HostRegexp":        expectNParameters(hostRegexp, 1),
+	"Path":              expectNParameters(path, 1),
+	"PathRegexp":        expectNParameters(pathRegexp, 1),
+	"PathPrefix":        expectNParameters(pathPrefix, 1),
+	"Header":            expectNParameters(header, 2),
+	"HeaderRegexp":      expectNParameters(headerRegexp, 2),
+	"Query":             expectNParameters(query, 1, 2),
+	"QueryRegexp":       expectNParameters(queryRegexp, 1, 2),
+	"Cookie":            expectNParameters(cookie, 2),
+	"CookieRegexp":      expectNParameters(cookieRegexp, 2),
+	"HTTPVersion":       expectNParameters(httpVersion, 1),
+	"TLS":               expectNParameters(tlsMatcher, 0),
+	"SNI":               expectNParameters(sni, 1),
+	"Port":              expectNParameters(port, 1),
 }
 
+// noParameterMatchers are the matchers which do not take any parameter.
+var noParameterMatchers = []string{"TLS"}
+
 func expectNParameters(fn func(*matchersTree, ...string) error, n ...int) func(*matchersTree, ...string) error {
 	return func(tree *matchersTree, s ...string) error {
 		if !slices.Contains(n, len(s)) {
@@ -58,6 +74,47 @@ func clientIP(tree *matchersTree, clientIP ...string) error {
 	return nil
 }
 
+// clientCountry returns the ClientCountry matcher builder, resolving the country of the client IPs with the given resolver.
+func clientCountry(resolver *geoip.Resolver) matcherBuilderFunc {
+	return func(tree *matchersTree, countries ...string) error {
+		if resolver == nil || !resolver.HasCountry() {
+			return errors.New("ClientCountry matcher: the GeoIP country database is not configured")
+		}
+
+		country := strings.ToUpper(strings.TrimSpace(countries[0]))
+		if len(country) != 2 {
+			return fmt.Errorf("invalid value %q for ClientCountry matcher, an ISO 3166-1 alpha-2 country code is expected", countries[0])
+		}
+
+		tree.matcher = func(req *http.Request) bool {
+			return resolver.Country(net.ParseIP(resolver.ClientIP(req))) == country
+		}
+
+		return nil
+	}
+}
+
+// clientASN returns the ClientASN matcher builder, resolving the autonomous system of the client IPs with the given resolver.
+func clientASN(resolver *geoip.Resolver) matcherBuilderFunc {
+	return func(tree *matchersTree, asns ...string) error {
+		if resolver == nil || !resolver.HasASN() {
+			return errors.New("ClientASN matcher: the GeoIP ASN database is not configured")
+		}
+
+		asn, err := geoip.ParseASN(asns[0])
+		if err != nil {
+			return fmt.Errorf("ClientASN matcher: %w", err)
+		}
+
+		tree.matcher = func(req *http.Request) bool {
+			reqASN, _ := resolver.AutonomousSystem(net.ParseIP(resolver.ClientIP(req)))
+			return reqASN != 0 && reqASN == asn
+		}
+
+		return nil
+	}
+}
+
 func method(tree *matchersTree, methods ...string) error {
 	method := strings.ToUpper(methods[0])
 
@@ -253,6 +310,125 @@ func queryRegexp(tree *matchersTree, queries ...string) error {
 	return nil
 }
 
+func cookie(tree *matchersTree, cookies ...string) error {
+	name, value := cookies[0], cookies[1]
+
+	tree.matcher = func(req *http.Request) bool {
+		return slices.ContainsFunc(req.CookiesNamed(name), func(c *http.Cookie) bool {
+			return c.Value == value
+		})
+	}
+
+	return nil
+}
+
+func cookieRegexp(tree *matchersTree, cookies ...string) error {
+	name, value := cookies[0], cookies[1]
+
+	re, err := regexp.Compile(value)
+	if err != nil {
+		return fmt.Errorf("compiling CookieRegexp matcher: %w", err)
+	}
+
+	tree.matcher = func(req *http.Request) bool {
+		return slices.ContainsFunc(req.CookiesNamed(name), func(c *http.Cookie) bool {
+			return re.MatchString(c.Value)
+		})
+	}
+
+	return nil
+}
+
+func httpVersion(tree *matchersTree, versions ...string) error {
+	var major, minor int
+	switch versions[0] {
+	case "1.0":
+		major, minor = 1, 0
+	case "1.1":
+		major, minor = 1, 1
+	case "2":
+		major = 2
+	case "3":
+		major = 3
+	default:
+		return fmt.Errorf("invalid value %q for HTTPVersion matcher, expected one of 1.0, 1.1, 2 or 3", versions[0])
+	}
+
+	tree.matcher = func(req *http.Request) bool {
+		if major > 1 {
+			return req.ProtoMajor == major
+		}
+
+		return req.ProtoMajor == major && req.ProtoMinor == minor
+	}
+
+	return nil
+}
+
+func tlsMatcher(tree *matchersTree, _ ...string) error {
+	tree.matcher = func(req *http.Request) bool {
+		return req.TLS != nil
+	}
+
+	return nil
+}
+
+func sni(tree *matchersTree, serverNames ...string) error {
+	serverName := serverNames[0]
+
+	if !IsASCII(serverName) {
+		return fmt.Errorf("invalid value %q for SNI matcher, non-ASCII characters are not allowed", serverName)
+	}
+
+	tree.matcher = func(req *http.Request) bool {
+		return req.TLS != nil && strings.EqualFold(req.TLS.ServerName, serverName)
+	}
+
+	return nil
+}
+
+// clientCertSubject matches the distinguished name of the subject of the verified client certificate,
+// in its RFC 2253 form (e.g. CN=client,O=Example).
+func clientCertSubject(tree *matchersTree, subjects ...string) error {
+	subject := subjects[0]
+
+	tree.matcher = func(req *http.Request) bool {
+		if req.TLS == nil || len(req.TLS.VerifiedChains) == 0 || len(req.TLS.VerifiedChains[0]) == 0 {
+			return false
+		}
+
+		return req.TLS.VerifiedChains[0][0].Subject.String() == subject
+	}
+
+	return nil
+}
+
+// port matches the local port on which the request has been received.
+func port(tree *matchersTree, ports ...string) error {
+	value, err := strconv.ParseUint(ports[0], 10, 16)
+	if err != nil || value == 0 {
+		return fmt.Errorf("invalid value %q for Port matcher, a port number is expected", ports[0])
+	}
+
+	expected := strconv.FormatUint(value, 10)
+
+	tree.matcher = func(req *http.Request) bool {
+		addr, ok := req.Context().Value(http.LocalAddrContextKey).(net.Addr)
+		if !ok {
+			return false
+		}
+
+		_, localPort, err := net.SplitHostPort(addr.String())
+		if err != nil {
+			return false
+		}
+
+		return localPort == expected
+	}
+
+	return nil
+}
+
 // IsASCII checks if the given string contains only ASCII characters.
 func IsASCII(s string) bool {
 	for i := range len(s) {
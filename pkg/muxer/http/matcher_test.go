@@ -1,13 +1,23 @@
 package http
 
 import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net"
 	"net/http"
 	"net/http/httptest"
+	"path/filepath"
 	"strings"
 	"testing"
 
+	"github.com/maxmind/mmdbwriter/mmdbtype"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+	"github.com/traefik/traefik/v3/pkg/geoip"
+	"github.com/traefik/traefik/v3/pkg/geoip/geoiptest"
 	"github.com/traefik/traefik/v3/pkg/middlewares/requestdecorator"
 )
 
@@ -97,6 +107,132 @@ func TestClientIPMatcher(t *testing.T) {
 	}
 }
 
+func TestGeoIPMatchers(t *testing.T) {
+	dir := t.TempDir()
+
+	countryDB := filepath.Join(dir, "country.mmdb")
+	geoiptest.WriteDatabase(t, countryDB, map[string]mmdbtype.Map{
+		"1.2.3.0/24":    {"country": mmdbtype.Map{"iso_code": mmdbtype.String("FR")}},
+		"2001:db8::/32": {"country": mmdbtype.Map{"iso_code": mmdbtype.String("JP")}},
+	})
+
+	asnDB := filepath.Join(dir, "asn.mmdb")
+	geoiptest.WriteDatabase(t, asnDB, map[string]mmdbtype.Map{
+		"1.2.0.0/16": {"autonomous_system_number": mmdbtype.Uint32(64500)},
+	})
+
+	resolver, err := geoip.NewResolver(geoip.Config{
+		CountryDatabase: countryDB,
+		ASNDatabase:     asnDB,
+		IPStrategy:      &dynamic.IPStrategy{Depth: 1},
+	})
+	require.NoError(t, err)
+
+	testCases := []struct {
+		desc          string
+		rule          string
+		expected      map[string]int
+		expectedError bool
+	}{
+		{
+			desc:          "invalid ClientCountry matcher",
+			rule:          "ClientCountry(`France`)",
+			expectedError: true,
+		},
+		{
+			desc:          "invalid ClientCountry matcher (too many parameters)",
+			rule:          "ClientCountry(`FR`, `JP`)",
+			expectedError: true,
+		},
+		{
+			desc:          "invalid ClientASN matcher",
+			rule:          "ClientASN(`foo`)",
+			expectedError: true,
+		},
+		{
+			desc: "valid ClientCountry matcher",
+			rule: "ClientCountry(`fr`)",
+			expected: map[string]int{
+				"1.2.3.4":     http.StatusOK,
+				"1.2.4.4":     http.StatusNotFound,
+				"2001:db8::1": http.StatusNotFound,
+				"foo":         http.StatusNotFound,
+			},
+		},
+		{
+			desc: "valid ClientCountry matcher with IPv6",
+			rule: "ClientCountry(`JP`)",
+			expected: map[string]int{
+				"1.2.3.4":     http.StatusNotFound,
+				"2001:db8::1": http.StatusOK,
+			},
+		},
+		{
+			desc: "valid ClientASN matcher",
+			rule: "ClientASN(`AS64500`)",
+			expected: map[string]int{
+				"1.2.3.4": http.StatusOK,
+				"1.2.4.4": http.StatusOK,
+				"5.6.7.8": http.StatusNotFound,
+			},
+		},
+		{
+			desc: "valid ClientASN and ClientCountry matchers",
+			rule: "ClientASN(`64500`) && !ClientCountry(`FR`)",
+			expected: map[string]int{
+				"1.2.3.4": http.StatusNotFound,
+				"1.2.4.4": http.StatusOK,
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+			parser, err := NewSyntaxParser(WithGeoIP(resolver))
+			require.NoError(t, err)
+
+			muxer := NewMuxer(parser)
+
+			err = muxer.AddRoute(test.rule, "", 0, handler)
+			if test.expectedError {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+
+			results := make(map[string]int)
+			for clientIP := range test.expected {
+				w := httptest.NewRecorder()
+
+				// The client IP is resolved from the X-Forwarded-For header, with the configured depth strategy.
+				req := httptest.NewRequest(http.MethodGet, "https://example.com", http.NoBody)
+				req.RemoteAddr = "10.0.0.1:1234"
+				req.Header.Set("X-Forwarded-For", clientIP)
+
+				muxer.ServeHTTP(w, req)
+				results[clientIP] = w.Code
+			}
+			assert.Equal(t, test.expected, results)
+		})
+	}
+}
+
+func TestGeoIPMatchers_notConfigured(t *testing.T) {
+	parser, err := NewSyntaxParser()
+	require.NoError(t, err)
+
+	muxer := NewMuxer(parser)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+
+	require.Error(t, muxer.AddRoute("ClientCountry(`FR`)", "", 0, handler))
+	require.Error(t, muxer.AddRoute("ClientASN(`64500`)", "", 0, handler))
+}
+
 func TestMethodMatcher(t *testing.T) {
 	testCases := []struct {
 		desc          string
@@ -1034,3 +1170,359 @@ func TestQueryRegexpMatcher(t *testing.T) {
 		})
 	}
 }
+
+func TestCookieMatchers(t *testing.T) {
+	testCases := []struct {
+		desc          string
+		rule          string
+		expected      map[string]int
+		expectedError bool
+	}{
+		{
+			desc:          "invalid Cookie matcher (no parameter)",
+			rule:          "Cookie()",
+			expectedError: true,
+		},
+		{
+			desc:          "invalid Cookie matcher (missing value parameter)",
+			rule:          "Cookie(`session`)",
+			expectedError: true,
+		},
+		{
+			desc:          "invalid Cookie matcher (empty value parameter)",
+			rule:          "Cookie(`session`, ``)",
+			expectedError: true,
+		},
+		{
+			desc:          "invalid CookieRegexp matcher (invalid regexp)",
+			rule:          "CookieRegexp(`session`, `(beta`)",
+			expectedError: true,
+		},
+		{
+			desc: "valid Cookie matcher",
+			rule: "Cookie(`group`, `beta`)",
+			expected: map[string]int{
+				"":                        http.StatusNotFound,
+				"group=beta":              http.StatusOK,
+				"foo=bar; group=beta":     http.StatusOK,
+				"group=alpha; group=beta": http.StatusOK,
+				"group=BETA":              http.StatusNotFound,
+				"Group=beta":              http.StatusNotFound,
+				"other=beta":              http.StatusNotFound,
+			},
+		},
+		{
+			desc: "valid CookieRegexp matcher",
+			rule: "CookieRegexp(`group`, `^(alpha|beta)$`)",
+			expected: map[string]int{
+				"":              http.StatusNotFound,
+				"group=alpha":   http.StatusOK,
+				"group=beta":    http.StatusOK,
+				"group=gamma":   http.StatusNotFound,
+				"group=betamax": http.StatusNotFound,
+				"other=beta":    http.StatusNotFound,
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+			parser, err := NewSyntaxParser()
+			require.NoError(t, err)
+
+			muxer := NewMuxer(parser)
+
+			err = muxer.AddRoute(test.rule, "", 0, handler)
+			if test.expectedError {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+
+			for cookies, expected := range test.expected {
+				w := httptest.NewRecorder()
+
+				req := httptest.NewRequest(http.MethodGet, "https://example.com", http.NoBody)
+				if cookies != "" {
+					req.Header.Set("Cookie", cookies)
+				}
+
+				muxer.ServeHTTP(w, req)
+				assert.Equal(t, expected, w.Code, cookies)
+			}
+		})
+	}
+}
+
+func TestHTTPVersionMatcher(t *testing.T) {
+	testCases := []struct {
+		desc          string
+		rule          string
+		expected      map[string]int
+		expectedError bool
+	}{
+		{
+			desc:          "invalid HTTPVersion matcher (no parameter)",
+			rule:          "HTTPVersion()",
+			expectedError: true,
+		},
+		{
+			desc:          "invalid HTTPVersion matcher (unknown version)",
+			rule:          "HTTPVersion(`4`)",
+			expectedError: true,
+		},
+		{
+			desc: "valid HTTPVersion matcher (HTTP/1.1)",
+			rule: "HTTPVersion(`1.1`)",
+			expected: map[string]int{
+				"HTTP/1.0": http.StatusNotFound,
+				"HTTP/1.1": http.StatusOK,
+				"HTTP/2.0": http.StatusNotFound,
+			},
+		},
+		{
+			desc: "valid HTTPVersion matcher (HTTP/2)",
+			rule: "HTTPVersion(`2`)",
+			expected: map[string]int{
+				"HTTP/1.1": http.StatusNotFound,
+				"HTTP/2.0": http.StatusOK,
+				"HTTP/3.0": http.StatusNotFound,
+			},
+		},
+		{
+			desc: "valid HTTPVersion matcher (HTTP/3)",
+			rule: "HTTPVersion(`3`)",
+			expected: map[string]int{
+				"HTTP/2.0": http.StatusNotFound,
+				"HTTP/3.0": http.StatusOK,
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+			parser, err := NewSyntaxParser()
+			require.NoError(t, err)
+
+			muxer := NewMuxer(parser)
+
+			err = muxer.AddRoute(test.rule, "", 0, handler)
+			if test.expectedError {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+
+			for proto, expected := range test.expected {
+				w := httptest.NewRecorder()
+
+				req := httptest.NewRequest(http.MethodGet, "https://example.com", http.NoBody)
+
+				var ok bool
+				req.ProtoMajor, req.ProtoMinor, ok = http.ParseHTTPVersion(proto)
+				require.True(t, ok)
+
+				muxer.ServeHTTP(w, req)
+				assert.Equal(t, expected, w.Code, proto)
+			}
+		})
+	}
+}
+
+func TestTLSMatchers(t *testing.T) {
+	clientCert := &x509.Certificate{Subject: pkix.Name{CommonName: "client", Organization: []string{"Example"}}}
+
+	plain := httptest.NewRequest(http.MethodGet, "http://example.com", http.NoBody)
+
+	unverified := httptest.NewRequest(http.MethodGet, "https://example.com", http.NoBody)
+	unverified.TLS = &tls.ConnectionState{ServerName: "example.com", PeerCertificates: []*x509.Certificate{clientCert}}
+
+	verified := httptest.NewRequest(http.MethodGet, "https://example.com", http.NoBody)
+	verified.TLS = &tls.ConnectionState{
+		ServerName:       "example.com",
+		PeerCertificates: []*x509.Certificate{clientCert},
+		VerifiedChains:   [][]*x509.Certificate{{clientCert}},
+	}
+
+	testCases := []struct {
+		desc          string
+		rule          string
+		expected      map[*http.Request]int
+		expectedError bool
+	}{
+		{
+			desc:          "invalid TLS matcher (too many parameters)",
+			rule:          "TLS(`true`)",
+			expectedError: true,
+		},
+		{
+			desc:          "invalid SNI matcher (no parameter)",
+			rule:          "SNI()",
+			expectedError: true,
+		},
+		{
+			desc:          "invalid SNI matcher (non-ASCII)",
+			rule:          "SNI(`héhé.example.com`)",
+			expectedError: true,
+		},
+		{
+			desc:          "invalid ClientCertSubject matcher (no parameter)",
+			rule:          "ClientCertSubject()",
+			expectedError: true,
+		},
+		{
+			desc: "valid TLS matcher",
+			rule: "TLS()",
+			expected: map[*http.Request]int{
+				plain:    http.StatusNotFound,
+				verified: http.StatusOK,
+			},
+		},
+		{
+			desc: "valid negated TLS matcher",
+			rule: "!TLS()",
+			expected: map[*http.Request]int{
+				plain:    http.StatusOK,
+				verified: http.StatusNotFound,
+			},
+		},
+		{
+			desc: "valid SNI matcher",
+			rule: "SNI(`EXAMPLE.com`)",
+			expected: map[*http.Request]int{
+				plain:    http.StatusNotFound,
+				verified: http.StatusOK,
+			},
+		},
+		{
+			desc: "valid SNI matcher (other server name)",
+			rule: "SNI(`example.org`)",
+			expected: map[*http.Request]int{
+				verified: http.StatusNotFound,
+			},
+		},
+		{
+			desc: "valid ClientCertSubject matcher",
+			rule: "ClientCertSubject(`CN=client,O=Example`)",
+			expected: map[*http.Request]int{
+				plain:      http.StatusNotFound,
+				unverified: http.StatusNotFound,
+				verified:   http.StatusOK,
+			},
+		},
+		{
+			desc: "valid ClientCertSubject matcher (other subject)",
+			rule: "ClientCertSubject(`CN=other`)",
+			expected: map[*http.Request]int{
+				verified: http.StatusNotFound,
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+			parser, err := NewSyntaxParser()
+			require.NoError(t, err)
+
+			muxer := NewMuxer(parser)
+
+			err = muxer.AddRoute(test.rule, "", 0, handler)
+			if test.expectedError {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+
+			for req, expected := range test.expected {
+				w := httptest.NewRecorder()
+
+				muxer.ServeHTTP(w, req)
+				assert.Equal(t, expected, w.Code, req.URL.String())
+			}
+		})
+	}
+}
+
+func TestPortMatcher(t *testing.T) {
+	testCases := []struct {
+		desc          string
+		rule          string
+		expected      map[string]int
+		expectedError bool
+	}{
+		{
+			desc:          "invalid Port matcher (no parameter)",
+			rule:          "Port()",
+			expectedError: true,
+		},
+		{
+			desc:          "invalid Port matcher (not a number)",
+			rule:          "Port(`http`)",
+			expectedError: true,
+		},
+		{
+			desc:          "invalid Port matcher (out of range)",
+			rule:          "Port(`65536`)",
+			expectedError: true,
+		},
+		{
+			desc: "valid Port matcher",
+			rule: "Port(`8443`)",
+			expected: map[string]int{
+				"":                 http.StatusNotFound,
+				"127.0.0.1:8443":   http.StatusOK,
+				"[::1]:8443":       http.StatusOK,
+				"127.0.0.1:443":    http.StatusNotFound,
+				"10.10.10.10:8443": http.StatusOK,
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+			parser, err := NewSyntaxParser()
+			require.NoError(t, err)
+
+			muxer := NewMuxer(parser)
+
+			err = muxer.AddRoute(test.rule, "", 0, handler)
+			if test.expectedError {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+
+			for localAddr, expected := range test.expected {
+				w := httptest.NewRecorder()
+
+				// The request host port is not the one used by the matcher.
+				req := httptest.NewRequest(http.MethodGet, "https://example.com:8443", http.NoBody)
+				if localAddr != "" {
+					addr, err := net.ResolveTCPAddr("tcp", localAddr)
+					require.NoError(t, err)
+
+					req = req.WithContext(context.WithValue(req.Context(), http.LocalAddrContextKey, addr))
+				}
+
+				muxer.ServeHTTP(w, req)
+				assert.Equal(t, expected, w.Code, localAddr)
+			}
+		})
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"slices"
 	"sort"
 	"strings"
 
@@ -72,12 +73,18 @@ func GetRulePriority(rule string) int {
 
 // AddRoute add a new route to the router.
 func (m *Muxer) AddRoute(rule string, syntax string, priority int, handler http.Handler) error {
+	return m.AddNamedRoute("", rule, syntax, priority, handler)
+}
+
+// AddNamedRoute adds a new route to the router, identified by the given name when explaining the routing.
+func (m *Muxer) AddNamedRoute(name, rule string, syntax string, priority int, handler http.Handler) error {
 	matchers, err := m.parser.parse(syntax, rule)
 	if err != nil {
 		return fmt.Errorf("error while parsing rule %s: %w", rule, err)
 	}
 
 	m.routes = append(m.routes, &route{
+		name:     name,
 		handler:  handler,
 		matchers: matchers,
 		priority: priority,
@@ -88,6 +95,38 @@ func (m *Muxer) AddRoute(rule string, syntax string, priority int, handler http.
 	return nil
 }
 
+// RouteExplanation describes the evaluation of a route against a request.
+type RouteExplanation struct {
+	Name     string
+	Priority int
+	Matched  bool
+	// FailedMatchers are the matchers which prevented the route from matching.
+	FailedMatchers []string
+}
+
+// Explain evaluates the routes against the request, in the order used to serve it.
+// The request is served by the first matching route.
+func (m *Muxer) Explain(req *http.Request) ([]RouteExplanation, error) {
+	req, err := withRoutingPath(req)
+	if err != nil {
+		return nil, fmt.Errorf("adding routing path: %w", err)
+	}
+
+	var explanations []RouteExplanation
+	for _, route := range m.routes {
+		matched, failures := route.matchers.explain(req)
+
+		explanations = append(explanations, RouteExplanation{
+			Name:           route.name,
+			Priority:       route.priority,
+			Matched:        matched,
+			FailedMatchers: failures,
+		})
+	}
+
+	return explanations, nil
+}
+
 func (m *Muxer) Parse(expr string) (*Matcher, error) {
 	matcher, err := m.parser.parse("", expr)
 	if nil != err {
@@ -227,6 +266,8 @@ func (r routes) Less(i, j int) bool { return r[i].priority > r[j].priority }
 // route holds the matchers to match HTTP route,
 // and the handler that will serve the request.
 type route struct {
+	// name identifies the route when explaining the routing.
+	name string
 	// matchers tree structure reflecting the rule.
 	matchers matchersTree
 	// handler responsible for handling the route.
@@ -242,6 +283,8 @@ type matchersTree struct {
 	// If matcher is not nil, it means that this matcherTree is a leaf of the tree.
 	// It is therefore mutually exclusive with left and right.
 	matcher MatcherFunc
+	// rule is the textual representation of the matcher, used to explain the evaluation of the tree.
+	rule string
 	// operator to combine the evaluation of left and right leaves.
 	operator string
 	// Mutually exclusive with matcher.
@@ -272,6 +315,34 @@ func (m *matchersTree) match(req *http.Request) bool {
 	}
 }
 
+// explain evaluates the tree against the request, like match,
+// and returns the leaf matchers which prevented the tree from matching.
+func (m *matchersTree) explain(req *http.Request) (bool, []string) {
+	if m.matcher != nil {
+		if m.matcher(req) {
+			return true, nil
+		}
+
+		return false, []string{m.rule}
+	}
+
+	leftMatch, leftFailures := m.left.explain(req)
+	rightMatch, rightFailures := m.right.explain(req)
+
+	switch m.operator {
+	case "or":
+		if leftMatch || rightMatch {
+			return true, nil
+		}
+	case "and":
+		if leftMatch && rightMatch {
+			return true, nil
+		}
+	}
+
+	return false, append(leftFailures, rightFailures...)
+}
+
 func (m *matchersTree) addRule(rule *rules.Tree, funcs matcherBuilderFuncs) error {
 	switch rule.Matcher {
 	case "and", "or":
@@ -285,12 +356,14 @@ func (m *matchersTree) addRule(rule *rules.Tree, funcs matcherBuilderFuncs) erro
 		m.right = &matchersTree{}
 		return m.right.addRule(rule.RuleRight, funcs)
 	default:
-		err := rules.CheckRule(rule)
-		if err != nil {
-			return fmt.Errorf("error while checking rule %s: %w", rule.Matcher, err)
+		if len(rule.Value) > 0 || !slices.Contains(noParameterMatchers, rule.Matcher) {
+			err := rules.CheckRule(rule)
+			if err != nil {
+				return fmt.Errorf("error while checking rule %s: %w", rule.Matcher, err)
+			}
 		}
 
-		err = funcs[rule.Matcher](m, rule.Value...)
+		err := funcs[rule.Matcher](m, rule.Value...)
 		if err != nil {
 			return fmt.Errorf("error while adding rule %s: %w", rule.Matcher, err)
 		}
@@ -301,6 +374,8 @@ func (m *matchersTree) addRule(rule *rules.Tree, funcs matcherBuilderFuncs) erro
 				return !matcherFunc(req)
 			}
 		}
+
+		m.rule = rule.String()
 	}
 
 	return nil
@@ -262,6 +262,33 @@ func TestMuxer(t *testing.T) {
 	}
 }
 
+func TestMuxer_Explain(t *testing.T) {
+	parser, err := NewSyntaxParser()
+	require.NoError(t, err)
+
+	muxer := NewMuxer(parser)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+	require.NoError(t, muxer.AddNamedRoute("api", "Host(`example.com`) && PathPrefix(`/api`)", "", 0, handler))
+	require.NoError(t, muxer.AddNamedRoute("host", "Host(`example.com`)", "", 1, handler))
+	require.NoError(t, muxer.AddNamedRoute("other", "Host(`example.org`) || Method(`POST`)", "", 2, handler))
+
+	req := testhelpers.MustNewRequest(http.MethodGet, "http://example.com/foo", http.NoBody)
+
+	var explanations []RouteExplanation
+	requestdecorator.New(nil).ServeHTTP(httptest.NewRecorder(), req, func(_ http.ResponseWriter, req *http.Request) {
+		explanations, err = muxer.Explain(req)
+	})
+	require.NoError(t, err)
+
+	expected := []RouteExplanation{
+		{Name: "other", Priority: 2, FailedMatchers: []string{"Host(`example.org`)", "Method(`POST`)"}},
+		{Name: "host", Priority: 1, Matched: true},
+		{Name: "api", FailedMatchers: []string{"PathPrefix(`/api`)"}},
+	}
+	assert.Equal(t, expected, explanations)
+}
+
 func Test_addRoutePriority(t *testing.T) {
 	type Case struct {
 		xFrom    string
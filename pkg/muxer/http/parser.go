@@ -7,6 +7,7 @@ import (
 	"slices"
 	"strings"
 
+	"github.com/traefik/traefik/v3/pkg/geoip"
 	"github.com/traefik/traefik/v3/pkg/rules"
 	"github.com/vulcand/predicate"
 )
@@ -33,6 +34,21 @@ func WithMatcher(syntax, matcherName string, builderFunc func(params ...string)
 	}
 }
 
+// WithGeoIP enables the ClientCountry and ClientASN matchers, resolving the GeoIP data of the client IPs with the given resolver.
+func WithGeoIP(resolver *geoip.Resolver) Options {
+	return func(syntaxFuncs map[string]matcherBuilderFuncs) {
+		if resolver == nil {
+			return
+		}
+
+		// The matchers are shared by all the parsers, so they are copied before being overridden.
+		funcs := maps.Clone(syntaxFuncs["v3"])
+		funcs["ClientCountry"] = expectNParameters(clientCountry(resolver), 1)
+		funcs["ClientASN"] = expectNParameters(clientASN(resolver), 1)
+		syntaxFuncs["v3"] = funcs
+	}
+}
+
 func NewSyntaxParser(opts ...Options) (SyntaxParser, error) {
 	syntaxFuncs := map[string]matcherBuilderFuncs{
 		"v2": httpFuncsV2,
@@ -1,7 +1,9 @@
 package tcp
 
 import (
+	"errors"
 	"fmt"
+	"net"
 	"regexp"
 	"slices"
 	"strconv"
@@ -10,12 +12,15 @@ import (
 
 	"github.com/go-acme/lego/v4/challenge/tlsalpn01"
 	"github.com/rs/zerolog/log"
+	"github.com/traefik/traefik/v3/pkg/geoip"
 	"github.com/traefik/traefik/v3/pkg/ip"
 )
 
 var tcpFuncs = map[string]func(*matchersTree, ...string) error{
 	"ALPN":          expect1Parameter(alpn),
 	"ClientIP":      expect1Parameter(clientIP),
+	"ClientCountry": expect1Parameter(clientCountry(nil)),
+	"ClientASN":     expect1Parameter(clientASN(nil)),
 	"HostSNI":       expect1Parameter(hostSNI),
 	"HostSNIRegexp": expect1Parameter(hostSNIRegexp),
 	"PortRegexp":    expect1Parameter(portRegexp),
@@ -64,6 +69,47 @@ func clientIP(tree *matchersTree, clientIP ...string) error {
 	return nil
 }
 
+// clientCountry returns the ClientCountry matcher builder, resolving the country of the client IPs with the given resolver.
+func clientCountry(resolver *geoip.Resolver) func(*matchersTree, ...string) error {
+	return func(tree *matchersTree, countries ...string) error {
+		if resolver == nil || !resolver.HasCountry() {
+			return errors.New("ClientCountry matcher: the GeoIP country database is not configured")
+		}
+
+		country := strings.ToUpper(strings.TrimSpace(countries[0]))
+		if len(country) != 2 {
+			return fmt.Errorf("invalid value %q for ClientCountry matcher, an ISO 3166-1 alpha-2 country code is expected", countries[0])
+		}
+
+		tree.matcher = func(meta ConnData) bool {
+			return resolver.Country(net.ParseIP(meta.remoteIP)) == country
+		}
+
+		return nil
+	}
+}
+
+// clientASN returns the ClientASN matcher builder, resolving the autonomous system of the client IPs with the given resolver.
+func clientASN(resolver *geoip.Resolver) func(*matchersTree, ...string) error {
+	return func(tree *matchersTree, asns ...string) error {
+		if resolver == nil || !resolver.HasASN() {
+			return errors.New("ClientASN matcher: the GeoIP ASN database is not configured")
+		}
+
+		asn, err := geoip.ParseASN(asns[0])
+		if err != nil {
+			return fmt.Errorf("ClientASN matcher: %w", err)
+		}
+
+		tree.matcher = func(meta ConnData) bool {
+			connASN, _ := resolver.AutonomousSystem(net.ParseIP(meta.remoteIP))
+			return connASN != 0 && connASN == asn
+		}
+
+		return nil
+	}
+}
+
 func portRegexp(tree *matchersTree, ports ...string) error {
 	var ranges [][2]int
 	for _, port := range ports {
@@ -1,10 +1,14 @@
 package tcp
 
 import (
+	"path/filepath"
 	"testing"
 
+	"github.com/maxmind/mmdbwriter/mmdbtype"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/traefik/traefik/v3/pkg/geoip"
+	"github.com/traefik/traefik/v3/pkg/geoip/geoiptest"
 	"github.com/traefik/traefik/v3/pkg/tcp"
 )
 
@@ -320,6 +324,84 @@ func Test_ClientIP(t *testing.T) {
 	}
 }
 
+func Test_GeoIP(t *testing.T) {
+	dir := t.TempDir()
+
+	countryDB := filepath.Join(dir, "country.mmdb")
+	geoiptest.WriteDatabase(t, countryDB, map[string]mmdbtype.Map{
+		"20.20.20.0/24": {"country": mmdbtype.Map{"iso_code": mmdbtype.String("FR")}},
+	})
+
+	asnDB := filepath.Join(dir, "asn.mmdb")
+	geoiptest.WriteDatabase(t, asnDB, map[string]mmdbtype.Map{
+		"20.20.0.0/16": {"autonomous_system_number": mmdbtype.Uint32(64500)},
+	})
+
+	resolver, err := geoip.NewResolver(geoip.Config{CountryDatabase: countryDB, ASNDatabase: asnDB})
+	require.NoError(t, err)
+
+	testCases := []struct {
+		desc     string
+		rule     string
+		expected map[string]bool
+		buildErr bool
+	}{
+		{
+			desc:     "Invalid ClientCountry matcher",
+			rule:     "ClientCountry(`France`)",
+			buildErr: true,
+		},
+		{
+			desc:     "Invalid ClientASN matcher",
+			rule:     "ClientASN(`foo`)",
+			buildErr: true,
+		},
+		{
+			desc: "valid ClientCountry matcher",
+			rule: "ClientCountry(`FR`)",
+			expected: map[string]bool{
+				"20.20.20.20": true,
+				"20.20.30.20": false,
+				"10.10.10.10": false,
+			},
+		},
+		{
+			desc: "valid ClientASN matcher",
+			rule: "ClientASN(`AS64500`)",
+			expected: map[string]bool{
+				"20.20.20.20": true,
+				"20.20.30.20": true,
+				"10.10.10.10": false,
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			muxer, err := NewMuxer(WithGeoIP(resolver))
+			require.NoError(t, err)
+
+			err = muxer.AddRoute(test.rule, "", 0, tcp.HandlerFunc(func(conn tcp.WriteCloser) {}))
+			if test.buildErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			for remoteIP, match := range test.expected {
+				meta := ConnData{
+					remoteIP: remoteIP,
+				}
+
+				handler, _ := muxer.Match(meta)
+				assert.Equal(t, match, handler != nil, remoteIP)
+			}
+		})
+	}
+}
+
 func Test_ALPN(t *testing.T) {
 	testCases := []struct {
 		desc     string
@@ -2,12 +2,14 @@ package tcp
 
 import (
 	"fmt"
+	"maps"
 	"net"
 	"sort"
 	"strconv"
 	"strings"
 
 	"github.com/rs/zerolog/log"
+	"github.com/traefik/traefik/v3/pkg/geoip"
 	"github.com/traefik/traefik/v3/pkg/rules"
 	"github.com/traefik/traefik/v3/pkg/tcp"
 	"github.com/traefik/traefik/v3/pkg/types"
@@ -67,10 +69,26 @@ type Muxer struct {
 	routes   routes
 	parser   predicate.Parser
 	parserV2 predicate.Parser
+	funcs    map[string]func(*matchersTree, ...string) error
+}
+
+// Option configures the muxer.
+type Option func(*Muxer)
+
+// WithGeoIP enables the ClientCountry and ClientASN matchers, resolving the GeoIP data of the client IPs with the given resolver.
+func WithGeoIP(resolver *geoip.Resolver) Option {
+	return func(m *Muxer) {
+		if resolver == nil {
+			return
+		}
+
+		m.funcs["ClientCountry"] = expect1Parameter(clientCountry(resolver))
+		m.funcs["ClientASN"] = expect1Parameter(clientASN(resolver))
+	}
 }
 
 // NewMuxer returns a TCP muxer.
-func NewMuxer() (*Muxer, error) {
+func NewMuxer(opts ...Option) (*Muxer, error) {
 	var matcherNames []string
 	for matcherName := range tcpFuncs {
 		matcherNames = append(matcherNames, matcherName)
@@ -91,10 +109,17 @@ func NewMuxer() (*Muxer, error) {
 		return nil, fmt.Errorf("error while creating v2 rules parser: %w", err)
 	}
 
-	return &Muxer{
+	muxer := &Muxer{
 		parser:   parser,
 		parserV2: parserV2,
-	}, nil
+		funcs:    maps.Clone(tcpFuncs),
+	}
+
+	for _, opt := range opts {
+		opt(muxer)
+	}
+
+	return muxer, nil
 }
 
 // Match returns the handler of the first route matching the connection metadata,
@@ -144,6 +169,12 @@ func GetRulePriority(rule string) int {
 // AddRoute adds a new route, associated to the given handler, at the given
 // priority, to the muxer.
 func (m *Muxer) AddRoute(rule string, syntax string, priority int, handler tcp.Handler) error {
+	return m.AddNamedRoute("", rule, syntax, priority, handler)
+}
+
+// AddNamedRoute adds a new route, associated to the given handler, at the given
+// priority, to the muxer. The route is identified by the given name when explaining the routing.
+func (m *Muxer) AddNamedRoute(name, rule string, syntax string, priority int, handler tcp.Handler) error {
 	var parse any
 	var err error
 	var matcherFuncs map[string]func(*matchersTree, ...string) error
@@ -162,7 +193,7 @@ func (m *Muxer) AddRoute(rule string, syntax string, priority int, handler tcp.H
 			return fmt.Errorf("error while parsing rule %s: %w", rule, err)
 		}
 
-		matcherFuncs = tcpFuncs
+		matcherFuncs = m.funcs
 	}
 
 	buildTree, ok := parse.(rules.TreeBuilder)
@@ -184,6 +215,7 @@ func (m *Muxer) AddRoute(rule string, syntax string, priority int, handler tcp.H
 	}
 
 	newRoute := &route{
+		name:     name,
 		handler:  handler,
 		matchers: matchers,
 		catchAll: catchAll,
@@ -196,6 +228,35 @@ func (m *Muxer) AddRoute(rule string, syntax string, priority int, handler tcp.H
 	return nil
 }
 
+// RouteExplanation describes the evaluation of a route against the connection metadata.
+type RouteExplanation struct {
+	Name     string
+	Priority int
+	// CatchAll indicates whether the route rule has exactly the catchAll value (HostSNI(`*`)).
+	CatchAll bool
+	Matched  bool
+	// FailedMatchers are the matchers which prevented the route from matching.
+	FailedMatchers []string
+}
+
+// Explain evaluates the routes against the connection metadata, in the order used by Match.
+func (m *Muxer) Explain(meta ConnData) []RouteExplanation {
+	var explanations []RouteExplanation
+	for _, route := range m.routes {
+		matched, failures := route.matchers.explain(meta)
+
+		explanations = append(explanations, RouteExplanation{
+			Name:           route.name,
+			Priority:       route.priority,
+			CatchAll:       route.catchAll,
+			Matched:        matched,
+			FailedMatchers: failures,
+		})
+	}
+
+	return explanations
+}
+
 // HasRoutes returns whether the muxer has routes.
 func (m *Muxer) HasRoutes() bool {
 	return len(m.routes) > 0
@@ -214,7 +275,7 @@ func (m *Muxer) Parse(rule string) (*Matcher, error) {
 	}
 	ruleTree := buildTree()
 	var matchers matchersTree
-	err = matchers.addRule(ruleTree, tcpFuncs)
+	err = matchers.addRule(ruleTree, m.funcs)
 	if err != nil {
 		return nil, fmt.Errorf("error while adding rule %s: %w", rule, err)
 	}
@@ -282,6 +343,8 @@ func (r routes) Less(i, j int) bool { return r[i].priority > r[j].priority }
 // route holds the matchers to match TCP route,
 // and the handler that will serve the connection.
 type route struct {
+	// name identifies the route when explaining the routing.
+	name string
 	// matchers tree structure reflecting the rule.
 	matchers matchersTree
 	// handler responsible for handling the route.
@@ -300,6 +363,8 @@ type matchersTree struct {
 	// If matcher is not nil, it means that this matcherTree is a leaf of the tree.
 	// It is therefore mutually exclusive with left and right.
 	matcher func(ConnData) bool
+	// rule is the textual representation of the matcher, used to explain the evaluation of the tree.
+	rule string
 	// operator to combine the evaluation of left and right leaves.
 	operator string
 	// Mutually exclusive with matcher.
@@ -330,6 +395,34 @@ func (m *matchersTree) match(meta ConnData) bool {
 	}
 }
 
+// explain evaluates the tree against the connection metadata, like match,
+// and returns the leaf matchers which prevented the tree from matching.
+func (m *matchersTree) explain(meta ConnData) (bool, []string) {
+	if m.matcher != nil {
+		if m.matcher(meta) {
+			return true, nil
+		}
+
+		return false, []string{m.rule}
+	}
+
+	leftMatch, leftFailures := m.left.explain(meta)
+	rightMatch, rightFailures := m.right.explain(meta)
+
+	switch m.operator {
+	case "or":
+		if leftMatch || rightMatch {
+			return true, nil
+		}
+	case "and":
+		if leftMatch && rightMatch {
+			return true, nil
+		}
+	}
+
+	return false, append(leftFailures, rightFailures...)
+}
+
 type matcherFuncs map[string]func(*matchersTree, ...string) error
 
 func (m *matchersTree) addRule(rule *rules.Tree, funcs matcherFuncs) error {
@@ -361,6 +454,8 @@ func (m *matchersTree) addRule(rule *rules.Tree, funcs matcherFuncs) error {
 				return !matcherFunc(meta)
 			}
 		}
+
+		m.rule = rule.String()
 	}
 
 	return nil
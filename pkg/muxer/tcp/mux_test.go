@@ -458,6 +458,23 @@ func Test_Priority(t *testing.T) {
 	}
 }
 
+func TestMuxer_Explain(t *testing.T) {
+	muxer, err := NewMuxer()
+	require.NoError(t, err)
+
+	handler := tcp.HandlerFunc(func(conn tcp.WriteCloser) {})
+	require.NoError(t, muxer.AddNamedRoute("sni", "HostSNI(`example.com`) && ALPN(`h2`)", "", 0, handler))
+	require.NoError(t, muxer.AddNamedRoute("catchall", "HostSNI(`*`)", "", -1, handler))
+
+	explanations := muxer.Explain(ConnData{serverName: "example.com", alpnProtos: []string{"http/1.1"}})
+
+	expected := []RouteExplanation{
+		{Name: "sni", FailedMatchers: []string{"ALPN(`h2`)"}},
+		{Name: "catchall", Priority: -1, CatchAll: true, Matched: true},
+	}
+	assert.Equal(t, expected, explanations)
+}
+
 func TestGetRulePriority(t *testing.T) {
 	testCases := []struct {
 		desc     string
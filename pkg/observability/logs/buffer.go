@@ -0,0 +1,107 @@
+package logs
+
+import (
+	"encoding/json"
+	"slices"
+	"sync"
+
+	"github.com/rs/zerolog"
+)
+
+// Entry is a log entry kept by a Buffer.
+// Only a fixed set of fields is kept,
+// so that the entries do not expose the configuration some log messages are carrying.
+type Entry struct {
+	Time           string `json:"time,omitempty" export:"true"`
+	Level          string `json:"level,omitempty" export:"true"`
+	Message        string `json:"message,omitempty" export:"true"`
+	Error          string `json:"error,omitempty" export:"true"`
+	ProviderName   string `json:"providerName,omitempty" export:"true"`
+	EntryPointName string `json:"entryPointName,omitempty" export:"true"`
+	RouterName     string `json:"routerName,omitempty" export:"true"`
+	ServiceName    string `json:"serviceName,omitempty" export:"true"`
+	MiddlewareName string `json:"middlewareName,omitempty" export:"true"`
+}
+
+// Buffer is an in-memory ring buffer of the last log entries,
+// which also keeps the last errors reported by the providers.
+// It is meant to be used as a writer of JSON log entries, such as the ones written by zerolog.
+type Buffer struct {
+	lock           sync.Mutex
+	entries        ring
+	providerErrors ring
+}
+
+// NewBuffer creates a Buffer keeping the given number of log entries, and of provider errors.
+func NewBuffer(size int) *Buffer {
+	return &Buffer{
+		entries:        ring{size: size},
+		providerErrors: ring{size: size},
+	}
+}
+
+// Write stores the given JSON log entry.
+// Entries which cannot be decoded are ignored.
+func (b *Buffer) Write(p []byte) (int, error) {
+	var entry Entry
+	if err := json.Unmarshal(p, &entry); err != nil {
+		return len(p), nil
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	b.entries.add(entry)
+
+	if entry.ProviderName != "" && entry.Level == zerolog.LevelErrorValue {
+		b.providerErrors.add(entry)
+	}
+
+	return len(p), nil
+}
+
+// Entries returns the last n log entries, from the oldest to the newest,
+// or all the kept entries if n is not positive.
+func (b *Buffer) Entries(n int) []Entry {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	return b.entries.last(n)
+}
+
+// ProviderErrors returns the last errors reported by the providers, from the oldest to the newest.
+func (b *Buffer) ProviderErrors() []Entry {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	return b.providerErrors.last(0)
+}
+
+type ring struct {
+	size    int
+	start   int
+	entries []Entry
+}
+
+func (r *ring) add(entry Entry) {
+	if r.size <= 0 {
+		return
+	}
+
+	if len(r.entries) < r.size {
+		r.entries = append(r.entries, entry)
+		return
+	}
+
+	r.entries[r.start] = entry
+	r.start = (r.start + 1) % r.size
+}
+
+func (r *ring) last(n int) []Entry {
+	entries := append(slices.Clone(r.entries[r.start:]), r.entries[:r.start]...)
+	if n > 0 && n < len(entries) {
+		return entries[len(entries)-n:]
+	}
+
+	return entries
+}
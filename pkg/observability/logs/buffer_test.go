@@ -0,0 +1,43 @@
+package logs
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuffer(t *testing.T) {
+	buffer := NewBuffer(3)
+	logger := zerolog.New(buffer)
+
+	logger.Info().Msg("first")
+	logger.Error().Str(ProviderName, "docker").Err(errors.New("connection refused")).Msg("Provider error")
+	logger.Debug().RawJSON("config", []byte(`{"secret":"value"}`)).Msg("Configuration received")
+	logger.Warn().Str(RouterName, "foo@file").Msg("last")
+
+	assert.Equal(t, []Entry{
+		{Level: "error", Message: "Provider error", Error: "connection refused", ProviderName: "docker"},
+		{Level: "debug", Message: "Configuration received"},
+		{Level: "warn", Message: "last", RouterName: "foo@file"},
+	}, buffer.Entries(0))
+
+	assert.Equal(t, []Entry{
+		{Level: "warn", Message: "last", RouterName: "foo@file"},
+	}, buffer.Entries(1))
+
+	assert.Equal(t, []Entry{
+		{Level: "error", Message: "Provider error", Error: "connection refused", ProviderName: "docker"},
+	}, buffer.ProviderErrors())
+}
+
+func TestBuffer_invalidEntry(t *testing.T) {
+	buffer := NewBuffer(3)
+
+	n, err := buffer.Write([]byte("not json"))
+	assert.NoError(t, err)
+	assert.Equal(t, 8, n)
+
+	assert.Empty(t, buffer.Entries(0))
+}
@@ -30,9 +30,11 @@ var (
 const (
 	ddConfigReloadsName           = "config.reload.total"
 	ddLastConfigReloadSuccessName = "config.reload.lastSuccessTimestamp"
+	ddConfigFileErrorsName        = "config.file.errors"
 	ddOpenConnsName               = "open.connections"
 
-	ddTLSCertsNotAfterTimestampName = "tls.certs.notAfterTimestamp"
+	ddTLSCertsNotAfterTimestampName     = "tls.certs.notAfterTimestamp"
+	ddTLSClientCertRevocationChecksName = "tls.clientcert.revocation.checks.total"
 
 	ddEntryPointReqsName        = "entrypoint.request.total"
 	ddEntryPointReqsTLSName     = "entrypoint.request.tls.total"
@@ -46,13 +48,17 @@ const (
 	ddRouterReqsBytesName    = "router.requests.bytes.total"
 	ddRouterRespsBytesName   = "router.responses.bytes.total"
 
-	ddServiceReqsName         = "service.request.total"
-	ddServiceReqsTLSName      = "service.request.tls.total"
-	ddServiceReqsDurationName = "service.request.duration"
-	ddServiceRetriesName      = "service.retries.total"
-	ddServiceServerUpName     = "service.server.up"
-	ddServiceReqsBytesName    = "service.requests.bytes.total"
-	ddServiceRespsBytesName   = "service.responses.bytes.total"
+	ddServiceReqsName              = "service.request.total"
+	ddServiceReqsTLSName           = "service.request.tls.total"
+	ddServiceReqsDurationName      = "service.request.duration"
+	ddServiceRetriesName           = "service.retries.total"
+	ddServiceServerUpName          = "service.server.up"
+	ddServiceReqsBytesName         = "service.requests.bytes.total"
+	ddServiceRespsBytesName        = "service.responses.bytes.total"
+	ddServiceMirrorComparisonsName = "service.mirror.comparisons.total"
+
+	ddMiddlewareOutcomesName            = "middleware.outcomes.total"
+	ddMiddlewareCircuitBreakerStateName = "middleware.circuitbreaker.state"
 )
 
 // RegisterDatadog registers the metrics pusher if this didn't happen yet and creates a datadog Registry instance.
@@ -71,10 +77,12 @@ func RegisterDatadog(ctx context.Context, config *otypes.Datadog) Registry {
 	initDatadogClient(ctx, config, datadogLogger)
 
 	registry := &standardRegistry{
-		configReloadsCounter:           datadogClient.NewCounter(ddConfigReloadsName, 1.0),
-		lastConfigReloadSuccessGauge:   datadogClient.NewGauge(ddLastConfigReloadSuccessName),
-		openConnectionsGauge:           datadogClient.NewGauge(ddOpenConnsName),
-		tlsCertsNotAfterTimestampGauge: datadogClient.NewGauge(ddTLSCertsNotAfterTimestampName),
+		configReloadsCounter:                 datadogClient.NewCounter(ddConfigReloadsName, 1.0),
+		lastConfigReloadSuccessGauge:         datadogClient.NewGauge(ddLastConfigReloadSuccessName),
+		configFileErrorsGauge:                datadogClient.NewGauge(ddConfigFileErrorsName),
+		openConnectionsGauge:                 datadogClient.NewGauge(ddOpenConnsName),
+		tlsCertsNotAfterTimestampGauge:       datadogClient.NewGauge(ddTLSCertsNotAfterTimestampName),
+		tlsClientCertRevocationChecksCounter: datadogClient.NewCounter(ddTLSClientCertRevocationChecksName, 1.0),
 	}
 
 	if config.AddEntryPointsLabels {
@@ -104,6 +112,13 @@ func RegisterDatadog(ctx context.Context, config *otypes.Datadog) Registry {
 		registry.serviceServerUpGauge = datadogClient.NewGauge(ddServiceServerUpName)
 		registry.serviceReqsBytesCounter = datadogClient.NewCounter(ddServiceReqsBytesName, 1.0)
 		registry.serviceRespsBytesCounter = datadogClient.NewCounter(ddServiceRespsBytesName, 1.0)
+		registry.serviceMirrorComparisonsCounter = datadogClient.NewCounter(ddServiceMirrorComparisonsName, 1.0)
+	}
+
+	if config.AddMiddlewaresLabels {
+		registry.middlewareEnabled = config.AddMiddlewaresLabels
+		registry.middlewareOutcomesCounter = datadogClient.NewCounter(ddMiddlewareOutcomesName, 1.0)
+		registry.middlewareCircuitBreakerStateGauge = datadogClient.NewGauge(ddMiddlewareCircuitBreakerStateName)
 	}
 
 	return registry
@@ -27,9 +27,11 @@ var (
 const (
 	influxDBConfigReloadsName           = "traefik.config.reload.total"
 	influxDBLastConfigReloadSuccessName = "traefik.config.reload.lastSuccessTimestamp"
+	influxDBConfigFileErrorsName        = "traefik.config.file.errors"
 	influxDBOpenConnsName               = "traefik.open.connections"
 
-	influxDBTLSCertsNotAfterTimestampName = "traefik.tls.certs.notAfterTimestamp"
+	influxDBTLSCertsNotAfterTimestampName     = "traefik.tls.certs.notAfterTimestamp"
+	influxDBTLSClientCertRevocationChecksName = "traefik.tls.clientcert.revocation.checks.total"
 
 	influxDBEntryPointReqsName        = "traefik.entrypoint.requests.total"
 	influxDBEntryPointReqsTLSName     = "traefik.entrypoint.requests.tls.total"
@@ -43,13 +45,17 @@ const (
 	influxDBRouterReqsBytesName    = "traefik.router.requests.bytes.total"
 	influxDBRouterRespsBytesName   = "traefik.router.responses.bytes.total"
 
-	influxDBServiceReqsName         = "traefik.service.requests.total"
-	influxDBServiceReqsTLSName      = "traefik.service.requests.tls.total"
-	influxDBServiceReqsDurationName = "traefik.service.request.duration"
-	influxDBServiceRetriesTotalName = "traefik.service.retries.total"
-	influxDBServiceServerUpName     = "traefik.service.server.up"
-	influxDBServiceReqsBytesName    = "traefik.service.requests.bytes.total"
-	influxDBServiceRespsBytesName   = "traefik.service.responses.bytes.total"
+	influxDBServiceReqsName              = "traefik.service.requests.total"
+	influxDBServiceReqsTLSName           = "traefik.service.requests.tls.total"
+	influxDBServiceReqsDurationName      = "traefik.service.request.duration"
+	influxDBServiceRetriesTotalName      = "traefik.service.retries.total"
+	influxDBServiceServerUpName          = "traefik.service.server.up"
+	influxDBServiceReqsBytesName         = "traefik.service.requests.bytes.total"
+	influxDBServiceRespsBytesName        = "traefik.service.responses.bytes.total"
+	influxDBServiceMirrorComparisonsName = "traefik.service.mirror.comparisons.total"
+
+	influxDBMiddlewareOutcomesName            = "traefik.middleware.outcomes.total"
+	influxDBMiddlewareCircuitBreakerStateName = "traefik.middleware.circuitbreaker.state"
 )
 
 // RegisterInfluxDB2 creates metrics exporter for InfluxDB2.
@@ -80,10 +86,12 @@ func RegisterInfluxDB2(ctx context.Context, config *otypes.InfluxDB2) Registry {
 	}
 
 	registry := &standardRegistry{
-		configReloadsCounter:           influxDB2Store.NewCounter(influxDBConfigReloadsName),
-		lastConfigReloadSuccessGauge:   influxDB2Store.NewGauge(influxDBLastConfigReloadSuccessName),
-		openConnectionsGauge:           influxDB2Store.NewGauge(influxDBOpenConnsName),
-		tlsCertsNotAfterTimestampGauge: influxDB2Store.NewGauge(influxDBTLSCertsNotAfterTimestampName),
+		configReloadsCounter:                 influxDB2Store.NewCounter(influxDBConfigReloadsName),
+		lastConfigReloadSuccessGauge:         influxDB2Store.NewGauge(influxDBLastConfigReloadSuccessName),
+		configFileErrorsGauge:                influxDB2Store.NewGauge(influxDBConfigFileErrorsName),
+		openConnectionsGauge:                 influxDB2Store.NewGauge(influxDBOpenConnsName),
+		tlsCertsNotAfterTimestampGauge:       influxDB2Store.NewGauge(influxDBTLSCertsNotAfterTimestampName),
+		tlsClientCertRevocationChecksCounter: influxDB2Store.NewCounter(influxDBTLSClientCertRevocationChecksName),
 	}
 
 	if config.AddEntryPointsLabels {
@@ -113,6 +121,13 @@ func RegisterInfluxDB2(ctx context.Context, config *otypes.InfluxDB2) Registry {
 		registry.serviceServerUpGauge = influxDB2Store.NewGauge(influxDBServiceServerUpName)
 		registry.serviceReqsBytesCounter = influxDB2Store.NewCounter(influxDBServiceReqsBytesName)
 		registry.serviceRespsBytesCounter = influxDB2Store.NewCounter(influxDBServiceRespsBytesName)
+		registry.serviceMirrorComparisonsCounter = influxDB2Store.NewCounter(influxDBServiceMirrorComparisonsName)
+	}
+
+	if config.AddMiddlewaresLabels {
+		registry.middlewareEnabled = config.AddMiddlewaresLabels
+		registry.middlewareOutcomesCounter = influxDB2Store.NewCounter(influxDBMiddlewareOutcomesName)
+		registry.middlewareCircuitBreakerStateGauge = influxDB2Store.NewGauge(influxDBMiddlewareCircuitBreakerStateName)
 	}
 
 	return registry
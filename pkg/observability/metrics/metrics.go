@@ -18,16 +18,20 @@ type Registry interface {
 	IsRouterEnabled() bool
 	// IsSvcEnabled shows whether metrics instrumentation is enabled on services.
 	IsSvcEnabled() bool
+	// IsMiddlewareEnabled shows whether metrics instrumentation is enabled on middlewares.
+	IsMiddlewareEnabled() bool
 
 	// server metrics
 
 	ConfigReloadsCounter() metrics.Counter
 	LastConfigReloadSuccessGauge() metrics.Gauge
+	ConfigFileErrorsGauge() metrics.Gauge
 	OpenConnectionsGauge() metrics.Gauge
 
 	// TLS
 
 	TLSCertsNotAfterTimestampGauge() metrics.Gauge
+	TLSClientCertRevocationChecksCounter() metrics.Counter
 
 	// entry point metrics
 
@@ -54,6 +58,12 @@ type Registry interface {
 	ServiceServerUpGauge() metrics.Gauge
 	ServiceReqsBytesCounter() metrics.Counter
 	ServiceRespsBytesCounter() metrics.Counter
+	ServiceMirrorComparisonsCounter() metrics.Counter
+
+	// middleware metrics
+
+	MiddlewareOutcomesCounter() metrics.Counter
+	MiddlewareCircuitBreakerStateGauge() metrics.Gauge
 }
 
 // NewVoidRegistry is a noop implementation of metrics.Registry.
@@ -68,8 +78,10 @@ func NewVoidRegistry() Registry {
 func NewMultiRegistry(registries []Registry) Registry {
 	var configReloadsCounter []metrics.Counter
 	var lastConfigReloadSuccessGauge []metrics.Gauge
+	var configFileErrorsGauge []metrics.Gauge
 	var openConnectionsGauge []metrics.Gauge
 	var tlsCertsNotAfterTimestampGauge []metrics.Gauge
+	var tlsClientCertRevocationChecksCounter []metrics.Counter
 	var entryPointReqsCounter []CounterWithHeaders
 	var entryPointReqsTLSCounter []metrics.Counter
 	var entryPointReqDurationHistogram []ScalableHistogram
@@ -87,6 +99,9 @@ func NewMultiRegistry(registries []Registry) Registry {
 	var serviceServerUpGauge []metrics.Gauge
 	var serviceReqsBytesCounter []metrics.Counter
 	var serviceRespsBytesCounter []metrics.Counter
+	var serviceMirrorComparisonsCounter []metrics.Counter
+	var middlewareOutcomesCounter []metrics.Counter
+	var middlewareCircuitBreakerStateGauge []metrics.Gauge
 
 	for _, r := range registries {
 		if r.ConfigReloadsCounter() != nil {
@@ -95,12 +110,18 @@ func NewMultiRegistry(registries []Registry) Registry {
 		if r.LastConfigReloadSuccessGauge() != nil {
 			lastConfigReloadSuccessGauge = append(lastConfigReloadSuccessGauge, r.LastConfigReloadSuccessGauge())
 		}
+		if r.ConfigFileErrorsGauge() != nil {
+			configFileErrorsGauge = append(configFileErrorsGauge, r.ConfigFileErrorsGauge())
+		}
 		if r.OpenConnectionsGauge() != nil {
 			openConnectionsGauge = append(openConnectionsGauge, r.OpenConnectionsGauge())
 		}
 		if r.TLSCertsNotAfterTimestampGauge() != nil {
 			tlsCertsNotAfterTimestampGauge = append(tlsCertsNotAfterTimestampGauge, r.TLSCertsNotAfterTimestampGauge())
 		}
+		if r.TLSClientCertRevocationChecksCounter() != nil {
+			tlsClientCertRevocationChecksCounter = append(tlsClientCertRevocationChecksCounter, r.TLSClientCertRevocationChecksCounter())
+		}
 		if r.EntryPointReqsCounter() != nil {
 			entryPointReqsCounter = append(entryPointReqsCounter, r.EntryPointReqsCounter())
 		}
@@ -152,61 +173,82 @@ func NewMultiRegistry(registries []Registry) Registry {
 		if r.ServiceRespsBytesCounter() != nil {
 			serviceRespsBytesCounter = append(serviceRespsBytesCounter, r.ServiceRespsBytesCounter())
 		}
+		if r.ServiceMirrorComparisonsCounter() != nil {
+			serviceMirrorComparisonsCounter = append(serviceMirrorComparisonsCounter, r.ServiceMirrorComparisonsCounter())
+		}
+		if r.MiddlewareOutcomesCounter() != nil {
+			middlewareOutcomesCounter = append(middlewareOutcomesCounter, r.MiddlewareOutcomesCounter())
+		}
+		if r.MiddlewareCircuitBreakerStateGauge() != nil {
+			middlewareCircuitBreakerStateGauge = append(middlewareCircuitBreakerStateGauge, r.MiddlewareCircuitBreakerStateGauge())
+		}
 	}
 
 	return &standardRegistry{
-		epEnabled:                      len(entryPointReqsCounter) > 0 || len(entryPointReqDurationHistogram) > 0,
-		svcEnabled:                     len(serviceReqsCounter) > 0 || len(serviceReqDurationHistogram) > 0 || len(serviceRetriesCounter) > 0 || len(serviceServerUpGauge) > 0,
-		routerEnabled:                  len(routerReqsCounter) > 0 || len(routerReqDurationHistogram) > 0,
-		configReloadsCounter:           multi.NewCounter(configReloadsCounter...),
-		lastConfigReloadSuccessGauge:   multi.NewGauge(lastConfigReloadSuccessGauge...),
-		openConnectionsGauge:           multi.NewGauge(openConnectionsGauge...),
-		tlsCertsNotAfterTimestampGauge: multi.NewGauge(tlsCertsNotAfterTimestampGauge...),
-		entryPointReqsCounter:          NewMultiCounterWithHeaders(entryPointReqsCounter...),
-		entryPointReqsTLSCounter:       multi.NewCounter(entryPointReqsTLSCounter...),
-		entryPointReqDurationHistogram: MultiHistogram(entryPointReqDurationHistogram),
-		entryPointReqsBytesCounter:     multi.NewCounter(entryPointReqsBytesCounter...),
-		entryPointRespsBytesCounter:    multi.NewCounter(entryPointRespsBytesCounter...),
-		routerReqsCounter:              NewMultiCounterWithHeaders(routerReqsCounter...),
-		routerReqsTLSCounter:           multi.NewCounter(routerReqsTLSCounter...),
-		routerReqDurationHistogram:     MultiHistogram(routerReqDurationHistogram),
-		routerReqsBytesCounter:         multi.NewCounter(routerReqsBytesCounter...),
-		routerRespsBytesCounter:        multi.NewCounter(routerRespsBytesCounter...),
-		serviceReqsCounter:             NewMultiCounterWithHeaders(serviceReqsCounter...),
-		serviceReqsTLSCounter:          multi.NewCounter(serviceReqsTLSCounter...),
-		serviceReqDurationHistogram:    MultiHistogram(serviceReqDurationHistogram),
-		serviceRetriesCounter:          multi.NewCounter(serviceRetriesCounter...),
-		serviceServerUpGauge:           multi.NewGauge(serviceServerUpGauge...),
-		serviceReqsBytesCounter:        multi.NewCounter(serviceReqsBytesCounter...),
-		serviceRespsBytesCounter:       multi.NewCounter(serviceRespsBytesCounter...),
+		epEnabled:                            len(entryPointReqsCounter) > 0 || len(entryPointReqDurationHistogram) > 0,
+		svcEnabled:                           len(serviceReqsCounter) > 0 || len(serviceReqDurationHistogram) > 0 || len(serviceRetriesCounter) > 0 || len(serviceServerUpGauge) > 0,
+		routerEnabled:                        len(routerReqsCounter) > 0 || len(routerReqDurationHistogram) > 0,
+		middlewareEnabled:                    len(middlewareOutcomesCounter) > 0 || len(middlewareCircuitBreakerStateGauge) > 0,
+		configReloadsCounter:                 multi.NewCounter(configReloadsCounter...),
+		lastConfigReloadSuccessGauge:         multi.NewGauge(lastConfigReloadSuccessGauge...),
+		configFileErrorsGauge:                multi.NewGauge(configFileErrorsGauge...),
+		openConnectionsGauge:                 multi.NewGauge(openConnectionsGauge...),
+		tlsCertsNotAfterTimestampGauge:       multi.NewGauge(tlsCertsNotAfterTimestampGauge...),
+		tlsClientCertRevocationChecksCounter: multi.NewCounter(tlsClientCertRevocationChecksCounter...),
+		entryPointReqsCounter:                NewMultiCounterWithHeaders(entryPointReqsCounter...),
+		entryPointReqsTLSCounter:             multi.NewCounter(entryPointReqsTLSCounter...),
+		entryPointReqDurationHistogram:       MultiHistogram(entryPointReqDurationHistogram),
+		entryPointReqsBytesCounter:           multi.NewCounter(entryPointReqsBytesCounter...),
+		entryPointRespsBytesCounter:          multi.NewCounter(entryPointRespsBytesCounter...),
+		routerReqsCounter:                    NewMultiCounterWithHeaders(routerReqsCounter...),
+		routerReqsTLSCounter:                 multi.NewCounter(routerReqsTLSCounter...),
+		routerReqDurationHistogram:           MultiHistogram(routerReqDurationHistogram),
+		routerReqsBytesCounter:               multi.NewCounter(routerReqsBytesCounter...),
+		routerRespsBytesCounter:              multi.NewCounter(routerRespsBytesCounter...),
+		serviceReqsCounter:                   NewMultiCounterWithHeaders(serviceReqsCounter...),
+		serviceReqsTLSCounter:                multi.NewCounter(serviceReqsTLSCounter...),
+		serviceReqDurationHistogram:          MultiHistogram(serviceReqDurationHistogram),
+		serviceRetriesCounter:                multi.NewCounter(serviceRetriesCounter...),
+		serviceServerUpGauge:                 multi.NewGauge(serviceServerUpGauge...),
+		serviceReqsBytesCounter:              multi.NewCounter(serviceReqsBytesCounter...),
+		serviceRespsBytesCounter:             multi.NewCounter(serviceRespsBytesCounter...),
+		serviceMirrorComparisonsCounter:      multi.NewCounter(serviceMirrorComparisonsCounter...),
+		middlewareOutcomesCounter:            multi.NewCounter(middlewareOutcomesCounter...),
+		middlewareCircuitBreakerStateGauge:   multi.NewGauge(middlewareCircuitBreakerStateGauge...),
 	}
 }
 
 type standardRegistry struct {
-	epEnabled                      bool
-	routerEnabled                  bool
-	svcEnabled                     bool
-	configReloadsCounter           metrics.Counter
-	lastConfigReloadSuccessGauge   metrics.Gauge
-	openConnectionsGauge           metrics.Gauge
-	tlsCertsNotAfterTimestampGauge metrics.Gauge
-	entryPointReqsCounter          CounterWithHeaders
-	entryPointReqsTLSCounter       metrics.Counter
-	entryPointReqDurationHistogram ScalableHistogram
-	entryPointReqsBytesCounter     metrics.Counter
-	entryPointRespsBytesCounter    metrics.Counter
-	routerReqsCounter              CounterWithHeaders
-	routerReqsTLSCounter           metrics.Counter
-	routerReqDurationHistogram     ScalableHistogram
-	routerReqsBytesCounter         metrics.Counter
-	routerRespsBytesCounter        metrics.Counter
-	serviceReqsCounter             CounterWithHeaders
-	serviceReqsTLSCounter          metrics.Counter
-	serviceReqDurationHistogram    ScalableHistogram
-	serviceRetriesCounter          metrics.Counter
-	serviceServerUpGauge           metrics.Gauge
-	serviceReqsBytesCounter        metrics.Counter
-	serviceRespsBytesCounter       metrics.Counter
+	epEnabled                            bool
+	routerEnabled                        bool
+	svcEnabled                           bool
+	middlewareEnabled                    bool
+	configReloadsCounter                 metrics.Counter
+	lastConfigReloadSuccessGauge         metrics.Gauge
+	configFileErrorsGauge                metrics.Gauge
+	openConnectionsGauge                 metrics.Gauge
+	tlsCertsNotAfterTimestampGauge       metrics.Gauge
+	tlsClientCertRevocationChecksCounter metrics.Counter
+	entryPointReqsCounter                CounterWithHeaders
+	entryPointReqsTLSCounter             metrics.Counter
+	entryPointReqDurationHistogram       ScalableHistogram
+	entryPointReqsBytesCounter           metrics.Counter
+	entryPointRespsBytesCounter          metrics.Counter
+	routerReqsCounter                    CounterWithHeaders
+	routerReqsTLSCounter                 metrics.Counter
+	routerReqDurationHistogram           ScalableHistogram
+	routerReqsBytesCounter               metrics.Counter
+	routerRespsBytesCounter              metrics.Counter
+	serviceReqsCounter                   CounterWithHeaders
+	serviceReqsTLSCounter                metrics.Counter
+	serviceReqDurationHistogram          ScalableHistogram
+	serviceRetriesCounter                metrics.Counter
+	serviceServerUpGauge                 metrics.Gauge
+	serviceReqsBytesCounter              metrics.Counter
+	serviceRespsBytesCounter             metrics.Counter
+	serviceMirrorComparisonsCounter      metrics.Counter
+	middlewareOutcomesCounter            metrics.Counter
+	middlewareCircuitBreakerStateGauge   metrics.Gauge
 }
 
 func (r *standardRegistry) IsEpEnabled() bool {
@@ -221,6 +263,10 @@ func (r *standardRegistry) IsSvcEnabled() bool {
 	return r.svcEnabled
 }
 
+func (r *standardRegistry) IsMiddlewareEnabled() bool {
+	return r.middlewareEnabled
+}
+
 func (r *standardRegistry) ConfigReloadsCounter() metrics.Counter {
 	return r.configReloadsCounter
 }
@@ -229,6 +275,10 @@ func (r *standardRegistry) LastConfigReloadSuccessGauge() metrics.Gauge {
 	return r.lastConfigReloadSuccessGauge
 }
 
+func (r *standardRegistry) ConfigFileErrorsGauge() metrics.Gauge {
+	return r.configFileErrorsGauge
+}
+
 func (r *standardRegistry) OpenConnectionsGauge() metrics.Gauge {
 	return r.openConnectionsGauge
 }
@@ -237,6 +287,10 @@ func (r *standardRegistry) TLSCertsNotAfterTimestampGauge() metrics.Gauge {
 	return r.tlsCertsNotAfterTimestampGauge
 }
 
+func (r *standardRegistry) TLSClientCertRevocationChecksCounter() metrics.Counter {
+	return r.tlsClientCertRevocationChecksCounter
+}
+
 func (r *standardRegistry) EntryPointReqsCounter() CounterWithHeaders {
 	return r.entryPointReqsCounter
 }
@@ -305,6 +359,18 @@ func (r *standardRegistry) ServiceRespsBytesCounter() metrics.Counter {
 	return r.serviceRespsBytesCounter
 }
 
+func (r *standardRegistry) ServiceMirrorComparisonsCounter() metrics.Counter {
+	return r.serviceMirrorComparisonsCounter
+}
+
+func (r *standardRegistry) MiddlewareOutcomesCounter() metrics.Counter {
+	return r.middlewareOutcomesCounter
+}
+
+func (r *standardRegistry) MiddlewareCircuitBreakerStateGauge() metrics.Gauge {
+	return r.middlewareCircuitBreakerStateGauge
+}
+
 // ScalableHistogram is a Histogram with a predefined time unit,
 // used when producing observations without explicitly setting the observed value.
 type ScalableHistogram interface {
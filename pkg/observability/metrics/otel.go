@@ -122,10 +122,14 @@ func RegisterOpenTelemetry(ctx context.Context, config *otypes.OTLP) Registry {
 		epEnabled:                      config.AddEntryPointsLabels,
 		routerEnabled:                  config.AddRoutersLabels,
 		svcEnabled:                     config.AddServicesLabels,
+		middlewareEnabled:              config.AddMiddlewaresLabels,
 		configReloadsCounter:           newOTLPCounterFrom(meter, configReloadsTotalName, "Config reloads"),
 		lastConfigReloadSuccessGauge:   newOTLPGaugeFrom(meter, configLastReloadSuccessName, "Last config reload success", "ms"),
+		configFileErrorsGauge:          newOTLPGaugeFrom(meter, configFileErrorsName, "Whether a file of the file provider directory is failing to load, by file", "1"),
 		openConnectionsGauge:           newOTLPGaugeFrom(meter, openConnectionsName, "How many open connections exist, by entryPoint and protocol", "1"),
 		tlsCertsNotAfterTimestampGauge: newOTLPGaugeFrom(meter, tlsCertsNotAfterTimestampName, "Certificate expiration timestamp", "s"),
+		tlsClientCertRevocationChecksCounter: newOTLPCounterFrom(meter, tlsClientCertRevocationChecksName,
+			"How many client certificate revocation checks were performed, partitioned by method and result."),
 	}
 
 	if config.AddEntryPointsLabels {
@@ -173,6 +177,16 @@ func RegisterOpenTelemetry(ctx context.Context, config *otypes.OTLP) Registry {
 			"The total size of requests in bytes received by a service, partitioned by status code, protocol, and method.")
 		reg.serviceRespsBytesCounter = newOTLPCounterFrom(meter, serviceRespsBytesTotalName,
 			"The total size of responses in bytes returned by a service, partitioned by status code, protocol, and method.")
+		reg.serviceMirrorComparisonsCounter = newOTLPCounterFrom(meter, serviceMirrorComparisonsTotalName,
+			"How many mirror responses were compared with the main service response, partitioned by service, mirror, and result.")
+	}
+
+	if config.AddMiddlewaresLabels {
+		reg.middlewareOutcomesCounter = newOTLPCounterFrom(meter, middlewareOutcomesTotalName,
+			"How many decisions were taken by a middleware, partitioned by middleware, type, and outcome.")
+		reg.middlewareCircuitBreakerStateGauge = newOTLPGaugeFrom(meter, middlewareCircuitBreakerStateName,
+			"Circuit breaker middleware state, described by gauge value of 0 (closed) or 1 (open).",
+			"1")
 	}
 
 	return reg
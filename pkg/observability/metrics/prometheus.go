@@ -24,11 +24,13 @@ const (
 	metricConfigPrefix          = MetricNamePrefix + "config_"
 	configReloadsTotalName      = metricConfigPrefix + "reloads_total"
 	configLastReloadSuccessName = metricConfigPrefix + "last_reload_success"
+	configFileErrorsName        = metricConfigPrefix + "file_errors"
 	openConnectionsName         = MetricNamePrefix + "open_connections"
 
 	// TLS.
-	metricsTLSPrefix              = MetricNamePrefix + "tls_"
-	tlsCertsNotAfterTimestampName = metricsTLSPrefix + "certs_not_after"
+	metricsTLSPrefix                  = MetricNamePrefix + "tls_"
+	tlsCertsNotAfterTimestampName     = metricsTLSPrefix + "certs_not_after"
+	tlsClientCertRevocationChecksName = metricsTLSPrefix + "client_cert_revocation_checks_total"
 
 	// entry point.
 	metricEntryPointPrefix        = MetricNamePrefix + "entrypoint_"
@@ -47,14 +49,20 @@ const (
 	routerRespsBytesTotalName = metricRouterPrefix + "responses_bytes_total"
 
 	// service level.
-	metricServicePrefix        = MetricNamePrefix + "service_"
-	serviceReqsTotalName       = metricServicePrefix + "requests_total"
-	serviceReqsTLSTotalName    = metricServicePrefix + "requests_tls_total"
-	serviceReqDurationName     = metricServicePrefix + "request_duration_seconds"
-	serviceRetriesTotalName    = metricServicePrefix + "retries_total"
-	serviceServerUpName        = metricServicePrefix + "server_up"
-	serviceReqsBytesTotalName  = metricServicePrefix + "requests_bytes_total"
-	serviceRespsBytesTotalName = metricServicePrefix + "responses_bytes_total"
+	metricServicePrefix               = MetricNamePrefix + "service_"
+	serviceReqsTotalName              = metricServicePrefix + "requests_total"
+	serviceReqsTLSTotalName           = metricServicePrefix + "requests_tls_total"
+	serviceReqDurationName            = metricServicePrefix + "request_duration_seconds"
+	serviceRetriesTotalName           = metricServicePrefix + "retries_total"
+	serviceServerUpName               = metricServicePrefix + "server_up"
+	serviceReqsBytesTotalName         = metricServicePrefix + "requests_bytes_total"
+	serviceRespsBytesTotalName        = metricServicePrefix + "responses_bytes_total"
+	serviceMirrorComparisonsTotalName = metricServicePrefix + "mirror_comparisons_total"
+
+	// middleware level.
+	metricMiddlewarePrefix            = MetricNamePrefix + "middleware_"
+	middlewareOutcomesTotalName       = metricMiddlewarePrefix + "outcomes_total"
+	middlewareCircuitBreakerStateName = metricMiddlewarePrefix + "circuit_breaker_state"
 )
 
 // promState holds all metric state internally and acts as the only Collector we register for Prometheus.
@@ -118,10 +126,18 @@ func initStandardRegistry(config *otypes.Prometheus) Registry {
 		Name: configLastReloadSuccessName,
 		Help: "Last config reload success",
 	}, []string{})
+	configFileErrors := newGaugeFrom(stdprometheus.GaugeOpts{
+		Name: configFileErrorsName,
+		Help: "Whether a file of the file provider directory is failing to load, by file",
+	}, []string{"file"})
 	tlsCertsNotAfterTimestamp := newGaugeFrom(stdprometheus.GaugeOpts{
 		Name: tlsCertsNotAfterTimestampName,
 		Help: "Certificate expiration timestamp",
 	}, []string{"cn", "serial", "sans"})
+	tlsClientCertRevocationChecks := newCounterFrom(stdprometheus.CounterOpts{
+		Name: tlsClientCertRevocationChecksName,
+		Help: "How many client certificate revocation checks were performed, partitioned by method and result.",
+	}, []string{"method", "result"})
 	openConnections := newGaugeFrom(stdprometheus.GaugeOpts{
 		Name: openConnectionsName,
 		Help: "How many open connections exist, by entryPoint and protocol",
@@ -130,18 +146,23 @@ func initStandardRegistry(config *otypes.Prometheus) Registry {
 	promState.vectors = []vector{
 		configReloads.cv,
 		lastConfigReloadSuccess.gv,
+		configFileErrors.gv,
 		tlsCertsNotAfterTimestamp.gv,
+		tlsClientCertRevocationChecks.cv,
 		openConnections.gv,
 	}
 
 	reg := &standardRegistry{
-		epEnabled:                      config.AddEntryPointsLabels,
-		routerEnabled:                  config.AddRoutersLabels,
-		svcEnabled:                     config.AddServicesLabels,
-		configReloadsCounter:           configReloads,
-		lastConfigReloadSuccessGauge:   lastConfigReloadSuccess,
-		tlsCertsNotAfterTimestampGauge: tlsCertsNotAfterTimestamp,
-		openConnectionsGauge:           openConnections,
+		epEnabled:                            config.AddEntryPointsLabels,
+		routerEnabled:                        config.AddRoutersLabels,
+		svcEnabled:                           config.AddServicesLabels,
+		middlewareEnabled:                    config.AddMiddlewaresLabels,
+		configReloadsCounter:                 configReloads,
+		lastConfigReloadSuccessGauge:         lastConfigReloadSuccess,
+		configFileErrorsGauge:                configFileErrors,
+		tlsCertsNotAfterTimestampGauge:       tlsCertsNotAfterTimestamp,
+		tlsClientCertRevocationChecksCounter: tlsClientCertRevocationChecks,
+		openConnectionsGauge:                 openConnections,
 	}
 
 	if config.AddEntryPointsLabels {
@@ -249,6 +270,10 @@ func initStandardRegistry(config *otypes.Prometheus) Registry {
 			Name: serviceRespsBytesTotalName,
 			Help: "The total size of responses in bytes returned by a service, partitioned by status code, protocol, and method.",
 		}, []string{"code", "method", "protocol", "service"})
+		serviceMirrorComparisonsTotal := newCounterFrom(stdprometheus.CounterOpts{
+			Name: serviceMirrorComparisonsTotalName,
+			Help: "How many mirror responses were compared with the main service response, partitioned by service, mirror, and result.",
+		}, []string{"service", "mirror", "result"})
 
 		promState.vectors = append(promState.vectors,
 			serviceReqs.cv,
@@ -258,6 +283,7 @@ func initStandardRegistry(config *otypes.Prometheus) Registry {
 			serviceServerUp.gv,
 			serviceReqsBytesTotal.cv,
 			serviceRespsBytesTotal.cv,
+			serviceMirrorComparisonsTotal.cv,
 		)
 
 		reg.serviceReqsCounter = serviceReqs
@@ -267,6 +293,26 @@ func initStandardRegistry(config *otypes.Prometheus) Registry {
 		reg.serviceServerUpGauge = serviceServerUp
 		reg.serviceReqsBytesCounter = serviceReqsBytesTotal
 		reg.serviceRespsBytesCounter = serviceRespsBytesTotal
+		reg.serviceMirrorComparisonsCounter = serviceMirrorComparisonsTotal
+	}
+
+	if config.AddMiddlewaresLabels {
+		middlewareOutcomes := newCounterFrom(stdprometheus.CounterOpts{
+			Name: middlewareOutcomesTotalName,
+			Help: "How many decisions were taken by a middleware, partitioned by middleware, type, and outcome.",
+		}, []string{"middleware", "type", "outcome"})
+		middlewareCircuitBreakerState := newGaugeFrom(stdprometheus.GaugeOpts{
+			Name: middlewareCircuitBreakerStateName,
+			Help: "Circuit breaker middleware state, described by gauge value of 0 (closed) or 1 (open).",
+		}, []string{"middleware", "type"})
+
+		promState.vectors = append(promState.vectors,
+			middlewareOutcomes.cv,
+			middlewareCircuitBreakerState.gv,
+		)
+
+		reg.middlewareOutcomesCounter = middlewareOutcomes
+		reg.middlewareCircuitBreakerStateGauge = middlewareCircuitBreakerState
 	}
 
 	return reg
@@ -300,6 +346,10 @@ func OnConfigurationUpdate(conf dynamic.Configuration, entryPoints []string) {
 		dynCfg.entryPoints[value] = true
 	}
 
+	for name := range conf.EntryPoints {
+		dynCfg.entryPoints[name] = true
+	}
+
 	if conf.HTTP == nil {
 		promState.SetDynamicConfig(dynCfg)
 		return
@@ -309,6 +359,10 @@ func OnConfigurationUpdate(conf dynamic.Configuration, entryPoints []string) {
 		dynCfg.routers[name] = true
 	}
 
+	for name := range conf.HTTP.Middlewares {
+		dynCfg.middlewares[name] = true
+	}
+
 	for serviceName, service := range conf.HTTP.Services {
 		dynCfg.services[serviceName] = make(map[string]bool)
 		if service.LoadBalancer != nil {
@@ -336,12 +390,13 @@ type vector interface {
 type prometheusState struct {
 	vectors []vector
 
-	mtx             sync.Mutex
-	dynamicConfig   *dynamicConfig
-	deletedEP       []string
-	deletedRouters  []string
-	deletedServices []string
-	deletedURLs     map[string][]string
+	mtx                sync.Mutex
+	dynamicConfig      *dynamicConfig
+	deletedEP          []string
+	deletedRouters     []string
+	deletedMiddlewares []string
+	deletedServices    []string
+	deletedURLs        map[string][]string
 }
 
 func (ps *prometheusState) SetDynamicConfig(dynamicConfig *dynamicConfig) {
@@ -360,6 +415,12 @@ func (ps *prometheusState) SetDynamicConfig(dynamicConfig *dynamicConfig) {
 		}
 	}
 
+	for middleware := range ps.dynamicConfig.middlewares {
+		if _, ok := dynamicConfig.middlewares[middleware]; !ok {
+			ps.deletedMiddlewares = append(ps.deletedMiddlewares, middleware)
+		}
+	}
+
 	for service, serV := range ps.dynamicConfig.services {
 		actualService, ok := dynamicConfig.services[service]
 		if !ok {
@@ -408,6 +469,12 @@ func (ps *prometheusState) Collect(ch chan<- stdprometheus.Metric) {
 		}
 	}
 
+	for _, middleware := range ps.deletedMiddlewares {
+		if !ps.dynamicConfig.hasMiddleware(middleware) {
+			ps.DeletePartialMatch(map[string]string{"middleware": middleware})
+		}
+	}
+
 	for _, service := range ps.deletedServices {
 		if !ps.dynamicConfig.hasService(service) {
 			ps.DeletePartialMatch(map[string]string{"service": service})
@@ -424,6 +491,7 @@ func (ps *prometheusState) Collect(ch chan<- stdprometheus.Metric) {
 
 	ps.deletedEP = nil
 	ps.deletedRouters = nil
+	ps.deletedMiddlewares = nil
 	ps.deletedServices = nil
 	ps.deletedURLs = make(map[string][]string)
 }
@@ -443,17 +511,19 @@ func newDynamicConfig() *dynamicConfig {
 	return &dynamicConfig{
 		entryPoints: make(map[string]bool),
 		routers:     make(map[string]bool),
+		middlewares: make(map[string]bool),
 		services:    make(map[string]map[string]bool),
 	}
 }
 
-// dynamicConfig holds the current configuration for entryPoints, services,
+// dynamicConfig holds the current configuration for entryPoints, routers, middlewares, services,
 // and server URLs in an optimized way to check for existence. This provides
 // a performant way to check whether the collected metrics belong to the
 // current configuration or to an outdated one.
 type dynamicConfig struct {
 	entryPoints map[string]bool
 	routers     map[string]bool
+	middlewares map[string]bool
 	services    map[string]map[string]bool
 }
 
@@ -472,6 +542,11 @@ func (d *dynamicConfig) hasRouter(routerName string) bool {
 	return ok
 }
 
+func (d *dynamicConfig) hasMiddleware(middlewareName string) bool {
+	_, ok := d.middlewares[middlewareName]
+	return ok
+}
+
 func (d *dynamicConfig) hasServerURL(serviceName, serverURL string) bool {
 	if service, hasService := d.services[serviceName]; hasService {
 		_, ok := service[serverURL]
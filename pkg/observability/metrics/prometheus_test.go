@@ -104,6 +104,10 @@ func TestPrometheus(t *testing.T) {
 
 	prometheusRegistry.ConfigReloadsCounter().Add(1)
 	prometheusRegistry.LastConfigReloadSuccessGauge().Set(float64(time.Now().Unix()))
+	prometheusRegistry.
+		ConfigFileErrorsGauge().
+		With("file", "foo.yml").
+		Set(1)
 	prometheusRegistry.
 		OpenConnectionsGauge().
 		With("entrypoint", "test", "protocol", "TCP").
@@ -113,6 +117,10 @@ func TestPrometheus(t *testing.T) {
 		TLSCertsNotAfterTimestampGauge().
 		With("cn", "value", "serial", "value", "sans", "value").
 		Set(float64(time.Now().Unix()))
+	prometheusRegistry.
+		TLSClientCertRevocationChecksCounter().
+		With("method", "ocsp", "result", "revoked").
+		Add(1)
 
 	prometheusRegistry.
 		EntryPointReqsCounter().
@@ -198,6 +206,13 @@ func TestPrometheus(t *testing.T) {
 			name:   configLastReloadSuccessName,
 			assert: buildTimestampAssert(t, configLastReloadSuccessName),
 		},
+		{
+			name: configFileErrorsName,
+			labels: map[string]string{
+				"file": "foo.yml",
+			},
+			assert: buildGaugeAssert(t, configFileErrorsName, 1),
+		},
 		{
 			name: openConnectionsName,
 			labels: map[string]string{
@@ -215,6 +230,14 @@ func TestPrometheus(t *testing.T) {
 			},
 			assert: buildTimestampAssert(t, tlsCertsNotAfterTimestampName),
 		},
+		{
+			name: tlsClientCertRevocationChecksName,
+			labels: map[string]string{
+				"method": "ocsp",
+				"result": "revoked",
+			},
+			assert: buildCounterAssert(t, tlsClientCertRevocationChecksName, 1),
+		},
 		{
 			name: entryPointReqsTotalName,
 			labels: map[string]string{
@@ -490,6 +513,55 @@ func TestPrometheusMetricRemoval(t *testing.T) {
 	assertMetricsExist(t, mustScrape(), entryPointReqsTotalName, serviceReqsTotalName, serviceServerUpName, routerReqsTotalName)
 }
 
+func TestPrometheusMiddlewareMetricRemoval(t *testing.T) {
+	promState = newPrometheusState()
+	promRegistry = prometheus.NewRegistry()
+	t.Cleanup(promState.reset)
+
+	prometheusRegistry := RegisterPrometheus(t.Context(), &otypes.Prometheus{AddMiddlewaresLabels: true})
+	defer promRegistry.Unregister(promState)
+
+	conf1 := dynamic.Configuration{
+		HTTP: th.BuildConfiguration(
+			th.WithMiddlewares(
+				th.WithMiddleware("basicauth@file", th.WithBasicAuth(&dynamic.BasicAuth{Users: []string{"foo:bar"}})),
+				th.WithMiddleware("auth@file", th.WithBasicAuth(&dynamic.BasicAuth{Users: []string{"foo:bar"}})),
+			),
+		),
+	}
+
+	conf2 := dynamic.Configuration{
+		HTTP: th.BuildConfiguration(
+			th.WithMiddlewares(
+				th.WithMiddleware("auth@file", th.WithBasicAuth(&dynamic.BasicAuth{Users: []string{"foo:bar"}})),
+			),
+		),
+	}
+
+	OnConfigurationUpdate(conf1, []string{})
+	OnConfigurationUpdate(conf2, []string{})
+
+	// The series of the removed middleware is exported on the first scrape, and removed after that scrape.
+	prometheusRegistry.
+		MiddlewareOutcomesCounter().
+		With("middleware", "basicauth@file", "type", "BasicAuth", "outcome", "rejected").
+		Add(1)
+
+	assertMetricsExist(t, mustScrape(), middlewareOutcomesTotalName)
+	assertMetricsAbsent(t, mustScrape(), middlewareOutcomesTotalName)
+
+	// The series of the middleware still in the configuration is kept.
+	prometheusRegistry.
+		MiddlewareOutcomesCounter().
+		With("middleware", "auth@file", "type", "BasicAuth", "outcome", "allowed").
+		Add(1)
+
+	delayForTrackingCompletion()
+
+	assertMetricsExist(t, mustScrape(), middlewareOutcomesTotalName)
+	assertMetricsExist(t, mustScrape(), middlewareOutcomesTotalName)
+}
+
 func TestPrometheusMetricRemoveEndpointForRecoveredService(t *testing.T) {
 	promState = newPrometheusState()
 	promRegistry = prometheus.NewRegistry()
@@ -19,9 +19,11 @@ var (
 const (
 	statsdConfigReloadsName           = "config.reload.total"
 	statsdLastConfigReloadSuccessName = "config.reload.lastSuccessTimestamp"
+	statsdConfigFileErrorsName        = "config.file.errors"
 	statsdOpenConnectionsName         = "open.connections"
 
-	statsdTLSCertsNotAfterTimestampName = "tls.certs.notAfterTimestamp"
+	statsdTLSCertsNotAfterTimestampName     = "tls.certs.notAfterTimestamp"
+	statsdTLSClientCertRevocationChecksName = "tls.clientcert.revocation.checks.total"
 
 	statsdEntryPointReqsName        = "entrypoint.request.total"
 	statsdEntryPointReqsTLSName     = "entrypoint.request.tls.total"
@@ -35,13 +37,17 @@ const (
 	statsdRouterReqsBytesName    = "router.requests.bytes.total"
 	statsdRouterRespsBytesName   = "router.responses.bytes.total"
 
-	statsdServiceReqsName         = "service.request.total"
-	statsdServiceReqsTLSName      = "service.request.tls.total"
-	statsdServiceReqsDurationName = "service.request.duration"
-	statsdServiceRetriesTotalName = "service.retries.total"
-	statsdServiceServerUpName     = "service.server.up"
-	statsdServiceReqsBytesName    = "service.requests.bytes.total"
-	statsdServiceRespsBytesName   = "service.responses.bytes.total"
+	statsdServiceReqsName              = "service.request.total"
+	statsdServiceReqsTLSName           = "service.request.tls.total"
+	statsdServiceReqsDurationName      = "service.request.duration"
+	statsdServiceRetriesTotalName      = "service.retries.total"
+	statsdServiceServerUpName          = "service.server.up"
+	statsdServiceReqsBytesName         = "service.requests.bytes.total"
+	statsdServiceRespsBytesName        = "service.responses.bytes.total"
+	statsdServiceMirrorComparisonsName = "service.mirror.comparisons.total"
+
+	statsdMiddlewareOutcomesName            = "middleware.outcomes.total"
+	statsdMiddlewareCircuitBreakerStateName = "middleware.circuitbreaker.state"
 )
 
 // RegisterStatsd registers the metrics pusher if this didn't happen yet and creates a statsd Registry instance.
@@ -58,10 +64,12 @@ func RegisterStatsd(ctx context.Context, config *otypes.Statsd) Registry {
 	}
 
 	registry := &standardRegistry{
-		configReloadsCounter:           statsdClient.NewCounter(statsdConfigReloadsName, 1.0),
-		lastConfigReloadSuccessGauge:   statsdClient.NewGauge(statsdLastConfigReloadSuccessName),
-		tlsCertsNotAfterTimestampGauge: statsdClient.NewGauge(statsdTLSCertsNotAfterTimestampName),
-		openConnectionsGauge:           statsdClient.NewGauge(statsdOpenConnectionsName),
+		configReloadsCounter:                 statsdClient.NewCounter(statsdConfigReloadsName, 1.0),
+		lastConfigReloadSuccessGauge:         statsdClient.NewGauge(statsdLastConfigReloadSuccessName),
+		configFileErrorsGauge:                statsdClient.NewGauge(statsdConfigFileErrorsName),
+		tlsCertsNotAfterTimestampGauge:       statsdClient.NewGauge(statsdTLSCertsNotAfterTimestampName),
+		tlsClientCertRevocationChecksCounter: statsdClient.NewCounter(statsdTLSClientCertRevocationChecksName, 1.0),
+		openConnectionsGauge:                 statsdClient.NewGauge(statsdOpenConnectionsName),
 	}
 
 	if config.AddEntryPointsLabels {
@@ -91,6 +99,13 @@ func RegisterStatsd(ctx context.Context, config *otypes.Statsd) Registry {
 		registry.serviceServerUpGauge = statsdClient.NewGauge(statsdServiceServerUpName)
 		registry.serviceReqsBytesCounter = statsdClient.NewCounter(statsdServiceReqsBytesName, 1.0)
 		registry.serviceRespsBytesCounter = statsdClient.NewCounter(statsdServiceRespsBytesName, 1.0)
+		registry.serviceMirrorComparisonsCounter = statsdClient.NewCounter(statsdServiceMirrorComparisonsName, 1.0)
+	}
+
+	if config.AddMiddlewaresLabels {
+		registry.middlewareEnabled = config.AddMiddlewaresLabels
+		registry.middlewareOutcomesCounter = statsdClient.NewCounter(statsdMiddlewareOutcomesName, 1.0)
+		registry.middlewareCircuitBreakerStateGauge = statsdClient.NewGauge(statsdMiddlewareCircuitBreakerStateName)
 	}
 
 	return registry
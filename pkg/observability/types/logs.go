@@ -78,6 +78,11 @@ func (l *AccessLog) SetDefaults() {
 	l.Fields.SetDefaults()
 }
 
+// MirrorComparisonLog holds the configuration settings for the mirror responses comparison logger.
+type MirrorComparisonLog struct {
+	FilePath string `description:"Mirror responses comparison log file path." json:"filePath,omitempty" toml:"filePath,omitempty" yaml:"filePath,omitempty"`
+}
+
 // AccessLogFilters holds filters configuration.
 type AccessLogFilters struct {
 	StatusCodes   []string       `description:"Keep access logs with status codes in the specified range." json:"statusCodes,omitempty" toml:"statusCodes,omitempty" yaml:"statusCodes,omitempty" export:"true"`
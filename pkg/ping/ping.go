@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"sync"
 )
 
 // Handler expose ping routes.
@@ -12,6 +13,9 @@ type Handler struct {
 	ManualRouting         bool   `description:"Manual routing" json:"manualRouting,omitempty" toml:"manualRouting,omitempty" yaml:"manualRouting,omitempty" export:"true"`
 	TerminatingStatusCode int    `description:"Terminating status code" json:"terminatingStatusCode,omitempty" toml:"terminatingStatusCode,omitempty" yaml:"terminatingStatusCode,omitempty" export:"true"`
 	terminating           bool
+
+	readinessOnce sync.Once
+	readiness     *Readiness
 }
 
 // SetDefaults sets the default values.
@@ -26,6 +30,17 @@ func (h *Handler) WithContext(ctx context.Context) {
 		<-ctx.Done()
 		h.terminating = true
 	}()
+
+	h.Readiness().WithContext(ctx)
+}
+
+// Readiness returns the handler of the readiness endpoint, which is served alongside the ping one.
+func (h *Handler) Readiness() *Readiness {
+	h.readinessOnce.Do(func() {
+		h.readiness = NewReadiness(h.TerminatingStatusCode)
+	})
+
+	return h.readiness
 }
 
 func (h *Handler) ServeHTTP(response http.ResponseWriter, request *http.Request) {
@@ -0,0 +1,192 @@
+package ping
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"sync"
+
+	"github.com/rs/zerolog/log"
+)
+
+// ReadinessStatus describes what Traefik is still waiting for before being ready.
+type ReadinessStatus struct {
+	Ready                       bool     `json:"ready"`
+	Terminating                 bool     `json:"terminating,omitempty"`
+	ConfigurationApplied        bool     `json:"configurationApplied"`
+	PendingProviders            []string `json:"pendingProviders,omitempty"`
+	PendingCertificateResolvers []string `json:"pendingCertificateResolvers,omitempty"`
+}
+
+// Readiness tracks whether Traefik is ready to serve traffic,
+// i.e. the configuration of every provider has been applied,
+// and every certificate resolver has been initialized.
+type Readiness struct {
+	terminatingStatusCode int
+
+	lock                 sync.RWMutex
+	providers            map[*pendingProvider]struct{}
+	resolvers            map[*pendingItem]struct{}
+	appliedProviders     map[string]struct{}
+	configurationApplied bool
+	terminating          bool
+}
+
+type pendingItem struct {
+	name string
+}
+
+// pendingProvider is a provider awaited by the readiness tracker.
+type pendingProvider struct {
+	name string
+	// messageName is the name of the provider in its configuration messages, once it has delivered one.
+	messageName string
+}
+
+// NewReadiness creates a new Readiness, which serves the given status code once Traefik is terminating.
+func NewReadiness(terminatingStatusCode int) *Readiness {
+	return &Readiness{
+		terminatingStatusCode: terminatingStatusCode,
+		providers:             make(map[*pendingProvider]struct{}),
+		resolvers:             make(map[*pendingItem]struct{}),
+		appliedProviders:      make(map[string]struct{}),
+	}
+}
+
+// WithContext causes the readiness endpoint to report Traefik as not ready once the given context is done.
+func (r *Readiness) WithContext(ctx context.Context) {
+	go func() {
+		<-ctx.Done()
+
+		r.lock.Lock()
+		r.terminating = true
+		r.lock.Unlock()
+	}()
+}
+
+// AddProvider registers a provider whose configuration has to be applied,
+// and returns the function to call with the provider name of its first configuration message.
+// The provider is pending until a configuration including this provider name has been applied.
+func (r *Readiness) AddProvider(name string) func(messageName string) {
+	item := &pendingProvider{name: name}
+
+	r.lock.Lock()
+	r.providers[item] = struct{}{}
+	r.lock.Unlock()
+
+	return func(messageName string) {
+		r.lock.Lock()
+		defer r.lock.Unlock()
+
+		item.messageName = messageName
+		if _, ok := r.appliedProviders[messageName]; ok {
+			delete(r.providers, item)
+		}
+	}
+}
+
+// AddCertificateResolver registers a certificate resolver which has to be initialized,
+// and returns the function to call once it is done.
+func (r *Readiness) AddCertificateResolver(name string) func() {
+	item := &pendingItem{name: name}
+
+	r.lock.Lock()
+	r.resolvers[item] = struct{}{}
+	r.lock.Unlock()
+
+	return func() {
+		r.lock.Lock()
+		delete(r.resolvers, item)
+		r.lock.Unlock()
+	}
+}
+
+// ConfigurationApplied marks a configuration as applied,
+// along with the names of the providers whose configuration messages it includes.
+func (r *Readiness) ConfigurationApplied(providers []string) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if !r.configurationApplied {
+		log.Debug().Msg("First configuration applied")
+	}
+
+	r.configurationApplied = true
+
+	for _, name := range providers {
+		r.appliedProviders[name] = struct{}{}
+	}
+
+	for item := range r.providers {
+		if _, ok := r.appliedProviders[item.messageName]; ok && item.messageName != "" {
+			delete(r.providers, item)
+		}
+	}
+}
+
+// Status returns the current readiness status.
+func (r *Readiness) Status() ReadinessStatus {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	status := ReadinessStatus{
+		Terminating:                 r.terminating,
+		ConfigurationApplied:        r.configurationApplied,
+		PendingProviders:            pendingNames(r.providers),
+		PendingCertificateResolvers: pendingResolverNames(r.resolvers),
+	}
+
+	status.Ready = !status.Terminating && status.ConfigurationApplied &&
+		len(status.PendingProviders) == 0 && len(status.PendingCertificateResolvers) == 0
+
+	return status
+}
+
+func (r *Readiness) ServeHTTP(rw http.ResponseWriter, _ *http.Request) {
+	status := r.Status()
+
+	statusCode := http.StatusOK
+	switch {
+	case status.Terminating:
+		statusCode = r.terminatingStatusCode
+	case !status.Ready:
+		statusCode = http.StatusServiceUnavailable
+	}
+
+	rw.Header().Set("Content-Type", "application/json")
+	rw.WriteHeader(statusCode)
+
+	if err := json.NewEncoder(rw).Encode(status); err != nil {
+		log.Error().Err(err).Msg("Unable to write readiness status")
+	}
+}
+
+// pendingNames returns the names of the pending providers,
+// using their configuration message names once known.
+func pendingNames(providers map[*pendingProvider]struct{}) []string {
+	var names []string
+	for item := range providers {
+		name := item.name
+		if item.messageName != "" {
+			name = item.messageName
+		}
+
+		names = append(names, name)
+	}
+
+	slices.Sort(names)
+
+	return names
+}
+
+func pendingResolverNames(items map[*pendingItem]struct{}) []string {
+	var names []string
+	for item := range items {
+		names = append(names, item.name)
+	}
+
+	slices.Sort(names)
+
+	return names
+}
@@ -0,0 +1,84 @@
+package ping
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadiness_ServeHTTP(t *testing.T) {
+	readiness := NewReadiness(http.StatusNoContent)
+
+	fileDelivered := readiness.AddProvider("file")
+	crdDelivered := readiness.AddProvider("crd")
+	resolverInitialized := readiness.AddCertificateResolver("myresolver")
+
+	assertStatus(t, readiness, http.StatusServiceUnavailable, ReadinessStatus{
+		PendingProviders:            []string{"crd", "file"},
+		PendingCertificateResolvers: []string{"myresolver"},
+	})
+
+	fileDelivered("file")
+	resolverInitialized()
+
+	// The first configuration applied does not include the file provider configuration yet.
+	readiness.ConfigurationApplied([]string{"internal"})
+
+	assertStatus(t, readiness, http.StatusServiceUnavailable, ReadinessStatus{
+		ConfigurationApplied: true,
+		PendingProviders:     []string{"crd", "file"},
+	})
+
+	readiness.ConfigurationApplied([]string{"file", "internal"})
+
+	assertStatus(t, readiness, http.StatusServiceUnavailable, ReadinessStatus{
+		ConfigurationApplied: true,
+		PendingProviders:     []string{"crd"},
+	})
+
+	// The configuration of a provider can be applied before it is reported as delivered.
+	readiness.ConfigurationApplied([]string{"file", "internal", "kubernetescrd"})
+
+	assertStatus(t, readiness, http.StatusServiceUnavailable, ReadinessStatus{
+		ConfigurationApplied: true,
+		PendingProviders:     []string{"crd"},
+	})
+
+	crdDelivered("kubernetescrd")
+
+	assertStatus(t, readiness, http.StatusOK, ReadinessStatus{
+		Ready:                true,
+		ConfigurationApplied: true,
+	})
+
+	ctx, cancel := context.WithCancel(t.Context())
+	readiness.WithContext(ctx)
+	cancel()
+
+	require.Eventually(t, func() bool { return readiness.Status().Terminating }, time.Second, 10*time.Millisecond)
+
+	assertStatus(t, readiness, http.StatusNoContent, ReadinessStatus{
+		Terminating:          true,
+		ConfigurationApplied: true,
+	})
+}
+
+func assertStatus(t *testing.T, readiness *Readiness, expectedCode int, expected ReadinessStatus) {
+	t.Helper()
+
+	rw := httptest.NewRecorder()
+	readiness.ServeHTTP(rw, httptest.NewRequest(http.MethodGet, "/ready", http.NoBody))
+
+	assert.Equal(t, expectedCode, rw.Code)
+	assert.Equal(t, "application/json", rw.Header().Get("Content-Type"))
+
+	var status ReadinessStatus
+	require.NoError(t, json.NewDecoder(rw.Body).Decode(&status))
+	assert.Equal(t, expected, status)
+}
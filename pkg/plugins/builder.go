@@ -5,14 +5,20 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"os"
 	"path/filepath"
+	"sync"
 
 	"github.com/rs/zerolog/log"
+	"github.com/traefik/traefik/v3/pkg/tcp"
 )
 
 // Constructor creates a plugin handler.
 type Constructor func(context.Context, http.Handler) (http.Handler, error)
 
+// TCPConstructor creates a TCP plugin handler.
+type TCPConstructor func(context.Context, tcp.Handler) (tcp.Handler, error)
+
 type pluginMiddleware interface {
 	NewHandler(ctx context.Context, next http.Handler) (http.Handler, error)
 }
@@ -23,8 +29,12 @@ type middlewareBuilder interface {
 
 // Builder is a plugin builder.
 type Builder struct {
-	providerBuilders   map[string]providerBuilder
-	middlewareBuilders map[string]middlewareBuilder
+	// lock protects the builders below, as local plugins can be reloaded while routers are being built.
+	lock sync.RWMutex
+
+	providerBuilders      map[string]providerBuilder
+	middlewareBuilders    map[string]middlewareBuilder
+	tcpMiddlewareBuilders map[string]*wasmTCPMiddlewareBuilder
 }
 
 // NewBuilder creates a new Builder.
@@ -32,8 +42,9 @@ func NewBuilder(manager *Manager, plugins map[string]Descriptor, localPlugins ma
 	ctx := context.Background()
 
 	pb := &Builder{
-		middlewareBuilders: map[string]middlewareBuilder{},
-		providerBuilders:   map[string]providerBuilder{},
+		middlewareBuilders:    map[string]middlewareBuilder{},
+		providerBuilders:      map[string]providerBuilder{},
+		tcpMiddlewareBuilders: map[string]*wasmTCPMiddlewareBuilder{},
 	}
 
 	for pName, desc := range plugins {
@@ -52,13 +63,21 @@ func NewBuilder(manager *Manager, plugins map[string]Descriptor, localPlugins ma
 
 		switch manifest.Type {
 		case typeMiddleware:
-			middleware, err := newMiddlewareBuilder(logCtx, manager.GoPath(), manifest, desc.ModuleName, desc.Settings)
+			middleware, err := newMiddlewareBuilder(logCtx, manager.GoPath(), manifest, desc.ModuleName, desc.Settings, false)
 			if err != nil {
 				return nil, err
 			}
 
 			pb.middlewareBuilders[pName] = middleware
 
+		case typeTCPMiddleware:
+			middleware, err := newTCPMiddlewareBuilder(manager.GoPath(), manifest, desc.ModuleName, desc.Settings, false)
+			if err != nil {
+				return nil, err
+			}
+
+			pb.tcpMiddlewareBuilders[pName] = middleware
+
 		case typeProvider:
 			pBuilder, err := newProviderBuilder(logCtx, manifest, manager.GoPath(), desc.Settings)
 			if err != nil {
@@ -87,13 +106,21 @@ func NewBuilder(manager *Manager, plugins map[string]Descriptor, localPlugins ma
 
 		switch manifest.Type {
 		case typeMiddleware:
-			middleware, err := newMiddlewareBuilder(logCtx, localGoPath, manifest, desc.ModuleName, desc.Settings)
+			middleware, err := newMiddlewareBuilder(logCtx, localGoPath, manifest, desc.ModuleName, desc.Settings, desc.Watch)
 			if err != nil {
 				return nil, err
 			}
 
 			pb.middlewareBuilders[pName] = middleware
 
+		case typeTCPMiddleware:
+			middleware, err := newTCPMiddlewareBuilder(localGoPath, manifest, desc.ModuleName, desc.Settings, desc.Watch)
+			if err != nil {
+				return nil, err
+			}
+
+			pb.tcpMiddlewareBuilders[pName] = middleware
+
 		case typeProvider:
 			builder, err := newProviderBuilder(logCtx, manifest, localGoPath, desc.Settings)
 			if err != nil {
@@ -110,7 +137,10 @@ func NewBuilder(manager *Manager, plugins map[string]Descriptor, localPlugins ma
 }
 
 // Build builds a middleware plugin.
-func (b Builder) Build(pName string, config map[string]any, middlewareName string) (Constructor, error) {
+func (b *Builder) Build(pName string, config map[string]any, middlewareName string) (Constructor, error) {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
 	if b.middlewareBuilders == nil {
 		return nil, fmt.Errorf("no plugin definitions in the static configuration: %s", pName)
 	}
@@ -128,7 +158,30 @@ func (b Builder) Build(pName string, config map[string]any, middlewareName strin
 	return nil, fmt.Errorf("unknown plugin type: %s", pName)
 }
 
-func newMiddlewareBuilder(ctx context.Context, goPath string, manifest *Manifest, moduleName string, settings Settings) (middlewareBuilder, error) {
+// BuildTCP builds a TCP middleware plugin.
+func (b *Builder) BuildTCP(pName string, config map[string]any, middlewareName string) (TCPConstructor, error) {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
+	if b.tcpMiddlewareBuilders == nil {
+		return nil, fmt.Errorf("no plugin definitions in the static configuration: %s", pName)
+	}
+
+	if descriptor, ok := b.tcpMiddlewareBuilders[pName]; ok {
+		m, err := descriptor.newMiddleware(config, middlewareName)
+		if err != nil {
+			return nil, err
+		}
+
+		return m.NewHandler, nil
+	}
+
+	return nil, fmt.Errorf("unknown TCP plugin type: %s", pName)
+}
+
+// newMiddlewareBuilder creates the builder of a middleware plugin.
+// When keepCode is true, the Wasm binary is kept in memory to ensure that the middlewares keep using it even if the file changes afterward.
+func newMiddlewareBuilder(ctx context.Context, goPath string, manifest *Manifest, moduleName string, settings Settings, keepCode bool) (middlewareBuilder, error) {
 	switch manifest.Runtime {
 	case runtimeWasm:
 		wasmPath, err := getWasmPath(manifest)
@@ -136,7 +189,7 @@ func newMiddlewareBuilder(ctx context.Context, goPath string, manifest *Manifest
 			return nil, fmt.Errorf("wasm path: %w", err)
 		}
 
-		return newWasmMiddlewareBuilder(goPath, moduleName, wasmPath, settings)
+		return newWasmMiddlewareBuilder(goPath, moduleName, wasmPath, settings, keepCode)
 
 	case runtimeYaegi, "":
 		i, err := newInterpreter(ctx, goPath, manifest, settings)
@@ -151,6 +204,21 @@ func newMiddlewareBuilder(ctx context.Context, goPath string, manifest *Manifest
 	}
 }
 
+// newTCPMiddlewareBuilder creates the builder of a TCP middleware plugin.
+// When keepCode is true, the Wasm binary is kept in memory to ensure that the middlewares keep using it even if the file changes afterward.
+func newTCPMiddlewareBuilder(goPath string, manifest *Manifest, moduleName string, settings Settings, keepCode bool) (*wasmTCPMiddlewareBuilder, error) {
+	if manifest.Runtime != runtimeWasm {
+		return nil, fmt.Errorf("unsupported TCP middleware plugin runtime: %s", manifest.Runtime)
+	}
+
+	wasmPath, err := getWasmPath(manifest)
+	if err != nil {
+		return nil, fmt.Errorf("wasm path: %w", err)
+	}
+
+	return newWasmTCPMiddlewareBuilder(goPath, moduleName, wasmPath, settings, keepCode)
+}
+
 func newProviderBuilder(ctx context.Context, manifest *Manifest, goPath string, settings Settings) (providerBuilder, error) {
 	switch manifest.Runtime {
 	case runtimeYaegi, "":
@@ -182,3 +250,12 @@ func getWasmPath(manifest *Manifest) (string, error) {
 
 	return wasmPath, nil
 }
+
+// loadWasmCode returns the binary kept in memory if any, and reads it from the given path otherwise.
+func loadWasmCode(path string, code []byte) ([]byte, error) {
+	if code != nil {
+		return code, nil
+	}
+
+	return os.ReadFile(path)
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+	"unsafe"
+)
+
+// Built by the tests with
+// GOOS=wasip1 GOARCH=wasm go build -buildmode=c-shared -o plugin.wasm .
+func main() {}
+
+type config struct {
+	DenyServerName string
+	DenyPrefix     string
+	FirstBytes     string
+}
+
+//go:wasmimport traefik_tcp get_config
+func getConfig(buf unsafe.Pointer, bufLimit uint32) uint32
+
+//go:wasmimport traefik_tcp get_client_addr
+func getClientAddr(buf unsafe.Pointer, bufLimit uint32) uint32
+
+//go:wasmimport traefik_tcp get_server_name
+func getServerName(buf unsafe.Pointer, bufLimit uint32) uint32
+
+//go:wasmimport traefik_tcp get_first_bytes
+func getFirstBytes(buf unsafe.Pointer, bufLimit uint32) uint32
+
+//go:wasmimport traefik_tcp set_first_bytes
+func setFirstBytes(ptr unsafe.Pointer, size uint32)
+
+//go:wasmimport traefik_tcp set_context_var
+func setContextVar(namePtr unsafe.Pointer, nameSize uint32, valuePtr unsafe.Pointer, valueSize uint32)
+
+// read calls the given host function, growing the buffer until the value fits in.
+func read(fn func(unsafe.Pointer, uint32) uint32) []byte {
+	buf := make([]byte, 64)
+	for {
+		size := fn(unsafe.Pointer(&buf[0]), uint32(len(buf)))
+		if int(size) <= len(buf) {
+			return buf[:size]
+		}
+		buf = make([]byte, size)
+	}
+}
+
+func setVar(name, value string) {
+	n, v := []byte(name), []byte(value+"\x00")
+	setContextVar(unsafe.Pointer(&n[0]), uint32(len(n)), unsafe.Pointer(&v[0]), uint32(len(v)-1))
+}
+
+var cfg config
+
+// connections is the number of connections handled by the guest instance.
+var connections int
+
+func init() {
+	raw := string(read(getConfig))
+	cfg.DenyServerName = configValue(raw, "denyServerName")
+	cfg.DenyPrefix = configValue(raw, "denyPrefix")
+	cfg.FirstBytes = configValue(raw, "firstBytes")
+}
+
+// configValue extracts a string value from the JSON configuration.
+// It does not use encoding/json to keep the binary small, and only supports values without escaped characters.
+func configValue(raw, key string) string {
+	_, value, ok := strings.Cut(raw, `"`+key+`":"`)
+	if !ok {
+		return ""
+	}
+
+	value, _, _ = strings.Cut(value, `"`)
+	return value
+}
+
+//go:wasmexport handle_conn_open
+func handleConnOpen() int32 {
+	if cfg.DenyServerName != "" && string(read(getServerName)) == cfg.DenyServerName {
+		return 0
+	}
+
+	connections++
+
+	setVar("wasm_client_addr", string(read(getClientAddr)))
+	setVar("wasm_connections", strconv.Itoa(connections))
+
+	return 1
+}
+
+//go:wasmexport handle_first_bytes
+func handleFirstBytes() int32 {
+	first := read(getFirstBytes)
+	if cfg.DenyPrefix != "" && strings.HasPrefix(string(first), cfg.DenyPrefix) {
+		return 0
+	}
+
+	if cfg.FirstBytes != "" {
+		b := []byte(cfg.FirstBytes)
+		setFirstBytes(unsafe.Pointer(&b[0]), uint32(len(b)))
+	}
+
+	return 1
+}
@@ -22,9 +22,13 @@ type wasmMiddlewareBuilder struct {
 	path     string
 	cache    wazero.CompilationCache
 	settings Settings
+	// code is the binary loaded when the builder was created, only kept for the watched local plugins,
+	// so that their middlewares keep using it until the plugin is reloaded.
+	// The binary is read from path for each middleware otherwise.
+	code []byte
 }
 
-func newWasmMiddlewareBuilder(goPath, moduleName, wasmPath string, settings Settings) (*wasmMiddlewareBuilder, error) {
+func newWasmMiddlewareBuilder(goPath, moduleName, wasmPath string, settings Settings, keepCode bool) (*wasmMiddlewareBuilder, error) {
 	ctx := context.Background()
 	path := filepath.Join(goPath, "src", moduleName, wasmPath)
 	cache := wazero.NewCompilationCache()
@@ -39,7 +43,12 @@ func newWasmMiddlewareBuilder(goPath, moduleName, wasmPath string, settings Sett
 		return nil, fmt.Errorf("compiling guest module: %w", err)
 	}
 
-	return &wasmMiddlewareBuilder{path: path, cache: cache, settings: settings}, nil
+	builder := &wasmMiddlewareBuilder{path: path, cache: cache, settings: settings}
+	if keepCode {
+		builder.code = code
+	}
+
+	return builder, nil
 }
 
 func (b wasmMiddlewareBuilder) newMiddleware(config map[string]any, middlewareName string) (pluginMiddleware, error) {
@@ -62,7 +71,7 @@ func (b wasmMiddlewareBuilder) newHandler(ctx context.Context, next http.Handler
 }
 
 func (b *wasmMiddlewareBuilder) buildMiddleware(ctx context.Context, next http.Handler, cfg reflect.Value, middlewareName string) (http.Handler, func(ctx context.Context) context.Context, error) {
-	code, err := os.ReadFile(b.path)
+	code, err := loadWasmCode(b.path, b.code)
 	if err != nil {
 		return nil, nil, fmt.Errorf("loading binary: %w", err)
 	}
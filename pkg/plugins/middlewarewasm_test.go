@@ -5,6 +5,7 @@ import (
 	"net/http/httptest"
 	"os"
 	"path"
+	"path/filepath"
 	"reflect"
 	"testing"
 
@@ -117,3 +118,47 @@ func TestSettingsWithoutSocket(t *testing.T) {
 		})
 	}
 }
+
+func TestNewWasmMiddlewareBuilder_keepCode(t *testing.T) {
+	code, err := os.ReadFile("./fixtures/withoutsocket/plugin.wasm")
+	require.NoError(t, err)
+
+	testCases := []struct {
+		desc        string
+		keepCode    bool
+		expectedErr bool
+	}{
+		{
+			desc:        "binary read for each middleware",
+			expectedErr: true,
+		},
+		{
+			desc:     "binary kept in memory",
+			keepCode: true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			goPath := t.TempDir()
+			wasmPath := filepath.Join(goPath, "src", "github.com", "traefik", "wasm", "plugin.wasm")
+			require.NoError(t, os.MkdirAll(filepath.Dir(wasmPath), 0o755))
+			require.NoError(t, os.WriteFile(wasmPath, code, 0o644))
+
+			builder, err := newWasmMiddlewareBuilder(goPath, "github.com/traefik/wasm", "plugin.wasm", Settings{}, test.keepCode)
+			require.NoError(t, err)
+
+			require.NoError(t, os.WriteFile(wasmPath, []byte("not a Wasm binary"), 0o644))
+
+			_, _, err = builder.buildMiddleware(t.Context(), http.NotFoundHandler(), reflect.ValueOf(map[string]any{}), "test")
+			if test.expectedErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
@@ -34,6 +34,17 @@ func SetupRemotePlugins(manager *Manager, plugins map[string]Descriptor) error {
 			_ = manager.ResetAll()
 			return fmt.Errorf("unable to install plugin %s: %w", pAlias, err)
 		}
+
+		manifest, err := manager.ReadManifest(desc.ModuleName)
+		if err != nil {
+			_ = manager.ResetAll()
+			return fmt.Errorf("unable to read plugin %s manifest: %w", pAlias, err)
+		}
+
+		if err = checkPluginType(desc.ModuleName, manifest); err != nil {
+			_ = manager.ResetAll()
+			return fmt.Errorf("invalid plugin %s: %w", pAlias, err)
+		}
 	}
 
 	err = manager.WriteState(plugins)
@@ -117,20 +128,7 @@ func checkLocalPluginManifest(descriptor LocalDescriptor) error {
 
 	var errs *multierror.Error
 
-	switch m.Type {
-	case typeMiddleware:
-		if m.Runtime != runtimeYaegi && m.Runtime != runtimeWasm && m.Runtime != "" {
-			errs = multierror.Append(errs, fmt.Errorf("%s: unsupported runtime '%q'", descriptor.ModuleName, m.Runtime))
-		}
-
-	case typeProvider:
-		if m.Runtime != runtimeYaegi && m.Runtime != "" {
-			errs = multierror.Append(errs, fmt.Errorf("%s: unsupported runtime '%q'", descriptor.ModuleName, m.Runtime))
-		}
-
-	default:
-		errs = multierror.Append(errs, fmt.Errorf("%s: unsupported type %q", descriptor.ModuleName, m.Type))
-	}
+	errs = multierror.Append(errs, checkPluginType(descriptor.ModuleName, m))
 
 	if m.IsYaegiPlugin() {
 		if m.Import == "" {
@@ -156,3 +154,28 @@ func checkLocalPluginManifest(descriptor LocalDescriptor) error {
 
 	return errs.ErrorOrNil()
 }
+
+// checkPluginType checks that the type of the plugin is supported, and that its runtime is supported for this type.
+func checkPluginType(moduleName string, m *Manifest) error {
+	switch m.Type {
+	case typeMiddleware:
+		if m.Runtime != runtimeYaegi && m.Runtime != runtimeWasm && m.Runtime != "" {
+			return fmt.Errorf("%s: unsupported runtime '%q'", moduleName, m.Runtime)
+		}
+
+	case typeTCPMiddleware:
+		if m.Runtime != runtimeWasm {
+			return fmt.Errorf("%s: unsupported runtime '%q'", moduleName, m.Runtime)
+		}
+
+	case typeProvider:
+		if m.Runtime != runtimeYaegi && m.Runtime != "" {
+			return fmt.Errorf("%s: unsupported runtime '%q'", moduleName, m.Runtime)
+		}
+
+	default:
+		return fmt.Errorf("%s: unsupported type %q", moduleName, m.Type)
+	}
+
+	return nil
+}
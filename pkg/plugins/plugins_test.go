@@ -83,3 +83,56 @@ func Test_checkRemotePluginsConfiguration(t *testing.T) {
 		})
 	}
 }
+
+func Test_checkPluginType(t *testing.T) {
+	testCases := []struct {
+		name     string
+		manifest Manifest
+		wantErr  bool
+	}{
+		{
+			name:     "yaegi middleware",
+			manifest: Manifest{Type: typeMiddleware},
+		},
+		{
+			name:     "wasm middleware",
+			manifest: Manifest{Type: typeMiddleware, Runtime: runtimeWasm},
+		},
+		{
+			name:     "wasm TCP middleware",
+			manifest: Manifest{Type: typeTCPMiddleware, Runtime: runtimeWasm},
+		},
+		{
+			name:     "yaegi TCP middleware",
+			manifest: Manifest{Type: typeTCPMiddleware, Runtime: runtimeYaegi},
+			wantErr:  true,
+		},
+		{
+			name:     "yaegi provider",
+			manifest: Manifest{Type: typeProvider, Runtime: runtimeYaegi},
+		},
+		{
+			name:     "wasm provider",
+			manifest: Manifest{Type: typeProvider, Runtime: runtimeWasm},
+			wantErr:  true,
+		},
+		{
+			name:     "unknown type",
+			manifest: Manifest{Type: "foo"},
+			wantErr:  true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := checkPluginType("github.com/module/name", &test.manifest)
+			if test.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,497 @@
+package plugins
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/tetratelabs/wazero"
+	"github.com/tetratelabs/wazero/api"
+	"github.com/traefik/traefik/v3/pkg/middlewares"
+	"github.com/traefik/traefik/v3/pkg/tcp"
+)
+
+// TCP middleware Wasm ABI.
+//
+// The host functions are exported by the "traefik_tcp" module.
+// The functions returning a value write it in the guest memory at buf only if its length is lower or equal to bufLimit,
+// and always return its length, so that the guest can retry with a larger buffer.
+//
+//	log(level i32, ptr i32, len i32)
+//	get_config(buf i32, bufLimit i32) i32
+//	get_client_addr(buf i32, bufLimit i32) i32
+//	get_server_name(buf i32, bufLimit i32) i32
+//	get_alpn(buf i32, bufLimit i32) i32 // comma separated list of the ALPN protocols.
+//	is_tls() i32
+//	get_first_bytes(buf i32, bufLimit i32) i32
+//	set_first_bytes(ptr i32, len i32)
+//	get_context_var(namePtr i32, nameLen i32, buf i32, bufLimit i32) i32
+//	set_context_var(namePtr i32, nameLen i32, valuePtr i32, valueLen i32)
+//
+// The guest exports the callbacks, returning 1 to allow the connection, and 0 to deny (close) it.
+//
+//	handle_conn_open() i32 // required, called when the connection is opened.
+//	handle_first_bytes() i32 // optional, called with the first bytes sent by the client, which can be modified.
+const (
+	tcpHostModuleName = "traefik_tcp"
+
+	tcpHandleConnOpen   = "handle_conn_open"
+	tcpHandleFirstBytes = "handle_first_bytes"
+)
+
+const (
+	// maxFirstBytes is the maximum number of bytes read from the client before calling handle_first_bytes.
+	maxFirstBytes = 4096
+	// firstBytesTimeout is the time to wait for the client to send its first bytes.
+	// It only applies to protocols where the server speaks first, as for the others the first bytes are already available.
+	firstBytesTimeout = time.Second
+)
+
+type wasmTCPMiddlewareBuilder struct {
+	path     string
+	cache    wazero.CompilationCache
+	settings Settings
+	// code is the binary loaded when the builder was created, only kept for the watched local plugins,
+	// so that their middlewares keep using it until the plugin is reloaded.
+	// The binary is read from path for each middleware otherwise.
+	code []byte
+}
+
+func newWasmTCPMiddlewareBuilder(goPath, moduleName, wasmPath string, settings Settings, keepCode bool) (*wasmTCPMiddlewareBuilder, error) {
+	ctx := context.Background()
+	path := filepath.Join(goPath, "src", moduleName, wasmPath)
+	cache := wazero.NewCompilationCache()
+
+	code, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("loading Wasm binary: %w", err)
+	}
+
+	rt := wazero.NewRuntimeWithConfig(ctx, wazero.NewRuntimeConfig().WithCompilationCache(cache))
+	if _, err = rt.CompileModule(ctx, code); err != nil {
+		return nil, fmt.Errorf("compiling guest module: %w", err)
+	}
+
+	builder := &wasmTCPMiddlewareBuilder{path: path, cache: cache, settings: settings}
+	if keepCode {
+		builder.code = code
+	}
+
+	return builder, nil
+}
+
+func (b wasmTCPMiddlewareBuilder) newMiddleware(config map[string]any, middlewareName string) (*WasmTCPMiddleware, error) {
+	return &WasmTCPMiddleware{
+		middlewareName: middlewareName,
+		config:         reflect.ValueOf(config),
+		builder:        b,
+	}, nil
+}
+
+func (b *wasmTCPMiddlewareBuilder) buildHandler(ctx context.Context, next tcp.Handler, cfg reflect.Value, middlewareName string) (*wasmTCPHandler, error) {
+	code, err := loadWasmCode(b.path, b.code)
+	if err != nil {
+		return nil, fmt.Errorf("loading binary: %w", err)
+	}
+
+	rt := wazero.NewRuntimeWithConfig(ctx, wazero.NewRuntimeConfig().WithCompilationCache(b.cache))
+
+	guestModule, err := rt.CompileModule(ctx, code)
+	if err != nil {
+		return nil, fmt.Errorf("compiling guest module: %w", err)
+	}
+
+	if _, ok := guestModule.ExportedFunctions()[tcpHandleConnOpen]; !ok {
+		return nil, fmt.Errorf("guest module does not export %s", tcpHandleConnOpen)
+	}
+	_, handleFirstBytes := guestModule.ExportedFunctions()[tcpHandleFirstBytes]
+
+	applyCtx, err := InstantiateHost(ctx, rt, guestModule, b.settings)
+	if err != nil {
+		return nil, fmt.Errorf("instantiating host module: %w", err)
+	}
+
+	h := &wasmTCPHandler{
+		next:             next,
+		runtime:          rt,
+		guestModule:      guestModule,
+		applyCtx:         applyCtx,
+		handleFirstBytes: handleFirstBytes,
+		logger:           middlewares.GetLogger(ctx, middlewareName, "wasm"),
+	}
+
+	if i := cfg.Interface(); i != nil {
+		config, ok := i.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("could not type assert config: %T", i)
+		}
+
+		h.config, err = json.Marshal(config)
+		if err != nil {
+			return nil, fmt.Errorf("marshaling config: %w", err)
+		}
+	}
+
+	if err = h.instantiateHostModule(ctx); err != nil {
+		return nil, fmt.Errorf("instantiating %s host module: %w", tcpHostModuleName, err)
+	}
+
+	h.moduleConfig = wazero.NewModuleConfig().WithSysWalltime().WithStartFunctions("_start", "_initialize")
+	for _, env := range b.settings.Envs {
+		h.moduleConfig = h.moduleConfig.WithEnv(env, os.Getenv(env))
+	}
+
+	if len(b.settings.Mounts) > 0 {
+		fsConfig := wazero.NewFSConfig()
+		for _, mount := range b.settings.Mounts {
+			withDir := fsConfig.WithDirMount
+			prefix, readOnly := strings.CutSuffix(mount, ":ro")
+			if readOnly {
+				withDir = fsConfig.WithReadOnlyDirMount
+			}
+			parts := strings.Split(prefix, ":")
+			switch {
+			case len(parts) == 1:
+				fsConfig = withDir(parts[0], parts[0])
+			case len(parts) == 2:
+				fsConfig = withDir(parts[0], parts[1])
+			default:
+				return nil, fmt.Errorf("invalid directory %q", mount)
+			}
+		}
+		h.moduleConfig = h.moduleConfig.WithFSConfig(fsConfig)
+	}
+
+	// Instantiating a first guest module checks that the guest initializes properly.
+	mod, err := h.instantiate(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("instantiating guest module: %w", err)
+	}
+	_ = mod.Close(ctx)
+
+	// Traefik does not close the middleware when creating a new instance on a configuration change.
+	// When the middleware is marked to be GC, we need to close the runtime so the wasm instances are properly closed.
+	runtime.SetFinalizer(h, func(h *wasmTCPHandler) {
+		if err := h.runtime.Close(context.Background()); err != nil {
+			h.logger.Err(err).Msg("[wasm] TCP middleware Close failed")
+		} else {
+			h.logger.Debug().Msg("[wasm] TCP middleware Close ok")
+		}
+	})
+
+	return h, nil
+}
+
+// WasmTCPMiddleware is a TCP handler plugin wrapper.
+type WasmTCPMiddleware struct {
+	middlewareName string
+	config         reflect.Value
+	builder        wasmTCPMiddlewareBuilder
+}
+
+// NewHandler creates a new TCP handler.
+func (m WasmTCPMiddleware) NewHandler(ctx context.Context, next tcp.Handler) (tcp.Handler, error) {
+	h, err := m.builder.buildHandler(ctx, next, m.config, m.middlewareName)
+	if err != nil {
+		return nil, fmt.Errorf("building Wasm TCP middleware: %w", err)
+	}
+
+	return h, nil
+}
+
+type tcpConnStateKey struct{}
+
+// tcpConnState is the state of the connection handled by a guest call.
+type tcpConnState struct {
+	conn       tcp.WriteCloser
+	firstBytes []byte
+}
+
+func connStateFrom(ctx context.Context) *tcpConnState {
+	state, _ := ctx.Value(tcpConnStateKey{}).(*tcpConnState)
+	return state
+}
+
+// wasmTCPHandler is a TCP handler calling the guest callbacks for each connection.
+// Each connection is handled by a fresh guest module instance,
+// so that the guest memory and globals cannot leak from a connection to another.
+type wasmTCPHandler struct {
+	next tcp.Handler
+
+	runtime          wazero.Runtime
+	guestModule      wazero.CompiledModule
+	moduleConfig     wazero.ModuleConfig
+	applyCtx         ContextApplier
+	handleFirstBytes bool
+	config           []byte
+
+	logger *zerolog.Logger
+}
+
+func (h *wasmTCPHandler) ServeTCP(conn tcp.WriteCloser) {
+	state := &tcpConnState{conn: conn}
+	ctx := context.WithValue(h.applyCtx(context.Background()), tcpConnStateKey{}, state)
+
+	mod, err := h.instantiate(ctx)
+	if err != nil {
+		h.logger.Error().Err(err).Msg("Unable to instantiate guest module")
+		_ = conn.Close()
+		return
+	}
+
+	allowed, err := h.call(ctx, mod, tcpHandleConnOpen)
+	if err != nil || !allowed {
+		h.reject(ctx, mod, conn, err)
+		return
+	}
+
+	if h.handleFirstBytes {
+		state.firstBytes, err = readFirstBytes(conn)
+		if err != nil {
+			h.logger.Debug().Err(err).Msg("Error while reading the first bytes")
+			_ = mod.Close(ctx)
+			_ = conn.Close()
+			return
+		}
+
+		allowed, err = h.call(ctx, mod, tcpHandleFirstBytes)
+		if err != nil || !allowed {
+			h.reject(ctx, mod, conn, err)
+			return
+		}
+
+		conn = &prefixConn{WriteCloser: conn, prefix: state.firstBytes}
+	}
+
+	// The instance is closed before serving the connection, as it lasts until the connection is closed.
+	_ = mod.Close(ctx)
+
+	h.next.ServeTCP(conn)
+}
+
+func (h *wasmTCPHandler) reject(ctx context.Context, mod api.Module, conn tcp.WriteCloser, err error) {
+	if err != nil {
+		h.logger.Error().Err(err).Msg("Error while calling guest module")
+	} else {
+		h.logger.Debug().Msgf("Connection from %s rejected", conn.RemoteAddr())
+	}
+
+	_ = mod.Close(ctx)
+	_ = conn.Close()
+}
+
+func (h *wasmTCPHandler) call(ctx context.Context, mod api.Module, name string) (bool, error) {
+	results, err := mod.ExportedFunction(name).Call(ctx)
+	if err != nil {
+		return false, fmt.Errorf("calling %s: %w", name, err)
+	}
+
+	if len(results) != 1 {
+		return false, fmt.Errorf("calling %s: unexpected number of results: %d", name, len(results))
+	}
+
+	return api.DecodeI32(results[0]) != 0, nil
+}
+
+func (h *wasmTCPHandler) instantiate(ctx context.Context) (api.Module, error) {
+	// The modules are anonymous so that the guest can be instantiated several times.
+	return h.runtime.InstantiateModule(h.applyCtx(ctx), h.guestModule, h.moduleConfig.WithName(""))
+}
+
+func (h *wasmTCPHandler) instantiateHostModule(ctx context.Context) error {
+	_, err := h.runtime.NewHostModuleBuilder(tcpHostModuleName).
+		NewFunctionBuilder().WithFunc(hostLog(h.logger)).Export("log").
+		NewFunctionBuilder().WithFunc(hostGetConfig(h.config)).Export("get_config").
+		NewFunctionBuilder().WithFunc(getClientAddr).Export("get_client_addr").
+		NewFunctionBuilder().WithFunc(getServerName).Export("get_server_name").
+		NewFunctionBuilder().WithFunc(getALPN).Export("get_alpn").
+		NewFunctionBuilder().WithFunc(isTLS).Export("is_tls").
+		NewFunctionBuilder().WithFunc(getFirstBytes).Export("get_first_bytes").
+		NewFunctionBuilder().WithFunc(setFirstBytes).Export("set_first_bytes").
+		NewFunctionBuilder().WithFunc(getContextVar).Export("get_context_var").
+		NewFunctionBuilder().WithFunc(setContextVar).Export("set_context_var").
+		Instantiate(ctx)
+	return err
+}
+
+// The host functions must not reference the handler,
+// otherwise the runtime would reference it back, preventing its finalizer from running.
+
+func hostLog(logger *zerolog.Logger) func(context.Context, api.Module, int32, uint32, uint32) {
+	return func(_ context.Context, mod api.Module, level int32, ptr, size uint32) {
+		msg := string(readMemory(mod, ptr, size))
+
+		switch level {
+		case -1:
+			logger.Debug().Msg(msg)
+		case 0:
+			logger.Info().Msg(msg)
+		case 1:
+			logger.Warn().Msg(msg)
+		case 2:
+			logger.Error().Msg(msg)
+		}
+	}
+}
+
+func hostGetConfig(config []byte) func(context.Context, api.Module, uint32, uint32) uint32 {
+	return func(_ context.Context, mod api.Module, buf, bufLimit uint32) uint32 {
+		return writeMemory(mod, buf, bufLimit, config)
+	}
+}
+
+func getClientAddr(ctx context.Context, mod api.Module, buf, bufLimit uint32) uint32 {
+	state := connStateFrom(ctx)
+	if state == nil {
+		return 0
+	}
+
+	return writeMemory(mod, buf, bufLimit, []byte(state.conn.RemoteAddr().String()))
+}
+
+// getServerName returns the SNI of the connection, as recorded in the connection context variables when reading the TLS ClientHello.
+func getServerName(ctx context.Context, mod api.Module, buf, bufLimit uint32) uint32 {
+	state := connStateFrom(ctx)
+	if state == nil {
+		return 0
+	}
+
+	return writeMemory(mod, buf, bufLimit, []byte(tcp.ContextVars(state.conn)[tcp.RequestTLSSNI]))
+}
+
+// getALPN returns the ALPN protocols of the connection, as recorded in the connection context variables when reading the TLS ClientHello.
+func getALPN(ctx context.Context, mod api.Module, buf, bufLimit uint32) uint32 {
+	state := connStateFrom(ctx)
+	if state == nil {
+		return 0
+	}
+
+	return writeMemory(mod, buf, bufLimit, []byte(tcp.ContextVars(state.conn)[tcp.RequestTLSALPN]))
+}
+
+func isTLS(ctx context.Context) uint32 {
+	state := connStateFrom(ctx)
+	if state == nil {
+		return 0
+	}
+
+	// The TLS ClientHello variables are only recorded for TLS connections.
+	if _, ok := tcp.ContextVars(state.conn)[tcp.RequestTLSSNI]; ok {
+		return 1
+	}
+
+	return 0
+}
+
+func getFirstBytes(ctx context.Context, mod api.Module, buf, bufLimit uint32) uint32 {
+	state := connStateFrom(ctx)
+	if state == nil {
+		return 0
+	}
+
+	return writeMemory(mod, buf, bufLimit, state.firstBytes)
+}
+
+func setFirstBytes(ctx context.Context, mod api.Module, ptr, size uint32) {
+	state := connStateFrom(ctx)
+	if state == nil {
+		return
+	}
+
+	state.firstBytes = readMemory(mod, ptr, size)
+}
+
+func getContextVar(ctx context.Context, mod api.Module, namePtr, nameSize, buf, bufLimit uint32) uint32 {
+	state := connStateFrom(ctx)
+	if state == nil {
+		return 0
+	}
+
+	name := string(readMemory(mod, namePtr, nameSize))
+
+	return writeMemory(mod, buf, bufLimit, []byte(tcp.ContextVars(state.conn)[name]))
+}
+
+func setContextVar(ctx context.Context, mod api.Module, namePtr, nameSize, valuePtr, valueSize uint32) {
+	state := connStateFrom(ctx)
+	if state == nil {
+		return
+	}
+
+	name := string(readMemory(mod, namePtr, nameSize))
+	value := string(readMemory(mod, valuePtr, valueSize))
+
+	tcp.ContextVars(state.conn, map[string]string{name: value})
+}
+
+// readMemory returns a copy of the guest memory at the given location.
+func readMemory(mod api.Module, ptr, size uint32) []byte {
+	data, ok := mod.Memory().Read(ptr, size)
+	if !ok {
+		panic(fmt.Errorf("out of range memory read: ptr=%d size=%d", ptr, size))
+	}
+
+	return append([]byte(nil), data...)
+}
+
+// writeMemory writes data in the guest memory if it fits in bufLimit, and returns its length.
+func writeMemory(mod api.Module, buf, bufLimit uint32, data []byte) uint32 {
+	size := uint32(len(data))
+	if size > 0 && size <= bufLimit && !mod.Memory().Write(buf, data) {
+		panic(fmt.Errorf("out of range memory write: buf=%d size=%d", buf, size))
+	}
+
+	return size
+}
+
+// readFirstBytes reads the first bytes sent by the client.
+// A timeout is not an error, as the client might wait for the server to speak first.
+func readFirstBytes(conn tcp.WriteCloser) ([]byte, error) {
+	if err := conn.SetReadDeadline(time.Now().Add(firstBytesTimeout)); err != nil {
+		return nil, fmt.Errorf("setting read deadline: %w", err)
+	}
+
+	buf := make([]byte, maxFirstBytes)
+	n, err := conn.Read(buf)
+
+	var netErr net.Error
+	if err != nil && (!errors.As(err, &netErr) || !netErr.Timeout()) {
+		return nil, err
+	}
+
+	if err := conn.SetReadDeadline(time.Time{}); err != nil {
+		return nil, fmt.Errorf("resetting read deadline: %w", err)
+	}
+
+	return buf[:n], nil
+}
+
+// prefixConn is a connection replaying the given prefix before reading from the connection.
+type prefixConn struct {
+	tcp.WriteCloser
+
+	prefix []byte
+}
+
+func (c *prefixConn) Read(p []byte) (int, error) {
+	if len(c.prefix) > 0 {
+		n := copy(p, c.prefix)
+		c.prefix = c.prefix[n:]
+		return n, nil
+	}
+
+	return c.WriteCloser.Read(p)
+}
+
+func (c *prefixConn) Context() context.Context {
+	return tcp.ContextOf(c.WriteCloser)
+}
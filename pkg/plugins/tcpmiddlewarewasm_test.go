@@ -0,0 +1,192 @@
+package plugins
+
+import (
+	"io"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tetratelabs/wazero"
+	"github.com/traefik/traefik/v3/pkg/tcp"
+)
+
+func TestWasmTCPMiddleware(t *testing.T) {
+	path := buildTCPMiddlewareFixture(t)
+	cache := wazero.NewCompilationCache()
+
+	testCases := []struct {
+		desc       string
+		config     map[string]any
+		serverName string
+		payload    string
+		expected   string
+		denied     bool
+	}{
+		{
+			desc:     "allowed connection",
+			payload:  "hello",
+			expected: "hello",
+		},
+		{
+			desc:     "modified first bytes",
+			config:   map[string]any{"firstBytes": "HELLO"},
+			payload:  "hello",
+			expected: "HELLO",
+		},
+		{
+			desc:       "denied server name",
+			config:     map[string]any{"denyServerName": "blocked.localhost"},
+			serverName: "blocked.localhost",
+			payload:    "hello",
+			denied:     true,
+		},
+		{
+			desc:       "allowed server name",
+			config:     map[string]any{"denyServerName": "blocked.localhost"},
+			serverName: "allowed.localhost",
+			payload:    "hello",
+			expected:   "hello",
+		},
+		{
+			desc:    "denied first bytes",
+			config:  map[string]any{"denyPrefix": "GET"},
+			payload: "GET / HTTP/1.1",
+			denied:  true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				received   []byte
+				clientAddr string
+				called     bool
+			)
+			next := tcp.HandlerFunc(func(conn tcp.WriteCloser) {
+				called = true
+				clientAddr = tcp.ContextVars(conn)["wasm_client_addr"]
+
+				var err error
+				received, err = io.ReadAll(conn)
+				assert.NoError(t, err)
+
+				_ = conn.Close()
+			})
+
+			builder := &wasmTCPMiddlewareBuilder{path: path, cache: cache}
+
+			handler, err := builder.buildHandler(t.Context(), next, reflect.ValueOf(test.config), "test")
+			require.NoError(t, err)
+
+			listener, err := net.Listen("tcp", "127.0.0.1:0")
+			require.NoError(t, err)
+			t.Cleanup(func() { _ = listener.Close() })
+
+			done := make(chan struct{})
+			go func() {
+				defer close(done)
+
+				conn, err := listener.Accept()
+				if err != nil {
+					return
+				}
+
+				nextConn := tcp.NewNextConn(conn.(*net.TCPConn))
+				if test.serverName != "" {
+					tcp.ContextVars(nextConn, map[string]string{tcp.RequestTLSSNI: test.serverName})
+				}
+
+				handler.ServeTCP(nextConn)
+			}()
+
+			conn, err := net.Dial("tcp", listener.Addr().String())
+			require.NoError(t, err)
+
+			// The writes can fail when the connection is denied, as it is closed by the server.
+			_, _ = conn.Write([]byte(test.payload))
+			_ = conn.(*net.TCPConn).CloseWrite()
+
+			// Waits for the server to close the connection.
+			_, _ = io.ReadAll(conn)
+			_ = conn.Close()
+			<-done
+
+			if test.denied {
+				assert.False(t, called)
+				return
+			}
+
+			require.True(t, called)
+			assert.Equal(t, test.expected, string(received))
+			assert.Equal(t, conn.LocalAddr().String(), clientAddr)
+		})
+	}
+}
+
+func TestWasmTCPMiddleware_instancePerConnection(t *testing.T) {
+	var connections []string
+	next := tcp.HandlerFunc(func(conn tcp.WriteCloser) {
+		connections = append(connections, tcp.ContextVars(conn)["wasm_connections"])
+		_ = conn.Close()
+	})
+
+	builder := &wasmTCPMiddlewareBuilder{path: buildTCPMiddlewareFixture(t), cache: wazero.NewCompilationCache()}
+
+	handler, err := builder.buildHandler(t.Context(), next, reflect.ValueOf(map[string]any(nil)), "test")
+	require.NoError(t, err)
+
+	for range 3 {
+		server, client := net.Pipe()
+		go func() { _, _ = client.Write([]byte("hello")) }()
+
+		handler.ServeTCP(tcp.NewNextConn(&pipeConn{Conn: server}))
+		_ = client.Close()
+	}
+
+	// The guest state of a connection is not visible to the next ones.
+	assert.Equal(t, []string{"1", "1", "1"}, connections)
+}
+
+func TestWasmTCPMiddleware_missingExport(t *testing.T) {
+	builder := &wasmTCPMiddlewareBuilder{path: "./fixtures/withoutsocket/plugin.wasm", cache: wazero.NewCompilationCache()}
+
+	_, err := builder.buildHandler(t.Context(), tcp.HandlerFunc(func(conn tcp.WriteCloser) {}), reflect.ValueOf(map[string]any(nil)), "test")
+	require.ErrorContains(t, err, "does not export handle_conn_open")
+}
+
+// buildTCPMiddlewareFixture builds the TCP middleware plugin fixture, and returns the path of its Wasm binary.
+func buildTCPMiddlewareFixture(t *testing.T) string {
+	t.Helper()
+
+	goBin, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("The Go toolchain is required to build the plugin fixture")
+	}
+
+	path := filepath.Join(t.TempDir(), "plugin.wasm")
+
+	cmd := exec.Command(goBin, "build", "-buildmode=c-shared", "-o", path, ".")
+	cmd.Dir = filepath.Join("fixtures", "tcpmiddleware")
+	cmd.Env = append(os.Environ(), "GOOS=wasip1", "GOARCH=wasm")
+
+	output, err := cmd.CombinedOutput()
+	require.NoError(t, err, string(output))
+
+	return path
+}
+
+// pipeConn is a net.Conn implementing tcp.WriteCloser.
+type pipeConn struct {
+	net.Conn
+}
+
+func (c *pipeConn) CloseWrite() error {
+	return c.Close()
+}
@@ -6,8 +6,9 @@ const (
 )
 
 const (
-	typeMiddleware = "middleware"
-	typeProvider   = "provider"
+	typeMiddleware    = "middleware"
+	typeTCPMiddleware = "tcpMiddleware"
+	typeProvider      = "provider"
 )
 
 type Settings struct {
@@ -38,6 +39,9 @@ type LocalDescriptor struct {
 
 	// Settings (optional)
 	Settings Settings `description:"Plugin's settings (works only for wasm plugins)." json:"settings,omitempty" toml:"settings,omitempty" yaml:"settings,omitempty" export:"true"`
+
+	// Watch (optional)
+	Watch bool `description:"Reload the plugin when its files change (works only for middleware plugins)." json:"watch,omitempty" toml:"watch,omitempty" yaml:"watch,omitempty" export:"true"`
 }
 
 // Manifest The plugin manifest.
@@ -0,0 +1,198 @@
+package plugins
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/rs/zerolog/log"
+	"github.com/traefik/traefik/v3/pkg/safe"
+)
+
+// reloadDelay is the time to wait after the last change of a plugin's files before reloading it,
+// as editors and build tools usually write several files, or the same file several times.
+const reloadDelay = 500 * time.Millisecond
+
+// WatchLocalPlugins watches the files of the local plugins with the watch mode enabled,
+// and reloads a plugin when they change.
+// The onReload callback is called once the changed plugins are reloaded,
+// so that the routers using them can be rebuilt.
+// When a plugin fails to reload, the error is logged and its previous build keeps being used.
+func (b *Builder) WatchLocalPlugins(pool *safe.Pool, localPlugins map[string]LocalDescriptor, onReload func()) error {
+	return b.watchLocalPlugins(pool, localGoPath, localPlugins, onReload)
+}
+
+func (b *Builder) watchLocalPlugins(pool *safe.Pool, goPath string, localPlugins map[string]LocalDescriptor, onReload func()) error {
+	// roots maps the source directory of each watched plugin to its name.
+	roots := make(map[string]string)
+	for pName, desc := range localPlugins {
+		if !desc.Watch {
+			continue
+		}
+
+		manifest, err := ReadManifest(goPath, desc.ModuleName)
+		if err != nil {
+			return fmt.Errorf("%s: failed to read manifest: %w", desc.ModuleName, err)
+		}
+
+		if manifest.Type == typeProvider {
+			log.Warn().Str("plugin", "plugin-"+pName).Msg("Watch mode is not supported for provider plugins")
+			continue
+		}
+
+		roots[filepath.Join(goPath, goPathSrc, filepath.FromSlash(desc.ModuleName))] = pName
+	}
+
+	if len(roots) == 0 {
+		return nil
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("error creating plugins watcher: %w", err)
+	}
+
+	for root := range roots {
+		if err := addWatchedDirs(watcher, root); err != nil {
+			_ = watcher.Close()
+			return fmt.Errorf("error watching %s: %w", root, err)
+		}
+	}
+
+	pool.GoCtx(func(ctx context.Context) {
+		defer watcher.Close()
+
+		timer := time.NewTimer(reloadDelay)
+		timer.Stop()
+		defer timer.Stop()
+
+		pending := make(map[string]struct{})
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+
+			case evt, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+
+				pName, ok := findPlugin(roots, evt.Name)
+				if !ok {
+					continue
+				}
+
+				// New directories, such as the ones created when vendoring dependencies, are also watched.
+				if evt.Has(fsnotify.Create) {
+					if info, err := os.Stat(evt.Name); err == nil && info.IsDir() {
+						if err := addWatchedDirs(watcher, evt.Name); err != nil {
+							log.Error().Err(err).Str("plugin", "plugin-"+pName).Msgf("Error watching %s", evt.Name)
+						}
+					}
+				}
+
+				pending[pName] = struct{}{}
+				timer.Reset(reloadDelay)
+
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+
+				log.Error().Err(err).Msg("Plugins watcher error")
+
+			case <-timer.C:
+				var reloaded bool
+				for pName := range pending {
+					logger := log.With().Str("plugin", "plugin-"+pName).Str("module", localPlugins[pName].ModuleName).Logger()
+
+					if err := b.reloadLocalPlugin(logger.WithContext(ctx), goPath, pName, localPlugins[pName]); err != nil {
+						logger.Error().Err(err).Msg("Unable to reload plugin, the previous version is kept")
+						continue
+					}
+
+					logger.Info().Msg("Plugin reloaded")
+					reloaded = true
+				}
+
+				clear(pending)
+
+				if reloaded {
+					onReload()
+				}
+			}
+		}
+	})
+
+	return nil
+}
+
+// reloadLocalPlugin recompiles a local plugin and replaces its builder.
+// The builder is left untouched if the plugin fails to compile.
+func (b *Builder) reloadLocalPlugin(ctx context.Context, goPath, pName string, desc LocalDescriptor) error {
+	manifest, err := ReadManifest(goPath, desc.ModuleName)
+	if err != nil {
+		return fmt.Errorf("failed to read manifest: %w", err)
+	}
+
+	switch manifest.Type {
+	case typeMiddleware:
+		middleware, err := newMiddlewareBuilder(ctx, goPath, manifest, desc.ModuleName, desc.Settings, true)
+		if err != nil {
+			return err
+		}
+
+		b.lock.Lock()
+		delete(b.tcpMiddlewareBuilders, pName)
+		b.middlewareBuilders[pName] = middleware
+		b.lock.Unlock()
+
+	case typeTCPMiddleware:
+		middleware, err := newTCPMiddlewareBuilder(goPath, manifest, desc.ModuleName, desc.Settings, true)
+		if err != nil {
+			return err
+		}
+
+		b.lock.Lock()
+		delete(b.middlewareBuilders, pName)
+		b.tcpMiddlewareBuilders[pName] = middleware
+		b.lock.Unlock()
+
+	default:
+		return fmt.Errorf("reloading %q plugins is not supported", manifest.Type)
+	}
+
+	return nil
+}
+
+// addWatchedDirs adds the given directory and all its subdirectories to the watcher.
+func addWatchedDirs(watcher *fsnotify.Watcher, root string) error {
+	return filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if !d.IsDir() {
+			return nil
+		}
+
+		return watcher.Add(path)
+	})
+}
+
+// findPlugin returns the name of the plugin owning the given file.
+func findPlugin(roots map[string]string, filename string) (string, bool) {
+	for root, pName := range roots {
+		if filename == root || strings.HasPrefix(filename, root+string(filepath.Separator)) {
+			return pName, true
+		}
+	}
+
+	return "", false
+}
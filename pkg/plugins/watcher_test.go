@@ -0,0 +1,121 @@
+package plugins
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/traefik/traefik/v3/pkg/safe"
+)
+
+const watchedPluginSource = `package watched
+
+import (
+	"context"
+	"net/http"
+)
+
+type Config struct{}
+
+func CreateConfig() *Config {
+	return &Config{}
+}
+
+func New(ctx context.Context, next http.Handler, config *Config, name string) (http.Handler, error) {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.Header().Set("X-Version", "%s")
+		next.ServeHTTP(rw, req)
+	}), nil
+}
+`
+
+func TestWatchLocalPlugins(t *testing.T) {
+	goPath := t.TempDir()
+	pluginDir := filepath.Join(goPath, goPathSrc, "github.com", "traefik", "watched")
+	require.NoError(t, os.MkdirAll(pluginDir, 0o755))
+
+	manifest := "displayName: Watched\ntype: middleware\nimport: github.com/traefik/watched\n"
+	require.NoError(t, os.WriteFile(filepath.Join(pluginDir, pluginManifest), []byte(manifest), 0o644))
+
+	writeSource := func(version string) {
+		t.Helper()
+
+		source := strings.Replace(watchedPluginSource, "%s", version, 1)
+		require.NoError(t, os.WriteFile(filepath.Join(pluginDir, "watched.go"), []byte(source), 0o644))
+	}
+
+	localPlugins := map[string]LocalDescriptor{
+		"watched": {ModuleName: "github.com/traefik/watched", Watch: true},
+	}
+
+	builder := &Builder{
+		middlewareBuilders:    map[string]middlewareBuilder{},
+		providerBuilders:      map[string]providerBuilder{},
+		tcpMiddlewareBuilders: map[string]*wasmTCPMiddlewareBuilder{},
+	}
+
+	writeSource("v1")
+	require.NoError(t, builder.reloadLocalPlugin(t.Context(), goPath, "watched", localPlugins["watched"]))
+
+	reloaded := make(chan struct{}, 1)
+	pool := safe.NewPool(t.Context())
+	t.Cleanup(pool.Stop)
+
+	err := builder.watchLocalPlugins(pool, goPath, localPlugins, func() { reloaded <- struct{}{} })
+	require.NoError(t, err)
+
+	assertVersion := func(expected string) {
+		t.Helper()
+
+		constructor, err := builder.Build("watched", nil, "test")
+		require.NoError(t, err)
+
+		handler, err := constructor(t.Context(), http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+		assert.Equal(t, expected, recorder.Header().Get("X-Version"))
+	}
+
+	assertVersion("v1")
+
+	// A plugin failing to compile is not reloaded, and the previous build is kept.
+	require.NoError(t, os.WriteFile(filepath.Join(pluginDir, "watched.go"), []byte("package watched\n\nfunc New("), 0o644))
+
+	select {
+	case <-reloaded:
+		t.Fatal("the plugin should not be reloaded")
+	case <-time.After(3 * reloadDelay):
+	}
+
+	assertVersion("v1")
+
+	writeSource("v2")
+
+	select {
+	case <-reloaded:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the plugin should be reloaded")
+	}
+
+	assertVersion("v2")
+}
+
+func TestWatchLocalPlugins_disabled(t *testing.T) {
+	builder := &Builder{}
+
+	localPlugins := map[string]LocalDescriptor{
+		// The manifest does not exist, but is never read as the watch mode is disabled.
+		"plugin": {ModuleName: "github.com/traefik/unknown"},
+	}
+
+	err := builder.watchLocalPlugins(safe.NewPool(t.Context()), t.TempDir(), localPlugins, func() {})
+	require.NoError(t, err)
+}
@@ -3,13 +3,18 @@ package aggregator
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/rs/zerolog/log"
 	"github.com/traefik/traefik/v3/pkg/config/dynamic"
 	"github.com/traefik/traefik/v3/pkg/config/static"
+	"github.com/traefik/traefik/v3/pkg/ping"
 	"github.com/traefik/traefik/v3/pkg/provider"
+	"github.com/traefik/traefik/v3/pkg/provider/acme"
 	"github.com/traefik/traefik/v3/pkg/provider/file"
+	"github.com/traefik/traefik/v3/pkg/provider/rest"
+	"github.com/traefik/traefik/v3/pkg/provider/tailscale"
 	"github.com/traefik/traefik/v3/pkg/provider/traefik"
 	"github.com/traefik/traefik/v3/pkg/redactor"
 	"github.com/traefik/traefik/v3/pkg/safe"
@@ -65,6 +70,7 @@ type ProviderAggregator struct {
 	fileProvider              provider.Provider
 	providers                 []provider.Provider
 	providersThrottleDuration time.Duration
+	readiness                 *ping.Readiness
 }
 
 // NewProviderAggregator returns an aggregate of all the providers configured in the static configuration.
@@ -169,6 +175,11 @@ func (p *ProviderAggregator) AddProvider(provider provider.Provider) error {
 	return nil
 }
 
+// SetReadiness sets the readiness tracker, which waits for the first configuration of every provider.
+func (p *ProviderAggregator) SetReadiness(readiness *ping.Readiness) {
+	p.readiness = readiness
+}
+
 // Init the provider.
 func (p *ProviderAggregator) Init() error {
 	return nil
@@ -176,25 +187,79 @@ func (p *ProviderAggregator) Init() error {
 
 // Provide calls the provide method of every providers.
 func (p *ProviderAggregator) Provide(configurationChan chan<- dynamic.Message, pool *safe.Pool) error {
+	// All the providers are awaited before any of them is launched,
+	// so that the readiness check cannot succeed while some of them are still starting.
+	var fileDelivered, internalDelivered func(string)
 	if p.fileProvider != nil {
-		p.launchProvider(configurationChan, pool, p.fileProvider)
+		fileDelivered = p.awaitProvider(p.fileProvider)
 	}
 
-	for _, prd := range p.providers {
+	delivered := make([]func(string), len(p.providers))
+	for i, prd := range p.providers {
+		delivered[i] = p.awaitProvider(prd)
+	}
+
+	if p.internalProvider != nil {
+		internalDelivered = p.awaitProvider(p.internalProvider)
+	}
+
+	if p.fileProvider != nil {
+		p.launchProvider(trackFirstMessage(configurationChan, pool, fileDelivered), pool, p.fileProvider)
+	}
+
+	for i, prd := range p.providers {
 		safe.Go(func() {
-			p.launchProvider(configurationChan, pool, prd)
+			p.launchProvider(trackFirstMessage(configurationChan, pool, delivered[i]), pool, prd)
 		})
 	}
 
 	// internal provider must be the last because we use it to know if all the providers are loaded.
 	// ConfigurationWatcher will wait for this requiredProvider before applying configurations.
 	if p.internalProvider != nil {
-		p.launchProvider(configurationChan, pool, p.internalProvider)
+		p.launchProvider(trackFirstMessage(configurationChan, pool, internalDelivered), pool, p.internalProvider)
 	}
 
 	return nil
 }
 
+// awaitProvider registers the given provider in the readiness tracker,
+// and returns the function to call with the provider name of its first configuration message.
+// It returns nil when the provider is not awaited.
+func (p *ProviderAggregator) awaitProvider(prd provider.Provider) func(string) {
+	if p.readiness == nil {
+		return nil
+	}
+
+	switch prd := prd.(type) {
+	case *acme.ChallengeTLSALPN:
+		// The TLS challenge provider only sends configurations while a challenge is presented.
+		return nil
+	case *rest.Provider:
+		// The REST provider only sends the configurations pushed to its API.
+		return nil
+	case *acme.Provider:
+		return resolverInitialized(p.readiness.AddCertificateResolver(prd.ResolverName))
+	case *tailscale.Provider:
+		return resolverInitialized(p.readiness.AddCertificateResolver(prd.ResolverName))
+	default:
+		return p.readiness.AddProvider(providerName(prd))
+	}
+}
+
+// resolverInitialized adapts the function marking a certificate resolver as initialized to a delivery callback.
+func resolverInitialized(initialized func()) func(string) {
+	return func(string) {
+		initialized()
+	}
+}
+
+// providerName returns the name of the given provider, derived from its package name (e.g. "docker" or "file"),
+// until it is known from its configuration messages.
+func providerName(prd provider.Provider) string {
+	name, _, _ := strings.Cut(strings.TrimPrefix(fmt.Sprintf("%T", prd), "*"), ".")
+	return name
+}
+
 func (p *ProviderAggregator) quietAddProvider(provider provider.Provider) {
 	err := p.AddProvider(provider)
 	if err != nil {
@@ -224,3 +289,34 @@ func (p *ProviderAggregator) launchProvider(configurationChan chan<- dynamic.Mes
 		return
 	}
 }
+
+// trackFirstMessage returns a channel forwarding the messages to the given one,
+// which calls delivered with the provider name of the first message before forwarding it.
+func trackFirstMessage(configurationChan chan<- dynamic.Message, pool *safe.Pool, delivered func(string)) chan<- dynamic.Message {
+	if delivered == nil {
+		return configurationChan
+	}
+
+	trackedChan := make(chan dynamic.Message)
+	pool.GoCtx(func(ctx context.Context) {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case msg := <-trackedChan:
+				if delivered != nil {
+					delivered(msg.ProviderName)
+					delivered = nil
+				}
+
+				select {
+				case <-ctx.Done():
+					return
+				case configurationChan <- msg:
+				}
+			}
+		}
+	})
+
+	return trackedChan
+}
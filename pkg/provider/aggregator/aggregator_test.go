@@ -2,6 +2,7 @@ package aggregator
 
 import (
 	"bytes"
+	"net/http"
 	"testing"
 	"time"
 
@@ -10,7 +11,9 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+	"github.com/traefik/traefik/v3/pkg/ping"
 	"github.com/traefik/traefik/v3/pkg/provider"
+	"github.com/traefik/traefik/v3/pkg/provider/rest"
 	"github.com/traefik/traefik/v3/pkg/safe"
 )
 
@@ -44,6 +47,39 @@ func TestProviderAggregator_Provide(t *testing.T) {
 	require.NoError(t, <-errCh)
 }
 
+func TestProviderAggregator_ProvideReadiness(t *testing.T) {
+	readiness := ping.NewReadiness(http.StatusServiceUnavailable)
+
+	aggregator := ProviderAggregator{
+		internalProvider: &providerMock{"internal"},
+		fileProvider:     &providerMock{"file"},
+		providers: []provider.Provider{
+			&providerMock{"salad"},
+			&mockNamespacedProvider{namespace: "silent"},
+			&rest.Provider{},
+		},
+		readiness: readiness,
+	}
+
+	cfgCh := make(chan dynamic.Message)
+	pool := safe.NewPool(t.Context())
+
+	t.Cleanup(pool.Stop)
+
+	go func() {
+		_ = aggregator.Provide(cfgCh, pool)
+	}()
+
+	requireReceivedMessageFromProviders(t, cfgCh, []string{"file", "salad", "internal"})
+
+	// The delivered providers are pending until their configuration is applied, and the REST provider is not awaited.
+	assert.Equal(t, []string{"aggregator", "file", "internal", "salad"}, readiness.Status().PendingProviders)
+
+	readiness.ConfigurationApplied([]string{"file", "internal", "salad"})
+
+	assert.Equal(t, []string{"aggregator"}, readiness.Status().PendingProviders)
+}
+
 func TestLaunchNamespacedProvider(t *testing.T) {
 	// Capture log output
 	var buf bytes.Buffer
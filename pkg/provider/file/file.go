@@ -10,12 +10,16 @@ import (
 	"os/signal"
 	"path"
 	"path/filepath"
+	"slices"
 	"strings"
+	"sync"
 	"syscall"
 	"text/template"
+	"time"
 
 	"github.com/Masterminds/sprig/v3"
 	"github.com/fsnotify/fsnotify"
+	gokitmetrics "github.com/go-kit/kit/metrics"
 	"github.com/rs/zerolog/log"
 	"github.com/traefik/paerser/file"
 	"github.com/traefik/traefik/v3/pkg/config/dynamic"
@@ -32,10 +36,31 @@ var _ provider.Provider = (*Provider)(nil)
 
 // Provider holds configurations of the provider.
 type Provider struct {
-	Directory                 string `description:"Load dynamic configuration from one or more .yml or .toml files in a directory." json:"directory,omitempty" toml:"directory,omitempty" yaml:"directory,omitempty" export:"true"`
-	Watch                     bool   `description:"Watch provider." json:"watch,omitempty" toml:"watch,omitempty" yaml:"watch,omitempty" export:"true"`
-	Filename                  string `description:"Load dynamic configuration from a file." json:"filename,omitempty" toml:"filename,omitempty" yaml:"filename,omitempty" export:"true"`
-	DebugLogGeneratedTemplate bool   `description:"Enable debug logging of generated configuration template." json:"debugLogGeneratedTemplate,omitempty" toml:"debugLogGeneratedTemplate,omitempty" yaml:"debugLogGeneratedTemplate,omitempty" export:"true"`
+	Directory                 string   `description:"Load dynamic configuration from one or more .yml, .toml or .json files in a directory." json:"directory,omitempty" toml:"directory,omitempty" yaml:"directory,omitempty" export:"true"`
+	Include                   []string `description:"Glob patterns of the files to load from the directory." json:"include,omitempty" toml:"include,omitempty" yaml:"include,omitempty" export:"true"`
+	Exclude                   []string `description:"Glob patterns of the files to ignore in the directory." json:"exclude,omitempty" toml:"exclude,omitempty" yaml:"exclude,omitempty" export:"true"`
+	Watch                     bool     `description:"Watch provider." json:"watch,omitempty" toml:"watch,omitempty" yaml:"watch,omitempty" export:"true"`
+	Filename                  string   `description:"Load dynamic configuration from a file." json:"filename,omitempty" toml:"filename,omitempty" yaml:"filename,omitempty" export:"true"`
+	DebugLogGeneratedTemplate bool     `description:"Enable debug logging of generated configuration template." json:"debugLogGeneratedTemplate,omitempty" toml:"debugLogGeneratedTemplate,omitempty" yaml:"debugLogGeneratedTemplate,omitempty" export:"true"`
+
+	// lock protects the state of the directory files below,
+	// as the configuration can be built concurrently by the watcher and on SIGHUP.
+	lock sync.Mutex
+	// lastConfigs holds the last configuration successfully loaded from each file of the directory.
+	lastConfigs map[string]*dynamic.Configuration
+	// fileErrors holds the error of each file of the directory failing to load.
+	fileErrors  map[string]FileError
+	errorsGauge gokitmetrics.Gauge
+}
+
+// FileError describes a file of the directory which failed to load.
+// While it fails, the last configuration successfully loaded from this file is used, if any.
+type FileError struct {
+	File  string    `json:"file"`
+	Error string    `json:"error"`
+	Since time.Time `json:"since"`
+	// Stale reports whether the last configuration successfully loaded from the file is used in place.
+	Stale bool `json:"stale"`
 }
 
 // SetDefaults sets the default values.
@@ -46,9 +71,38 @@ func (p *Provider) SetDefaults() {
 
 // Init the provider.
 func (p *Provider) Init() error {
+	for _, pattern := range slices.Concat(p.Include, p.Exclude) {
+		if _, err := filepath.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid glob pattern %q: %w", pattern, err)
+		}
+	}
+
 	return nil
 }
 
+// SetErrorsGauge sets the gauge reporting the files of the directory failing to load.
+// It must be called before the provider is started.
+func (p *Provider) SetErrorsGauge(gauge gokitmetrics.Gauge) {
+	p.errorsGauge = gauge
+}
+
+// Errors returns the files of the directory currently failing to load, sorted by name.
+func (p *Provider) Errors() []FileError {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	fileErrors := make([]FileError, 0, len(p.fileErrors))
+	for _, fileError := range p.fileErrors {
+		fileErrors = append(fileErrors, fileError)
+	}
+
+	slices.SortFunc(fileErrors, func(a, b FileError) int {
+		return strings.Compare(a.File, b.File)
+	})
+
+	return fileErrors
+}
+
 // Provide allows the file provider to provide configurations to traefik
 // using the given configuration channel.
 func (p *Provider) Provide(configurationChan chan<- dynamic.Message, pool *safe.Pool) error {
@@ -221,11 +275,21 @@ func (p *Provider) buildConfiguration() (*dynamic.Configuration, error) {
 	ctx := log.With().Str(logs.ProviderName, providerName).Logger().WithContext(context.Background())
 
 	if len(p.Directory) > 0 {
-		configurations, err := p.collectFileConfigs(ctx, p.Directory, "")
+		p.lock.Lock()
+		defer p.lock.Unlock()
+
+		state := &directoryState{
+			configs: make(map[string]*dynamic.Configuration),
+			errors:  make(map[string]FileError),
+		}
+
+		configurations, err := p.collectFileConfigs(ctx, p.Directory, "", state)
 		if err != nil {
 			return nil, fmt.Errorf("collecting file configs: %w", err)
 		}
 
+		p.updateDirectoryState(state)
+
 		return provider.Merge(ctx, configurations, provider.ResourceStrategySkipDuplicates), nil
 	}
 
@@ -381,8 +445,16 @@ func (p *Provider) loadFileConfig(ctx context.Context, filename string, parseTem
 	return configuration, nil
 }
 
+// directoryState holds the outcome of a directory load.
+type directoryState struct {
+	configs map[string]*dynamic.Configuration
+	errors  map[string]FileError
+}
+
 // collectFileConfigs recursively collects configurations from files in the given directory.
-func (p *Provider) collectFileConfigs(ctx context.Context, directory, prefix string) ([]provider.NamedConfiguration, error) {
+// A file (or subdirectory) failing to load does not prevent the others from being loaded:
+// its error is recorded, and the last configuration successfully loaded from it is used instead, if any.
+func (p *Provider) collectFileConfigs(ctx context.Context, directory, prefix string, state *directoryState) ([]provider.NamedConfiguration, error) {
 	var configurations []provider.NamedConfiguration
 
 	fileList, err := os.ReadDir(directory)
@@ -398,26 +470,37 @@ func (p *Provider) collectFileConfigs(ctx context.Context, directory, prefix str
 		}
 
 		if item.IsDir() {
-			sub, err := p.collectFileConfigs(ctx, itemPath, filename)
+			sub, err := p.collectFileConfigs(ctx, itemPath, filename, state)
 			if err != nil {
-				return nil, fmt.Errorf("loading content configuration from subdirectory %s: %w", item, err)
+				log.Ctx(ctx).Error().Err(err).Msgf("Unable to load configuration from subdirectory %s", itemPath)
+
+				configurations = append(configurations, p.recordError(state, filename, err, true)...)
+				continue
 			}
 			configurations = append(configurations, sub...)
 			continue
 		}
 
 		switch strings.ToLower(filepath.Ext(item.Name())) {
-		case ".toml", ".yaml", ".yml":
+		case ".toml", ".yaml", ".yml", ".json":
 			// noop
 		default:
 			continue
 		}
 
+		if !p.isIncluded(filename) {
+			continue
+		}
+
 		c, err := p.loadFileConfig(ctx, itemPath, true)
 		if err != nil {
-			return nil, fmt.Errorf("%s: %w", itemPath, err)
+			log.Ctx(ctx).Error().Err(err).Msgf("Unable to load configuration from file %s", itemPath)
+
+			configurations = append(configurations, p.recordError(state, filename, err, false)...)
+			continue
 		}
 
+		state.configs[filename] = c.DeepCopy()
 		configurations = append(configurations, provider.NamedConfiguration{
 			Name:          filename,
 			Configuration: c,
@@ -427,6 +510,85 @@ func (p *Provider) collectFileConfigs(ctx context.Context, directory, prefix str
 	return configurations, nil
 }
 
+// recordError records the error of the given file, or of the files of the given subdirectory,
+// and returns their last configurations successfully loaded.
+func (p *Provider) recordError(state *directoryState, filename string, err error, isDir bool) []provider.NamedConfiguration {
+	var configurations []provider.NamedConfiguration
+	for name, c := range p.lastConfigs {
+		if name != filename && (!isDir || !strings.HasPrefix(name, filename+string(filepath.Separator))) {
+			continue
+		}
+
+		state.configs[name] = c
+		configurations = append(configurations, provider.NamedConfiguration{
+			Name:          name,
+			Configuration: c,
+		})
+	}
+
+	// Sorting to keep the merge order stable.
+	slices.SortFunc(configurations, func(a, b provider.NamedConfiguration) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	since := time.Now()
+	if previous, ok := p.fileErrors[filename]; ok && previous.Error == err.Error() {
+		since = previous.Since
+	}
+
+	state.errors[filename] = FileError{
+		File:  filename,
+		Error: err.Error(),
+		Since: since,
+		Stale: len(configurations) > 0,
+	}
+
+	return configurations
+}
+
+// updateDirectoryState replaces the state of the directory files with the outcome of the last load.
+func (p *Provider) updateDirectoryState(state *directoryState) {
+	if p.errorsGauge != nil {
+		for name := range p.fileErrors {
+			if _, ok := state.errors[name]; !ok {
+				p.errorsGauge.With("file", name).Set(0)
+			}
+		}
+
+		for name := range state.errors {
+			p.errorsGauge.With("file", name).Set(1)
+		}
+	}
+
+	p.lastConfigs = state.configs
+	p.fileErrors = state.errors
+}
+
+// isIncluded reports whether the file, given by its path relative to the directory, matches the include and exclude patterns.
+// A pattern containing a path separator is matched against the relative path, otherwise against the file name.
+func (p *Provider) isIncluded(filename string) bool {
+	if len(p.Include) > 0 && !matchAny(p.Include, filename) {
+		return false
+	}
+
+	return !matchAny(p.Exclude, filename)
+}
+
+func matchAny(patterns []string, filename string) bool {
+	for _, pattern := range patterns {
+		name := filename
+		if !strings.ContainsRune(pattern, '/') {
+			name = filepath.Base(filename)
+		}
+
+		if ok, _ := filepath.Match(filepath.FromSlash(pattern), name); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (p *Provider) decodeConfiguration(filePath, content string) (*dynamic.Configuration, error) {
 	configuration := &dynamic.Configuration{
 		HTTP: &dynamic.HTTPConfiguration{
@@ -106,6 +106,136 @@ func TestErrorWhenEmptyConfig(t *testing.T) {
 	}
 }
 
+func TestBuildConfiguration_faultIsolation(t *testing.T) {
+	tempDir := t.TempDir()
+
+	writeFile(t, filepath.Join(tempDir, "foo.toml"), "[http.routers.foo]\n  rule = \"Host(`foo.localhost`)\"\n  service = \"foo\"\n")
+	writeFile(t, filepath.Join(tempDir, "bar.yml"), "http:\n  routers:\n    bar:\n      rule: Host(`bar.localhost`)\n      service: bar\n")
+
+	provider := &Provider{Directory: tempDir}
+
+	conf, err := provider.buildConfiguration()
+	require.NoError(t, err)
+	assert.Len(t, conf.HTTP.Routers, 2)
+	assert.Empty(t, provider.Errors())
+
+	// The last good version of a broken file is kept.
+	writeFile(t, filepath.Join(tempDir, "bar.yml"), "http:\n  routers:\n    bar: [\n")
+	// A new broken file is skipped.
+	writeFile(t, filepath.Join(tempDir, "baz.toml"), "[http.routers.baz\n")
+
+	conf, err = provider.buildConfiguration()
+	require.NoError(t, err)
+	assert.Len(t, conf.HTTP.Routers, 2)
+	assert.Contains(t, conf.HTTP.Routers, "bar")
+
+	fileErrors := provider.Errors()
+	require.Len(t, fileErrors, 2)
+	assert.Equal(t, "bar.yml", fileErrors[0].File)
+	assert.True(t, fileErrors[0].Stale)
+	assert.Equal(t, "baz.toml", fileErrors[1].File)
+	assert.False(t, fileErrors[1].Stale)
+
+	since := fileErrors[0].Since
+
+	conf, err = provider.buildConfiguration()
+	require.NoError(t, err)
+	assert.Len(t, conf.HTTP.Routers, 2)
+
+	fileErrors = provider.Errors()
+	require.Len(t, fileErrors, 2)
+	assert.Equal(t, since, fileErrors[0].Since)
+
+	// Fixed and removed files are not reported anymore.
+	writeFile(t, filepath.Join(tempDir, "bar.yml"), "http:\n  routers:\n    bar2:\n      rule: Host(`bar.localhost`)\n      service: bar\n")
+	require.NoError(t, os.Remove(filepath.Join(tempDir, "baz.toml")))
+
+	conf, err = provider.buildConfiguration()
+	require.NoError(t, err)
+	assert.Len(t, conf.HTTP.Routers, 2)
+	assert.Contains(t, conf.HTTP.Routers, "bar2")
+	assert.Empty(t, provider.Errors())
+}
+
+func TestBuildConfiguration_json(t *testing.T) {
+	tempDir := t.TempDir()
+
+	writeFile(t, filepath.Join(tempDir, "foo.json"), `{"http": {"routers": {"foo": {"rule": "Host(`+"`foo.localhost`"+`)", "service": "foo"}}}}`)
+
+	provider := &Provider{Directory: tempDir}
+
+	conf, err := provider.buildConfiguration()
+	require.NoError(t, err)
+	require.Contains(t, conf.HTTP.Routers, "foo")
+	assert.Equal(t, "Host(`foo.localhost`)", conf.HTTP.Routers["foo"].Rule)
+}
+
+func TestBuildConfiguration_includeExclude(t *testing.T) {
+	testCases := []struct {
+		desc            string
+		include         []string
+		exclude         []string
+		expectedRouters []string
+	}{
+		{
+			desc:            "no patterns",
+			expectedRouters: []string{"bar", "baz", "foo"},
+		},
+		{
+			desc:            "include by name",
+			include:         []string{"*.yml"},
+			expectedRouters: []string{"bar", "baz"},
+		},
+		{
+			desc:            "include by path",
+			include:         []string{"sub/*"},
+			expectedRouters: []string{"baz"},
+		},
+		{
+			desc:            "exclude by name",
+			exclude:         []string{"ba?.yml"},
+			expectedRouters: []string{"foo"},
+		},
+		{
+			desc:            "include and exclude",
+			include:         []string{"*.yml"},
+			exclude:         []string{"sub/*"},
+			expectedRouters: []string{"bar"},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			tempDir := t.TempDir()
+			require.NoError(t, os.Mkdir(filepath.Join(tempDir, "sub"), 0o755))
+
+			writeFile(t, filepath.Join(tempDir, "foo.toml"), "[http.routers.foo]\n  service = \"foo\"\n")
+			writeFile(t, filepath.Join(tempDir, "bar.yml"), "http:\n  routers:\n    bar:\n      service: bar\n")
+			writeFile(t, filepath.Join(tempDir, "sub", "baz.yml"), "http:\n  routers:\n    baz:\n      service: baz\n")
+
+			provider := &Provider{Directory: tempDir, Include: test.include, Exclude: test.exclude}
+			require.NoError(t, provider.Init())
+
+			conf, err := provider.buildConfiguration()
+			require.NoError(t, err)
+
+			var routers []string
+			for name := range conf.HTTP.Routers {
+				routers = append(routers, name)
+			}
+			assert.ElementsMatch(t, test.expectedRouters, routers)
+		})
+	}
+}
+
+func TestInit_invalidPattern(t *testing.T) {
+	provider := &Provider{Directory: t.TempDir(), Include: []string{"[a-"}}
+
+	assert.Error(t, provider.Init())
+}
+
 func TestProvideWithoutWatch(t *testing.T) {
 	for _, test := range getTestCases() {
 		t.Run(test.desc+" without watch", func(t *testing.T) {
@@ -356,3 +486,10 @@ func createTempFile(srcPath, tempDir string) (*os.File, error) {
 	_, err = io.Copy(file, src)
 	return file, err
 }
+
+func writeFile(t *testing.T, filename, content string) {
+	t.Helper()
+
+	err := os.WriteFile(filename, []byte(content), 0o644)
+	require.NoError(t, err)
+}
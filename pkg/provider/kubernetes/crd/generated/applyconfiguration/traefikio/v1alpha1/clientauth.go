@@ -29,8 +29,9 @@ package v1alpha1
 // ClientAuthApplyConfiguration represents a declarative configuration of the ClientAuth type for use
 // with apply.
 type ClientAuthApplyConfiguration struct {
-	SecretNames    []string `json:"secretNames,omitempty"`
-	ClientAuthType *string  `json:"clientAuthType,omitempty"`
+	SecretNames    []string                      `json:"secretNames,omitempty"`
+	ClientAuthType *string                       `json:"clientAuthType,omitempty"`
+	Revocation     *RevocationApplyConfiguration `json:"revocation,omitempty"`
 }
 
 // ClientAuthApplyConfiguration constructs a declarative configuration of the ClientAuth type for use with
@@ -56,3 +57,11 @@ func (b *ClientAuthApplyConfiguration) WithClientAuthType(value string) *ClientA
 	b.ClientAuthType = &value
 	return b
 }
+
+// WithRevocation sets the Revocation field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Revocation field is set to the value of the last call.
+func (b *ClientAuthApplyConfiguration) WithRevocation(value *RevocationApplyConfiguration) *ClientAuthApplyConfiguration {
+	b.Revocation = value
+	return b
+}
@@ -59,6 +59,9 @@ type MiddlewareSpecApplyConfiguration struct {
 	Retry             *RetryApplyConfiguration          `json:"retry,omitempty"`
 	ContentType       *dynamic.ContentType              `json:"contentType,omitempty"`
 	GrpcWeb           *dynamic.GrpcWeb                  `json:"grpcWeb,omitempty"`
+	FaultInjection    *FaultInjectionApplyConfiguration `json:"faultInjection,omitempty"`
+	RequestID         *dynamic.RequestID                `json:"requestID,omitempty"`
+	GeoBlock          *dynamic.GeoBlock                 `json:"geoBlock,omitempty"`
 	Plugin            map[string]v1.JSON                `json:"plugin,omitempty"`
 }
 
@@ -268,6 +271,30 @@ func (b *MiddlewareSpecApplyConfiguration) WithGrpcWeb(value dynamic.GrpcWeb) *M
 	return b
 }
 
+// WithFaultInjection sets the FaultInjection field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the FaultInjection field is set to the value of the last call.
+func (b *MiddlewareSpecApplyConfiguration) WithFaultInjection(value *FaultInjectionApplyConfiguration) *MiddlewareSpecApplyConfiguration {
+	b.FaultInjection = value
+	return b
+}
+
+// WithRequestID sets the RequestID field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RequestID field is set to the value of the last call.
+func (b *MiddlewareSpecApplyConfiguration) WithRequestID(value dynamic.RequestID) *MiddlewareSpecApplyConfiguration {
+	b.RequestID = &value
+	return b
+}
+
+// WithGeoBlock sets the GeoBlock field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the GeoBlock field is set to the value of the last call.
+func (b *MiddlewareSpecApplyConfiguration) WithGeoBlock(value dynamic.GeoBlock) *MiddlewareSpecApplyConfiguration {
+	b.GeoBlock = &value
+	return b
+}
+
 // WithPlugin puts the entries into the Plugin field in the declarative configuration
 // and returns the receiver, so that objects can be build by chaining "With" function invocations.
 // If called multiple times, the entries provided by each call will be put on the Plugin field,
@@ -62,6 +62,10 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &traefikiov1alpha1.DigestAuthApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("ErrorPage"):
 		return &traefikiov1alpha1.ErrorPageApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("FaultDelay"):
+		return &traefikiov1alpha1.FaultDelayApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("FaultInjection"):
+		return &traefikiov1alpha1.FaultInjectionApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("ForwardAuth"):
 		return &traefikiov1alpha1.ForwardAuthApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("ForwardingTimeouts"):
@@ -110,6 +114,8 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &traefikiov1alpha1.ResponseForwardingApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("Retry"):
 		return &traefikiov1alpha1.RetryApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("Revocation"):
+		return &traefikiov1alpha1.RevocationApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("RootCA"):
 		return &traefikiov1alpha1.RootCAApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("Route"):
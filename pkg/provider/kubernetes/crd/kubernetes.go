@@ -302,6 +302,12 @@ func (p *Provider) loadConfigurationFromCRD(ctx context.Context, client Client)
 			continue
 		}
 
+		faultInjection, err := createFaultInjectionMiddleware(middleware.Spec.FaultInjection)
+		if err != nil {
+			logger.Error().Err(err).Msg("Error while reading fault injection middleware")
+			continue
+		}
+
 		conf.HTTP.Middlewares[id] = &dynamic.Middleware{
 			AddPrefix:         middleware.Spec.AddPrefix,
 			StripPrefix:       middleware.Spec.StripPrefix,
@@ -328,6 +334,9 @@ func (p *Provider) loadConfigurationFromCRD(ctx context.Context, client Client)
 			Retry:             retry,
 			ContentType:       middleware.Spec.ContentType,
 			GrpcWeb:           middleware.Spec.GrpcWeb,
+			FaultInjection:    faultInjection,
+			RequestID:         createRequestIDMiddleware(middleware.Spec.RequestID),
+			GeoBlock:          middleware.Spec.GeoBlock,
 			Plugin:            plugin,
 		}
 	}
@@ -828,6 +837,64 @@ func createCircuitBreakerMiddleware(circuitBreaker *traefikv1alpha1.CircuitBreak
 	return cb, nil
 }
 
+func createFaultInjectionMiddleware(faultInjection *traefikv1alpha1.FaultInjection) (*dynamic.FaultInjection, error) {
+	if faultInjection == nil {
+		return nil, nil
+	}
+
+	fi := &dynamic.FaultInjection{
+		Headers:           faultInjection.Headers,
+		Abort:             faultInjection.Abort,
+		ResetConnection:   faultInjection.ResetConnection,
+		ResponseBandwidth: faultInjection.ResponseBandwidth,
+	}
+	fi.SetDefaults()
+
+	if faultInjection.Percentage != nil {
+		fi.Percentage = *faultInjection.Percentage
+	}
+
+	if faultInjection.Delay != nil {
+		fi.Delay = &dynamic.FaultDelay{}
+
+		if faultInjection.Delay.Duration != nil {
+			if err := fi.Delay.Duration.Set(faultInjection.Delay.Duration.String()); err != nil {
+				return nil, err
+			}
+		}
+
+		if faultInjection.Delay.Jitter != nil {
+			if err := fi.Delay.Jitter.Set(faultInjection.Delay.Jitter.String()); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return fi, nil
+}
+
+func createRequestIDMiddleware(requestID *dynamic.RequestID) *dynamic.RequestID {
+	if requestID == nil {
+		return nil
+	}
+
+	r := &dynamic.RequestID{
+		FromTraceID:    requestID.FromTraceID,
+		IgnoreIncoming: requestID.IgnoreIncoming,
+	}
+	r.SetDefaults()
+
+	if requestID.HeaderName != "" {
+		r.HeaderName = requestID.HeaderName
+	}
+
+	if requestID.Generator != "" {
+		r.Generator = requestID.Generator
+	}
+
+	return r
+}
+
 func createCompressMiddleware(compress *traefikv1alpha1.Compress) *dynamic.Compress {
 	if compress == nil {
 		return nil
@@ -887,72 +954,83 @@ func createRateLimitMiddleware(client Client, namespace string, rateLimit *traef
 	}
 
 	if rateLimit.Redis != nil {
-		rl.Redis = &dynamic.Redis{
-			DB:             rateLimit.Redis.DB,
-			PoolSize:       rateLimit.Redis.PoolSize,
-			MinIdleConns:   rateLimit.Redis.MinIdleConns,
-			MaxActiveConns: rateLimit.Redis.MaxActiveConns,
+		var err error
+		rl.Redis, err = createRedis(client, namespace, rateLimit.Redis)
+		if err != nil {
+			return nil, err
 		}
-		rl.Redis.SetDefaults()
+	}
 
-		if len(rateLimit.Redis.Endpoints) > 0 {
-			rl.Redis.Endpoints = rateLimit.Redis.Endpoints
-		}
+	return rl, nil
+}
 
-		if rateLimit.Redis.TLS != nil {
-			rl.Redis.TLS = &types.ClientTLS{
-				InsecureSkipVerify: rateLimit.Redis.TLS.InsecureSkipVerify,
-			}
+// createRedis converts the Redis configuration of a middleware, loading the referenced secrets.
+func createRedis(client Client, namespace string, redis *traefikv1alpha1.Redis) (*dynamic.Redis, error) {
+	r := &dynamic.Redis{
+		DB:             redis.DB,
+		PoolSize:       redis.PoolSize,
+		MinIdleConns:   redis.MinIdleConns,
+		MaxActiveConns: redis.MaxActiveConns,
+	}
+	r.SetDefaults()
 
-			if len(rateLimit.Redis.TLS.CASecret) > 0 {
-				caSecret, err := loadCASecret(namespace, rateLimit.Redis.TLS.CASecret, client)
-				if err != nil {
-					return nil, fmt.Errorf("failed to load auth ca secret: %w", err)
-				}
-				rl.Redis.TLS.CA = caSecret
-			}
+	if len(redis.Endpoints) > 0 {
+		r.Endpoints = redis.Endpoints
+	}
 
-			if len(rateLimit.Redis.TLS.CertSecret) > 0 {
-				authSecretCert, authSecretKey, err := loadAuthTLSSecret(namespace, rateLimit.Redis.TLS.CertSecret, client)
-				if err != nil {
-					return nil, fmt.Errorf("failed to load auth secret: %w", err)
-				}
-				rl.Redis.TLS.Cert = authSecretCert
-				rl.Redis.TLS.Key = authSecretKey
-			}
+	if redis.TLS != nil {
+		r.TLS = &types.ClientTLS{
+			InsecureSkipVerify: redis.TLS.InsecureSkipVerify,
 		}
 
-		if rateLimit.Redis.DialTimeout != nil {
-			err := rl.Redis.DialTimeout.Set(rateLimit.Redis.DialTimeout.String())
+		if len(redis.TLS.CASecret) > 0 {
+			caSecret, err := loadCASecret(namespace, redis.TLS.CASecret, client)
 			if err != nil {
-				return nil, err
+				return nil, fmt.Errorf("failed to load auth ca secret: %w", err)
 			}
+			r.TLS.CA = caSecret
 		}
 
-		if rateLimit.Redis.ReadTimeout != nil {
-			err := rl.Redis.ReadTimeout.Set(rateLimit.Redis.ReadTimeout.String())
+		if len(redis.TLS.CertSecret) > 0 {
+			authSecretCert, authSecretKey, err := loadAuthTLSSecret(namespace, redis.TLS.CertSecret, client)
 			if err != nil {
-				return nil, err
+				return nil, fmt.Errorf("failed to load auth secret: %w", err)
 			}
+			r.TLS.Cert = authSecretCert
+			r.TLS.Key = authSecretKey
 		}
+	}
 
-		if rateLimit.Redis.WriteTimeout != nil {
-			err := rl.Redis.WriteTimeout.Set(rateLimit.Redis.WriteTimeout.String())
-			if err != nil {
-				return nil, err
-			}
+	if redis.DialTimeout != nil {
+		err := r.DialTimeout.Set(redis.DialTimeout.String())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if redis.ReadTimeout != nil {
+		err := r.ReadTimeout.Set(redis.ReadTimeout.String())
+		if err != nil {
+			return nil, err
 		}
+	}
 
-		if rateLimit.Redis.Secret != "" {
-			var err error
-			rl.Redis.Username, rl.Redis.Password, err = loadRedisCredentials(namespace, rateLimit.Redis.Secret, client)
-			if err != nil {
-				return nil, err
-			}
+	if redis.WriteTimeout != nil {
+		err := r.WriteTimeout.Set(redis.WriteTimeout.String())
+		if err != nil {
+			return nil, err
 		}
 	}
 
-	return rl, nil
+	if redis.Secret != "" {
+		var err error
+		r.Username, r.Password, err = loadRedisCredentials(namespace, redis.Secret, client)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return r, nil
 }
 
 func loadRedisCredentials(namespace, secretName string, k8sClient Client) (string, string, error) {
@@ -1027,6 +1105,7 @@ func createForwardAuthMiddleware(k8sClient Client, namespace string, auth *traef
 		PreserveLocationHeader:   auth.PreserveLocationHeader,
 		PreserveRequestMethod:    auth.PreserveRequestMethod,
 		AuthSigninURL:            auth.AuthSigninURL,
+		GRPC:                     auth.GRPC,
 	}
 	forwardAuth.SetDefaults()
 
@@ -1063,9 +1142,52 @@ func createForwardAuthMiddleware(k8sClient Client, namespace string, auth *traef
 		forwardAuth.TLS.CAOptional = auth.TLS.CAOptional
 	}
 
+	if auth.Cache != nil {
+		cache, err := createForwardAuthCache(k8sClient, namespace, auth.Cache)
+		if err != nil {
+			return nil, err
+		}
+		forwardAuth.Cache = cache
+	}
+
 	return forwardAuth, nil
 }
 
+func createForwardAuthCache(k8sClient Client, namespace string, cache *traefikv1alpha1.ForwardAuthCache) (*dynamic.ForwardAuthCache, error) {
+	c := &dynamic.ForwardAuthCache{}
+	c.SetDefaults()
+
+	if cache.Key != nil {
+		c.Key = *cache.Key
+	}
+
+	if cache.TTL != nil {
+		if err := c.TTL.Set(cache.TTL.String()); err != nil {
+			return nil, err
+		}
+	}
+
+	if cache.NegativeTTL != nil {
+		if err := c.NegativeTTL.Set(cache.NegativeTTL.String()); err != nil {
+			return nil, err
+		}
+	}
+
+	if cache.MaxEntries != nil {
+		c.MaxEntries = *cache.MaxEntries
+	}
+
+	if cache.Redis != nil {
+		var err error
+		c.Redis, err = createRedis(k8sClient, namespace, cache.Redis)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return c, nil
+}
+
 func loadCASecret(namespace, secretName string, k8sClient Client) (string, error) {
 	secret, ok, err := k8sClient.GetSecret(namespace, secretName)
 	if err != nil {
@@ -1352,6 +1474,42 @@ func buildTLSOptions(ctx context.Context, client Client) map[string]tls.Options
 			CAFiles:        clientCAs,
 			ClientAuthType: tlsOptionsCRD.Spec.ClientAuth.ClientAuthType,
 		}
+
+		if revocation := tlsOptionsCRD.Spec.ClientAuth.Revocation; revocation != nil {
+			tlsOption.ClientAuth.Revocation = &tls.Revocation{
+				CRLURLs:  revocation.CRLURLs,
+				OCSP:     revocation.OCSP,
+				SoftFail: revocation.SoftFail,
+			}
+
+			for _, secretName := range revocation.CRLSecretNames {
+				secret, exists, err := client.GetSecret(tlsOptionsCRD.Namespace, secretName)
+				if err != nil {
+					logger.Error().Err(err).Msgf("Failed to fetch secret %s/%s", tlsOptionsCRD.Namespace, secretName)
+					continue
+				}
+
+				if !exists {
+					logger.Warn().Msgf("Secret %s/%s does not exist", tlsOptionsCRD.Namespace, secretName)
+					continue
+				}
+
+				crl, ok := secret.Data["ca.crl"]
+				if !ok {
+					logger.Error().Msgf("Secret %s/%s does not contain ca.crl", tlsOptionsCRD.Namespace, secretName)
+					continue
+				}
+
+				tlsOption.ClientAuth.Revocation.CRLFiles = append(tlsOption.ClientAuth.Revocation.CRLFiles, types.FileOrContent(crl))
+			}
+
+			if revocation.RefreshInterval != nil {
+				err := tlsOption.ClientAuth.Revocation.RefreshInterval.Set(revocation.RefreshInterval.String())
+				if err != nil {
+					logger.Error().Err(err).Msg("Error while reading RefreshInterval")
+				}
+			}
+		}
 		tlsOption.SniStrict = tlsOptionsCRD.Spec.SniStrict
 
 		if tlsOptionsCRD.Spec.ALPNProtocols != nil {
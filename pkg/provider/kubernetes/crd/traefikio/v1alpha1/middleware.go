@@ -52,6 +52,9 @@ type MiddlewareSpec struct {
 	Retry             *Retry                     `json:"retry,omitempty"`
 	ContentType       *dynamic.ContentType       `json:"contentType,omitempty"`
 	GrpcWeb           *dynamic.GrpcWeb           `json:"grpcWeb,omitempty"`
+	FaultInjection    *FaultInjection            `json:"faultInjection,omitempty"`
+	RequestID         *dynamic.RequestID         `json:"requestID,omitempty"`
+	GeoBlock          *dynamic.GeoBlock          `json:"geoBlock,omitempty"`
 	// Plugin defines the middleware plugin configuration.
 	// More info: https://doc.traefik.io/traefik/v3.6/reference/routing-configuration/http/middlewares/overview/#community-middlewares
 	Plugin map[string]apiextensionv1.JSON `json:"plugin,omitempty"`
@@ -133,6 +136,45 @@ type CircuitBreaker struct {
 
 // +k8s:deepcopy-gen=true
 
+// FaultInjection holds the fault injection middleware configuration.
+// This middleware injects faults (delays, aborts, connection resets and bandwidth limits) into a percentage of the requests.
+// More info: https://doc.traefik.io/traefik/v3.6/reference/routing-configuration/http/middlewares/faultinjection/
+type FaultInjection struct {
+	// Percentage defines the percentage of the matching requests into which the faults are injected.
+	// Default: 100.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	Percentage *int `json:"percentage,omitempty"`
+	// Headers restricts the fault injection to the requests having all the given headers, with the given values.
+	// An empty value matches any value of the header.
+	Headers map[string]string `json:"headers,omitempty"`
+	// Delay defines the delay added to the requests before they are forwarded.
+	Delay *FaultDelay `json:"delay,omitempty"`
+	// Abort defines the response returned in place of forwarding the requests.
+	Abort *dynamic.FaultAbort `json:"abort,omitempty"`
+	// ResetConnection defines whether the client connection is reset in place of forwarding the requests.
+	ResetConnection bool `json:"resetConnection,omitempty"`
+	// ResponseBandwidth defines the maximum bandwidth of the responses, in bytes per second.
+	// +kubebuilder:validation:Minimum=0
+	ResponseBandwidth int64 `json:"responseBandwidth,omitempty"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// FaultDelay holds the fault injection delay configuration.
+type FaultDelay struct {
+	// Duration defines the fixed delay added to the requests.
+	// +kubebuilder:validation:Pattern="^([0-9]+(ns|us|µs|ms|s|m|h)?)+$"
+	// +kubebuilder:validation:XIntOrString
+	Duration *intstr.IntOrString `json:"duration,omitempty"`
+	// Jitter defines the maximum random delay added to the fixed delay.
+	// +kubebuilder:validation:Pattern="^([0-9]+(ns|us|µs|ms|s|m|h)?)+$"
+	// +kubebuilder:validation:XIntOrString
+	Jitter *intstr.IntOrString `json:"jitter,omitempty"`
+}
+
+// +k8s:deepcopy-gen=true
+
 // Chain holds the configuration of the chain middleware.
 // This middleware enables to define reusable combinations of other pieces of middleware.
 // More info: https://doc.traefik.io/traefik/v3.6/reference/routing-configuration/http/middlewares/chain/
@@ -215,6 +257,37 @@ type ForwardAuth struct {
 	PreserveRequestMethod bool `json:"preserveRequestMethod,omitempty"`
 	// AuthSigninURL specifies the URL to redirect to when the authentication server returns 401 Unauthorized.
 	AuthSigninURL string `json:"authSigninURL,omitempty"`
+	// GRPC defines whether to call the authentication server with the gRPC external authorization API,
+	// compatible with the Envoy ext_authz Authorization/Check API, instead of HTTP.
+	// When enabled, Address is the gRPC target of the authorization server, such as authz.example.com:9191.
+	GRPC bool `json:"grpc,omitempty"`
+	// Cache defines the configuration of the authentication decisions cache.
+	// When set, the responses of the authentication server are reused for the requests sharing the same cache key.
+	Cache *ForwardAuthCache `json:"cache,omitempty"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// ForwardAuthCache holds the ForwardAuth decisions cache configuration.
+type ForwardAuthCache struct {
+	// Key defines the request parts the cache key is computed from.
+	Key *dynamic.ForwardAuthCacheKey `json:"key,omitempty"`
+	// TTL defines how long the allow decisions are kept, unless the authentication server response has a Cache-Control header.
+	// Default value is 60s.
+	// +kubebuilder:validation:Pattern="^([0-9]+(ns|us|µs|ms|s|m|h)?)+$"
+	// +kubebuilder:validation:XIntOrString
+	TTL *intstr.IntOrString `json:"ttl,omitempty"`
+	// NegativeTTL defines how long the deny decisions are kept, unless the authentication server response has a Cache-Control header.
+	// Default value is 0s, meaning that the deny decisions are not cached.
+	// +kubebuilder:validation:Pattern="^([0-9]+(ns|us|µs|ms|s|m|h)?)+$"
+	// +kubebuilder:validation:XIntOrString
+	NegativeTTL *intstr.IntOrString `json:"negativeTTL,omitempty"`
+	// MaxEntries defines the maximum number of decisions kept in memory.
+	// Default value is 10000.
+	MaxEntries *int `json:"maxEntries,omitempty"`
+	// Redis stores the decisions in Redis, to share them across the Traefik instances.
+	// When set, MaxEntries is not used.
+	Redis *Redis `json:"redis,omitempty"`
 }
 
 // +k8s:deepcopy-gen=true
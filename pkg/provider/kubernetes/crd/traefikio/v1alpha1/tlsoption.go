@@ -2,6 +2,7 @@ package v1alpha1
 
 import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // +genclient
@@ -62,6 +63,23 @@ type ClientAuth struct {
 	// ClientAuthType defines the client authentication type to apply.
 	// +kubebuilder:validation:Enum=NoClientCert;RequestClientCert;RequireAnyClientCert;VerifyClientCertIfGiven;RequireAndVerifyClientCert
 	ClientAuthType string `json:"clientAuthType,omitempty"`
+	// Revocation defines the revocation checks applied to the verified client certificates.
+	Revocation *Revocation `json:"revocation,omitempty"`
+}
+
+// Revocation defines how the revocation status of client certificates is checked.
+type Revocation struct {
+	// CRLSecretNames defines the names of the referenced Kubernetes Secrets storing certificate revocation lists, under the ca.crl key.
+	CRLSecretNames []string `json:"crlSecretNames,omitempty"`
+	// CRLURLs defines the URLs from which the certificate revocation lists are downloaded.
+	CRLURLs []string `json:"crlURLs,omitempty"`
+	// RefreshInterval defines how often the certificate revocation lists are reloaded.
+	// +kubebuilder:validation:XIntOrString
+	RefreshInterval *intstr.IntOrString `json:"refreshInterval,omitempty"`
+	// OCSP enables checking the client certificates against the OCSP responders they advertise.
+	OCSP bool `json:"ocsp,omitempty"`
+	// SoftFail accepts the client certificates whose revocation status cannot be determined.
+	SoftFail bool `json:"softFail,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
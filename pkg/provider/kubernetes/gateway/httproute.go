@@ -674,6 +674,10 @@ func buildHostRule(hostnames []gatev1.Hostname) (string, int) {
 // * Largest number of query param matches (+10 each).
 //
 // In case of multiple matches for a route, the maximum priority among all matches is retain.
+//
+// The Gateway API defines no cookie, protocol version, client certificate or port match,
+// thus the Cookie, CookieRegexp, HTTPVersion, TLS, SNI, ClientCertSubject and Port matchers are never used:
+// the TLS termination and the port are given by the Gateway listener the route is attached to.
 func buildMatchRule(hostnames []gatev1.Hostname, match gatev1.HTTPRouteMatch) (string, int) {
 	path := ptr.Deref(match.Path, gatev1.HTTPPathMatch{
 		Type:  ptr.To(gatev1.PathMatchPathPrefix),
@@ -30,6 +30,7 @@ var resourceLogFields = map[reflect.Type]resourceMeta{
 	reflect.TypeFor[dynamic.TCPServersTransport](): {logs.ServersTransportName, "TCP servers transport"},
 	reflect.TypeFor[dynamic.UDPRouter]():           {logs.RouterName, "UDP router"},
 	reflect.TypeFor[dynamic.UDPService]():          {logs.ServiceName, "UDP service"},
+	reflect.TypeFor[dynamic.EntryPoint]():          {logs.EntryPointName, "entry point"},
 }
 
 // ResourceStrategy defines how the merge should handle resources.
@@ -101,6 +102,7 @@ func Merge(ctx context.Context, configurations []NamedConfiguration, strategy Re
 
 			merged.TLS.Certificates = mergeCertificates(ctx, merged.TLS.Certificates, c.Configuration.TLS.Certificates, c.Name, strategy)
 		}
+		mergeResourceMap(ctx, reflect.ValueOf(&merged.EntryPoints).Elem(), reflect.ValueOf(c.Configuration.EntryPoints), c.Name, tracker, strategy)
 	}
 
 	deleteConflicts(ctx, tracker)
@@ -481,6 +481,47 @@ func TestMerge(t *testing.T) {
 				}
 			}),
 		},
+		{
+			desc: "Entry points: multiple providers different entry points",
+			configurations: map[string]*dynamic.Configuration{
+				"provider1": {
+					EntryPoints: map[string]*dynamic.EntryPoint{
+						"tenant1": {Address: ":8001"},
+					},
+				},
+				"provider2": {
+					EntryPoints: map[string]*dynamic.EntryPoint{
+						"tenant2": {Address: ":8002"},
+					},
+				},
+			},
+			strategy: ResourceStrategySkipDuplicates,
+			expected: buildExpectedConfiguration(func(c *dynamic.Configuration) {
+				c.EntryPoints = map[string]*dynamic.EntryPoint{
+					"tenant1": {Address: ":8001"},
+					"tenant2": {Address: ":8002"},
+				}
+			}),
+		},
+		{
+			desc: "Entry points: conflict multiple providers same entry point different config",
+			configurations: map[string]*dynamic.Configuration{
+				"provider1": {
+					EntryPoints: map[string]*dynamic.EntryPoint{
+						"tenant1": {Address: ":8001"},
+					},
+				},
+				"provider2": {
+					EntryPoints: map[string]*dynamic.EntryPoint{
+						"tenant1": {Address: ":8002"},
+					},
+				},
+			},
+			strategy: ResourceStrategyMerge,
+			expected: buildExpectedConfiguration(func(c *dynamic.Configuration) {
+				c.EntryPoints = map[string]*dynamic.EntryPoint{}
+			}),
+		},
 		{
 			desc: "nil configuration from one provider",
 			configurations: map[string]*dynamic.Configuration{
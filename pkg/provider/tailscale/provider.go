@@ -72,7 +72,11 @@ func (p *Provider) Provide(dynMessages chan<- dynamic.Message, pool *safe.Pool)
 }
 
 // watchDomains watches for Tailscale domain certificates that should be fetched from the Tailscale daemon.
+// The configuration is always sent back for the first update,
+// which signals that the resolver is initialized, even when there is no certificate.
 func (p *Provider) watchDomains(ctx context.Context) {
+	var initialized bool
+
 	for {
 		select {
 		case <-ctx.Done():
@@ -83,10 +87,12 @@ func (p *Provider) watchDomains(ctx context.Context) {
 			newDomains := p.findNewDomains(domains)
 			purged := p.purgeUnusedCerts(domains)
 
-			if len(newDomains) == 0 && !purged {
+			if len(newDomains) == 0 && !purged && initialized {
 				continue
 			}
 
+			initialized = true
+
 			// TODO: what should we do if the fetched certificate is going to expire before the next refresh tick?
 			p.fetchCerts(ctx, newDomains)
 			p.sendDynamicConfig()
@@ -344,9 +344,19 @@ func (i *Provider) pingConfiguration(cfg *dynamic.Configuration) {
 			// "default" stands for the default rule syntax in Traefik v3, i.e. the v3 syntax.
 			RuleSyntax: "default",
 		}
+
+		cfg.HTTP.Routers["ready"] = &dynamic.Router{
+			EntryPoints: []string{i.staticCfg.Ping.EntryPoint},
+			Service:     "ready@internal",
+			Priority:    math.MaxInt,
+			Rule:        "Path(`/ready`)",
+			// "default" stands for the default rule syntax in Traefik v3, i.e. the v3 syntax.
+			RuleSyntax: "default",
+		}
 	}
 
 	cfg.HTTP.Services["ping"] = &dynamic.Service{}
+	cfg.HTTP.Services["ready"] = &dynamic.Service{}
 }
 
 func (i *Provider) restConfiguration(cfg *dynamic.Configuration) {
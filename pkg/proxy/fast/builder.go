@@ -11,6 +11,7 @@ import (
 
 	"github.com/traefik/traefik/v3/pkg/config/dynamic"
 	"github.com/traefik/traefik/v3/pkg/config/static"
+	"golang.org/x/net/http2"
 )
 
 // TransportManager manages transport used for backend communications.
@@ -101,11 +102,13 @@ func (r *ProxyBuilder) getPool(cfgName string, config *dynamic.ServersTransport,
 
 	idleConnTimeout := 90 * time.Second
 	dialTimeout := 30 * time.Second
-	var responseHeaderTimeout time.Duration
+	var responseHeaderTimeout, readIdleTimeout, pingTimeout time.Duration
 	if config.ForwardingTimeouts != nil {
 		idleConnTimeout = time.Duration(config.ForwardingTimeouts.IdleConnTimeout)
 		dialTimeout = time.Duration(config.ForwardingTimeouts.DialTimeout)
 		responseHeaderTimeout = time.Duration(config.ForwardingTimeouts.ResponseHeaderTimeout)
+		readIdleTimeout = time.Duration(config.ForwardingTimeouts.ReadIdleTimeout)
+		pingTimeout = time.Duration(config.ForwardingTimeouts.PingTimeout)
 	}
 
 	proxyDialer := newDialer(dialerConfig{
@@ -120,6 +123,43 @@ func (r *ProxyBuilder) getPool(cfgName string, config *dynamic.ServersTransport,
 		return proxyDialer.Dial("tcp", addrFromURL(targetURL))
 	})
 
+	// HTTP/2 is used with prior knowledge for h2c,
+	// and negotiated with the backend servers (ALPN) for https.
+	h2c := targetURL.Scheme == schemeH2C
+	if h2c || (targetURL.Scheme == schemeHTTPS && !config.DisableHTTP2) {
+		h2TLSConfig := &tls.Config{}
+		if tlsConfig != nil {
+			h2TLSConfig = tlsConfig.Clone()
+		}
+		h2TLSConfig.NextProtos = []string{http2.NextProtoTLS, "http/1.1"}
+
+		// As for the standard proxy, the network proxy is not used for h2c.
+		h2ProxyURL := proxyURL
+		if h2c {
+			h2ProxyURL = nil
+		}
+
+		h2Dialer := newDialer(dialerConfig{
+			DialKeepAlive: 0,
+			DialTimeout:   dialTimeout,
+			TLS:           !h2c,
+			ProxyURL:      h2ProxyURL,
+		}, h2TLSConfig)
+
+		transport := &http2.Transport{
+			AllowHTTP:       true,
+			IdleConnTimeout: idleConnTimeout,
+			ReadIdleTimeout: readIdleTimeout,
+			PingTimeout:     pingTimeout,
+			// As for HTTP/1.1, the compressed responses are not requested automatically.
+			DisableCompression: true,
+		}
+
+		connPool.http2 = newHTTP2ConnPool(transport, h2c, responseHeaderTimeout, func() (net.Conn, error) {
+			return h2Dialer.Dial("tcp", addrFromURL(targetURL))
+		})
+	}
+
 	r.pools[cfgName][targetURL.String()] = connPool
 
 	return connPool
@@ -317,6 +317,10 @@ type connPool struct {
 	bufferPool            pool[[]byte]
 	limitedReaderPool     pool[*io.LimitedReader]
 	doneCh                chan struct{}
+
+	// http2 is the pool of HTTP/2 connections, used instead of this pool when HTTP/2 is enabled,
+	// except for the connection upgrades and when the backend server does not negotiate HTTP/2.
+	http2 *http2ConnPool
 }
 
 // newConnPool creates a new connPool.
@@ -346,12 +350,17 @@ func newConnPool(maxIdleConn int, idleConnTimeout, responseHeaderTimeout time.Du
 	return c
 }
 
-// Close closes stop the cleanIdleConn goroutine.
+// Close closes stop the cleanIdleConn goroutine,
+// and closes the HTTP/2 connections.
 func (c *connPool) Close() {
 	if c.idleConnTimeout > 0 {
 		close(c.doneCh)
 		c.ticker.Stop()
 	}
+
+	if c.http2 != nil {
+		c.http2.Close()
+	}
 }
 
 // AcquireConn returns an idle net.Conn from the pool.
@@ -451,6 +460,11 @@ func (c *connPool) askForNewConn(errCh chan<- error) {
 		return
 	}
 
+	c.releaseConn(c.newConn(co))
+}
+
+// newConn wraps the given net.Conn, and starts its read loop.
+func (c *connPool) newConn(co net.Conn) *conn {
 	newConn := &conn{
 		Conn:                  co,
 		br:                    bufio.NewReaderSize(co, bufioSize),
@@ -464,7 +478,7 @@ func (c *connPool) askForNewConn(errCh chan<- error) {
 	}
 	go newConn.readLoop()
 
-	c.releaseConn(newConn)
+	return newConn
 }
 
 // isBodyAllowedForStatus reports whether a given response status code permits a body.
@@ -18,6 +18,7 @@ import (
 const (
 	schemeHTTP   = "http"
 	schemeHTTPS  = "https"
+	schemeH2C    = "h2c"
 	schemeSocks5 = "socks5"
 )
 
@@ -184,7 +185,7 @@ func addrFromURL(u *url.URL) string {
 
 	if u.Port() == "" {
 		switch u.Scheme {
-		case schemeHTTP:
+		case schemeHTTP, schemeH2C:
 			return addr + ":80"
 		case schemeHTTPS:
 			return addr + ":443"
@@ -0,0 +1,403 @@
+package fast
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"golang.org/x/net/http/httpguts"
+	"golang.org/x/net/http2"
+)
+
+// http1NegotiatedError is returned when the backend server does not negotiate HTTP/2.
+// It holds the connection on which HTTP/1.1 has been negotiated, if any, to send the request on.
+type http1NegotiatedError struct {
+	conn net.Conn
+}
+
+func (e *http1NegotiatedError) Error() string {
+	return "HTTP/1.1 negotiated with the backend server"
+}
+
+// http2Dial is an in-flight dial of an HTTP/2 connection, shared by the requests waiting for a connection.
+type http2Dial struct {
+	done chan struct{}
+	err  error
+}
+
+// http2ConnPool is a pool of multiplexed HTTP/2 connections.
+// When the backend server does not negotiate HTTP/2 (ALPN),
+// the HTTP/1.1 connection pool is used for all the subsequent requests.
+type http2ConnPool struct {
+	transport             *http2.Transport
+	dialer                func() (net.Conn, error)
+	h2c                   bool
+	responseHeaderTimeout time.Duration
+
+	http1 atomic.Bool
+
+	mu      sync.Mutex
+	conns   []*http2.ClientConn
+	dialing *http2Dial
+	closed  bool
+}
+
+// newHTTP2ConnPool creates a new http2ConnPool.
+// When h2c is true, HTTP/2 is used with prior knowledge over cleartext connections.
+func newHTTP2ConnPool(transport *http2.Transport, h2c bool, responseHeaderTimeout time.Duration, dialer func() (net.Conn, error)) *http2ConnPool {
+	return &http2ConnPool{
+		transport:             transport,
+		dialer:                dialer,
+		h2c:                   h2c,
+		responseHeaderTimeout: responseHeaderTimeout,
+	}
+}
+
+// isHTTP1 returns whether the backend server has negotiated HTTP/1.1 instead of HTTP/2.
+func (c *http2ConnPool) isHTTP1() bool {
+	return c.http1.Load()
+}
+
+// Close gracefully closes all the connections of the pool.
+func (c *http2ConnPool) Close() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.closed = true
+
+	for _, cc := range c.conns {
+		go shutdownHTTP2Conn(cc)
+	}
+	c.conns = nil
+}
+
+// AcquireConn returns a connection on which a new stream has been reserved.
+// A new connection is dialed when all the connections have reached their maximum number of concurrent streams.
+func (c *http2ConnPool) AcquireConn() (*http2.ClientConn, error) {
+	for {
+		c.mu.Lock()
+
+		if c.http1.Load() {
+			c.mu.Unlock()
+			return nil, &http1NegotiatedError{}
+		}
+
+		if cc := c.reserveConnLocked(); cc != nil {
+			c.mu.Unlock()
+			return cc, nil
+		}
+
+		if d := c.dialing; d != nil {
+			c.mu.Unlock()
+
+			<-d.done
+			if d.err != nil {
+				return nil, d.err
+			}
+			continue
+		}
+
+		d := &http2Dial{done: make(chan struct{})}
+		c.dialing = d
+		c.mu.Unlock()
+
+		cc, err := c.dial()
+
+		c.mu.Lock()
+		c.dialing = nil
+
+		// When the pool has been closed while dialing,
+		// the connection is only used for this request, and closed once the request is done.
+		if cc != nil && c.closed && cc.ReserveNewRequest() {
+			c.mu.Unlock()
+			close(d.done)
+
+			go shutdownHTTP2Conn(cc)
+
+			return cc, nil
+		}
+
+		if cc != nil {
+			c.conns = append(c.conns, cc)
+		}
+		c.mu.Unlock()
+
+		// The negotiated connection is only used by this request.
+		d.err = err
+		if errors.As(err, new(*http1NegotiatedError)) {
+			d.err = &http1NegotiatedError{}
+		}
+		close(d.done)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// reserveConnLocked reserves a new stream on an existing connection,
+// and removes the closed connections from the pool.
+func (c *http2ConnPool) reserveConnLocked() *http2.ClientConn {
+	conns := c.conns[:0]
+	var reserved *http2.ClientConn
+	for _, cc := range c.conns {
+		state := cc.State()
+		if state.Closed || state.Closing {
+			continue
+		}
+
+		conns = append(conns, cc)
+
+		if reserved == nil && cc.ReserveNewRequest() {
+			reserved = cc
+		}
+	}
+
+	clear(c.conns[len(conns):])
+	c.conns = conns
+
+	return reserved
+}
+
+func (c *http2ConnPool) dial() (*http2.ClientConn, error) {
+	co, err := c.dialer()
+	if err != nil {
+		return nil, fmt.Errorf("create conn: %w", err)
+	}
+
+	if !c.h2c {
+		tlsConn, ok := co.(*tls.Conn)
+		if !ok {
+			_ = co.Close()
+			return nil, fmt.Errorf("unexpected connection type %T", co)
+		}
+
+		// The handshake is already done when the connection is not established through a proxy.
+		if err := tlsConn.Handshake(); err != nil {
+			_ = co.Close()
+			return nil, fmt.Errorf("TLS handshake: %w", err)
+		}
+
+		if tlsConn.ConnectionState().NegotiatedProtocol != http2.NextProtoTLS {
+			c.http1.Store(true)
+
+			return nil, &http1NegotiatedError{conn: co}
+		}
+	}
+
+	cc, err := c.transport.NewClientConn(co)
+	if err != nil {
+		_ = co.Close()
+		return nil, fmt.Errorf("create HTTP/2 conn: %w", err)
+	}
+
+	return cc, nil
+}
+
+// shutdownHTTP2Conn gracefully closes the connection, once its active streams are done.
+func shutdownHTTP2Conn(cc *http2.ClientConn) {
+	if err := cc.Shutdown(context.Background()); err != nil {
+		log.Debug().Err(err).Msg("Unexpected error while closing the HTTP/2 connection")
+	}
+}
+
+// roundTrip sends the request on the given connection,
+// and enforces the response header timeout.
+func (c *http2ConnPool) roundTrip(cc *http2.ClientConn, req *http.Request) (*http.Response, error) {
+	if c.responseHeaderTimeout <= 0 {
+		return cc.RoundTrip(req)
+	}
+
+	ctx, cancel := context.WithCancelCause(req.Context())
+	timer := time.AfterFunc(c.responseHeaderTimeout, func() {
+		cancel(timeoutError{errors.New("timeout awaiting response headers")})
+	})
+
+	res, err := cc.RoundTrip(req.WithContext(ctx))
+	timer.Stop()
+
+	if err != nil {
+		var errT timeoutError
+		if errors.As(context.Cause(ctx), &errT) {
+			err = errT
+		}
+
+		cancel(nil)
+		return nil, err
+	}
+
+	res.Body = &cancelBody{ReadCloser: res.Body, cancel: func() { cancel(nil) }}
+
+	return res, nil
+}
+
+// cancelBody is a response body which cancels the request context when it is closed.
+type cancelBody struct {
+	io.ReadCloser
+
+	cancel func()
+}
+
+func (b *cancelBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+
+	return err
+}
+
+// newHTTP2Request creates the request to send to the backend server over HTTP/2.
+func (p *ReverseProxy) newHTTP2Request(req *http.Request, u2 *url.URL, forwardedFor string, setForwardedFor bool) *http.Request {
+	header := make(http.Header, len(req.Header)+2)
+	for k, v := range req.Header {
+		header[k] = v
+	}
+
+	for _, f := range header["Connection"] {
+		for sf := range strings.SplitSeq(f, ",") {
+			if sf = strings.TrimSpace(sf); sf != "" {
+				header.Del(sf)
+			}
+		}
+	}
+
+	for _, h := range hopHeaders {
+		header.Del(h)
+	}
+
+	if httpguts.HeaderValuesContainsToken(req.Header["Te"], "trailers") {
+		header.Set("Te", "trailers")
+	}
+
+	if p.debug {
+		header.Set("X-Traefik-Fast-Proxy", "enabled")
+	}
+
+	if setForwardedFor {
+		header.Set("X-Forwarded-For", forwardedFor)
+	}
+
+	if p.connPool.http2.h2c {
+		u2.Scheme = schemeHTTP
+	}
+
+	host := u2.Host
+	if p.passHostHeader {
+		host = req.Host
+	}
+
+	outReq := &http.Request{
+		Method:        req.Method,
+		URL:           u2,
+		Proto:         "HTTP/2.0",
+		ProtoMajor:    2,
+		Header:        header,
+		Body:          req.Body,
+		ContentLength: req.ContentLength,
+		Trailer:       req.Trailer,
+		Host:          host,
+	}
+
+	return outReq.WithContext(req.Context())
+}
+
+// roundTripHTTP2 sends the request over HTTP/2 and writes the response,
+// streaming the body and forwarding the trailers (e.g. for gRPC).
+// It returns an http1NegotiatedError, before anything is written, if the backend server does not support HTTP/2.
+func (p *ReverseProxy) roundTripHTTP2(rw http.ResponseWriter, req, outReq *http.Request) error {
+	pool := p.connPool.http2
+
+	var res *http.Response
+	for retry := false; ; retry = true {
+		cc, err := pool.AcquireConn()
+		if err != nil {
+			return fmt.Errorf("acquire HTTP/2 connection: %w", err)
+		}
+
+		res, err = pool.roundTrip(cc, outReq)
+		if err == nil {
+			break
+		}
+
+		// The connection may have been closed by the server (e.g. GOAWAY) before the request has been sent,
+		// in which case the request is retried once on another connection.
+		state := cc.State()
+		if retry || !isReplayable(req) || !(state.Closed || state.Closing) || req.Context().Err() != nil {
+			return err
+		}
+
+		log.Ctx(req.Context()).Debug().Err(err).Msg("Error while sending request over HTTP/2, retrying")
+	}
+	defer res.Body.Close()
+
+	for _, header := range hopHeaders {
+		res.Header.Del(header)
+	}
+
+	// RFC 7234, section 5.4: Should treat Pragma: no-cache like Cache-Control: no-cache.
+	if res.Header.Get("Pragma") == "no-cache" && res.Header.Get("Cache-Control") == "" {
+		res.Header.Set("Cache-Control", "no-cache")
+	}
+
+	for k, v := range res.Header {
+		for _, s := range v {
+			rw.Header().Add(k, s)
+		}
+	}
+
+	announcedTrailers := len(res.Trailer)
+	if announcedTrailers > 0 {
+		trailerKeys := make([]string, 0, announcedTrailers)
+		for k := range res.Trailer {
+			trailerKeys = append(trailerKeys, k)
+		}
+		rw.Header().Add("Trailer", strings.Join(trailerKeys, ", "))
+	}
+
+	rw.WriteHeader(res.StatusCode)
+
+	if announcedTrailers > 0 {
+		// Force chunking, and send the headers right away, as the trailers are only known at the end of the stream.
+		if err := http.NewResponseController(rw).Flush(); err != nil {
+			log.Ctx(req.Context()).Debug().Err(err).Msg("Error while flushing response headers")
+		}
+	}
+
+	b := p.connPool.bufferPool.Get()
+	if b == nil {
+		b = make([]byte, bufferSize)
+	}
+	defer p.connPool.bufferPool.Put(b)
+
+	// Responses of unknown length (e.g. gRPC streams) are flushed after each write.
+	var w io.Writer = rw
+	if res.ContentLength == -1 {
+		w = &writeFlusher{rw}
+	}
+
+	if _, err := io.CopyBuffer(w, res.Body, b); err != nil {
+		return err
+	}
+
+	if len(res.Trailer) == announcedTrailers {
+		for k, v := range res.Trailer {
+			rw.Header()[k] = v
+		}
+		return nil
+	}
+
+	for k, v := range res.Trailer {
+		rw.Header()[http.TrailerPrefix+k] = v
+	}
+
+	return nil
+}
@@ -0,0 +1,182 @@
+package fast
+
+import (
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/traefik/traefik/v3/pkg/config/static"
+	"github.com/traefik/traefik/v3/pkg/testhelpers"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+)
+
+func TestHTTP2(t *testing.T) {
+	testCases := []struct {
+		desc          string
+		scheme        string
+		serverHTTP2   bool
+		expectedProto string
+	}{
+		{
+			desc:          "HTTPS with HTTP/2 server",
+			scheme:        "https",
+			serverHTTP2:   true,
+			expectedProto: "HTTP/2.0",
+		},
+		{
+			desc:          "HTTPS with HTTP/1.1 server",
+			scheme:        "https",
+			expectedProto: "HTTP/1.1",
+		},
+		{
+			desc:          "h2c",
+			scheme:        "h2c",
+			expectedProto: "HTTP/2.0",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			handler := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				assert.Equal(t, test.expectedProto, req.Proto)
+				assert.Equal(t, "enabled", req.Header.Get("X-Traefik-Fast-Proxy"))
+				assert.Empty(t, req.Header.Get("Foo"))
+
+				body, err := io.ReadAll(req.Body)
+				assert.NoError(t, err)
+
+				_, _ = rw.Write(body)
+			})
+
+			var server *httptest.Server
+			if test.scheme == "h2c" {
+				server = httptest.NewServer(h2c.NewHandler(handler, &http2.Server{}))
+			} else {
+				server = httptest.NewUnstartedServer(handler)
+				server.EnableHTTP2 = test.serverHTTP2
+				server.StartTLS()
+			}
+			t.Cleanup(server.Close)
+
+			builder := NewProxyBuilder(&transportManagerMock{tlsConfig: &tls.Config{InsecureSkipVerify: true}}, static.FastProxyConfig{Debug: true})
+
+			targetURL := testhelpers.MustParseURL(server.URL)
+			targetURL.Scheme = test.scheme
+
+			proxyHandler, err := builder.Build("", targetURL, true, false)
+			require.NoError(t, err)
+
+			for range 2 {
+				req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("foo"))
+				req.Header.Set("Connection", "Foo")
+				req.Header.Set("Foo", "bar")
+
+				rec := httptest.NewRecorder()
+				proxyHandler.ServeHTTP(rec, req)
+
+				assert.Equal(t, http.StatusOK, rec.Code)
+				assert.Equal(t, "foo", rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestHTTP2_multiplexing(t *testing.T) {
+	const requests = 10
+
+	var (
+		conns   atomic.Int32
+		arrived sync.WaitGroup
+	)
+	arrived.Add(requests)
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		// All the requests are handled concurrently.
+		arrived.Done()
+		arrived.Wait()
+
+		rw.WriteHeader(http.StatusOK)
+	}))
+	server.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			conns.Add(1)
+		}
+	}
+	server.EnableHTTP2 = true
+	server.StartTLS()
+	t.Cleanup(server.Close)
+
+	builder := NewProxyBuilder(&transportManagerMock{tlsConfig: &tls.Config{InsecureSkipVerify: true}}, static.FastProxyConfig{})
+
+	proxyHandler, err := builder.Build("", testhelpers.MustParseURL(server.URL), true, false)
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+	for range requests {
+		wg.Go(func() {
+			rec := httptest.NewRecorder()
+			proxyHandler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", http.NoBody))
+
+			assert.Equal(t, http.StatusOK, rec.Code)
+		})
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(1), conns.Load())
+}
+
+func TestHTTP2_streamingAndTrailers(t *testing.T) {
+	server := httptest.NewServer(h2c.NewHandler(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.Header().Set("Content-Type", "application/grpc")
+		rw.Header().Set("Trailer", "Grpc-Status")
+		rw.WriteHeader(http.StatusOK)
+
+		for i := range 3 {
+			_, err := fmt.Fprintf(rw, "message %d\n", i)
+			assert.NoError(t, err)
+
+			rw.(http.Flusher).Flush()
+		}
+
+		rw.Header().Set("Grpc-Status", "0")
+		rw.Header().Set(http.TrailerPrefix+"Grpc-Message", "done")
+	}), &http2.Server{}))
+	t.Cleanup(server.Close)
+
+	builder := NewProxyBuilder(&transportManagerMock{}, static.FastProxyConfig{})
+
+	targetURL := testhelpers.MustParseURL(server.URL)
+	targetURL.Scheme = "h2c"
+
+	proxyHandler, err := builder.Build("", targetURL, true, false)
+	require.NoError(t, err)
+
+	proxyServer := httptest.NewServer(proxyHandler)
+	t.Cleanup(proxyServer.Close)
+
+	res, err := http.Get(proxyServer.URL)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = res.Body.Close() })
+
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, "application/grpc", res.Header.Get("Content-Type"))
+
+	body, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+
+	assert.Equal(t, "message 0\nmessage 1\nmessage 2\n", string(body))
+	assert.Equal(t, "0", res.Trailer.Get("Grpc-Status"))
+	assert.Equal(t, "done", res.Trailer.Get("Grpc-Message"))
+}
@@ -4,6 +4,7 @@ import (
 	"bufio"
 	"bytes"
 	"encoding/base64"
+	"errors"
 	"fmt"
 	"io"
 	"net"
@@ -132,6 +133,32 @@ func (p *ReverseProxy) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
 		defer req.Body.Close()
 	}
 
+	reqUpType := upgradeType(req.Header)
+	if !isGraphic(reqUpType) {
+		proxyhttputil.ErrorHandler(rw, req, fmt.Errorf("client tried to switch to invalid protocol %q", reqUpType))
+		return
+	}
+
+	u2 := p.outURL(req)
+	forwardedFor, setForwardedFor := forwardedFor(req)
+
+	// Connection upgrades are not supported over HTTP/2,
+	// so they are always sent on an HTTP/1.1 connection.
+	var http1Conn net.Conn
+	if p.connPool.http2 != nil && reqUpType == "" && !p.connPool.http2.isHTTP1() {
+		err := p.roundTripHTTP2(rw, req, p.newHTTP2Request(req, u2, forwardedFor, setForwardedFor))
+
+		var negotiatedErr *http1NegotiatedError
+		if !errors.As(err, &negotiatedErr) {
+			if err != nil {
+				proxyhttputil.ErrorHandler(rw, req, err)
+			}
+			return
+		}
+
+		http1Conn = negotiatedErr.conn
+	}
+
 	outReq := fasthttp.AcquireRequest()
 	defer fasthttp.ReleaseRequest(outReq)
 
@@ -162,12 +189,6 @@ func (p *ReverseProxy) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
 		outReq.Header.Set("X-Traefik-Fast-Proxy", "enabled")
 	}
 
-	reqUpType := upgradeType(req.Header)
-	if !isGraphic(reqUpType) {
-		proxyhttputil.ErrorHandler(rw, req, fmt.Errorf("client tried to switch to invalid protocol %q", reqUpType))
-		return
-	}
-
 	if reqUpType != "" {
 		outReq.Header.Set("Connection", "Upgrade")
 		outReq.Header.Set("Upgrade", reqUpType)
@@ -177,6 +198,30 @@ func (p *ReverseProxy) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
 		}
 	}
 
+	outReq.SetHost(u2.Host)
+	outReq.Header.SetHost(u2.Host)
+
+	if p.passHostHeader {
+		outReq.Header.SetHost(req.Host)
+	}
+
+	outReq.SetRequestURI(u2.RequestURI())
+
+	outReq.SetBodyStream(req.Body, int(req.ContentLength))
+
+	outReq.Header.SetMethod(req.Method)
+
+	if setForwardedFor {
+		outReq.Header.Set("X-Forwarded-For", forwardedFor)
+	}
+
+	if err := p.roundTrip(rw, req, outReq, reqUpType, http1Conn); err != nil {
+		proxyhttputil.ErrorHandler(rw, req, err)
+	}
+}
+
+// outURL returns the URL of the request to send to the backend server.
+func (p *ReverseProxy) outURL(req *http.Request) *url.URL {
 	u2 := new(url.URL)
 	*u2 = *req.URL
 	u2.Scheme = p.targetURL.Scheme
@@ -199,47 +244,41 @@ func (p *ReverseProxy) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
 
 	u2.RawQuery = strings.ReplaceAll(u.RawQuery, ";", "&")
 
-	outReq.SetHost(u2.Host)
-	outReq.Header.SetHost(u2.Host)
+	return u2
+}
 
-	if p.passHostHeader {
-		outReq.Header.SetHost(req.Host)
+// forwardedFor returns the X-Forwarded-For header value to send to the backend server,
+// and whether it has to be set.
+func forwardedFor(req *http.Request) (string, bool) {
+	if proxyhttputil.ShouldNotAppendXFF(req.Context()) {
+		return "", false
 	}
 
-	outReq.SetRequestURI(u2.RequestURI())
-
-	outReq.SetBodyStream(req.Body, int(req.ContentLength))
-
-	outReq.Header.SetMethod(req.Method)
-
-	if !proxyhttputil.ShouldNotAppendXFF(req.Context()) {
-		if clientIP, _, err := net.SplitHostPort(req.RemoteAddr); err == nil {
-			// If we aren't the first proxy retain prior
-			// X-Forwarded-For information as a comma+space
-			// separated list and fold multiple headers into one.
-			prior, ok := req.Header["X-Forwarded-For"]
-			if len(prior) > 0 {
-				clientIP = strings.Join(prior, ", ") + ", " + clientIP
-			}
-
-			omit := ok && prior == nil // Go Issue 38079: nil now means don't populate the header
-			if !omit {
-				outReq.Header.Set("X-Forwarded-For", clientIP)
-			}
-		}
+	clientIP, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		return "", false
 	}
 
-	if err := p.roundTrip(rw, req, outReq, reqUpType); err != nil {
-		proxyhttputil.ErrorHandler(rw, req, err)
+	// If we aren't the first proxy retain prior
+	// X-Forwarded-For information as a comma+space
+	// separated list and fold multiple headers into one.
+	prior, ok := req.Header["X-Forwarded-For"]
+	if len(prior) > 0 {
+		clientIP = strings.Join(prior, ", ") + ", " + clientIP
 	}
+
+	omit := ok && prior == nil // Go Issue 38079: nil now means don't populate the header
+
+	return clientIP, !omit
 }
 
+// When not nil, the given newConn is used for the first attempt to send the request.
 // Note that unlike the net/http RoundTrip:
 //   - we are not supporting "100 Continue" response to forward them as-is to the client.
 //   - we are not asking for compressed response automatically. That is because this will add an extra cost when the
 //     client is asking for an uncompressed response, as we will have to un-compress it, and nowadays most clients are
 //     already asking for compressed response (allowing "passthrough" compression).
-func (p *ReverseProxy) roundTrip(rw http.ResponseWriter, req *http.Request, outReq *fasthttp.Request, reqUpType string) error {
+func (p *ReverseProxy) roundTrip(rw http.ResponseWriter, req *http.Request, outReq *fasthttp.Request, reqUpType string, newConn net.Conn) error {
 	ctx := req.Context()
 	trace := httptrace.ContextClientTrace(ctx)
 
@@ -247,15 +286,23 @@ func (p *ReverseProxy) roundTrip(rw http.ResponseWriter, req *http.Request, outR
 	for {
 		select {
 		case <-ctx.Done():
+			if newConn != nil {
+				_ = newConn.Close()
+			}
 			return ctx.Err()
 
 		default:
 		}
 
 		var err error
-		co, err = p.connPool.AcquireConn()
-		if err != nil {
-			return fmt.Errorf("acquire connection: %w", err)
+		if newConn != nil {
+			co = p.connPool.newConn(newConn)
+			newConn = nil
+		} else {
+			co, err = p.connPool.AcquireConn()
+			if err != nil {
+				return fmt.Errorf("acquire connection: %w", err)
+			}
 		}
 
 		// Before writing the request,
@@ -51,10 +51,8 @@ func (b *SmartBuilder) Build(configName string, targetURL *url.URL, passHostHead
 		return nil, fmt.Errorf("getting ServersTransport: %w", err)
 	}
 
-	// The fast proxy implementation cannot handle HTTP/2 requests for now.
-	// For the https scheme we cannot guess if the backend communication will use HTTP2,
-	// thus we check if HTTP/2 is disabled to use the fast proxy implementation when this is possible.
-	if targetURL.Scheme == "h2c" || (targetURL.Scheme == "https" && !serversTransport.DisableHTTP2) {
+	// The fast proxy implementation cannot handle HTTP/3 requests.
+	if targetURL.Scheme == "https" && serversTransport.HTTP3 != nil {
 		return b.proxyBuilder.Build(configName, targetURL, passHostHeader, preservePath, flushInterval)
 	}
 	return b.fastProxyBuilder.Build(configName, targetURL, passHostHeader, preservePath)
@@ -9,6 +9,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	ptypes "github.com/traefik/paerser/types"
 	"github.com/traefik/traefik/v3/pkg/config/dynamic"
 	"github.com/traefik/traefik/v3/pkg/config/static"
 	"github.com/traefik/traefik/v3/pkg/proxy/httputil"
@@ -37,7 +38,7 @@ func TestSmartBuilder_Build(t *testing.T) {
 			desc:            "fastproxy with https and without DisableHTTP2",
 			https:           true,
 			fastProxyConfig: static.FastProxyConfig{Debug: true},
-			wantFastProxy:   false,
+			wantFastProxy:   true,
 		},
 		{
 			desc:             "fastproxy with https and DisableHTTP2",
@@ -46,11 +47,21 @@ func TestSmartBuilder_Build(t *testing.T) {
 			fastProxyConfig:  static.FastProxyConfig{Debug: true},
 			wantFastProxy:    true,
 		},
+		{
+			desc:  "fastproxy with https and HTTP3",
+			https: true,
+			serversTransport: dynamic.ServersTransport{
+				HTTP3:              &dynamic.ServersTransportHTTP3{},
+				ForwardingTimeouts: &dynamic.ForwardingTimeouts{DialTimeout: ptypes.Duration(100 * time.Millisecond)},
+			},
+			fastProxyConfig: static.FastProxyConfig{Debug: true},
+			wantFastProxy:   false,
+		},
 		{
 			desc:            "fastproxy with h2c",
 			h2c:             true,
 			fastProxyConfig: static.FastProxyConfig{Debug: true},
-			wantFastProxy:   false,
+			wantFastProxy:   true,
 		},
 	}
 
@@ -10,6 +10,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	ptypes "github.com/traefik/paerser/types"
+	"github.com/traefik/traefik/v3/pkg/api/auth"
 	"github.com/traefik/traefik/v3/pkg/config/dynamic"
 	"github.com/traefik/traefik/v3/pkg/config/static"
 	otypes "github.com/traefik/traefik/v3/pkg/observability/types"
@@ -798,6 +799,15 @@ func TestDo_staticConfiguration(t *testing.T) {
 		Insecure:  true,
 		Dashboard: true,
 		Debug:     true,
+		Auth: &auth.Config{
+			Tokens: []auth.Token{
+				{Name: "foo", Value: "secret", Scopes: []string{"write"}},
+			},
+			TokensFile: "tokens",
+			ClientCertificates: []auth.ClientCertificate{
+				{Identity: "admin", Scopes: []string{"read"}},
+			},
+		},
 	}
 
 	config.Metrics = &otypes.Metrics{
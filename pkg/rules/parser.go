@@ -113,6 +113,31 @@ func (tree *Tree) ParseMatchers(matchers []string) []string {
 	}
 }
 
+// String returns the textual representation of the tree, as written in a rule.
+func (tree *Tree) String() string {
+	switch tree.Matcher {
+	case and, or:
+		operator := "&&"
+		if tree.Matcher == or {
+			operator = "||"
+		}
+
+		return fmt.Sprintf("(%s %s %s)", tree.RuleLeft, operator, tree.RuleRight)
+	default:
+		values := make([]string, 0, len(tree.Value))
+		for _, value := range tree.Value {
+			values = append(values, "`"+value+"`")
+		}
+
+		var not string
+		if tree.Not {
+			not = "!"
+		}
+
+		return fmt.Sprintf("%s%s(%s)", not, tree.Matcher, strings.Join(values, ", "))
+	}
+}
+
 // CheckRule validates the given rule.
 func CheckRule(rule *Tree) error {
 	if len(rule.Value) == 0 {
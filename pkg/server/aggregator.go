@@ -41,6 +41,9 @@ func mergeConfiguration(configurations dynamic.Configurations, defaultEntryPoint
 		},
 	}
 
+	// Entry points are not qualified with the provider name, as routers reference them by name.
+	entryPointProviders := make(map[string][]string)
+
 	var defaultTLSOptionProviders []string
 	var defaultTLSStoreProviders []string
 	for pvd, configuration := range configurations {
@@ -152,6 +155,23 @@ func mergeConfiguration(configurations dynamic.Configurations, defaultEntryPoint
 				conf.TLS.Options[tlsOptionsName] = options
 			}
 		}
+
+		for entryPointName, entryPoint := range configuration.EntryPoints {
+			if conf.EntryPoints == nil {
+				conf.EntryPoints = make(map[string]*dynamic.EntryPoint)
+			}
+
+			entryPointProviders[entryPointName] = append(entryPointProviders[entryPointName], pvd)
+			conf.EntryPoints[entryPointName] = entryPoint
+		}
+	}
+
+	for entryPointName, providers := range entryPointProviders {
+		if len(providers) > 1 {
+			slices.Sort(providers)
+			log.Error().Str(logs.EntryPointName, entryPointName).Msgf("Entry point defined in multiple providers: %v", providers)
+			delete(conf.EntryPoints, entryPointName)
+		}
 	}
 
 	if len(defaultTLSStoreProviders) > 1 {
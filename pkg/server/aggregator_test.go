@@ -477,6 +477,67 @@ func Test_mergeConfiguration_defaultTCPEntryPoint(t *testing.T) {
 	assert.Equal(t, expected, actual.TCP)
 }
 
+func Test_mergeConfiguration_entryPoints(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		given    dynamic.Configurations
+		expected map[string]*dynamic.EntryPoint
+	}{
+		{
+			desc:     "No entry points",
+			given:    dynamic.Configurations{"provider-1": &dynamic.Configuration{}},
+			expected: nil,
+		},
+		{
+			desc: "Entry points are not qualified with the provider name",
+			given: dynamic.Configurations{
+				"provider-1": &dynamic.Configuration{
+					EntryPoints: map[string]*dynamic.EntryPoint{
+						"tenant-1": {Address: ":8001"},
+					},
+				},
+				"provider-2": &dynamic.Configuration{
+					EntryPoints: map[string]*dynamic.EntryPoint{
+						"tenant-2": {Address: ":8002"},
+					},
+				},
+			},
+			expected: map[string]*dynamic.EntryPoint{
+				"tenant-1": {Address: ":8001"},
+				"tenant-2": {Address: ":8002"},
+			},
+		},
+		{
+			desc: "Entry point defined in multiple providers is removed",
+			given: dynamic.Configurations{
+				"provider-1": &dynamic.Configuration{
+					EntryPoints: map[string]*dynamic.EntryPoint{
+						"tenant-1": {Address: ":8001"},
+						"tenant-2": {Address: ":8002"},
+					},
+				},
+				"provider-2": &dynamic.Configuration{
+					EntryPoints: map[string]*dynamic.EntryPoint{
+						"tenant-1": {Address: ":8001"},
+					},
+				},
+			},
+			expected: map[string]*dynamic.EntryPoint{
+				"tenant-2": {Address: ":8002"},
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			actual := mergeConfiguration(test.given, []string{"defaultEP"})
+			assert.Equal(t, test.expected, actual.EntryPoints)
+		})
+	}
+}
+
 func Test_applyModel(t *testing.T) {
 	testCases := []struct {
 		desc     string
@@ -3,7 +3,11 @@ package server
 import (
 	"context"
 	"encoding/json"
+	"maps"
 	"reflect"
+	"slices"
+	"sync"
+	"time"
 
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
@@ -23,16 +27,30 @@ type ConfigurationWatcher struct {
 
 	allProvidersConfigs chan dynamic.Message
 
-	newConfigs chan dynamic.Configurations
+	newConfigs chan configurationsUpdate
+
+	// reload is used to re-apply the last configuration, even if it did not change.
+	reload chan struct{}
 
 	requiredProvider       string
 	configurationListeners []func(dynamic.Configuration)
+	appliedListeners       []func(providers []string)
 
 	configurationTransformers []func(context.Context, dynamic.Configurations) dynamic.Configurations
 
+	lastUpdatesMu sync.RWMutex
+	lastUpdates   map[string]time.Time
+
 	routinesPool *safe.Pool
 }
 
+// configurationsUpdate is the set of provider configurations to apply,
+// along with the names of all the providers which have sent a configuration, even an empty one.
+type configurationsUpdate struct {
+	configurations dynamic.Configurations
+	providers      []string
+}
+
 // NewConfigurationWatcher creates a new ConfigurationWatcher.
 func NewConfigurationWatcher(
 	routinesPool *safe.Pool,
@@ -43,10 +61,12 @@ func NewConfigurationWatcher(
 	return &ConfigurationWatcher{
 		providerAggregator:  pvd,
 		allProvidersConfigs: make(chan dynamic.Message, 100),
-		newConfigs:          make(chan dynamic.Configurations),
+		newConfigs:          make(chan configurationsUpdate),
+		reload:              make(chan struct{}, 1),
 		routinesPool:        routinesPool,
 		defaultEntryPoints:  defaultEntryPoints,
 		requiredProvider:    requiredProvider,
+		lastUpdates:         make(map[string]time.Time),
 	}
 }
 
@@ -63,11 +83,36 @@ func (c *ConfigurationWatcher) Stop() {
 	close(c.newConfigs)
 }
 
+// Reload re-applies the last configuration to the listeners, even if it did not change.
+// It is used when something the configuration depends on has changed, such as a local plugin being reloaded.
+func (c *ConfigurationWatcher) Reload() {
+	select {
+	case c.reload <- struct{}{}:
+	default:
+		// A reload is already pending.
+	}
+}
+
+// LastUpdates returns the last time each provider sent a new configuration.
+func (c *ConfigurationWatcher) LastUpdates() map[string]time.Time {
+	c.lastUpdatesMu.RLock()
+	defer c.lastUpdatesMu.RUnlock()
+
+	return maps.Clone(c.lastUpdates)
+}
+
 // AddListener adds a new listener function used when new configuration is provided.
 func (c *ConfigurationWatcher) AddListener(listener func(dynamic.Configuration)) {
 	c.configurationListeners = append(c.configurationListeners, listener)
 }
 
+// AddAppliedListener adds a listener called each time a configuration has been applied, or would have been if it had changed,
+// with the names of the providers whose configurations it includes.
+// It is called after the configuration listeners.
+func (c *ConfigurationWatcher) AddAppliedListener(listener func(providers []string)) {
+	c.appliedListeners = append(c.appliedListeners, listener)
+}
+
 // AddTransformer registers a function to modify configurations before they are applied.
 func (c *ConfigurationWatcher) AddTransformer(transformer func(context.Context, dynamic.Configurations) dynamic.Configurations) {
 	c.configurationTransformers = append(c.configurationTransformers, transformer)
@@ -94,15 +139,23 @@ func (c *ConfigurationWatcher) startProviderAggregator() {
 func (c *ConfigurationWatcher) receiveConfigurations(ctx context.Context) {
 	newConfigurations := make(dynamic.Configurations)
 	transformedConfigurations := make(dynamic.Configurations)
+	receivedProviders := make(map[string]struct{})
 
-	var output chan dynamic.Configurations
+	var output chan configurationsUpdate
+
+	// DeepCopy is necessary because transformedConfigurations gets modified later by the consumer of c.newConfigs.
+	update := func() configurationsUpdate {
+		return configurationsUpdate{
+			configurations: transformedConfigurations.DeepCopy(),
+			providers:      slices.Sorted(maps.Keys(receivedProviders)),
+		}
+	}
 
 	for {
 		select {
 		case <-ctx.Done():
 			return
-		// DeepCopy is necessary because transformedConfigurations gets modified later by the consumer of c.newConfigs.
-		case output <- transformedConfigurations.DeepCopy():
+		case output <- update():
 			output = nil
 
 		default:
@@ -121,6 +174,12 @@ func (c *ConfigurationWatcher) receiveConfigurations(ctx context.Context) {
 					continue
 				}
 
+				if _, ok := receivedProviders[configMsg.ProviderName]; !ok {
+					receivedProviders[configMsg.ProviderName] = struct{}{}
+					// The first configuration of a provider is notified to the applied listeners, even if it is empty.
+					output = c.newConfigs
+				}
+
 				if isEmptyConfiguration(configMsg.Configuration) {
 					logger.Debug().Msg("Skipping empty configuration")
 					continue
@@ -136,6 +195,10 @@ func (c *ConfigurationWatcher) receiveConfigurations(ctx context.Context) {
 
 				newConfigurations[configMsg.ProviderName] = configMsg.Configuration.DeepCopy()
 
+				c.lastUpdatesMu.Lock()
+				c.lastUpdates[configMsg.ProviderName] = time.Now()
+				c.lastUpdatesMu.Unlock()
+
 				transformedConfigurations = newConfigurations
 				for _, transform := range c.configurationTransformers {
 					transformedConfigurations = transform(logger.WithContext(ctx), transformedConfigurations.DeepCopy())
@@ -143,8 +206,7 @@ func (c *ConfigurationWatcher) receiveConfigurations(ctx context.Context) {
 
 				output = c.newConfigs
 
-			// DeepCopy is necessary because newConfigurations gets modified later by the consumer of c.newConfigs.
-			case output <- transformedConfigurations.DeepCopy():
+			case output <- update():
 				output = nil
 			}
 		}
@@ -154,38 +216,53 @@ func (c *ConfigurationWatcher) receiveConfigurations(ctx context.Context) {
 // applyConfigurations receives the full set of configurations from
 // receiveConfigurations and applies them if they differ from the previous set.
 // It waits for the required provider's configuration before applying any configs.
+// The last set is applied again on reload.
 func (c *ConfigurationWatcher) applyConfigurations(ctx context.Context) {
 	var lastConfigurations dynamic.Configurations
 	for {
 		select {
 		case <-ctx.Done():
 			return
-		case newConfigs, ok := <-c.newConfigs:
+		case <-c.reload:
+			if lastConfigurations == nil {
+				continue
+			}
+
+			c.notifyListeners(lastConfigurations)
+		case update, ok := <-c.newConfigs:
 			if !ok {
 				return
 			}
 
+			newConfigs := update.configurations
+
 			// We wait for first configuration of the required provider before applying configurations.
 			if _, ok := newConfigs[c.requiredProvider]; c.requiredProvider != "" && !ok {
 				continue
 			}
 
-			if reflect.DeepEqual(newConfigs, lastConfigurations) {
-				continue
-			}
+			if !reflect.DeepEqual(newConfigs, lastConfigurations) {
+				c.notifyListeners(newConfigs)
 
-			conf := mergeConfiguration(newConfigs.DeepCopy(), c.defaultEntryPoints)
-			conf = applyModel(conf)
-
-			for _, listener := range c.configurationListeners {
-				listener(conf)
+				lastConfigurations = newConfigs
 			}
 
-			lastConfigurations = newConfigs
+			for _, listener := range c.appliedListeners {
+				listener(update.providers)
+			}
 		}
 	}
 }
 
+func (c *ConfigurationWatcher) notifyListeners(configs dynamic.Configurations) {
+	conf := mergeConfiguration(configs.DeepCopy(), c.defaultEntryPoints)
+	conf = applyModel(conf)
+
+	for _, listener := range c.configurationListeners {
+		listener(conf)
+	}
+}
+
 func logConfiguration(logger zerolog.Logger, configMsg dynamic.Message) {
 	if logger.GetLevel() > zerolog.DebugLevel {
 		return
@@ -199,6 +276,9 @@ func logConfiguration(logger zerolog.Logger, configMsg dynamic.Message) {
 			cleanedOptions := make(map[string]tls.Options, len(copyConf.TLS.Options))
 			for name, option := range copyConf.TLS.Options {
 				option.ClientAuth.CAFiles = []types.FileOrContent{}
+				if option.ClientAuth.Revocation != nil {
+					option.ClientAuth.Revocation.CRLFiles = []types.FileOrContent{}
+				}
 				cleanedOptions[name] = option
 			}
 
@@ -252,6 +332,7 @@ func isEmptyConfiguration(conf *dynamic.Configuration) bool {
 	tlsEmpty := conf.TLS == nil || conf.TLS.Certificates == nil && conf.TLS.Stores == nil && conf.TLS.Options == nil
 	tcpEmpty := conf.TCP.Routers == nil && conf.TCP.Services == nil && conf.TCP.Middlewares == nil
 	udpEmpty := conf.UDP.Routers == nil && conf.UDP.Services == nil
+	entryPointsEmpty := conf.EntryPoints == nil
 
-	return httpEmpty && tlsEmpty && tcpEmpty && udpEmpty
+	return httpEmpty && tlsEmpty && tcpEmpty && udpEmpty && entryPointsEmpty
 }
@@ -420,6 +420,90 @@ func TestListenProvidersSkipsSameConfigurationForProvider(t *testing.T) {
 	assert.Equal(t, 1, configurationReloads, "Same configuration should not be published multiple times")
 }
 
+func TestConfigurationWatcher_LastUpdates(t *testing.T) {
+	routinesPool := safe.NewPool(t.Context())
+
+	message := dynamic.Message{
+		ProviderName: "mock",
+		Configuration: &dynamic.Configuration{
+			HTTP: th.BuildConfiguration(
+				th.WithRouters(th.WithRouter("foo", th.WithEntryPoints("ep"))),
+			),
+		},
+	}
+
+	pvd := &mockProvider{
+		messages: []dynamic.Message{message},
+	}
+
+	watcher := NewConfigurationWatcher(routinesPool, pvd, []string{}, "")
+	assert.Empty(t, watcher.LastUpdates())
+
+	before := time.Now()
+	watcher.Start()
+
+	t.Cleanup(watcher.Stop)
+	t.Cleanup(routinesPool.Stop)
+
+	// give some time so that the configuration can be processed
+	time.Sleep(100 * time.Millisecond)
+
+	lastUpdates := watcher.LastUpdates()
+	assert.Len(t, lastUpdates, 1)
+	assert.False(t, lastUpdates["mock"].Before(before))
+}
+
+func TestReloadPublishesLastConfiguration(t *testing.T) {
+	routinesPool := safe.NewPool(t.Context())
+
+	message := dynamic.Message{
+		ProviderName: "mock",
+		Configuration: &dynamic.Configuration{
+			HTTP: th.BuildConfiguration(
+				th.WithRouters(th.WithRouter("foo", th.WithEntryPoints("ep"))),
+				th.WithServices(
+					th.WithService("bar", th.WithServiceServersLoadBalancer()),
+				),
+			),
+		},
+	}
+
+	pvd := &mockProvider{
+		messages: []dynamic.Message{message},
+	}
+
+	watcher := NewConfigurationWatcher(routinesPool, pvd, []string{}, "")
+
+	var mu sync.Mutex
+	var publishedConfigs []dynamic.Configuration
+	watcher.AddListener(func(conf dynamic.Configuration) {
+		mu.Lock()
+		defer mu.Unlock()
+		publishedConfigs = append(publishedConfigs, conf)
+	})
+
+	watcher.Start()
+
+	t.Cleanup(watcher.Stop)
+	t.Cleanup(routinesPool.Stop)
+
+	// give some time so that the configuration can be processed
+	time.Sleep(100 * time.Millisecond)
+
+	watcher.Reload()
+
+	// give some time so that the configuration can be reloaded
+	time.Sleep(100 * time.Millisecond)
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	assert.Len(t, publishedConfigs, 2)
+	if len(publishedConfigs) == 2 {
+		assert.Equal(t, publishedConfigs[0], publishedConfigs[1])
+	}
+}
+
 func TestListenProvidersDoesNotSkipFlappingConfiguration(t *testing.T) {
 	routinesPool := safe.NewPool(t.Context())
 
@@ -909,6 +993,65 @@ func TestPublishConfigUpdatedByConfigWatcherListener(t *testing.T) {
 	assert.Equal(t, 1, publishedConfigCount)
 }
 
+func TestConfigurationWatcher_AppliedListener(t *testing.T) {
+	routinesPool := safe.NewPool(t.Context())
+
+	pvd := &mockProvider{
+		wait: 10 * time.Millisecond,
+		messages: []dynamic.Message{
+			{
+				ProviderName: "mock",
+				Configuration: &dynamic.Configuration{
+					HTTP: th.BuildConfiguration(
+						th.WithRouters(th.WithRouter("foo", th.WithEntryPoints("ep"))),
+					),
+				},
+			},
+			{
+				// The first configuration of a provider is reported as applied, even if it is empty.
+				ProviderName:  "empty",
+				Configuration: &dynamic.Configuration{},
+			},
+		},
+	}
+
+	watcher := NewConfigurationWatcher(routinesPool, pvd, []string{}, "")
+
+	var mu sync.Mutex
+	var publishedConfigCount int
+	var appliedProviders []string
+
+	watcher.AddListener(func(_ dynamic.Configuration) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		publishedConfigCount++
+	})
+	watcher.AddAppliedListener(func(providers []string) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		appliedProviders = providers
+	})
+
+	watcher.Start()
+
+	t.Cleanup(watcher.Stop)
+	t.Cleanup(routinesPool.Stop)
+
+	assert.EventuallyWithT(t, func(c *assert.CollectT) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		assert.Equal(c, []string{"empty", "mock"}, appliedProviders)
+	}, time.Second, 10*time.Millisecond)
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	assert.Equal(t, 1, publishedConfigCount)
+}
+
 func TestConfigurationWatcher_MultipleTransformers(t *testing.T) {
 	routinesPool := safe.NewPool(t.Context())
 	t.Cleanup(routinesPool.Stop)
@@ -0,0 +1,259 @@
+//go:build !windows
+
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pires/go-proxyproto"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// handoverListenersEnv holds the names of the sockets handed over to the new process, separated by colons.
+	// As for systemd socket activation, the sockets are passed as file descriptors starting at 3, in the same order.
+	handoverListenersEnv = "TRAEFIK_HANDOVER_LISTENERS"
+	// handoverReadyEnv holds the file descriptor used by the new process to report it is ready.
+	handoverReadyEnv = "TRAEFIK_HANDOVER_READY_FD"
+
+	// handoverTimeout is the maximum time to wait for the new process to be ready.
+	handoverTimeout = time.Minute
+
+	// listenFdsStart is the first file descriptor passed to a child process, after stdin, stdout and stderr.
+	listenFdsStart = 3
+)
+
+// handoverFiles returns the sockets handed over by the previous process, if any.
+func handoverFiles() []*os.File {
+	names := os.Getenv(handoverListenersEnv)
+	if names == "" {
+		return nil
+	}
+
+	_ = os.Unsetenv(handoverListenersEnv)
+
+	var files []*os.File
+	for i, name := range strings.Split(names, ":") {
+		files = append(files, os.NewFile(uintptr(listenFdsStart+i), name))
+	}
+
+	return files
+}
+
+// notifyHandoverReady reports to the previous process, if any, that the sockets are served,
+// so that it can start draining its connections.
+func notifyHandoverReady() {
+	fd := os.Getenv(handoverReadyEnv)
+	if fd == "" {
+		return
+	}
+
+	_ = os.Unsetenv(handoverReadyEnv)
+
+	n, err := strconv.Atoi(fd)
+	if err != nil {
+		log.Error().Err(err).Msg("Invalid handover ready file descriptor")
+		return
+	}
+
+	ready := os.NewFile(uintptr(n), "handover-ready")
+	defer ready.Close()
+
+	if _, err := ready.Write([]byte{1}); err != nil {
+		log.Error().Err(err).Msg("Unable to notify the previous process")
+		return
+	}
+
+	log.Info().Msg("Notified the previous process that the listeners are handed over")
+}
+
+// handover starts a new process with the same arguments, passes it the sockets of all the entry points,
+// and stops the server gracefully once the new process is ready.
+// If the new process fails to start, or is not ready in time, the server keeps running.
+func (s *Server) handover(ctx context.Context) {
+	if !s.handingOver.CompareAndSwap(false, true) {
+		log.Warn().Msg("A handover is already in progress")
+		return
+	}
+
+	if err := s.startNewProcess(ctx); err != nil {
+		log.Error().Err(err).Msg("Unable to hand over the listeners to a new process")
+		s.handingOver.Store(false)
+		return
+	}
+
+	log.Info().Msg("Listeners handed over to the new process, stopping server gracefully")
+
+	s.stopServer()
+}
+
+func (s *Server) startNewProcess(ctx context.Context) error {
+	names, files, err := s.socketFiles()
+	defer func() {
+		for _, f := range files {
+			_ = f.Close()
+		}
+	}()
+	if err != nil {
+		return err
+	}
+
+	executable, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("getting executable: %w", err)
+	}
+
+	readyReader, readyWriter, err := os.Pipe()
+	if err != nil {
+		return fmt.Errorf("creating ready pipe: %w", err)
+	}
+	defer readyReader.Close()
+
+	cmd := exec.Command(executable, os.Args[1:]...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.ExtraFiles = append(files, readyWriter)
+	cmd.Env = append(handoverEnviron(),
+		handoverListenersEnv+"="+strings.Join(names, ":"),
+		handoverReadyEnv+"="+strconv.Itoa(listenFdsStart+len(files)),
+	)
+
+	err = cmd.Start()
+	// The child process has its own copy of the write end of the pipe,
+	// which is closed when it exits, unblocking the read below.
+	_ = readyWriter.Close()
+	if err != nil {
+		return fmt.Errorf("starting new process: %w", err)
+	}
+
+	log.Info().Int("pid", cmd.Process.Pid).Strs("listeners", names).Msg("New process started, waiting for it to be ready")
+
+	ready := make(chan error, 1)
+	go func() {
+		_, err := readyReader.Read(make([]byte, 1))
+		if errors.Is(err, io.EOF) {
+			err = errors.New("new process exited before being ready")
+		}
+		ready <- err
+	}()
+
+	timer := time.NewTimer(handoverTimeout)
+	defer timer.Stop()
+
+	select {
+	case err = <-ready:
+	case <-timer.C:
+		err = fmt.Errorf("new process not ready after %s", handoverTimeout)
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	if err != nil {
+		_ = cmd.Process.Kill()
+		// Reaps the child process.
+		go func() { _ = cmd.Wait() }()
+
+		return err
+	}
+
+	return nil
+}
+
+// socketFiles returns a duplicate of the file descriptor of each entry point socket, and the matching names.
+// The sockets of the dynamic entry points are handed over as well, and are used by the new process once its providers define them again.
+func (s *Server) socketFiles() ([]string, []*os.File, error) {
+	var names []string
+	var files []*os.File
+
+	tcpEntryPoints := maps.Clone(s.tcpEntryPoints)
+	maps.Copy(tcpEntryPoints, s.dynEntryPoints.tcpEntryPoints())
+
+	for name, ep := range tcpEntryPoints {
+		f, err := listenerFile(ep.listener)
+		if err != nil {
+			return nil, files, fmt.Errorf("entry point %s: %w", name, err)
+		}
+
+		names = append(names, name)
+		files = append(files, f)
+
+		if ep.http3Server == nil {
+			continue
+		}
+
+		f, err = packetConnFile(ep.http3Server.http3conn)
+		if err != nil {
+			return nil, files, fmt.Errorf("entry point %s: HTTP/3: %w", name, err)
+		}
+
+		names = append(names, name)
+		files = append(files, f)
+	}
+
+	for name, ep := range s.udpEntryPoints {
+		f, err := ep.listener.File()
+		if err != nil {
+			return nil, files, fmt.Errorf("entry point %s: %w", name, err)
+		}
+
+		names = append(names, name)
+		files = append(files, f)
+	}
+
+	return names, files, nil
+}
+
+// handoverEnviron returns the environment of the current process,
+// without the variables related to socket activation, which are set for the new process.
+func handoverEnviron() []string {
+	var env []string
+	for _, kv := range os.Environ() {
+		name, _, _ := strings.Cut(kv, "=")
+
+		switch name {
+		case handoverListenersEnv, handoverReadyEnv, "LISTEN_PID", "LISTEN_FDS", "LISTEN_FDNAMES":
+			continue
+		}
+
+		env = append(env, kv)
+	}
+
+	return env
+}
+
+func listenerFile(listener net.Listener) (*os.File, error) {
+	for {
+		switch l := listener.(type) {
+		case *onceCloseListener:
+			listener = l.Listener
+		case *proxyproto.Listener:
+			listener = l.Listener
+		case tcpKeepAliveListener:
+			return l.File()
+		case *net.TCPListener:
+			return l.File()
+		default:
+			return nil, fmt.Errorf("unsupported listener type %T", listener)
+		}
+	}
+}
+
+func packetConnFile(conn net.PacketConn) (*os.File, error) {
+	udpConn, ok := conn.(*net.UDPConn)
+	if !ok {
+		return nil, fmt.Errorf("unsupported packet conn type %T", conn)
+	}
+
+	return udpConn.File()
+}
@@ -0,0 +1,98 @@
+//go:build !windows
+
+package server
+
+import (
+	"net"
+	"strconv"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/traefik/traefik/v3/pkg/config/static"
+)
+
+func TestListenerFile(t *testing.T) {
+	testCases := []struct {
+		desc          string
+		proxyProtocol *static.ProxyProtocol
+	}{
+		{
+			desc: "TCP listener",
+		},
+		{
+			desc:          "proxy protocol listener",
+			proxyProtocol: &static.ProxyProtocol{Insecure: true},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			config := &static.EntryPoint{Address: "127.0.0.1:0", ProxyProtocol: test.proxyProtocol}
+			config.SetDefaults()
+
+			listener, err := buildListener(t.Context(), "test", config)
+			require.NoError(t, err)
+			t.Cleanup(func() { _ = listener.Close() })
+
+			f, err := listenerFile(listener)
+			require.NoError(t, err)
+			t.Cleanup(func() { _ = f.Close() })
+
+			handedOver, err := net.FileListener(f)
+			require.NoError(t, err)
+			t.Cleanup(func() { _ = handedOver.Close() })
+
+			assert.Equal(t, listener.Addr().String(), handedOver.Addr().String())
+
+			// The original listener can be closed, the socket is still served by the handed over one.
+			require.NoError(t, listener.Close())
+
+			go func() {
+				conn, err := handedOver.Accept()
+				if err == nil {
+					_ = conn.Close()
+				}
+			}()
+
+			conn, err := net.Dial("tcp", handedOver.Addr().String())
+			require.NoError(t, err)
+			_ = conn.Close()
+		})
+	}
+}
+
+func TestNotifyHandoverReady(t *testing.T) {
+	var fds [2]int
+	require.NoError(t, syscall.Pipe(fds[:]))
+	t.Cleanup(func() { _ = syscall.Close(fds[0]) })
+
+	t.Setenv(handoverReadyEnv, strconv.Itoa(fds[1]))
+
+	notifyHandoverReady()
+
+	buf := make([]byte, 2)
+	n, err := syscall.Read(fds[0], buf)
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+
+	// The write end of the pipe is closed once notified.
+	n, err = syscall.Read(fds[0], buf)
+	require.NoError(t, err)
+	assert.Equal(t, 0, n)
+}
+
+func TestHandoverEnviron(t *testing.T) {
+	t.Setenv(handoverListenersEnv, "web")
+	t.Setenv("LISTEN_FDS", "1")
+	t.Setenv("TRAEFIK_TEST_HANDOVER", "foo")
+
+	env := handoverEnviron()
+
+	assert.Contains(t, env, "TRAEFIK_TEST_HANDOVER=foo")
+	assert.NotContains(t, env, handoverListenersEnv+"=web")
+	assert.NotContains(t, env, "LISTEN_FDS=1")
+}
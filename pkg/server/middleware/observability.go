@@ -6,6 +6,7 @@ import (
 	"net/http"
 
 	"github.com/containous/alice"
+	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 	"github.com/traefik/traefik/v3/pkg/config/dynamic"
 	"github.com/traefik/traefik/v3/pkg/config/static"
@@ -27,18 +28,28 @@ type ObservabilityMgr struct {
 	semConvMetricRegistry  *metrics.SemConvMetricsRegistry
 	tracer                 *tracing.Tracer
 	tracerCloser           io.Closer
+	mirrorComparisonLog    io.WriteCloser
+	mirrorComparisonLogger *zerolog.Logger
 }
 
 // NewObservabilityMgr creates a new ObservabilityMgr.
-func NewObservabilityMgr(config static.Configuration, metricsRegistry metrics.Registry, semConvMetricRegistry *metrics.SemConvMetricsRegistry, accessLoggerMiddleware accesslog.Accesslog, tracer *tracing.Tracer, tracerCloser io.Closer) *ObservabilityMgr {
-	return &ObservabilityMgr{
+func NewObservabilityMgr(config static.Configuration, metricsRegistry metrics.Registry, semConvMetricRegistry *metrics.SemConvMetricsRegistry, accessLoggerMiddleware accesslog.Accesslog, tracer *tracing.Tracer, tracerCloser io.Closer, mirrorComparisonLog io.WriteCloser) *ObservabilityMgr {
+	mgr := &ObservabilityMgr{
 		config:                 config,
 		metricsRegistry:        metricsRegistry,
 		semConvMetricRegistry:  semConvMetricRegistry,
 		accessLoggerMiddleware: accessLoggerMiddleware,
 		tracer:                 tracer,
 		tracerCloser:           tracerCloser,
+		mirrorComparisonLog:    mirrorComparisonLog,
 	}
+
+	if mirrorComparisonLog != nil {
+		logger := zerolog.New(mirrorComparisonLog).With().Timestamp().Logger()
+		mgr.mirrorComparisonLogger = &logger
+	}
+
+	return mgr
 }
 
 // BuildEPChain an observability middleware chain by entry point.
@@ -98,7 +109,26 @@ func (o *ObservabilityMgr) SemConvMetricsRegistry() *metrics.SemConvMetricsRegis
 	return o.semConvMetricRegistry
 }
 
-// Close closes the accessLogger and tracer.
+// MirrorComparisonLogger returns the logger of the mirror responses differences,
+// or nil if the mirror comparison log is not configured.
+func (o *ObservabilityMgr) MirrorComparisonLogger() *zerolog.Logger {
+	if o == nil {
+		return nil
+	}
+
+	return o.mirrorComparisonLogger
+}
+
+// TCPTracer returns the tracer for TCP connections, or nil if tracing is disabled.
+func (o *ObservabilityMgr) TCPTracer() *tracing.Tracer {
+	if o == nil || o.config.Tracing == nil {
+		return nil
+	}
+
+	return o.tracer
+}
+
+// Close closes the accessLogger, the tracer, and the mirror comparison log.
 func (o *ObservabilityMgr) Close() {
 	if o == nil {
 		return
@@ -115,6 +145,12 @@ func (o *ObservabilityMgr) Close() {
 			log.Error().Err(err).Msg("Could not close the tracer")
 		}
 	}
+
+	if o.mirrorComparisonLog != nil {
+		if err := o.mirrorComparisonLog.Close(); err != nil {
+			log.Error().Err(err).Msg("Could not close the mirror comparison log file")
+		}
+	}
 }
 
 func (o *ObservabilityMgr) RotateAccessLogs() error {
@@ -158,7 +194,7 @@ func (o *ObservabilityMgr) shouldMeter(internal bool, observabilityConfig dynami
 		return false
 	}
 
-	if !o.metricsRegistry.IsEpEnabled() && !o.metricsRegistry.IsRouterEnabled() && !o.metricsRegistry.IsSvcEnabled() {
+	if !o.metricsRegistry.IsEpEnabled() && !o.metricsRegistry.IsRouterEnabled() && !o.metricsRegistry.IsSvcEnabled() && !o.metricsRegistry.IsMiddlewareEnabled() {
 		return false
 	}
 
@@ -2,7 +2,9 @@ package tcpmiddleware
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"reflect"
 	"slices"
 	"strings"
 
@@ -23,12 +25,13 @@ const (
 
 // Builder the middleware builder.
 type Builder struct {
-	configs map[string]*runtime.TCPMiddlewareInfo
+	configs       map[string]*runtime.TCPMiddlewareInfo
+	pluginBuilder PluginsBuilder
 }
 
 // NewBuilder creates a new Builder.
-func NewBuilder(configs map[string]*runtime.TCPMiddlewareInfo) *Builder {
-	return &Builder{configs: configs}
+func NewBuilder(configs map[string]*runtime.TCPMiddlewareInfo, pluginBuilder PluginsBuilder) *Builder {
+	return &Builder{configs: configs, pluginBuilder: pluginBuilder}
 }
 
 // BuildChain creates a middleware chain.
@@ -127,6 +130,27 @@ func (b *Builder) buildConstructor(ctx context.Context, middlewareName string) (
 		}
 	})
 
+	// Plugin
+	if config.Plugin != nil && b.pluginBuilder != nil && !reflect.ValueOf(b.pluginBuilder).IsNil() { // Using "reflect" because "b.pluginBuilder" is an interface.
+		if middleware != nil {
+			return nil, errors.New("cannot create middleware: multi-types middleware not supported, consider declaring two different pieces of middleware instead")
+		}
+
+		pluginType, rawPluginConfig, err := findPluginConfig(config.Plugin)
+		if err != nil {
+			return nil, fmt.Errorf("plugin: %w", err)
+		}
+
+		plug, err := b.pluginBuilder.BuildTCP(pluginType, rawPluginConfig, middlewareName)
+		if err != nil {
+			return nil, fmt.Errorf("plugin: %w", err)
+		}
+
+		middleware = func(next tcp.Handler) (tcp.Handler, error) {
+			return plug(ctx, next)
+		}
+	}
+
 	if middleware == nil {
 		return nil, fmt.Errorf("invalid middleware %q configuration: invalid middleware type or middleware does not exist", middlewareName)
 	}
@@ -46,6 +46,11 @@ type Manager struct {
 	conf               *runtime.Configuration
 	tlsManager         *tls.Manager
 	parser             httpmuxer.SyntaxParser
+
+	// The muxers of the entry points, and of the routers having child routers, are kept to explain the routing.
+	entryPointMuxers    map[string]*httpmuxer.Muxer
+	entryPointTLSMuxers map[string]*httpmuxer.Muxer
+	childMuxers         map[string]*httpmuxer.Muxer
 }
 
 // NewManager creates a new Manager.
@@ -57,13 +62,16 @@ func NewManager(conf *runtime.Configuration,
 	parser httpmuxer.SyntaxParser,
 ) *Manager {
 	return &Manager{
-		routerHandlers:     make(map[string]http.Handler),
-		serviceManager:     serviceManager,
-		observabilityMgr:   observabilityMgr,
-		middlewaresBuilder: middlewaresBuilder,
-		conf:               conf,
-		tlsManager:         tlsManager,
-		parser:             parser,
+		routerHandlers:      make(map[string]http.Handler),
+		serviceManager:      serviceManager,
+		observabilityMgr:    observabilityMgr,
+		middlewaresBuilder:  middlewaresBuilder,
+		conf:                conf,
+		tlsManager:          tlsManager,
+		parser:              parser,
+		entryPointMuxers:    make(map[string]*httpmuxer.Muxer),
+		entryPointTLSMuxers: make(map[string]*httpmuxer.Muxer),
+		childMuxers:         make(map[string]*httpmuxer.Muxer),
 	}
 }
 
@@ -163,6 +171,11 @@ func (m *Manager) ParseRouterTree() {
 func (m *Manager) BuildHandlers(rootCtx context.Context, entryPoints []string, tls bool) map[string]http.Handler {
 	entryPointHandlers := make(map[string]http.Handler)
 
+	muxers := m.entryPointMuxers
+	if tls {
+		muxers = m.entryPointTLSMuxers
+	}
+
 	defaultObsConfig := dynamic.RouterObservabilityConfig{}
 	defaultObsConfig.SetDefaults()
 
@@ -178,13 +191,14 @@ func (m *Manager) BuildHandlers(rootCtx context.Context, entryPoints []string, t
 			epObsConfig = model.Observability
 		}
 
-		handler, err := m.buildEntryPointHandler(ctx, entryPointName, routers, epObsConfig)
+		handler, muxer, err := m.buildEntryPointHandler(ctx, entryPointName, routers, epObsConfig)
 		if err != nil {
 			logger.Error().Err(err).Send()
 			continue
 		}
 
 		entryPointHandlers[entryPointName] = handler
+		muxers[entryPointName] = muxer
 	}
 
 	// Create default handlers.
@@ -224,12 +238,12 @@ func (m *Manager) getHTTPRouters(ctx context.Context, entryPoints []string, tls
 	return make(map[string]map[string]*runtime.RouterInfo)
 }
 
-func (m *Manager) buildEntryPointHandler(ctx context.Context, entryPointName string, configs map[string]*runtime.RouterInfo, config dynamic.RouterObservabilityConfig) (http.Handler, error) {
+func (m *Manager) buildEntryPointHandler(ctx context.Context, entryPointName string, configs map[string]*runtime.RouterInfo, config dynamic.RouterObservabilityConfig) (http.Handler, *httpmuxer.Muxer, error) {
 	muxer := httpmuxer.NewMuxer(m.parser)
 
 	defaultHandler, err := m.observabilityMgr.BuildEPChain(ctx, entryPointName, false, config).Then(http.NotFoundHandler())
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	muxer.SetDefaultHandler(defaultHandler)
@@ -274,7 +288,7 @@ func (m *Manager) buildEntryPointHandler(ctx context.Context, entryPointName str
 			continue
 		}
 
-		if err = muxer.AddRoute(routerConfig.Rule, routerConfig.RuleSyntax, routerConfig.Priority, handler); err != nil {
+		if err = muxer.AddNamedRoute(routerName, routerConfig.Rule, routerConfig.RuleSyntax, routerConfig.Priority, handler); err != nil {
 			routerConfig.AddError(err, true)
 			logger.Error().Err(err).Send()
 			continue
@@ -286,7 +300,12 @@ func (m *Manager) buildEntryPointHandler(ctx context.Context, entryPointName str
 		return recovery.New(ctx, next)
 	})
 
-	return chain.Then(muxer)
+	handler, err := chain.Then(muxer)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return handler, muxer, nil
 }
 
 func (m *Manager) buildRouterHandler(ctx context.Context, entryPointName, routerName string, routerConfig *runtime.RouterInfo) (http.Handler, error) {
@@ -337,10 +356,13 @@ func (m *Manager) buildHTTPHandler(ctx context.Context, router *runtime.RouterIn
 	switch {
 	case len(router.ChildRefs) > 0:
 		// This router routes to child routers - create a muxer for them
-		nextHandler, err = m.buildChildRoutersMuxer(ctx, entryPointName, router.ChildRefs)
+		var childMuxer *httpmuxer.Muxer
+		childMuxer, err = m.buildChildRoutersMuxer(ctx, entryPointName, router.ChildRefs)
 		if err != nil {
 			return nil, fmt.Errorf("building child routers muxer: %w", err)
 		}
+		m.childMuxers[routerName] = childMuxer
+		nextHandler = childMuxer
 		serviceName = fmt.Sprintf("%s-muxer", routerName)
 	case router.Service != "":
 		// This router routes to a service
@@ -455,7 +477,7 @@ func (m *Manager) handleCycle(victimRouter string, path []string) {
 }
 
 // buildChildRoutersMuxer creates a muxer for child routers.
-func (m *Manager) buildChildRoutersMuxer(ctx context.Context, entryPointName string, childRefs []string) (http.Handler, error) {
+func (m *Manager) buildChildRoutersMuxer(ctx context.Context, entryPointName string, childRefs []string) (*httpmuxer.Muxer, error) {
 	childMuxer := httpmuxer.NewMuxer(m.parser)
 
 	// Set a default handler for the child muxer (404 Not Found).
@@ -490,7 +512,7 @@ func (m *Manager) buildChildRoutersMuxer(ctx context.Context, entryPointName str
 		}
 
 		// Add the child router to the muxer.
-		if err = childMuxer.AddRoute(childRouter.Rule, childRouter.RuleSyntax, childRouter.Priority, childHandler); err != nil {
+		if err = childMuxer.AddNamedRoute(childName, childRouter.Rule, childRouter.RuleSyntax, childRouter.Priority, childHandler); err != nil {
 			childRouter.AddError(err, true)
 			logger.Error().Err(err).Send()
 			continue
@@ -506,3 +528,62 @@ func (m *Manager) buildChildRoutersMuxer(ctx context.Context, entryPointName str
 
 	return childMuxer, nil
 }
+
+// Explain explains how the request, received by the entry point, is routed by the HTTP routers.
+// The request is evaluated by the muxers built for the TLS requests when it has a TLS connection state.
+func (m *Manager) Explain(entryPointName string, req *http.Request) (*runtime.RoutingExplanation, error) {
+	muxers := m.entryPointMuxers
+	if req.TLS != nil {
+		muxers = m.entryPointTLSMuxers
+	}
+
+	explanation := &runtime.RoutingExplanation{Candidates: []runtime.RouteCandidate{}}
+
+	muxer, parent := muxers[entryPointName], ""
+	for muxer != nil {
+		routes, err := muxer.Explain(req)
+		if err != nil {
+			return nil, err
+		}
+
+		var matched string
+		for _, route := range routes {
+			candidate := runtime.RouteCandidate{
+				Name:           route.Name,
+				Parent:         parent,
+				Priority:       route.Priority,
+				Matched:        route.Matched,
+				FailedMatchers: route.FailedMatchers,
+			}
+
+			if rt, ok := m.conf.Routers[route.Name]; ok {
+				candidate.Rule = rt.Rule
+			}
+
+			explanation.Candidates = append(explanation.Candidates, candidate)
+
+			if matched == "" && route.Matched {
+				matched = route.Name
+			}
+		}
+
+		if matched == "" {
+			// The request is not handled, even when a parent router matched.
+			explanation.Router, explanation.Middlewares, explanation.Service = "", nil, ""
+			break
+		}
+
+		rt := m.conf.Routers[matched]
+
+		// The middlewares of the router have been qualified when building its handler.
+		explanation.Router = matched
+		explanation.Middlewares = append(explanation.Middlewares, rt.Middlewares...)
+
+		muxer, parent = m.childMuxers[matched], matched
+		if muxer == nil {
+			explanation.Service = provider.GetQualifiedName(provider.AddInContext(context.Background(), matched), rt.Service)
+		}
+	}
+
+	return explanation, nil
+}
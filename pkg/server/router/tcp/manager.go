@@ -13,10 +13,13 @@ import (
 
 	"github.com/rs/zerolog/log"
 	"github.com/traefik/traefik/v3/pkg/config/runtime"
+	"github.com/traefik/traefik/v3/pkg/geoip"
+	"github.com/traefik/traefik/v3/pkg/middlewares/observability"
 	"github.com/traefik/traefik/v3/pkg/middlewares/snicheck"
 	httpmuxer "github.com/traefik/traefik/v3/pkg/muxer/http"
 	tcpmuxer "github.com/traefik/traefik/v3/pkg/muxer/tcp"
 	"github.com/traefik/traefik/v3/pkg/observability/logs"
+	"github.com/traefik/traefik/v3/pkg/observability/tracing"
 	"github.com/traefik/traefik/v3/pkg/server/provider"
 	tcpservice "github.com/traefik/traefik/v3/pkg/server/service/tcp"
 	"github.com/traefik/traefik/v3/pkg/tcp"
@@ -36,6 +39,8 @@ type Manager struct {
 	httpHandlers       map[string]http.Handler
 	httpsHandlers      map[string]http.Handler
 	tlsManager         *traefiktls.Manager
+	tracer             *tracing.Tracer
+	geoIPResolver      *geoip.Resolver
 	conf               *runtime.Configuration
 }
 
@@ -46,6 +51,7 @@ func NewManager(conf *runtime.Configuration,
 	httpHandlers map[string]http.Handler,
 	httpsHandlers map[string]http.Handler,
 	tlsManager *traefiktls.Manager,
+	tracer *tracing.Tracer,
 ) *Manager {
 	return &Manager{
 		serviceManager:     serviceManager,
@@ -53,10 +59,16 @@ func NewManager(conf *runtime.Configuration,
 		httpHandlers:       httpHandlers,
 		httpsHandlers:      httpsHandlers,
 		tlsManager:         tlsManager,
+		tracer:             tracer,
 		conf:               conf,
 	}
 }
 
+// SetGeoIPResolver sets the resolver used by the ClientCountry and ClientASN matchers.
+func (m *Manager) SetGeoIPResolver(resolver *geoip.Resolver) {
+	m.geoIPResolver = resolver
+}
+
 // BuildHandlers builds the handlers for the given entrypoints.
 func (m *Manager) BuildHandlers(rootCtx context.Context, entryPoints []string) map[string]*Router {
 	entryPointsRouters := m.getTCPRouters(rootCtx, entryPoints)
@@ -103,7 +115,7 @@ type nameAndConfig struct {
 
 func (m *Manager) buildEntryPointHandler(ctx context.Context, configs map[string]*runtime.TCPRouterInfo, configsHTTP map[string]*runtime.RouterInfo, handlerHTTP, handlerHTTPS http.Handler) (*Router, error) {
 	// Build a new Router.
-	router, err := NewRouter()
+	router, err := NewRouter(tcpmuxer.WithGeoIP(m.geoIPResolver))
 	if err != nil {
 		return nil, err
 	}
@@ -332,12 +344,13 @@ func (m *Manager) addTCPHandlers(ctx context.Context, configs map[string]*runtim
 				continue
 			}
 			handler = tcp.NewFieldHandler(handler, map[string]string{tcp.RouterName: routerName})
+			handler = observability.NewTCPRouter(ctxRouter, m.tracer, routerName, routerConfig.Service, handler)
 		}
 
 		if routerConfig.TLS == nil {
 			logger.Debug().Msgf("Adding route for %q", routerConfig.Rule)
 
-			if err := router.muxerTCP.AddRoute(routerConfig.Rule, routerConfig.RuleSyntax, routerConfig.Priority, handler); err != nil {
+			if err := router.muxerTCP.AddNamedRoute(routerName, routerConfig.Rule, routerConfig.RuleSyntax, routerConfig.Priority, handler); err != nil {
 				routerConfig.AddError(err, true)
 				logger.Error().Err(err).Send()
 			}
@@ -347,7 +360,7 @@ func (m *Manager) addTCPHandlers(ctx context.Context, configs map[string]*runtim
 		if routerConfig.TLS.Passthrough {
 			logger.Debug().Msgf("Adding Passthrough route for %q", routerConfig.Rule)
 
-			if err := router.muxerTCPTLS.AddRoute(routerConfig.Rule, routerConfig.RuleSyntax, routerConfig.Priority, handler); err != nil {
+			if err := router.muxerTCPTLS.AddNamedRoute(routerName, routerConfig.Rule, routerConfig.RuleSyntax, routerConfig.Priority, handler); err != nil {
 				routerConfig.AddError(err, true)
 				logger.Error().Err(err).Send()
 			}
@@ -381,7 +394,7 @@ func (m *Manager) addTCPHandlers(ctx context.Context, configs map[string]*runtim
 
 			logger.Debug().Msgf("Adding special TLS closing route for %q because broken TLS options %s", routerConfig.Rule, tlsOptionsName)
 
-			if err := router.muxerTCPTLS.AddRoute(routerConfig.Rule, routerConfig.RuleSyntax, routerConfig.Priority, &brokenTLSRouter{}); err != nil {
+			if err := router.muxerTCPTLS.AddNamedRoute(routerName, routerConfig.Rule, routerConfig.RuleSyntax, routerConfig.Priority, &brokenTLSRouter{}); err != nil {
 				routerConfig.AddError(err, true)
 				logger.Error().Err(err).Send()
 			}
@@ -408,11 +421,12 @@ func (m *Manager) addTCPHandlers(ctx context.Context, configs map[string]*runtim
 			continue
 		}
 
+		handler = observability.NewTCPRouter(ctxRouter, m.tracer, routerName, routerConfig.Service, handler)
 		handler = tcp.TLSServer(handler, tlsConf, routerConfig.TLS.Plugin, nil)
 
 		logger.Debug().Msgf("Adding TLS route for %q", routerConfig.Rule)
 
-		if err := router.muxerTCPTLS.AddRoute(routerConfig.Rule, routerConfig.RuleSyntax, routerConfig.Priority, handler); err != nil {
+		if err := router.muxerTCPTLS.AddNamedRoute(routerName, routerConfig.Rule, routerConfig.RuleSyntax, routerConfig.Priority, handler); err != nil {
 			routerConfig.AddError(err, true)
 			logger.Error().Err(err).Send()
 			continue
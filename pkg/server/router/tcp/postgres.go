@@ -3,6 +3,7 @@ package tcp
 import (
 	"bufio"
 	"bytes"
+	"context"
 	"errors"
 	"io"
 	"net"
@@ -85,6 +86,8 @@ func (r *Router) servePostgres(conn tcp.WriteCloser) {
 		return
 	}
 
+	setHelloVars(conn, hello)
+
 	// Contains also TCP TLS passthrough routes.
 	handlerTCPTLS, _ := r.muxerTCPTLS.Match(connData)
 	if handlerTCPTLS == nil {
@@ -172,3 +175,8 @@ func (c *postgresConn) Write(p []byte) (n int, err error) {
 
 	return 1, nil
 }
+
+// Context returns the context of the underlying connection.
+func (c *postgresConn) Context() context.Context {
+	return tcp.ContextOf(c.WriteCloser)
+}
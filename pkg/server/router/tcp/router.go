@@ -11,6 +11,7 @@ import (
 	"net"
 	"net/http"
 	"slices"
+	"strings"
 	"time"
 
 	"github.com/go-acme/lego/v4/challenge/tlsalpn01"
@@ -61,19 +62,19 @@ type Router struct {
 	plugin            map[string]map[string]any
 }
 
-// NewRouter returns a new TCP router.
-func NewRouter() (*Router, error) {
-	muxTCP, err := tcpmuxer.NewMuxer()
+// NewRouter returns a new TCP router, whose muxers are configured with the given options.
+func NewRouter(opts ...tcpmuxer.Option) (*Router, error) {
+	muxTCP, err := tcpmuxer.NewMuxer(opts...)
 	if err != nil {
 		return nil, err
 	}
 
-	muxTCPTLS, err := tcpmuxer.NewMuxer()
+	muxTCPTLS, err := tcpmuxer.NewMuxer(opts...)
 	if err != nil {
 		return nil, err
 	}
 
-	muxHTTPS, err := tcpmuxer.NewMuxer()
+	muxHTTPS, err := tcpmuxer.NewMuxer(opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -178,6 +179,8 @@ func (r *Router) ServeTCPRoute(conn tcp.WriteCloser) {
 		return
 	}
 
+	setHelloVars(conn, hello)
+
 	if !hello.isTLS {
 		handler, _ := r.muxerTCP.Match(connData)
 		switch {
@@ -241,6 +244,52 @@ func (r *Router) ServeTCPRoute(conn tcp.WriteCloser) {
 	conn.Close()
 }
 
+// Explain explains how a connection with the given metadata is routed, following the ServeTCPRoute decisions.
+// It returns the evaluation of the TCP routes, and the name of the TCP router handling the connection,
+// which is empty when the connection is handled by the HTTP routers, or closed.
+func (r *Router) Explain(connData tcpmuxer.ConnData, isTLS bool) ([]tcpmuxer.RouteExplanation, string) {
+	if !isTLS {
+		routes := r.muxerTCP.Explain(connData)
+
+		return routes, firstMatchingRoute(routes, true)
+	}
+
+	routes := r.muxerTCPTLS.Explain(connData)
+
+	// An HTTPS router with a specific HostSNI takes precedence over the TCP TLS routers.
+	httpsRoutes := r.muxerHTTPS.Explain(connData)
+	httpsIdx := slices.IndexFunc(httpsRoutes, func(route tcpmuxer.RouteExplanation) bool { return route.Matched })
+	if httpsIdx >= 0 && !httpsRoutes[httpsIdx].CatchAll {
+		return routes, ""
+	}
+
+	if name := firstMatchingRoute(routes, false); name != "" {
+		return routes, name
+	}
+
+	// Fallback on HTTPS catchAll.
+	if httpsIdx >= 0 {
+		return routes, ""
+	}
+
+	return routes, firstMatchingRoute(routes, true)
+}
+
+// firstMatchingRoute returns the name of the first matching route, considering the catchAll routes if asked.
+func firstMatchingRoute(routes []tcpmuxer.RouteExplanation, withCatchAll bool) string {
+	for _, route := range routes {
+		if route.Matched {
+			if route.CatchAll && !withCatchAll {
+				return ""
+			}
+
+			return route.Name
+		}
+	}
+
+	return ""
+}
+
 // AddTCPRoute defines a handler for the given rule.
 func (r *Router) AddTCPRoute(rule string, priority int, target tcp.Handler) error {
 	return r.muxerTCP.AddRoute(rule, "", priority, target)
@@ -391,10 +440,19 @@ func (c *Conn) Read(p []byte) (n int, err error) {
 }
 
 func (c *Conn) Context() context.Context {
-	if nc, ok := c.WriteCloser.(tcp.NextConn); ok {
-		return nc.Context()
+	return tcp.ContextOf(c.WriteCloser)
+}
+
+// setHelloVars records the SNI and ALPN protocols of a TLS ClientHello in the connection context variables.
+func setHelloVars(conn tcp.WriteCloser, hello *clientHello) {
+	if !hello.isTLS {
+		return
 	}
-	return context.Background()
+
+	tcp.ContextVars(conn, map[string]string{
+		tcp.RequestTLSSNI:  hello.serverName,
+		tcp.RequestTLSALPN: strings.Join(hello.protos, ","),
+	})
 }
 
 type clientHello struct {
@@ -3,10 +3,13 @@ package server
 import (
 	"context"
 	"fmt"
+	"slices"
 
 	"github.com/rs/zerolog/log"
 	"github.com/traefik/traefik/v3/pkg/config/runtime"
 	"github.com/traefik/traefik/v3/pkg/config/static"
+	"github.com/traefik/traefik/v3/pkg/geoip"
+	"github.com/traefik/traefik/v3/pkg/middlewares/requestdecorator"
 	httpmuxer "github.com/traefik/traefik/v3/pkg/muxer/http"
 	"github.com/traefik/traefik/v3/pkg/server/middleware"
 	tcpmiddleware "github.com/traefik/traefik/v3/pkg/server/middleware/tcp"
@@ -14,6 +17,7 @@ import (
 	tcprouter "github.com/traefik/traefik/v3/pkg/server/router/tcp"
 	udprouter "github.com/traefik/traefik/v3/pkg/server/router/udp"
 	"github.com/traefik/traefik/v3/pkg/server/service"
+	"github.com/traefik/traefik/v3/pkg/server/service/drain"
 	tcpsvc "github.com/traefik/traefik/v3/pkg/server/service/tcp"
 	udpsvc "github.com/traefik/traefik/v3/pkg/server/service/udp"
 	"github.com/traefik/traefik/v3/pkg/tcp"
@@ -27,6 +31,8 @@ type RouterFactory struct {
 	entryPointsUDP []string
 
 	allowACMEByPass map[string]bool
+	// handlesTLSChallenge reports whether a certificate resolver handles the ACME TLS challenge.
+	handlesTLSChallenge bool
 
 	managerFactory *service.ManagerFactory
 
@@ -37,14 +43,25 @@ type RouterFactory struct {
 
 	dialerManager *tcp.DialerManager
 
+	geoIPResolver *geoip.Resolver
+
+	// The drain managers track the connections to the servers across configuration reloads.
+	httpDrainManager *drain.Manager
+	tcpDrainManager  *drain.Manager
+
 	cancelPrevState func()
 
 	parser httpmuxer.SyntaxParser
+
+	// The entry points configuration and request decorator are used to explain the routing.
+	staticEntryPoints static.EntryPoints
+	reqDecorator      *requestdecorator.RequestDecorator
 }
 
 // NewRouterFactory creates a new RouterFactory.
 func NewRouterFactory(staticConfiguration static.Configuration, managerFactory *service.ManagerFactory, tlsManager *tls.Manager,
 	observabilityMgr *middleware.ObservabilityMgr, pluginBuilder middleware.PluginsBuilder, dialerManager *tcp.DialerManager,
+	geoIPResolver *geoip.Resolver,
 ) (*RouterFactory, error) {
 	handlesTLSChallenge := false
 	for _, resolver := range staticConfiguration.CertificatesResolvers {
@@ -72,7 +89,7 @@ func NewRouterFactory(staticConfiguration static.Configuration, managerFactory *
 		}
 	}
 
-	parser, err := httpmuxer.NewSyntaxParser()
+	parser, err := httpmuxer.NewSyntaxParser(httpmuxer.WithGeoIP(geoIPResolver))
 	if err != nil {
 		return nil, fmt.Errorf("creating parser: %w", err)
 	}
@@ -85,8 +102,16 @@ func NewRouterFactory(staticConfiguration static.Configuration, managerFactory *
 		tlsManager:       tlsManager,
 		pluginBuilder:    pluginBuilder,
 		dialerManager:    dialerManager,
+		geoIPResolver:    geoIPResolver,
+		httpDrainManager: drain.NewManager(),
+		tcpDrainManager:  drain.NewManager(),
 		allowACMEByPass:  allowACMEByPass,
 		parser:           parser,
+
+		staticEntryPoints: staticConfiguration.EntryPoints,
+		reqDecorator:      requestdecorator.New(staticConfiguration.HostResolver),
+
+		handlesTLSChallenge: handlesTLSChallenge,
 	}, nil
 }
 
@@ -102,29 +127,47 @@ func (f *RouterFactory) CreateRouters(rtConf *runtime.Configuration) (map[string
 	// HTTP
 	serviceManager := f.managerFactory.Build(rtConf)
 
-	middlewaresBuilder := middleware.NewBuilder(rtConf.Middlewares, serviceManager, f.pluginBuilder)
+	middlewaresBuilder := middleware.NewBuilder(rtConf.Middlewares, serviceManager, f.pluginBuilder, f.observabilityMgr.MetricsRegistry())
+	middlewaresBuilder.SetGeoIPResolver(f.geoIPResolver)
 
 	serviceManager.SetMiddlewareChainBuilder(middlewaresBuilder)
+	serviceManager.SetDrainManager(f.httpDrainManager)
 
 	routerManager := router.NewManager(rtConf, serviceManager, middlewaresBuilder, f.observabilityMgr, f.tlsManager, f.parser)
 
 	routerManager.ParseRouterTree()
 
-	handlersNonTLS := routerManager.BuildHandlers(ctx, f.entryPointsTCP, false)
-	handlersTLS := routerManager.BuildHandlers(ctx, f.entryPointsTCP, true)
+	entryPointsTCP := f.entryPointsTCP
+	for name, ep := range rtConf.EntryPoints {
+		if ep.Status == runtime.StatusEnabled {
+			entryPointsTCP = append(slices.Clip(entryPointsTCP), name)
+		}
+	}
+
+	handlersNonTLS := routerManager.BuildHandlers(ctx, entryPointsTCP, false)
+	handlersTLS := routerManager.BuildHandlers(ctx, entryPointsTCP, true)
 
 	serviceManager.LaunchHealthCheck(ctx)
 
 	// TCP
 	svcTCPManager := tcpsvc.NewManager(rtConf, f.dialerManager)
+	svcTCPManager.SetDrainManager(f.tcpDrainManager)
 
-	middlewaresTCPBuilder := tcpmiddleware.NewBuilder(rtConf.TCPMiddlewares)
+	tcpPluginBuilder, _ := f.pluginBuilder.(tcpmiddleware.PluginsBuilder)
+	middlewaresTCPBuilder := tcpmiddleware.NewBuilder(rtConf.TCPMiddlewares, tcpPluginBuilder)
 
-	rtTCPManager := tcprouter.NewManager(rtConf, svcTCPManager, middlewaresTCPBuilder, handlersNonTLS, handlersTLS, f.tlsManager)
-	routersTCP := rtTCPManager.BuildHandlers(ctx, f.entryPointsTCP)
+	rtTCPManager := tcprouter.NewManager(rtConf, svcTCPManager, middlewaresTCPBuilder, handlersNonTLS, handlersTLS, f.tlsManager, f.observabilityMgr.TCPTracer())
+	rtTCPManager.SetGeoIPResolver(f.geoIPResolver)
+	routersTCP := rtTCPManager.BuildHandlers(ctx, entryPointsTCP)
 
 	for ep, r := range routersTCP {
-		if allowACMEByPass, ok := f.allowACMEByPass[ep]; ok && allowACMEByPass {
+		allowACMEByPass, ok := f.allowACMEByPass[ep]
+		if !ok {
+			// Dynamic entry points cannot enable the ACME bypass explicitly.
+			allowACMEByPass = !f.handlesTLSChallenge
+		}
+
+		if allowACMEByPass {
 			r.EnableACMETLSPassthrough()
 		}
 	}
@@ -136,7 +179,19 @@ func (f *RouterFactory) CreateRouters(rtConf *runtime.Configuration) (map[string
 	rtUDPManager := udprouter.NewManager(rtConf, svcUDPManager)
 	routersUDP := rtUDPManager.BuildHandlers(ctx, f.entryPointsUDP)
 
+	// Drains the connections to the servers which are not part of the new configuration anymore.
+	f.httpDrainManager.Commit()
+	f.tcpDrainManager.Commit()
+
 	rtConf.PopulateUsedBy()
 
+	rtConf.Routing = &routingExplainer{
+		staticEntryPoints: f.staticEntryPoints,
+		reqDecorator:      f.reqDecorator,
+		conf:              rtConf,
+		httpRouters:       routerManager,
+		tcpRouters:        routersTCP,
+	}
+
 	return routersTCP, routersUDP
 }
@@ -0,0 +1,156 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+
+	"github.com/traefik/traefik/v3/pkg/config/runtime"
+	"github.com/traefik/traefik/v3/pkg/config/static"
+	"github.com/traefik/traefik/v3/pkg/middlewares/requestdecorator"
+	tcpmuxer "github.com/traefik/traefik/v3/pkg/muxer/tcp"
+	"github.com/traefik/traefik/v3/pkg/server/provider"
+	"github.com/traefik/traefik/v3/pkg/server/router"
+	tcprouter "github.com/traefik/traefik/v3/pkg/server/router/tcp"
+	"github.com/traefik/traefik/v3/pkg/types"
+)
+
+// routingExplainer explains the routing with the routers built by the router factory,
+// and with the request processing done by the entry points before routing the requests.
+type routingExplainer struct {
+	staticEntryPoints static.EntryPoints
+	reqDecorator      *requestdecorator.RequestDecorator
+
+	conf        *runtime.Configuration
+	httpRouters *router.Manager
+	tcpRouters  map[string]*tcprouter.Router
+}
+
+// ExplainHTTP explains how the request, received by the given entry point, is routed.
+func (e *routingExplainer) ExplainHTTP(entryPointName string, req *http.Request) (*runtime.RoutingExplanation, error) {
+	epConfig, localAddr, err := e.entryPoint(entryPointName)
+	if err != nil {
+		return nil, err
+	}
+
+	req = req.WithContext(context.WithValue(req.Context(), http.LocalAddrContextKey, localAddr))
+
+	var routedReq *http.Request
+	handler, err := newEntryPointHandler(req.Context(), epConfig, e.reqDecorator, http.HandlerFunc(func(_ http.ResponseWriter, req *http.Request) {
+		routedReq = req
+	}))
+	if err != nil {
+		return nil, fmt.Errorf("building entry point handler: %w", err)
+	}
+
+	rw := &statusResponseWriter{header: make(http.Header)}
+	handler.ServeHTTP(rw, req)
+
+	if routedReq == nil {
+		return nil, fmt.Errorf("request rejected by the entry point with the status code %d", rw.code)
+	}
+
+	return e.httpRouters.Explain(entryPointName, routedReq)
+}
+
+// ExplainTCP explains how the connection, received by the given entry point, is routed.
+func (e *routingExplainer) ExplainTCP(entryPointName string, conn runtime.TCPConnInfo) (*runtime.RoutingExplanation, error) {
+	_, localAddr, err := e.entryPoint(entryPointName)
+	if err != nil {
+		return nil, err
+	}
+
+	explanation := &runtime.RoutingExplanation{Candidates: []runtime.RouteCandidate{}}
+
+	tcpRouter, ok := e.tcpRouters[entryPointName]
+	if !ok {
+		return explanation, nil
+	}
+
+	connData := tcpmuxer.FakeConnData(types.CanonicalDomain(conn.ServerName), conn.ClientIP, conn.ALPN, localAddr.IP.String(), localAddr.Port)
+
+	routes, routerName := tcpRouter.Explain(connData, conn.TLS)
+	for _, route := range routes {
+		candidate := runtime.RouteCandidate{
+			Name:           route.Name,
+			Priority:       route.Priority,
+			Matched:        route.Matched,
+			FailedMatchers: route.FailedMatchers,
+		}
+
+		if rt, ok := e.conf.TCPRouters[route.Name]; ok {
+			candidate.Rule = rt.Rule
+		}
+
+		explanation.Candidates = append(explanation.Candidates, candidate)
+	}
+
+	if rt, ok := e.conf.TCPRouters[routerName]; ok {
+		ctx := provider.AddInContext(context.Background(), routerName)
+
+		explanation.Router = routerName
+		for _, middleware := range rt.Middlewares {
+			explanation.Middlewares = append(explanation.Middlewares, provider.GetQualifiedName(ctx, middleware))
+		}
+		explanation.Service = provider.GetQualifiedName(ctx, rt.Service)
+	}
+
+	return explanation, nil
+}
+
+// entryPoint returns the configuration of the entry point, and its local address used by the Port matchers.
+func (e *routingExplainer) entryPoint(name string) (*static.EntryPoint, *net.TCPAddr, error) {
+	if name == "" {
+		return nil, nil, errors.New("entryPoint is required")
+	}
+
+	epConfig, ok := e.staticEntryPoints[name]
+	if !ok {
+		info, ok := e.conf.EntryPoints[name]
+		if !ok || info.Status == runtime.StatusDisabled {
+			return nil, nil, fmt.Errorf("entryPoint %q does not exist", name)
+		}
+
+		epConfig = static.NewDynamicEntryPoint(info.EntryPoint)
+	}
+
+	addr := &net.TCPAddr{IP: net.IPv4zero}
+
+	// The address is only used to provide the port to the matchers.
+	if host, port, err := net.SplitHostPort(epConfig.GetAddress()); err == nil {
+		if ip := net.ParseIP(host); ip != nil {
+			addr.IP = ip
+		}
+
+		addr.Port, _ = strconv.Atoi(port)
+	}
+
+	return epConfig, addr, nil
+}
+
+// statusResponseWriter discards the response, and only keeps its status code.
+type statusResponseWriter struct {
+	header http.Header
+	code   int
+}
+
+func (s *statusResponseWriter) Header() http.Header {
+	return s.header
+}
+
+func (s *statusResponseWriter) Write(b []byte) (int, error) {
+	if s.code == 0 {
+		s.code = http.StatusOK
+	}
+
+	return len(b), nil
+}
+
+func (s *statusResponseWriter) WriteHeader(code int) {
+	if s.code == 0 {
+		s.code = code
+	}
+}
@@ -0,0 +1,599 @@
+package server
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/traefik/traefik/v3/pkg/api"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+	"github.com/traefik/traefik/v3/pkg/config/runtime"
+	"github.com/traefik/traefik/v3/pkg/config/static"
+	"github.com/traefik/traefik/v3/pkg/server/service"
+	"github.com/traefik/traefik/v3/pkg/tcp"
+	traefiktls "github.com/traefik/traefik/v3/pkg/tls"
+)
+
+func TestRoutingExplainer_ExplainHTTP(t *testing.T) {
+	conf := dynamic.Configuration{
+		HTTP: &dynamic.HTTPConfiguration{
+			Routers: map[string]*dynamic.Router{
+				"api@file": {
+					EntryPoints: []string{"web"},
+					Service:     "svc@file",
+					Rule:        "Host(`example.com`) && PathPrefix(`/api`)",
+					Middlewares: []string{"auth"},
+				},
+				"host@docker": {
+					EntryPoints: []string{"web"},
+					Service:     "svc@file",
+					Rule:        "Host(`example.com`)",
+				},
+				"beta@file": {
+					EntryPoints: []string{"web"},
+					Service:     "svc@file",
+					Rule:        "Host(`example.com`) && Cookie(`group`, `beta`)",
+					Priority:    1000,
+				},
+				"secure@file": {
+					EntryPoints: []string{"web"},
+					Service:     "svc@file",
+					Rule:        "Host(`example.com`)",
+					TLS:         &dynamic.RouterTLSConfig{},
+				},
+				"parent@file": {
+					EntryPoints: []string{"web"},
+					Rule:        "Host(`parent.com`)",
+					Middlewares: []string{"auth"},
+				},
+				"child@file": {
+					Service:     "svc",
+					Rule:        "Path(`/child`)",
+					Middlewares: []string{"compress"},
+					ParentRefs:  []string{"parent@file"},
+				},
+				"other@file": {
+					EntryPoints: []string{"other"},
+					Service:     "svc@file",
+					Rule:        "Host(`example.com`)",
+				},
+			},
+			Middlewares: map[string]*dynamic.Middleware{
+				"auth@file": {
+					Headers: &dynamic.Headers{CustomRequestHeaders: map[string]string{"X-Auth": "foo"}},
+				},
+				"compress@file": {
+					Compress: &dynamic.Compress{Encodings: []string{"gzip"}},
+				},
+			},
+			Services: map[string]*dynamic.Service{
+				"svc@file": {
+					LoadBalancer: &dynamic.ServersLoadBalancer{
+						Servers: []dynamic.Server{{URL: "http://127.0.0.1:8080"}},
+					},
+				},
+			},
+		},
+	}
+
+	testCases := []struct {
+		desc        string
+		entryPoint  string
+		target      string
+		host        string
+		cookie      string
+		tls         bool
+		expectedErr bool
+		expected    *runtime.RoutingExplanation
+	}{
+		{
+			desc:        "missing entry point",
+			target:      "/",
+			host:        "example.com",
+			expectedErr: true,
+		},
+		{
+			desc:        "unknown entry point",
+			entryPoint:  "foo",
+			target:      "/",
+			host:        "example.com",
+			expectedErr: true,
+		},
+		{
+			desc:        "request rejected by the entry point",
+			entryPoint:  "web",
+			target:      "/api/foo#bar",
+			host:        "example.com",
+			expectedErr: true,
+		},
+		{
+			desc:       "matching the longest rule",
+			entryPoint: "web",
+			target:     "/api/users",
+			host:       "example.com",
+			expected: &runtime.RoutingExplanation{
+				Router:      "api@file",
+				Middlewares: []string{"auth@file"},
+				Service:     "svc@file",
+				Candidates: []runtime.RouteCandidate{
+					{
+						Name:           "beta@file",
+						Rule:           "Host(`example.com`) && Cookie(`group`, `beta`)",
+						Priority:       1000,
+						FailedMatchers: []string{"Cookie(`group`, `beta`)"},
+					},
+					{
+						Name:     "api@file",
+						Rule:     "Host(`example.com`) && PathPrefix(`/api`)",
+						Priority: 41,
+						Matched:  true,
+					},
+					{
+						Name:     "host@docker",
+						Rule:     "Host(`example.com`)",
+						Priority: 19,
+						Matched:  true,
+					},
+					{
+						Name:           "parent@file",
+						Rule:           "Host(`parent.com`)",
+						Priority:       18,
+						FailedMatchers: []string{"Host(`parent.com`)"},
+					},
+				},
+			},
+		},
+		{
+			desc:       "matching the sanitized path",
+			entryPoint: "web",
+			target:     "/foo/../api",
+			host:       "example.com",
+			expected: &runtime.RoutingExplanation{
+				Router:      "api@file",
+				Middlewares: []string{"auth@file"},
+				Service:     "svc@file",
+				Candidates: []runtime.RouteCandidate{
+					{
+						Name:           "beta@file",
+						Rule:           "Host(`example.com`) && Cookie(`group`, `beta`)",
+						Priority:       1000,
+						FailedMatchers: []string{"Cookie(`group`, `beta`)"},
+					},
+					{
+						Name:     "api@file",
+						Rule:     "Host(`example.com`) && PathPrefix(`/api`)",
+						Priority: 41,
+						Matched:  true,
+					},
+					{
+						Name:     "host@docker",
+						Rule:     "Host(`example.com`)",
+						Priority: 19,
+						Matched:  true,
+					},
+					{
+						Name:           "parent@file",
+						Rule:           "Host(`parent.com`)",
+						Priority:       18,
+						FailedMatchers: []string{"Host(`parent.com`)"},
+					},
+				},
+			},
+		},
+		{
+			desc:       "matching the highest priority",
+			entryPoint: "web",
+			target:     "/",
+			host:       "example.com",
+			cookie:     "group=beta",
+			expected: &runtime.RoutingExplanation{
+				Router:  "beta@file",
+				Service: "svc@file",
+				Candidates: []runtime.RouteCandidate{
+					{
+						Name:     "beta@file",
+						Rule:     "Host(`example.com`) && Cookie(`group`, `beta`)",
+						Priority: 1000,
+						Matched:  true,
+					},
+					{
+						Name:           "api@file",
+						Rule:           "Host(`example.com`) && PathPrefix(`/api`)",
+						Priority:       41,
+						FailedMatchers: []string{"PathPrefix(`/api`)"},
+					},
+					{
+						Name:     "host@docker",
+						Rule:     "Host(`example.com`)",
+						Priority: 19,
+						Matched:  true,
+					},
+					{
+						Name:           "parent@file",
+						Rule:           "Host(`parent.com`)",
+						Priority:       18,
+						FailedMatchers: []string{"Host(`parent.com`)"},
+					},
+				},
+			},
+		},
+		{
+			desc:       "matching a child router",
+			entryPoint: "web",
+			target:     "/child",
+			host:       "parent.com",
+			expected: &runtime.RoutingExplanation{
+				Router:      "child@file",
+				Middlewares: []string{"auth@file", "compress@file"},
+				Service:     "svc@file",
+				Candidates: []runtime.RouteCandidate{
+					{
+						Name:           "beta@file",
+						Rule:           "Host(`example.com`) && Cookie(`group`, `beta`)",
+						Priority:       1000,
+						FailedMatchers: []string{"Host(`example.com`)", "Cookie(`group`, `beta`)"},
+					},
+					{
+						Name:           "api@file",
+						Rule:           "Host(`example.com`) && PathPrefix(`/api`)",
+						Priority:       41,
+						FailedMatchers: []string{"Host(`example.com`)", "PathPrefix(`/api`)"},
+					},
+					{
+						Name:           "host@docker",
+						Rule:           "Host(`example.com`)",
+						Priority:       19,
+						FailedMatchers: []string{"Host(`example.com`)"},
+					},
+					{
+						Name:     "parent@file",
+						Rule:     "Host(`parent.com`)",
+						Priority: 18,
+						Matched:  true,
+					},
+					{
+						Name:     "child@file",
+						Parent:   "parent@file",
+						Rule:     "Path(`/child`)",
+						Priority: 14,
+						Matched:  true,
+					},
+				},
+			},
+		},
+		{
+			desc:       "not matching a child router",
+			entryPoint: "web",
+			target:     "/foo",
+			host:       "parent.com",
+			expected: &runtime.RoutingExplanation{
+				Candidates: []runtime.RouteCandidate{
+					{
+						Name:           "beta@file",
+						Rule:           "Host(`example.com`) && Cookie(`group`, `beta`)",
+						Priority:       1000,
+						FailedMatchers: []string{"Host(`example.com`)", "Cookie(`group`, `beta`)"},
+					},
+					{
+						Name:           "api@file",
+						Rule:           "Host(`example.com`) && PathPrefix(`/api`)",
+						Priority:       41,
+						FailedMatchers: []string{"Host(`example.com`)", "PathPrefix(`/api`)"},
+					},
+					{
+						Name:           "host@docker",
+						Rule:           "Host(`example.com`)",
+						Priority:       19,
+						FailedMatchers: []string{"Host(`example.com`)"},
+					},
+					{
+						Name:     "parent@file",
+						Rule:     "Host(`parent.com`)",
+						Priority: 18,
+						Matched:  true,
+					},
+					{
+						Name:           "child@file",
+						Parent:         "parent@file",
+						Rule:           "Path(`/child`)",
+						Priority:       14,
+						FailedMatchers: []string{"Path(`/child`)"},
+					},
+				},
+			},
+		},
+		{
+			desc:       "TLS request",
+			entryPoint: "web",
+			target:     "/",
+			host:       "example.com",
+			tls:        true,
+			expected: &runtime.RoutingExplanation{
+				Router:  "secure@file",
+				Service: "svc@file",
+				Candidates: []runtime.RouteCandidate{
+					{
+						Name:     "secure@file",
+						Rule:     "Host(`example.com`)",
+						Priority: 19,
+						Matched:  true,
+					},
+				},
+			},
+		},
+	}
+
+	explainer := createRoutingExplainer(t, conf)
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			req := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+			req.URL.Path = test.target
+			req.URL.RawPath = test.target
+			req.RequestURI = test.target
+			req.Host = test.host
+			if test.cookie != "" {
+				req.Header.Set("Cookie", test.cookie)
+			}
+			if test.tls {
+				req.TLS = &tls.ConnectionState{ServerName: test.host}
+			}
+
+			explanation, err := explainer.ExplainHTTP(test.entryPoint, req)
+			if test.expectedErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, explanation)
+		})
+	}
+}
+
+func TestRoutingExplainer_ExplainHTTP_entryPointMiddlewares(t *testing.T) {
+	conf := applyModel(dynamic.Configuration{
+		HTTP: &dynamic.HTTPConfiguration{
+			Routers: map[string]*dynamic.Router{
+				"api@file": {
+					EntryPoints: []string{"web"},
+					Service:     "svc@file",
+					Rule:        "PathPrefix(`/api`)",
+					Middlewares: []string{"compress"},
+				},
+			},
+			Models: map[string]*dynamic.Model{
+				"web@internal": {
+					Middlewares: []string{"auth@file"},
+				},
+			},
+			Middlewares: map[string]*dynamic.Middleware{
+				"auth@file": {
+					Headers: &dynamic.Headers{CustomRequestHeaders: map[string]string{"X-Auth": "foo"}},
+				},
+				"compress@file": {
+					Compress: &dynamic.Compress{Encodings: []string{"gzip"}},
+				},
+			},
+			Services: map[string]*dynamic.Service{
+				"svc@file": {
+					LoadBalancer: &dynamic.ServersLoadBalancer{
+						Servers: []dynamic.Server{{URL: "http://127.0.0.1:8080"}},
+					},
+				},
+			},
+		},
+	})
+
+	explainer := createRoutingExplainer(t, conf)
+
+	explanation, err := explainer.ExplainHTTP("web", httptest.NewRequest(http.MethodGet, "/api", http.NoBody))
+	require.NoError(t, err)
+
+	assert.Equal(t, "api@file", explanation.Router)
+	assert.Equal(t, []string{"auth@file", "compress@file"}, explanation.Middlewares)
+	assert.Equal(t, "svc@file", explanation.Service)
+}
+
+func TestRoutingExplainer_ExplainTCP(t *testing.T) {
+	conf := dynamic.Configuration{
+		HTTP: &dynamic.HTTPConfiguration{
+			Routers: map[string]*dynamic.Router{
+				"https@file": {
+					EntryPoints: []string{"websecure"},
+					Service:     "svc@file",
+					Rule:        "Host(`web.com`)",
+					TLS:         &dynamic.RouterTLSConfig{},
+				},
+			},
+			Services: map[string]*dynamic.Service{
+				"svc@file": {
+					LoadBalancer: &dynamic.ServersLoadBalancer{
+						Servers: []dynamic.Server{{URL: "http://127.0.0.1:8080"}},
+					},
+				},
+			},
+		},
+		TCP: &dynamic.TCPConfiguration{
+			Routers: map[string]*dynamic.TCPRouter{
+				"sni@file": {
+					EntryPoints: []string{"websecure"},
+					Service:     "svc",
+					Rule:        "HostSNI(`example.com`) && ALPN(`h2`)",
+					Middlewares: []string{"allowlist"},
+					TLS:         &dynamic.RouterTCPTLSConfig{},
+				},
+				"client@file": {
+					EntryPoints: []string{"websecure"},
+					Service:     "svc",
+					Rule:        "ClientIP(`10.0.0.0/8`)",
+					TLS:         &dynamic.RouterTCPTLSConfig{},
+				},
+				"catchall@file": {
+					EntryPoints: []string{"websecure"},
+					Service:     "svc",
+					Rule:        "HostSNI(`*`)",
+				},
+			},
+			Middlewares: map[string]*dynamic.TCPMiddleware{
+				"allowlist@file": {
+					IPAllowList: &dynamic.TCPIPAllowList{SourceRange: []string{"0.0.0.0/0"}},
+				},
+			},
+			Services: map[string]*dynamic.TCPService{
+				"svc@file": {
+					LoadBalancer: &dynamic.TCPServersLoadBalancer{
+						Servers: []dynamic.TCPServer{{Address: "127.0.0.1:8080"}},
+					},
+				},
+			},
+		},
+	}
+
+	testCases := []struct {
+		desc        string
+		entryPoint  string
+		conn        runtime.TCPConnInfo
+		expectedErr bool
+		expected    *runtime.RoutingExplanation
+	}{
+		{
+			desc:        "unknown entry point",
+			entryPoint:  "foo",
+			expectedErr: true,
+		},
+		{
+			desc:       "matching SNI and ALPN",
+			entryPoint: "websecure",
+			conn: runtime.TCPConnInfo{
+				ServerName: "EXAMPLE.com",
+				ALPN:       []string{"h2"},
+				ClientIP:   "192.168.1.1",
+				TLS:        true,
+			},
+			expected: &runtime.RoutingExplanation{
+				Router:      "sni@file",
+				Middlewares: []string{"allowlist@file"},
+				Service:     "svc@file",
+				Candidates: []runtime.RouteCandidate{
+					{
+						Name:     "sni@file",
+						Rule:     "HostSNI(`example.com`) && ALPN(`h2`)",
+						Priority: 36,
+						Matched:  true,
+					},
+					{
+						Name:           "client@file",
+						Rule:           "ClientIP(`10.0.0.0/8`)",
+						Priority:       22,
+						FailedMatchers: []string{"ClientIP(`10.0.0.0/8`)"},
+					},
+				},
+			},
+		},
+		{
+			desc:       "HTTPS router taking precedence",
+			entryPoint: "websecure",
+			conn: runtime.TCPConnInfo{
+				ServerName: "web.com",
+				ClientIP:   "10.0.0.1",
+				TLS:        true,
+			},
+			expected: &runtime.RoutingExplanation{
+				Candidates: []runtime.RouteCandidate{
+					{
+						Name:           "sni@file",
+						Rule:           "HostSNI(`example.com`) && ALPN(`h2`)",
+						Priority:       36,
+						FailedMatchers: []string{"HostSNI(`example.com`)", "ALPN(`h2`)"},
+					},
+					{
+						Name:     "client@file",
+						Rule:     "ClientIP(`10.0.0.0/8`)",
+						Priority: 22,
+						Matched:  true,
+					},
+				},
+			},
+		},
+		{
+			desc:       "non-TLS connection",
+			entryPoint: "websecure",
+			conn:       runtime.TCPConnInfo{ClientIP: "10.0.0.1"},
+			expected: &runtime.RoutingExplanation{
+				Router:  "catchall@file",
+				Service: "svc@file",
+				Candidates: []runtime.RouteCandidate{
+					{
+						Name:     "catchall@file",
+						Rule:     "HostSNI(`*`)",
+						Priority: -1,
+						Matched:  true,
+					},
+				},
+			},
+		},
+	}
+
+	explainer := createRoutingExplainer(t, conf)
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			explanation, err := explainer.ExplainTCP(test.entryPoint, test.conn)
+			if test.expectedErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, explanation)
+		})
+	}
+}
+
+func createRoutingExplainer(t *testing.T, conf dynamic.Configuration) runtime.RoutingExplainer {
+	t.Helper()
+
+	entryPoints := map[string]*static.EntryPoint{}
+	for name, address := range map[string]string{"web": ":80", "websecure": ":443", "other": ":8080"} {
+		ep := &static.EntryPoint{Address: address}
+		ep.SetDefaults()
+		entryPoints[name] = ep
+	}
+
+	staticConfig := static.Configuration{EntryPoints: entryPoints}
+
+	transportManager := service.NewTransportManager(nil)
+	transportManager.Update(map[string]*dynamic.ServersTransport{"default@internal": {}})
+
+	managerFactory := service.NewManagerFactory(staticConfig, nil, nil, transportManager, proxyBuilderMock{}, nil, api.SupportDumpSources{})
+
+	dialerManager := tcp.NewDialerManager(nil)
+	dialerManager.Update(map[string]*dynamic.TCPServersTransport{"default@internal": {}})
+
+	tlsManager := traefiktls.NewManager(nil)
+	tlsManager.UpdateConfigs(t.Context(), nil, map[string]traefiktls.Options{"default": {}}, nil)
+
+	factory, err := NewRouterFactory(staticConfig, managerFactory, tlsManager, nil, nil, dialerManager, nil)
+	require.NoError(t, err)
+
+	rtConf := runtime.NewConfig(conf)
+	routers, _ := factory.CreateRouters(rtConf)
+
+	// The HTTPS routes are added when the entry points switch to the new routers.
+	for _, router := range routers {
+		router.SetHTTPForwarder(tcp.HandlerFunc(func(tcp.WriteCloser) {}))
+		router.SetHTTPSForwarder(tcp.HandlerFunc(func(tcp.WriteCloser) {}))
+	}
+
+	require.NotNil(t, rtConf.Routing)
+
+	return rtConf.Routing
+}
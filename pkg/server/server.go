@@ -5,9 +5,12 @@ import (
 	"errors"
 	"os"
 	"os/signal"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/rs/zerolog/log"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
 	"github.com/traefik/traefik/v3/pkg/observability/metrics"
 	"github.com/traefik/traefik/v3/pkg/safe"
 	"github.com/traefik/traefik/v3/pkg/server/middleware"
@@ -18,19 +21,27 @@ type Server struct {
 	watcher          *ConfigurationWatcher
 	tcpEntryPoints   TCPEntryPoints
 	udpEntryPoints   UDPEntryPoints
+	dynEntryPoints   *DynamicEntryPoints
 	observabilityMgr *middleware.ObservabilityMgr
 
 	signals  chan os.Signal
 	stopChan chan bool
+	// stopServer stops the server gracefully, as when the context given to Start is done.
+	stopServer context.CancelFunc
+
+	// handingOver reports whether the listeners are being handed over to a new process.
+	handingOver atomic.Bool
+	readyOnce   sync.Once
 
 	routinesPool *safe.Pool
 }
 
 // NewServer returns an initialized Server.
-func NewServer(routinesPool *safe.Pool, entryPoints TCPEntryPoints, entryPointsUDP UDPEntryPoints, watcher *ConfigurationWatcher, observabilityMgr *middleware.ObservabilityMgr) *Server {
+func NewServer(routinesPool *safe.Pool, entryPoints TCPEntryPoints, entryPointsUDP UDPEntryPoints, dynEntryPoints *DynamicEntryPoints, watcher *ConfigurationWatcher, observabilityMgr *middleware.ObservabilityMgr) *Server {
 	srv := &Server{
 		watcher:          watcher,
 		tcpEntryPoints:   entryPoints,
+		dynEntryPoints:   dynEntryPoints,
 		observabilityMgr: observabilityMgr,
 		signals:          make(chan os.Signal, 1),
 		stopChan:         make(chan bool, 1),
@@ -40,11 +51,19 @@ func NewServer(routinesPool *safe.Pool, entryPoints TCPEntryPoints, entryPointsU
 
 	srv.configureSignals()
 
+	// The listeners are served once the first configuration is applied,
+	// so the previous process which handed them over, if any, can stop.
+	watcher.AddListener(func(_ dynamic.Configuration) {
+		srv.readyOnce.Do(notifyHandoverReady)
+	})
+
 	return srv
 }
 
 // Start starts the server and Stop/Close it when context is Done.
 func (s *Server) Start(ctx context.Context) {
+	ctx, s.stopServer = context.WithCancel(ctx)
+
 	go func() {
 		<-ctx.Done()
 		logger := log.Ctx(ctx)
@@ -69,7 +88,11 @@ func (s *Server) Wait() {
 func (s *Server) Stop() {
 	defer log.Info().Msg("Server stopped")
 
-	s.tcpEntryPoints.Stop()
+	var wg sync.WaitGroup
+	wg.Go(s.tcpEntryPoints.Stop)
+	wg.Go(s.dynEntryPoints.Stop)
+	wg.Wait()
+
 	s.udpEntryPoints.Stop()
 
 	s.stopChan <- true
@@ -0,0 +1,182 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"sync"
+
+	"github.com/rs/zerolog/log"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+	"github.com/traefik/traefik/v3/pkg/config/runtime"
+	"github.com/traefik/traefik/v3/pkg/config/static"
+	"github.com/traefik/traefik/v3/pkg/observability/logs"
+	"github.com/traefik/traefik/v3/pkg/observability/metrics"
+	tcprouter "github.com/traefik/traefik/v3/pkg/server/router/tcp"
+	"github.com/traefik/traefik/v3/pkg/types"
+)
+
+// DynamicEntryPoints manages the entry points defined in the dynamic configuration,
+// whose listeners are opened and closed while the server is running.
+type DynamicEntryPoints struct {
+	staticEntryPoints  static.EntryPoints
+	hostResolverConfig *types.HostResolverConfig
+	metricsRegistry    metrics.Registry
+
+	lock        sync.Mutex
+	entryPoints map[string]*dynamicEntryPoint
+	stopped     bool
+	// shutdowns tracks the entry points which are being shut down.
+	shutdowns sync.WaitGroup
+}
+
+type dynamicEntryPoint struct {
+	*TCPEntryPoint
+
+	config *dynamic.EntryPoint
+}
+
+// NewDynamicEntryPoints creates a new DynamicEntryPoints.
+// The entry points of the static configuration cannot be overridden by the dynamic ones.
+func NewDynamicEntryPoints(staticEntryPoints static.EntryPoints, hostResolverConfig *types.HostResolverConfig, metricsRegistry metrics.Registry) *DynamicEntryPoints {
+	return &DynamicEntryPoints{
+		staticEntryPoints:  staticEntryPoints,
+		hostResolverConfig: hostResolverConfig,
+		metricsRegistry:    metricsRegistry,
+		entryPoints:        make(map[string]*dynamicEntryPoint),
+	}
+}
+
+// Update opens the listeners of the entry points added to the given configuration,
+// and gracefully closes the ones of the entry points removed from it, or whose configuration changed.
+// The entry points which cannot be opened are reported as disabled in the given configuration.
+func (d *DynamicEntryPoints) Update(rtConf *runtime.Configuration) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	if d.stopped {
+		return
+	}
+
+	// The entry points are closed first, so that their addresses can be reused by the new ones.
+	for name, ep := range d.entryPoints {
+		if info, ok := rtConf.EntryPoints[name]; ok && reflect.DeepEqual(info.EntryPoint, ep.config) {
+			continue
+		}
+
+		d.shutdown(name, ep)
+		delete(d.entryPoints, name)
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(rtConf.EntryPoints)) {
+		info := rtConf.EntryPoints[name]
+
+		if _, ok := d.entryPoints[name]; ok {
+			continue
+		}
+
+		ctx := log.With().Str(logs.EntryPointName, name).Logger().WithContext(context.Background())
+
+		ep, err := d.open(ctx, name, info.EntryPoint)
+		if err != nil {
+			log.Ctx(ctx).Error().Err(err).Msg("Unable to open dynamic entry point")
+			info.AddError(err)
+			continue
+		}
+
+		log.Ctx(ctx).Info().Str("address", info.Address).Msg("Dynamic entry point opened")
+
+		d.entryPoints[name] = &dynamicEntryPoint{TCPEntryPoint: ep, config: info.EntryPoint}
+
+		go ep.Start(ctx)
+	}
+}
+
+// Switch switches the TCP routers of the dynamic entry points.
+func (d *DynamicEntryPoints) Switch(routersTCP map[string]*tcprouter.Router) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	for name, ep := range d.entryPoints {
+		if rt, ok := routersTCP[name]; ok {
+			ep.SwitchRouter(rt)
+		}
+	}
+}
+
+// Stop gracefully stops all the dynamic entry points, and waits for the ones being shut down.
+func (d *DynamicEntryPoints) Stop() {
+	d.lock.Lock()
+
+	d.stopped = true
+
+	for name, ep := range d.entryPoints {
+		d.shutdown(name, ep)
+	}
+
+	d.entryPoints = make(map[string]*dynamicEntryPoint)
+
+	d.lock.Unlock()
+
+	d.shutdowns.Wait()
+}
+
+// tcpEntryPoints returns the running dynamic entry points.
+func (d *DynamicEntryPoints) tcpEntryPoints() TCPEntryPoints {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	eps := make(TCPEntryPoints, len(d.entryPoints))
+	for name, ep := range d.entryPoints {
+		eps[name] = ep.TCPEntryPoint
+	}
+
+	return eps
+}
+
+func (d *DynamicEntryPoints) open(ctx context.Context, name string, config *dynamic.EntryPoint) (*TCPEntryPoint, error) {
+	if _, ok := d.staticEntryPoints[name]; ok {
+		return nil, errors.New("entry point already defined in the static configuration")
+	}
+
+	if config.Address == "" {
+		return nil, errors.New("empty address")
+	}
+
+	epConfig := static.NewDynamicEntryPoint(config)
+
+	protocol, err := epConfig.GetProtocol()
+	if err != nil {
+		return nil, err
+	}
+
+	if protocol != "tcp" {
+		return nil, fmt.Errorf("unsupported protocol %s: only TCP entry points can be defined in the dynamic configuration", protocol)
+	}
+
+	openConnectionsGauge := d.metricsRegistry.
+		OpenConnectionsGauge().
+		With("entrypoint", name, "protocol", "TCP")
+
+	return NewTCPEntryPoint(ctx, name, epConfig, d.hostResolverConfig, openConnectionsGauge)
+}
+
+// shutdown closes the listener of the given entry point right away,
+// and gives the active connections the configured grace timeout to finish in the background.
+func (d *DynamicEntryPoints) shutdown(name string, ep *dynamicEntryPoint) {
+	logger := log.With().Str(logs.EntryPointName, name).Logger()
+
+	ep.inShutdown.Store(true)
+	if err := ep.listener.Close(); err != nil {
+		logger.Error().Err(err).Msg("Unable to close dynamic entry point listener")
+	}
+
+	d.shutdowns.Go(func() {
+		ep.Shutdown(logger.WithContext(context.Background()))
+
+		logger.Info().Msg("Dynamic entry point closed")
+	})
+}
@@ -0,0 +1,101 @@
+package server
+
+import (
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+	"github.com/traefik/traefik/v3/pkg/config/runtime"
+	"github.com/traefik/traefik/v3/pkg/config/static"
+	"github.com/traefik/traefik/v3/pkg/observability/metrics"
+)
+
+func TestDynamicEntryPoints_Update(t *testing.T) {
+	staticEntryPoints := static.EntryPoints{"web": {Address: ":80"}}
+
+	dynEntryPoints := NewDynamicEntryPoints(staticEntryPoints, nil, metrics.NewVoidRegistry())
+	t.Cleanup(dynEntryPoints.Stop)
+
+	rtConf := runtime.NewConfig(dynamic.Configuration{
+		EntryPoints: map[string]*dynamic.EntryPoint{
+			"tenant": {Address: "127.0.0.1:0"},
+			"web":    {Address: "127.0.0.1:0"},
+			"udp":    {Address: "127.0.0.1:0/udp"},
+			"empty":  {},
+		},
+	})
+
+	dynEntryPoints.Update(rtConf)
+
+	assert.Equal(t, runtime.StatusEnabled, rtConf.EntryPoints["tenant"].Status)
+	assert.Empty(t, rtConf.EntryPoints["tenant"].Err)
+	assert.Equal(t, runtime.StatusDisabled, rtConf.EntryPoints["web"].Status)
+	assert.Equal(t, []string{"entry point already defined in the static configuration"}, rtConf.EntryPoints["web"].Err)
+	assert.Equal(t, runtime.StatusDisabled, rtConf.EntryPoints["udp"].Status)
+	assert.Equal(t, []string{"unsupported protocol udp: only TCP entry points can be defined in the dynamic configuration"}, rtConf.EntryPoints["udp"].Err)
+	assert.Equal(t, runtime.StatusDisabled, rtConf.EntryPoints["empty"].Status)
+	assert.Equal(t, []string{"empty address"}, rtConf.EntryPoints["empty"].Err)
+
+	eps := dynEntryPoints.tcpEntryPoints()
+	require.Len(t, eps, 1)
+	require.Contains(t, eps, "tenant")
+
+	addr := eps["tenant"].listener.Addr().String()
+
+	resp, err := http.Get("http://" + addr)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	// An unchanged configuration keeps the running entry point.
+	dynEntryPoints.Update(runtime.NewConfig(dynamic.Configuration{
+		EntryPoints: map[string]*dynamic.EntryPoint{
+			"tenant": {Address: "127.0.0.1:0"},
+		},
+	}))
+
+	assert.Same(t, eps["tenant"], dynEntryPoints.tcpEntryPoints()["tenant"])
+
+	// A removed entry point stops listening.
+	dynEntryPoints.Update(runtime.NewConfig(dynamic.Configuration{}))
+
+	assert.Empty(t, dynEntryPoints.tcpEntryPoints())
+
+	_, err = net.DialTimeout("tcp", addr, time.Second)
+	require.Error(t, err)
+}
+
+func TestDynamicEntryPoints_UpdateAddressReused(t *testing.T) {
+	dynEntryPoints := NewDynamicEntryPoints(nil, nil, metrics.NewVoidRegistry())
+	t.Cleanup(dynEntryPoints.Stop)
+
+	dynEntryPoints.Update(runtime.NewConfig(dynamic.Configuration{
+		EntryPoints: map[string]*dynamic.EntryPoint{
+			"tenant": {Address: "127.0.0.1:0"},
+		},
+	}))
+
+	addr := dynEntryPoints.tcpEntryPoints()["tenant"].listener.Addr().String()
+
+	// The configuration of the entry point changes, while the new one uses the same address.
+	rtConf := runtime.NewConfig(dynamic.Configuration{
+		EntryPoints: map[string]*dynamic.EntryPoint{
+			"tenant": {
+				Address:       addr,
+				ProxyProtocol: &dynamic.EntryPointProxyProtocol{Insecure: true},
+			},
+		},
+	})
+
+	dynEntryPoints.Update(rtConf)
+
+	assert.Equal(t, runtime.StatusEnabled, rtConf.EntryPoints["tenant"].Status)
+	assert.Empty(t, rtConf.EntryPoints["tenant"].Err)
+
+	require.Contains(t, dynEntryPoints.tcpEntryPoints(), "tenant")
+	assert.Equal(t, addr, dynEntryPoints.tcpEntryPoints()["tenant"].listener.Addr().String())
+}
@@ -167,9 +167,12 @@ func (eps TCPEntryPoints) Stop() {
 }
 
 // Switch the TCP routers.
+// The routers of the entry points which are not part of eps, such as the dynamic ones, are ignored.
 func (eps TCPEntryPoints) Switch(routersTCP map[string]*tcprouter.Router) {
 	for entryPointName, rt := range routersTCP {
-		eps[entryPointName].SwitchRouter(rt)
+		if ep, ok := eps[entryPointName]; ok {
+			ep.SwitchRouter(rt)
+		}
 	}
 }
 
@@ -293,7 +296,12 @@ func (e *TCPEntryPoint) Start(ctx context.Context) {
 				}
 			}
 
-			e.switcher.ServeTCP(tcp.NewNextConn(newTrackedConnection(writeCloser, e.tracker)))
+			nextConn := tcp.NewNextConn(newTrackedConnection(writeCloser, e.tracker))
+			if proxyConn, ok := conn.(*proxyproto.Conn); ok && proxyConn.ProxyHeader() != nil {
+				tcp.ContextVars(nextConn, map[string]string{tcp.ProxyProtocolSource: proxyConn.ProxyHeader().SourceAddr.String()})
+			}
+
+			e.switcher.ServeTCP(nextConn)
 		})
 	}
 }
@@ -404,6 +412,11 @@ func (c *writeCloserWrapper) CloseWrite() error {
 	return c.writeCloser.CloseWrite()
 }
 
+// NetConn returns the wrapped connection.
+func (c *writeCloserWrapper) NetConn() net.Conn {
+	return c.Conn
+}
+
 // writeCloser returns the given connection, augmented with the WriteCloser
 // implementation, if any was found within the underlying conn.
 func writeCloser(conn net.Conn) (tcp.WriteCloser, error) {
@@ -626,20 +639,10 @@ type httpServer struct {
 	Switcher  *middlewares.HTTPHandlerSwitcher
 }
 
-func newHTTPServer(ctx context.Context, ln net.Listener, configuration *static.EntryPoint, withH2c bool, reqDecorator *requestdecorator.RequestDecorator) (*httpServer, error) {
-	if configuration.HTTP2.MaxConcurrentStreams < 0 {
-		return nil, errors.New("max concurrent streams value must be greater than or equal to zero")
-	}
-	if configuration.HTTP2.MaxDecoderHeaderTableSize < 0 {
-		return nil, errors.New("max decoder header table size value must be greater than or equal to zero")
-	}
-	if configuration.HTTP2.MaxEncoderHeaderTableSize < 0 {
-		return nil, errors.New("max encoder header table size value must be greater than or equal to zero")
-	}
-
-	httpSwitcher := middlewares.NewHandlerSwitcher(http.NotFoundHandler())
-
-	next, err := alice.New(middleware.GlobalFilters(ctx), requestdecorator.WrapHandler(reqDecorator)).Then(httpSwitcher)
+// newEntryPointHandler returns the handler processing the requests received by the entry point,
+// before handing them over to the routers handler.
+func newEntryPointHandler(ctx context.Context, configuration *static.EntryPoint, reqDecorator *requestdecorator.RequestDecorator, routersHandler http.Handler) (http.Handler, error) {
+	next, err := alice.New(middleware.GlobalFilters(ctx), requestdecorator.WrapHandler(reqDecorator)).Then(routersHandler)
 	if err != nil {
 		return nil, err
 	}
@@ -660,13 +663,6 @@ func newHTTPServer(ctx context.Context, ln net.Listener, configuration *static.E
 		handler = newKeepAliveMiddleware(handler, configuration.Transport.KeepAliveMaxRequests, configuration.Transport.KeepAliveMaxTime)
 	}
 
-	var protocols http.Protocols
-	protocols.SetHTTP1(true)
-	protocols.SetHTTP2(true)
-
-	// With the addition of UnencryptedHTTP2 in http.Server#Protocols in go1.24 setting the h2c handler is not necessary anymore.
-	protocols.SetUnencryptedHTTP2(withH2c)
-
 	handler = contenttype.DisableAutoDetection(handler)
 
 	if configuration.HTTP.EncodeQuerySemicolons {
@@ -683,7 +679,33 @@ func newHTTPServer(ctx context.Context, ln net.Listener, configuration *static.E
 
 	handler = normalizePath(handler)
 
-	handler = denyFragment(handler)
+	return denyFragment(handler), nil
+}
+
+func newHTTPServer(ctx context.Context, ln net.Listener, configuration *static.EntryPoint, withH2c bool, reqDecorator *requestdecorator.RequestDecorator) (*httpServer, error) {
+	if configuration.HTTP2.MaxConcurrentStreams < 0 {
+		return nil, errors.New("max concurrent streams value must be greater than or equal to zero")
+	}
+	if configuration.HTTP2.MaxDecoderHeaderTableSize < 0 {
+		return nil, errors.New("max decoder header table size value must be greater than or equal to zero")
+	}
+	if configuration.HTTP2.MaxEncoderHeaderTableSize < 0 {
+		return nil, errors.New("max encoder header table size value must be greater than or equal to zero")
+	}
+
+	httpSwitcher := middlewares.NewHandlerSwitcher(http.NotFoundHandler())
+
+	handler, err := newEntryPointHandler(ctx, configuration, reqDecorator, httpSwitcher)
+	if err != nil {
+		return nil, err
+	}
+
+	var protocols http.Protocols
+	protocols.SetHTTP1(true)
+	protocols.SetHTTP2(true)
+
+	// With the addition of UnencryptedHTTP2 in http.Server#Protocols in go1.24 setting the h2c handler is not necessary anymore.
+	protocols.SetUnencryptedHTTP2(withH2c)
 
 	serverHTTP := &http.Server{
 		Protocols: &protocols,
@@ -750,6 +772,7 @@ func newHTTPServer(ctx context.Context, ln net.Listener, configuration *static.E
 		}
 	}
 
+	debugConnection := os.Getenv(debugConnectionEnv) != ""
 	if debugConnection || (configuration.Transport != nil && (configuration.Transport.KeepAliveMaxTime > 0 || configuration.Transport.KeepAliveMaxRequests > 0)) {
 		serverHTTP.ConnContext = func(ctx context.Context, c net.Conn) context.Context {
 			cState := &connState{Start: time.Now()}
@@ -824,6 +847,11 @@ func (t *trackedConnection) Close() error {
 	return t.WriteCloser.Close()
 }
 
+// NetConn returns the tracked connection.
+func (t *trackedConnection) NetConn() net.Conn {
+	return t.WriteCloser
+}
+
 // denyFragment rejects the request if the URL path contains a fragment (hash character).
 // When go receives an HTTP request, it assumes the absence of fragment URL.
 // However, it is still possible to send a fragment in the request.
@@ -11,7 +11,7 @@ import (
 )
 
 func (s *Server) configureSignals() {
-	signal.Notify(s.signals, syscall.SIGUSR1)
+	signal.Notify(s.signals, syscall.SIGUSR1, syscall.SIGUSR2)
 }
 
 func (s *Server) listenSignals(ctx context.Context) {
@@ -20,12 +20,18 @@ func (s *Server) listenSignals(ctx context.Context) {
 		case <-ctx.Done():
 			return
 		case sig := <-s.signals:
-			if sig == syscall.SIGUSR1 {
+			switch sig {
+			case syscall.SIGUSR1:
 				log.Info().Msgf("Closing and re-opening log files for rotation: %+v", sig)
 
 				if err := s.observabilityMgr.RotateAccessLogs(); err != nil {
 					log.Error().Err(err).Msg("Error rotating access log")
 				}
+
+			case syscall.SIGUSR2:
+				log.Info().Msgf("Handing over the listeners to a new process: %+v", sig)
+
+				s.routinesPool.GoCtx(s.handover)
 			}
 		}
 	}
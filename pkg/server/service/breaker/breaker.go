@@ -0,0 +1,309 @@
+package breaker
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	gokitmetrics "github.com/go-kit/kit/metrics"
+	"github.com/rs/zerolog/log"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+	"github.com/traefik/traefik/v3/pkg/config/runtime"
+	"github.com/traefik/traefik/v3/pkg/observability/logs"
+	"github.com/vulcand/oxy/v2/memmetrics"
+)
+
+// States of a server circuit breaker.
+const (
+	// StateClosed is the state of a breaker letting the requests through, while watching the server metrics.
+	StateClosed = "closed"
+	// StateOpen is the state of a breaker whose server is taken out of the load-balancing.
+	StateOpen = "open"
+	// StateHalfOpen is the state of a breaker whose server is back in the load-balancing,
+	// until the trial requests decide whether the breaker closes or opens again.
+	StateHalfOpen = "halfOpen"
+)
+
+// StatusSetter is notified of the status changes of the servers of a service.
+type StatusSetter interface {
+	SetStatus(ctx context.Context, childName string, up bool)
+}
+
+// Reporter is notified of the changes of the servers status and circuit breaker state.
+type Reporter interface {
+	UpdateServerStatus(server, status string)
+	RecordCircuitBreakerState(server, state, err string)
+}
+
+type metricsRegistry interface {
+	ServiceServerUpGauge() gokitmetrics.Gauge
+}
+
+// Breakers holds the circuit breakers attached to the servers of a load-balancer.
+// It takes the servers whose breaker is open out of the load-balancing, through the next StatusSetter.
+type Breakers struct {
+	serviceName      string
+	expression       string
+	condition        condition
+	checkPeriod      time.Duration
+	fallbackDuration time.Duration
+	trialRequests    int
+
+	next     StatusSetter
+	reporter Reporter
+	metrics  metricsRegistry
+
+	mu      sync.Mutex
+	servers map[string]*server
+}
+
+type server struct {
+	targetURL string
+	metrics   *memmetrics.RTMetrics
+
+	state string
+	// up is the status of the server reported by the health checks.
+	up bool
+
+	nextCheck time.Time
+	// trials is the number of trial requests sent while the breaker is half-open,
+	// and successes the number of those which have succeeded.
+	trials    int
+	successes int
+}
+
+// New creates the circuit breakers of the servers of a load-balancer.
+func New(serviceName string, config dynamic.ServerCircuitBreaker, next StatusSetter, reporter Reporter, metrics metricsRegistry) (*Breakers, error) {
+	cond, err := parseExpression(config.Expression)
+	if err != nil {
+		return nil, fmt.Errorf("parsing circuit breaker expression %q: %w", config.Expression, err)
+	}
+
+	return &Breakers{
+		serviceName:      serviceName,
+		expression:       config.Expression,
+		condition:        cond,
+		checkPeriod:      time.Duration(config.CheckPeriod),
+		fallbackDuration: time.Duration(config.FallbackDuration),
+		trialRequests:    max(config.TrialRequests, 1),
+		next:             next,
+		reporter:         reporter,
+		metrics:          metrics,
+		servers:          make(map[string]*server),
+	}, nil
+}
+
+// SetStatus records the status of the server reported by the health checks,
+// and notifies the next StatusSetter, unless the breaker of the server is open.
+func (b *Breakers) SetStatus(ctx context.Context, childName string, up bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	srv, ok := b.servers[childName]
+	if !ok {
+		b.next.SetStatus(ctx, childName, up)
+		return
+	}
+
+	srv.up = up
+	if srv.state == StateOpen {
+		return
+	}
+
+	b.next.SetStatus(ctx, childName, up)
+}
+
+// WrapHandler returns a handler watching the responses of the given server,
+// to open its breaker when the expression is met.
+func (b *Breakers) WrapHandler(ctx context.Context, name, targetURL string, next http.Handler) (http.Handler, error) {
+	rtMetrics, err := memmetrics.NewRTMetrics()
+	if err != nil {
+		return nil, fmt.Errorf("creating metrics: %w", err)
+	}
+
+	srv := &server{
+		targetURL: targetURL,
+		metrics:   rtMetrics,
+		state:     StateClosed,
+		up:        true,
+	}
+
+	b.mu.Lock()
+	b.servers[name] = srv
+	b.mu.Unlock()
+
+	if b.reporter != nil {
+		b.reporter.RecordCircuitBreakerState(targetURL, StateClosed, "")
+	}
+
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		trial := b.startRequest(srv)
+
+		start := time.Now()
+		codeCatcher := &codeCatcher{ResponseWriter: rw}
+
+		next.ServeHTTP(codeCatcher, req)
+
+		b.endRequest(ctx, name, srv, trial, codeCatcher.statusCode, time.Since(start))
+	}), nil
+}
+
+// startRequest tells whether the request is a trial request.
+func (b *Breakers) startRequest(srv *server) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if srv.state != StateHalfOpen || srv.trials >= b.trialRequests {
+		return false
+	}
+
+	srv.trials++
+
+	return true
+}
+
+func (b *Breakers) endRequest(ctx context.Context, name string, srv *server, trial bool, statusCode int, latency time.Duration) {
+	// The status code is not set when the request has been canceled before the response was written.
+	if statusCode == 0 {
+		statusCode = http.StatusBadGateway
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch srv.state {
+	case StateClosed:
+		srv.metrics.Record(statusCode, latency)
+
+		now := time.Now()
+		if now.Before(srv.nextCheck) {
+			return
+		}
+		srv.nextCheck = now.Add(b.checkPeriod)
+
+		if b.condition(srv.metrics) {
+			b.open(ctx, name, srv, "circuit breaker open: "+b.expression)
+		}
+
+	case StateHalfOpen:
+		if !trial {
+			return
+		}
+
+		if statusCode >= http.StatusInternalServerError {
+			b.open(ctx, name, srv, fmt.Sprintf("circuit breaker open: trial request failed with status code %d", statusCode))
+			return
+		}
+
+		srv.successes++
+		if srv.successes < b.trialRequests {
+			return
+		}
+
+		log.Ctx(ctx).Debug().Str(logs.ServiceName, b.serviceName).Str("server", srv.targetURL).
+			Msg("Closing server circuit breaker")
+
+		srv.state = StateClosed
+		srv.metrics.Reset()
+		srv.nextCheck = time.Time{}
+		b.report(srv, "")
+	}
+}
+
+// open takes the server out of the load-balancing for the fallback duration.
+// It must be called with the lock held.
+func (b *Breakers) open(ctx context.Context, name string, srv *server, reason string) {
+	log.Ctx(ctx).Warn().Str(logs.ServiceName, b.serviceName).Str("server", srv.targetURL).
+		Msgf("Opening server circuit breaker for %s: %s", b.fallbackDuration, reason)
+
+	srv.state = StateOpen
+	srv.metrics.Reset()
+	b.report(srv, reason)
+
+	b.next.SetStatus(ctx, name, false)
+	b.metrics.ServiceServerUpGauge().With("service", b.serviceName, "url", srv.targetURL).Set(0)
+	if b.reporter != nil {
+		b.reporter.UpdateServerStatus(srv.targetURL, runtime.StatusDown)
+	}
+
+	go func() {
+		timer := time.NewTimer(b.fallbackDuration)
+		defer timer.Stop()
+
+		select {
+		case <-ctx.Done():
+		case <-timer.C:
+			b.halfOpen(ctx, name, srv)
+		}
+	}()
+}
+
+// halfOpen puts the server back into the load-balancing, if it is healthy, to send the trial requests.
+func (b *Breakers) halfOpen(ctx context.Context, name string, srv *server) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	log.Ctx(ctx).Debug().Str(logs.ServiceName, b.serviceName).Str("server", srv.targetURL).
+		Msg("Half-opening server circuit breaker")
+
+	srv.state = StateHalfOpen
+	srv.trials = 0
+	srv.successes = 0
+	b.report(srv, "")
+
+	if !srv.up {
+		return
+	}
+
+	b.next.SetStatus(ctx, name, true)
+	b.metrics.ServiceServerUpGauge().With("service", b.serviceName, "url", srv.targetURL).Set(1)
+	if b.reporter != nil {
+		b.reporter.UpdateServerStatus(srv.targetURL, runtime.StatusUp)
+	}
+}
+
+func (b *Breakers) report(srv *server, err string) {
+	if b.reporter == nil {
+		return
+	}
+
+	b.reporter.RecordCircuitBreakerState(srv.targetURL, srv.state, err)
+}
+
+type codeCatcher struct {
+	http.ResponseWriter
+
+	statusCode int
+}
+
+func (c *codeCatcher) WriteHeader(statusCode int) {
+	// The last status code written is the one sent to the client.
+	c.statusCode = statusCode
+	c.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (c *codeCatcher) Write(bytes []byte) (int, error) {
+	if c.statusCode < http.StatusOK {
+		c.statusCode = http.StatusOK
+	}
+
+	return c.ResponseWriter.Write(bytes)
+}
+
+func (c *codeCatcher) Flush() {
+	if flusher, ok := c.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (c *codeCatcher) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	if h, ok := c.ResponseWriter.(http.Hijacker); ok {
+		return h.Hijack()
+	}
+
+	return nil, nil, fmt.Errorf("not a hijacker: %T", c.ResponseWriter)
+}
@@ -0,0 +1,228 @@
+package breaker
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ptypes "github.com/traefik/paerser/types"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+	"github.com/traefik/traefik/v3/pkg/config/runtime"
+	"github.com/traefik/traefik/v3/pkg/observability/metrics"
+	"github.com/vulcand/oxy/v2/memmetrics"
+)
+
+func TestParseExpression(t *testing.T) {
+	testCases := []struct {
+		desc       string
+		expression string
+		codes      []int
+		expected   bool
+		expectErr  bool
+	}{
+		{
+			desc:       "network error ratio met",
+			expression: "NetworkErrorRatio() > 0.5",
+			codes:      []int{http.StatusBadGateway, http.StatusBadGateway, http.StatusOK},
+			expected:   true,
+		},
+		{
+			desc:       "network error ratio not met",
+			expression: "NetworkErrorRatio() > 0.5",
+			codes:      []int{http.StatusBadGateway, http.StatusOK, http.StatusOK},
+		},
+		{
+			desc:       "response code ratio",
+			expression: "ResponseCodeRatio(500, 600, 0, 600) >= 0.5",
+			codes:      []int{http.StatusInternalServerError, http.StatusOK},
+			expected:   true,
+		},
+		{
+			desc:       "minimum request count not reached",
+			expression: "RequestCount() >= 10 && NetworkErrorRatio() > 0.5",
+			codes:      []int{http.StatusBadGateway},
+		},
+		{
+			desc:       "minimum request count reached",
+			expression: "RequestCount() >= 2 && NetworkErrorRatio() > 0.5",
+			codes:      []int{http.StatusBadGateway, http.StatusBadGateway},
+			expected:   true,
+		},
+		{
+			desc:       "or",
+			expression: "LatencyAtQuantileMS(50.0) > 10000 || ResponseCodeRatio(500, 600, 0, 600) > 0",
+			codes:      []int{http.StatusServiceUnavailable},
+			expected:   true,
+		},
+		{
+			desc:       "not",
+			expression: "!(RequestCount() > 0)",
+			codes:      []int{http.StatusOK},
+		},
+		{
+			desc:       "unknown function",
+			expression: "Foo() > 0.5",
+			expectErr:  true,
+		},
+		{
+			desc:       "not a condition",
+			expression: "NetworkErrorRatio()",
+			expectErr:  true,
+		},
+		{
+			desc:       "invalid operand",
+			expression: `NetworkErrorRatio() > "foo"`,
+			expectErr:  true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			cond, err := parseExpression(test.expression)
+			if test.expectErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			rtMetrics, err := memmetrics.NewRTMetrics()
+			require.NoError(t, err)
+
+			for _, code := range test.codes {
+				rtMetrics.Record(code, time.Millisecond)
+			}
+
+			assert.Equal(t, test.expected, cond(rtMetrics))
+		})
+	}
+}
+
+func TestBreakers(t *testing.T) {
+	ctx, cancel := context.WithCancel(t.Context())
+	t.Cleanup(cancel)
+
+	balancer := &statusSetterMock{statuses: make(map[string]bool)}
+	info := &runtime.ServiceInfo{}
+
+	breakers, err := New("foo", dynamic.ServerCircuitBreaker{
+		Expression:       "NetworkErrorRatio() > 0.5",
+		FallbackDuration: ptypes.Duration(50 * time.Millisecond),
+		TrialRequests:    2,
+	}, balancer, info, metrics.NewVoidRegistry())
+	require.NoError(t, err)
+
+	var (
+		mu         sync.Mutex
+		statusCode = http.StatusBadGateway
+	)
+	next := http.HandlerFunc(func(rw http.ResponseWriter, _ *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		rw.WriteHeader(statusCode)
+	})
+	setStatusCode := func(code int) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		statusCode = code
+	}
+
+	info.UpdateServerStatus("http://127.0.0.1", runtime.StatusUp)
+	handler, err := breakers.WrapHandler(ctx, "server", "http://127.0.0.1", next)
+	require.NoError(t, err)
+
+	serve := func() int {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", http.NoBody))
+		return rec.Code
+	}
+
+	// The breaker opens on the first failure, and takes the server out of the load-balancing.
+	assert.Equal(t, http.StatusBadGateway, serve())
+	assert.False(t, balancer.status("server"))
+	assert.Equal(t, StateOpen, info.GetAllHealth()["http://127.0.0.1"].CircuitBreaker)
+	assert.Equal(t, runtime.StatusDown, info.GetAllStatus()["http://127.0.0.1"])
+
+	// The health checks do not put the server back into the load-balancing while the breaker is open.
+	breakers.SetStatus(ctx, "server", true)
+	assert.False(t, balancer.status("server"))
+
+	// Once the fallback duration is over, a failed trial request opens the breaker again.
+	assert.Eventually(t, func() bool { return balancer.status("server") }, time.Second, 5*time.Millisecond)
+	assert.Equal(t, StateHalfOpen, info.GetAllHealth()["http://127.0.0.1"].CircuitBreaker)
+
+	serve()
+	assert.False(t, balancer.status("server"))
+	assert.Equal(t, StateOpen, info.GetAllHealth()["http://127.0.0.1"].CircuitBreaker)
+
+	// The breaker closes once all the trial requests have succeeded.
+	setStatusCode(http.StatusOK)
+	assert.Eventually(t, func() bool { return balancer.status("server") }, time.Second, 5*time.Millisecond)
+
+	serve()
+	assert.Equal(t, StateHalfOpen, info.GetAllHealth()["http://127.0.0.1"].CircuitBreaker)
+
+	serve()
+	assert.Equal(t, StateClosed, info.GetAllHealth()["http://127.0.0.1"].CircuitBreaker)
+	assert.True(t, balancer.status("server"))
+	assert.Equal(t, runtime.StatusUp, info.GetAllStatus()["http://127.0.0.1"])
+}
+
+func TestBreakers_unhealthyServer(t *testing.T) {
+	ctx, cancel := context.WithCancel(t.Context())
+	t.Cleanup(cancel)
+
+	balancer := &statusSetterMock{statuses: make(map[string]bool)}
+
+	breakers, err := New("foo", dynamic.ServerCircuitBreaker{
+		Expression:       "NetworkErrorRatio() > 0.5",
+		FallbackDuration: ptypes.Duration(20 * time.Millisecond),
+		TrialRequests:    1,
+	}, balancer, nil, metrics.NewVoidRegistry())
+	require.NoError(t, err)
+
+	handler, err := breakers.WrapHandler(ctx, "server", "http://127.0.0.1", http.HandlerFunc(func(rw http.ResponseWriter, _ *http.Request) {
+		rw.WriteHeader(http.StatusGatewayTimeout)
+	}))
+	require.NoError(t, err)
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", http.NoBody))
+	require.False(t, balancer.status("server"))
+
+	// The server has been marked unhealthy while the breaker is open:
+	// it is not put back into the load-balancing once the breaker is half-open.
+	breakers.SetStatus(ctx, "server", false)
+
+	time.Sleep(100 * time.Millisecond)
+	assert.False(t, balancer.status("server"))
+
+	breakers.SetStatus(ctx, "server", true)
+	assert.True(t, balancer.status("server"))
+}
+
+type statusSetterMock struct {
+	mu       sync.Mutex
+	statuses map[string]bool
+}
+
+func (s *statusSetterMock) SetStatus(_ context.Context, childName string, up bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.statuses[childName] = up
+}
+
+func (s *statusSetterMock) status(childName string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.statuses[childName]
+}
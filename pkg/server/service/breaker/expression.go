@@ -0,0 +1,127 @@
+package breaker
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/vulcand/oxy/v2/memmetrics"
+	"github.com/vulcand/predicate"
+)
+
+// condition tells whether the breaker must open, given the server metrics.
+type condition func(m *memmetrics.RTMetrics) bool
+
+// metric computes a value from the server metrics.
+type metric func(m *memmetrics.RTMetrics) float64
+
+// parseExpression parses the expression opening the breaker.
+// It supports the functions of the CircuitBreaker middleware (NetworkErrorRatio, ResponseCodeRatio, LatencyAtQuantileMS),
+// along with RequestCount, which allows to require a minimum number of requests before the breaker opens.
+func parseExpression(expression string) (condition, error) {
+	parser, err := predicate.NewParser(predicate.Def{
+		Operators: predicate.Operators{
+			AND: andFunc,
+			OR:  orFunc,
+			NOT: notFunc,
+			EQ:  compare("==", func(a, b float64) bool { return a == b }),
+			NEQ: compare("!=", func(a, b float64) bool { return a != b }),
+			LT:  compare("<", func(a, b float64) bool { return a < b }),
+			LE:  compare("<=", func(a, b float64) bool { return a <= b }),
+			GT:  compare(">", func(a, b float64) bool { return a > b }),
+			GE:  compare(">=", func(a, b float64) bool { return a >= b }),
+		},
+		Functions: map[string]any{
+			"NetworkErrorRatio":   networkErrorRatio,
+			"ResponseCodeRatio":   responseCodeRatio,
+			"LatencyAtQuantileMS": latencyAtQuantileMS,
+			"RequestCount":        requestCount,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := parser.Parse(expression)
+	if err != nil {
+		return nil, err
+	}
+
+	cond, ok := out.(condition)
+	if !ok {
+		return nil, fmt.Errorf("expression %q is not a condition", expression)
+	}
+
+	return cond, nil
+}
+
+func networkErrorRatio() metric {
+	return func(m *memmetrics.RTMetrics) float64 {
+		return m.NetworkErrorRatio()
+	}
+}
+
+func responseCodeRatio(startA, endA, startB, endB int) metric {
+	return func(m *memmetrics.RTMetrics) float64 {
+		return m.ResponseCodeRatio(startA, endA, startB, endB)
+	}
+}
+
+func latencyAtQuantileMS(quantile float64) metric {
+	return func(m *memmetrics.RTMetrics) float64 {
+		h, err := m.LatencyHistogram()
+		if err != nil {
+			log.Error().Err(err).Msg("Unable to get latency histogram")
+			return 0
+		}
+
+		return float64(h.LatencyAtQuantile(quantile) / time.Millisecond)
+	}
+}
+
+func requestCount() metric {
+	return func(m *memmetrics.RTMetrics) float64 {
+		return float64(m.TotalCount())
+	}
+}
+
+func compare(operator string, cmp func(a, b float64) bool) func(left, right any) (condition, error) {
+	return func(left, right any) (condition, error) {
+		m, ok := left.(metric)
+		if !ok {
+			return nil, fmt.Errorf("%s: left operand must be a function, got %T", operator, left)
+		}
+
+		var value float64
+		switch v := right.(type) {
+		case int:
+			value = float64(v)
+		case float64:
+			value = v
+		default:
+			return nil, fmt.Errorf("%s: right operand must be a number, got %T", operator, right)
+		}
+
+		return func(metrics *memmetrics.RTMetrics) bool {
+			return cmp(m(metrics), value)
+		}, nil
+	}
+}
+
+func andFunc(left, right condition) condition {
+	return func(m *memmetrics.RTMetrics) bool {
+		return left(m) && right(m)
+	}
+}
+
+func orFunc(left, right condition) condition {
+	return func(m *memmetrics.RTMetrics) bool {
+		return left(m) || right(m)
+	}
+}
+
+func notFunc(c condition) condition {
+	return func(m *memmetrics.RTMetrics) bool {
+		return !c(m)
+	}
+}
@@ -0,0 +1,326 @@
+package drain
+
+import (
+	"io"
+	"net"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/traefik/traefik/v3/pkg/config/runtime"
+	"github.com/traefik/traefik/v3/pkg/observability/logs"
+)
+
+// Reporter is notified of the changes of the draining servers of a service.
+type Reporter interface {
+	UpdateDrainingServer(server string, draining *runtime.DrainingServer)
+}
+
+// Manager tracks the connections to the servers of the load-balancers across configuration reloads.
+// The connections to a server which is removed from the configuration, or marked unhealthy,
+// are drained: they are kept until they end, or closed once the drain timeout of the service is reached.
+type Manager struct {
+	mu       sync.Mutex
+	services map[string]*Service
+}
+
+// NewManager creates a new Manager.
+func NewManager() *Manager {
+	return &Manager{services: make(map[string]*Service)}
+}
+
+// Service returns the connection tracker of the given service, and registers it in the configuration being built.
+// A zero timeout disables the draining of the service connections.
+func (m *Manager) Service(name string, timeout time.Duration, reporter Reporter) *Service {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	svc, ok := m.services[name]
+	if !ok {
+		svc = &Service{
+			name:    name,
+			servers: make(map[string]*server),
+		}
+		m.services[name] = svc
+	}
+
+	svc.mu.Lock()
+	defer svc.mu.Unlock()
+
+	svc.registered = true
+	svc.timeout = timeout
+	svc.reporter = reporter
+
+	return svc
+}
+
+// Commit must be called once a configuration has been built.
+// It drains the connections to the servers which have not been registered since the previous commit.
+func (m *Manager) Commit() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for name, svc := range m.services {
+		if svc.commit() {
+			delete(m.services, name)
+		}
+	}
+}
+
+// Service tracks the connections to the servers of a service.
+type Service struct {
+	name string
+
+	mu         sync.Mutex
+	registered bool
+	timeout    time.Duration
+	reporter   Reporter
+	servers    map[string]*server
+}
+
+type server struct {
+	// name is the name of the server, as registered, used to report its draining.
+	name  string
+	conns map[io.Closer]struct{}
+
+	// registered tells whether the server has been registered since the previous commit.
+	registered bool
+	// removed tells whether the server has been removed from the configuration.
+	removed  bool
+	draining *runtime.DrainingServer
+	timer    *time.Timer
+}
+
+// AddServer registers the server in the configuration being built.
+func (s *Service) AddServer(name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	srv := s.getServer(name)
+	srv.name = name
+	srv.registered = true
+}
+
+// Track tracks the connection to the server until the returned release function is called.
+func (s *Service) Track(name string, conn io.Closer) (release func()) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	srv := s.getServer(name)
+	srv.conns[conn] = struct{}{}
+
+	if srv.draining != nil {
+		srv.draining.Connections = len(srv.conns)
+		s.report(srv)
+	}
+
+	return func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		delete(srv.conns, conn)
+
+		if srv.draining == nil {
+			return
+		}
+
+		if len(srv.conns) > 0 {
+			srv.draining.Connections = len(srv.conns)
+			s.report(srv)
+			return
+		}
+
+		log.Debug().Str(logs.ServiceName, s.name).Str("server", srv.name).Msg("Server drained")
+		s.stopDraining(srv)
+	}
+}
+
+// SetStatus drains the connections to the server when it goes down,
+// and stops draining them when the server goes back up.
+func (s *Service) SetStatus(name string, up bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	srv, ok := s.servers[serverKey(name)]
+	if !ok || srv.removed {
+		return
+	}
+
+	if up {
+		s.stopDraining(srv)
+		return
+	}
+
+	s.startDraining(srv)
+}
+
+// commit drains the servers which have not been registered since the previous commit,
+// and tells whether the service does not track anything anymore.
+func (s *Service) commit() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, srv := range s.servers {
+		switch {
+		case srv.registered && srv.removed:
+			// The server is back in the configuration.
+			srv.removed = false
+			s.stopDraining(srv)
+
+		case !srv.registered && !srv.removed:
+			srv.removed = true
+			s.startDraining(srv)
+
+		case srv.draining != nil:
+			// The reporter may have changed with the new configuration.
+			s.report(srv)
+		}
+
+		srv.registered = false
+	}
+
+	registered := s.registered
+	s.registered = false
+
+	return !registered && len(s.servers) == 0
+}
+
+func (s *Service) getServer(name string) *server {
+	key := serverKey(name)
+
+	srv, ok := s.servers[key]
+	if !ok {
+		srv = &server{name: name, conns: make(map[io.Closer]struct{})}
+		s.servers[key] = srv
+	}
+
+	return srv
+}
+
+func (s *Service) startDraining(srv *server) {
+	if srv.draining != nil {
+		return
+	}
+
+	if s.timeout <= 0 || len(srv.conns) == 0 {
+		s.forget(srv)
+		return
+	}
+
+	log.Debug().Str(logs.ServiceName, s.name).Str("server", srv.name).
+		Int("connections", len(srv.conns)).Msgf("Draining server connections for %s", s.timeout)
+
+	now := time.Now()
+	draining := &runtime.DrainingServer{
+		Since:       now,
+		Deadline:    now.Add(s.timeout),
+		Connections: len(srv.conns),
+	}
+
+	srv.draining = draining
+	srv.timer = time.AfterFunc(s.timeout, func() {
+		s.closeConns(srv, draining)
+	})
+
+	s.report(srv)
+}
+
+func (s *Service) stopDraining(srv *server) {
+	if srv.draining == nil {
+		return
+	}
+
+	srv.timer.Stop()
+	srv.timer = nil
+	srv.draining = nil
+
+	if s.reporter != nil {
+		s.reporter.UpdateDrainingServer(srv.name, nil)
+	}
+
+	s.forget(srv)
+}
+
+// forget stops tracking the server if it has been removed from the configuration.
+func (s *Service) forget(srv *server) {
+	key := serverKey(srv.name)
+	if srv.removed && s.servers[key] == srv {
+		delete(s.servers, key)
+	}
+}
+
+func (s *Service) closeConns(srv *server, draining *runtime.DrainingServer) {
+	s.mu.Lock()
+
+	if srv.draining != draining {
+		// Draining has been stopped in the meantime.
+		s.mu.Unlock()
+		return
+	}
+
+	conns := make([]io.Closer, 0, len(srv.conns))
+	for conn := range srv.conns {
+		conns = append(conns, conn)
+	}
+
+	s.stopDraining(srv)
+
+	s.mu.Unlock()
+
+	log.Debug().Str(logs.ServiceName, s.name).Str("server", srv.name).
+		Int("connections", len(conns)).Msg("Drain timeout reached, closing server connections")
+
+	for _, conn := range conns {
+		closeGracefully(conn)
+	}
+}
+
+func (s *Service) report(srv *server) {
+	if s.reporter == nil {
+		return
+	}
+
+	draining := *srv.draining
+	s.reporter.UpdateDrainingServer(srv.name, &draining)
+}
+
+// serverKey returns the key identifying the given server URL or address,
+// so that the different spellings of the same URL (e.g. with a trailing slash, or the default port) match the same server.
+func serverKey(name string) string {
+	u, err := url.Parse(name)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		// TCP server address.
+		return name
+	}
+
+	scheme := strings.ToLower(u.Scheme)
+	host := strings.ToLower(u.Hostname())
+	port := u.Port()
+
+	switch {
+	case port == "80" && (scheme == "http" || scheme == "h2c" || scheme == "ws"),
+		port == "443" && (scheme == "https" || scheme == "wss"):
+		port = ""
+	}
+
+	if port != "" {
+		host = net.JoinHostPort(host, port)
+	} else if strings.Contains(host, ":") {
+		// IPv6 address.
+		host = "[" + host + "]"
+	}
+
+	return scheme + "://" + host + strings.TrimRight(u.EscapedPath(), "/")
+}
+
+// closeGracefully closes the write side of the connection first, when supported,
+// so that the peer receives an end of stream rather than a reset.
+func closeGracefully(conn io.Closer) {
+	if cw, ok := conn.(interface{ CloseWrite() error }); ok {
+		_ = cw.CloseWrite()
+	}
+
+	_ = conn.Close()
+}
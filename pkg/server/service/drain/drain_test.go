@@ -0,0 +1,290 @@
+package drain
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/traefik/traefik/v3/pkg/config/runtime"
+)
+
+func TestManager_removedServer(t *testing.T) {
+	manager := NewManager()
+	info := &runtime.ServiceInfo{}
+
+	svc := manager.Service("foo", 50*time.Millisecond, info)
+	svc.AddServer("http://127.0.0.1:80")
+	svc.AddServer("http://127.0.0.1:81")
+	manager.Commit()
+
+	conn := &fakeConn{}
+	svc.Track("http://127.0.0.1:80", conn)
+
+	// The new configuration does not contain the first server anymore.
+	info = &runtime.ServiceInfo{}
+	svc = manager.Service("foo", 50*time.Millisecond, info)
+	svc.AddServer("http://127.0.0.1:81")
+	manager.Commit()
+
+	draining := info.GetAllDraining()
+	require.Contains(t, draining, "http://127.0.0.1:80")
+	assert.Equal(t, 1, draining["http://127.0.0.1:80"].Connections)
+	assert.Equal(t, 50*time.Millisecond, draining["http://127.0.0.1:80"].Deadline.Sub(draining["http://127.0.0.1:80"].Since))
+	assert.False(t, conn.isClosed())
+
+	assert.Eventually(t, conn.isClosed, time.Second, 10*time.Millisecond)
+	assert.True(t, conn.isWriteClosed())
+	assert.Empty(t, info.GetAllDraining())
+}
+
+func TestManager_drainedBeforeTimeout(t *testing.T) {
+	manager := NewManager()
+	info := &runtime.TCPServiceInfo{}
+
+	svc := manager.Service("foo", time.Hour, info)
+	svc.AddServer("127.0.0.1:80")
+	manager.Commit()
+
+	conn1 := &fakeConn{}
+	release1 := svc.Track("127.0.0.1:80", conn1)
+	conn2 := &fakeConn{}
+	release2 := svc.Track("127.0.0.1:80", conn2)
+
+	manager.Service("foo", time.Hour, info)
+	manager.Commit()
+
+	require.Contains(t, info.GetAllDraining(), "127.0.0.1:80")
+	assert.Equal(t, 2, info.GetAllDraining()["127.0.0.1:80"].Connections)
+
+	release1()
+	assert.Equal(t, 1, info.GetAllDraining()["127.0.0.1:80"].Connections)
+
+	release2()
+	assert.Empty(t, info.GetAllDraining())
+	assert.False(t, conn1.isClosed())
+	assert.False(t, conn2.isClosed())
+
+	// Once drained, the server and its service are not tracked anymore.
+	manager.Commit()
+	assert.Empty(t, manager.services)
+}
+
+func TestManager_serverAddedBack(t *testing.T) {
+	manager := NewManager()
+	info := &runtime.ServiceInfo{}
+
+	svc := manager.Service("foo", 50*time.Millisecond, info)
+	svc.AddServer("http://127.0.0.1:80")
+	manager.Commit()
+
+	conn := &fakeConn{}
+	svc.Track("http://127.0.0.1:80", conn)
+
+	manager.Service("foo", 50*time.Millisecond, info)
+	manager.Commit()
+	require.Contains(t, info.GetAllDraining(), "http://127.0.0.1:80")
+
+	svc = manager.Service("foo", 50*time.Millisecond, info)
+	svc.AddServer("http://127.0.0.1:80")
+	manager.Commit()
+	assert.Empty(t, info.GetAllDraining())
+
+	time.Sleep(100 * time.Millisecond)
+	assert.False(t, conn.isClosed())
+}
+
+func TestManager_unhealthyServer(t *testing.T) {
+	manager := NewManager()
+	info := &runtime.ServiceInfo{}
+
+	svc := manager.Service("foo", 50*time.Millisecond, info)
+	svc.AddServer("http://127.0.0.1:80")
+	manager.Commit()
+
+	conn := &fakeConn{}
+	svc.Track("http://127.0.0.1:80", conn)
+
+	balancer := &fakeStatusSetter{}
+	statusSetter := svc.WrapStatusSetter(balancer)
+
+	statusSetter.SetStatus(t.Context(), "http://127.0.0.1:80", false)
+	assert.Equal(t, map[string]bool{"http://127.0.0.1:80": false}, balancer.statuses)
+	require.Contains(t, info.GetAllDraining(), "http://127.0.0.1:80")
+
+	statusSetter.SetStatus(t.Context(), "http://127.0.0.1:80", true)
+	assert.Equal(t, map[string]bool{"http://127.0.0.1:80": true}, balancer.statuses)
+	assert.Empty(t, info.GetAllDraining())
+
+	statusSetter.SetStatus(t.Context(), "http://127.0.0.1:80", false)
+	assert.Eventually(t, conn.isClosed, time.Second, 10*time.Millisecond)
+	assert.Empty(t, info.GetAllDraining())
+}
+
+func TestManager_unhealthyServerWithDifferentSpelling(t *testing.T) {
+	manager := NewManager()
+	info := &runtime.ServiceInfo{}
+
+	svc := manager.Service("foo", time.Hour, info)
+	svc.AddServer("http://127.0.0.1:80/")
+	manager.Commit()
+
+	conn := &fakeConn{}
+	svc.Track("http://127.0.0.1:80/", conn)
+
+	// The health checkers may report the status of the server with a different spelling of its URL.
+	statusSetter := svc.WrapStatusSetter(&fakeStatusSetter{})
+	statusSetter.SetStatus(t.Context(), "http://127.0.0.1", false)
+
+	draining := info.GetAllDraining()
+	require.Contains(t, draining, "http://127.0.0.1:80/")
+	assert.Equal(t, 1, draining["http://127.0.0.1:80/"].Connections)
+
+	statusSetter.SetStatus(t.Context(), "HTTP://127.0.0.1:80", true)
+	assert.Empty(t, info.GetAllDraining())
+	assert.False(t, conn.isClosed())
+}
+
+func Test_serverKey(t *testing.T) {
+	testCases := []struct {
+		name     string
+		expected string
+	}{
+		{name: "http://127.0.0.1", expected: "http://127.0.0.1"},
+		{name: "http://127.0.0.1:80/", expected: "http://127.0.0.1"},
+		{name: "HTTP://Example.com:80", expected: "http://example.com"},
+		{name: "https://example.com:443/foo/", expected: "https://example.com/foo"},
+		{name: "https://example.com:80", expected: "https://example.com:80"},
+		{name: "h2c://[::1]:80", expected: "h2c://[::1]"},
+		{name: "http://[::1]:8080", expected: "http://[::1]:8080"},
+		{name: "127.0.0.1:8080", expected: "127.0.0.1:8080"},
+		{name: "localhost:8080", expected: "localhost:8080"},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.expected, serverKey(test.name))
+		})
+	}
+}
+
+func TestManager_noTimeout(t *testing.T) {
+	manager := NewManager()
+	info := &runtime.ServiceInfo{}
+
+	svc := manager.Service("foo", 0, info)
+	svc.AddServer("http://127.0.0.1:80")
+	manager.Commit()
+
+	conn := &fakeConn{}
+	svc.Track("http://127.0.0.1:80", conn)
+
+	manager.Service("foo", 0, info)
+	manager.Commit()
+
+	assert.Empty(t, info.GetAllDraining())
+	assert.False(t, conn.isClosed())
+}
+
+func TestService_WrapHTTP(t *testing.T) {
+	manager := NewManager()
+	info := &runtime.ServiceInfo{}
+
+	svc := manager.Service("foo", time.Hour, info)
+	svc.AddServer("http://127.0.0.1:80")
+	manager.Commit()
+
+	hijacked := make(chan struct{})
+	done := make(chan struct{})
+	next := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		conn, brw, err := http.NewResponseController(rw).Hijack()
+		require.NoError(t, err)
+		defer conn.Close()
+
+		_, _ = brw.WriteString("HTTP/1.1 101 Switching Protocols\r\nConnection: Upgrade\r\nUpgrade: test\r\n\r\n")
+		_ = brw.Flush()
+
+		close(hijacked)
+		<-done
+	})
+
+	server := httptest.NewServer(svc.WrapHTTP("http://127.0.0.1:80", next))
+	t.Cleanup(server.Close)
+
+	conn, err := net.Dial("tcp", server.Listener.Addr().String())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	_, err = conn.Write([]byte("GET / HTTP/1.1\r\nHost: localhost\r\nConnection: Upgrade\r\nUpgrade: test\r\n\r\n"))
+	require.NoError(t, err)
+
+	resp, err := http.ReadResponse(bufio.NewReader(conn), nil)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusSwitchingProtocols, resp.StatusCode)
+
+	<-hijacked
+
+	manager.Service("foo", time.Hour, info)
+	manager.Commit()
+
+	require.Contains(t, info.GetAllDraining(), "http://127.0.0.1:80")
+	assert.Equal(t, 1, info.GetAllDraining()["http://127.0.0.1:80"].Connections)
+
+	close(done)
+	assert.Eventually(t, func() bool { return len(info.GetAllDraining()) == 0 }, time.Second, 10*time.Millisecond)
+}
+
+type fakeConn struct {
+	mu          sync.Mutex
+	closed      bool
+	writeClosed bool
+}
+
+func (c *fakeConn) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.closed = true
+	return nil
+}
+
+func (c *fakeConn) CloseWrite() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.writeClosed = true
+	return nil
+}
+
+func (c *fakeConn) isClosed() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.closed
+}
+
+func (c *fakeConn) isWriteClosed() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.writeClosed
+}
+
+type fakeStatusSetter struct {
+	statuses map[string]bool
+}
+
+func (f *fakeStatusSetter) SetStatus(_ context.Context, childName string, up bool) {
+	if f.statuses == nil {
+		f.statuses = make(map[string]bool)
+	}
+	f.statuses[childName] = up
+}
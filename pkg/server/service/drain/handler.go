@@ -0,0 +1,92 @@
+package drain
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"net/http"
+
+	"github.com/traefik/traefik/v3/pkg/tcp"
+	"golang.org/x/net/http/httpguts"
+)
+
+// StatusSetter is notified of the status changes of the servers of a service.
+type StatusSetter interface {
+	SetStatus(ctx context.Context, childName string, up bool)
+}
+
+// WrapStatusSetter returns a StatusSetter draining the connections to the servers going down,
+// before notifying the next StatusSetter.
+func (s *Service) WrapStatusSetter(next StatusSetter) StatusSetter {
+	return &statusSetter{service: s, next: next}
+}
+
+type statusSetter struct {
+	service *Service
+	next    StatusSetter
+}
+
+func (s *statusSetter) SetStatus(ctx context.Context, childName string, up bool) {
+	s.service.SetStatus(childName, up)
+	s.next.SetStatus(ctx, childName, up)
+}
+
+// WrapTCP returns a handler tracking the connections it handles for the given server.
+func (s *Service) WrapTCP(server string, next tcp.Handler) tcp.Handler {
+	return tcp.HandlerFunc(func(conn tcp.WriteCloser) {
+		release := s.Track(server, conn)
+		defer release()
+
+		next.ServeTCP(conn)
+	})
+}
+
+// WrapHTTP returns a handler tracking the upgraded connections (e.g. WebSocket) it handles for the given server.
+// Regular HTTP requests are not tracked, as their lifetime is bound to the server timeouts.
+func (s *Service) WrapHTTP(server string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if !httpguts.HeaderValuesContainsToken(req.Header["Connection"], "Upgrade") {
+			next.ServeHTTP(rw, req)
+			return
+		}
+
+		trw := &trackingResponseWriter{ResponseWriter: rw, service: s, server: server}
+		defer trw.release()
+
+		next.ServeHTTP(trw, req)
+	})
+}
+
+// trackingResponseWriter tracks the connection hijacked through it.
+type trackingResponseWriter struct {
+	http.ResponseWriter
+
+	service *Service
+	server  string
+	untrack func()
+}
+
+func (t *trackingResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	conn, brw, err := http.NewResponseController(t.ResponseWriter).Hijack()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	t.untrack = t.service.Track(t.server, conn)
+
+	return conn, brw, nil
+}
+
+func (t *trackingResponseWriter) Flush() {
+	_ = http.NewResponseController(t.ResponseWriter).Flush()
+}
+
+func (t *trackingResponseWriter) Unwrap() http.ResponseWriter {
+	return t.ResponseWriter
+}
+
+func (t *trackingResponseWriter) release() {
+	if t.untrack != nil {
+		t.untrack()
+	}
+}
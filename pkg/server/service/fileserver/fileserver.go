@@ -0,0 +1,366 @@
+package fileserver
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"io/fs"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+)
+
+// precompressedVariants are the precompressed variants of the files, in order of preference.
+var precompressedVariants = []struct {
+	encoding  string
+	extension string
+}{
+	{encoding: "br", extension: ".br"},
+	{encoding: "zstd", extension: ".zst"},
+	{encoding: "gzip", extension: ".gz"},
+}
+
+// Config holds the file server static configuration.
+type Config struct {
+	Roots []string `description:"Directories the file server services are allowed to serve files from." json:"roots,omitempty" toml:"roots,omitempty" yaml:"roots,omitempty"`
+}
+
+// Handler serves static files from a local directory.
+type Handler struct {
+	root          string
+	indexFiles    []string
+	spaFallback   bool
+	precompressed bool
+	browse        bool
+}
+
+// New creates a new file server handler.
+// The root directory of the configuration must be one of the allowed roots, or one of their subdirectories.
+func New(config dynamic.FileServer, allowedRoots []string) (*Handler, error) {
+	if config.Root == "" {
+		return nil, errors.New("root directory is required")
+	}
+
+	root, err := resolveRoot(config.Root, allowedRoots)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := os.Stat(root)
+	if err != nil {
+		return nil, fmt.Errorf("reading root directory: %w", err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("root %q is not a directory", config.Root)
+	}
+
+	indexFiles := config.IndexFiles
+	if len(indexFiles) == 0 {
+		indexFiles = []string{"index.html"}
+	}
+
+	return &Handler{
+		root:          root,
+		indexFiles:    indexFiles,
+		spaFallback:   config.SPAFallback,
+		precompressed: config.Precompressed,
+		browse:        config.Browse,
+	}, nil
+}
+
+func (h *Handler) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet && req.Method != http.MethodHead {
+		rw.Header().Set("Allow", "GET, HEAD")
+		http.Error(rw, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	// The name is relative to the root directory, and cannot escape it.
+	name := strings.TrimPrefix(path.Clean("/"+req.URL.Path), "/")
+	if name == "" {
+		name = "."
+	}
+
+	file, info, err := h.open(name)
+	if errors.Is(err, fs.ErrNotExist) && h.spaFallback {
+		h.serveIndex(rw, req, ".", true)
+		return
+	}
+	if err != nil {
+		serveError(rw, err)
+		return
+	}
+	defer file.Close()
+
+	if !info.IsDir() {
+		h.serveFile(rw, req, name, file, info)
+		return
+	}
+
+	if !strings.HasSuffix(req.URL.Path, "/") {
+		redirectToDirectory(rw, req)
+		return
+	}
+
+	if h.serveIndex(rw, req, name, false) {
+		return
+	}
+
+	if !h.browse {
+		http.Error(rw, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+		return
+	}
+
+	h.serveListing(rw, req, file)
+}
+
+// serveIndex serves the first index file found in the given directory, and tells whether one has been found.
+// When fallback is true, a not found response is sent if there is no index file.
+func (h *Handler) serveIndex(rw http.ResponseWriter, req *http.Request, dir string, fallback bool) bool {
+	for _, indexFile := range h.indexFiles {
+		name := path.Join(dir, indexFile)
+
+		file, info, err := h.open(name)
+		if err != nil {
+			continue
+		}
+
+		if info.IsDir() {
+			_ = file.Close()
+			continue
+		}
+
+		h.serveFile(rw, req, name, file, info)
+		_ = file.Close()
+
+		return true
+	}
+
+	if fallback {
+		http.Error(rw, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+	}
+
+	return false
+}
+
+func (h *Handler) serveFile(rw http.ResponseWriter, req *http.Request, name string, file *os.File, info fs.FileInfo) {
+	content := io.ReadSeeker(file)
+
+	if h.precompressed {
+		rw.Header().Add("Vary", "Accept-Encoding")
+
+		if variant, variantInfo, encoding := h.openPrecompressed(req, name); variant != nil {
+			defer variant.Close()
+
+			// The content type is the one of the original file, not the one of the variant.
+			contentType, err := detectContentType(name, file)
+			if err != nil {
+				serveError(rw, err)
+				return
+			}
+
+			rw.Header().Set("Content-Type", contentType)
+			rw.Header().Set("Content-Encoding", encoding)
+
+			content, info = variant, variantInfo
+		}
+	}
+
+	rw.Header().Set("ETag", etag(info))
+
+	http.ServeContent(rw, req, path.Base(name), info.ModTime(), content)
+}
+
+// openPrecompressed opens the preferred precompressed variant of the file accepted by the client, if any.
+func (h *Handler) openPrecompressed(req *http.Request, name string) (*os.File, fs.FileInfo, string) {
+	accepted := acceptedEncodings(req.Header.Values("Accept-Encoding"))
+
+	for _, variant := range precompressedVariants {
+		if !slices.Contains(accepted, variant.encoding) {
+			continue
+		}
+
+		file, info, err := h.open(name + variant.extension)
+		if err != nil {
+			continue
+		}
+
+		if info.IsDir() {
+			_ = file.Close()
+			continue
+		}
+
+		return file, info, variant.encoding
+	}
+
+	return nil, nil, ""
+}
+
+func (h *Handler) serveListing(rw http.ResponseWriter, req *http.Request, dir *os.File) {
+	entries, err := dir.ReadDir(-1)
+	if err != nil {
+		serveError(rw, err)
+		return
+	}
+
+	slices.SortFunc(entries, func(a, b fs.DirEntry) int {
+		return strings.Compare(a.Name(), b.Name())
+	})
+
+	rw.Header().Set("Content-Type", "text/html; charset=utf-8")
+	rw.WriteHeader(http.StatusOK)
+
+	if req.Method == http.MethodHead {
+		return
+	}
+
+	var b strings.Builder
+	b.WriteString("<!doctype html>\n<meta name=\"viewport\" content=\"width=device-width\">\n<pre>\n")
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() {
+			name += "/"
+		}
+
+		link := url.URL{Path: name}
+		fmt.Fprintf(&b, "<a href=\"%s\">%s</a>\n", link.String(), html.EscapeString(name))
+	}
+	b.WriteString("</pre>\n")
+
+	if _, err := io.WriteString(rw, b.String()); err != nil {
+		log.Debug().Err(err).Msg("Error while writing directory listing")
+	}
+}
+
+func (h *Handler) open(name string) (*os.File, fs.FileInfo, error) {
+	file, err := os.OpenInRoot(h.root, name)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return nil, nil, err
+	}
+
+	return file, info, nil
+}
+
+// resolveRoot resolves the given root directory against the allowed roots, and returns its real path.
+// A relative root is resolved against each of the allowed roots in order, and the first existing one is used.
+// The symbolic links are evaluated, so that they cannot be used to escape the allowed roots.
+func resolveRoot(root string, allowedRoots []string) (string, error) {
+	if len(allowedRoots) == 0 {
+		return "", errors.New("no file server root is allowed in the static configuration")
+	}
+
+	for _, allowedRoot := range allowedRoots {
+		allowed, err := filepath.Abs(allowedRoot)
+		if err != nil {
+			return "", fmt.Errorf("resolving allowed root %q: %w", allowedRoot, err)
+		}
+
+		allowed, err = filepath.EvalSymlinks(allowed)
+		if err != nil {
+			log.Debug().Err(err).Str("root", allowedRoot).Msg("Skipping unavailable file server root")
+			continue
+		}
+
+		candidate := root
+		if !filepath.IsAbs(candidate) {
+			candidate = filepath.Join(allowed, candidate)
+		}
+
+		candidate, err = filepath.EvalSymlinks(candidate)
+		if err != nil {
+			if filepath.IsAbs(root) {
+				return "", fmt.Errorf("reading root directory: %w", err)
+			}
+			continue
+		}
+
+		rel, err := filepath.Rel(allowed, candidate)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			continue
+		}
+
+		return candidate, nil
+	}
+
+	return "", fmt.Errorf("root %q is not within the allowed file server roots", root)
+}
+
+// redirectToDirectory redirects to the directory path with a trailing slash.
+// The location is relative, so that it remains valid when the path has been rewritten by a middleware (e.g. StripPrefix).
+func redirectToDirectory(rw http.ResponseWriter, req *http.Request) {
+	target := path.Base(req.URL.Path) + "/"
+	if req.URL.RawQuery != "" {
+		target += "?" + req.URL.RawQuery
+	}
+
+	rw.Header().Set("Location", target)
+	rw.WriteHeader(http.StatusMovedPermanently)
+}
+
+func serveError(rw http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		http.Error(rw, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+	case errors.Is(err, fs.ErrPermission):
+		http.Error(rw, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+	default:
+		// Includes the attempts to escape the root directory through symbolic links.
+		log.Debug().Err(err).Msg("Error while serving file")
+		http.Error(rw, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+	}
+}
+
+// detectContentType returns the content type of the file, from its extension or its content.
+func detectContentType(name string, file io.ReadSeeker) (string, error) {
+	if contentType := mime.TypeByExtension(path.Ext(name)); contentType != "" {
+		return contentType, nil
+	}
+
+	var buf [512]byte
+	n, _ := io.ReadFull(file, buf[:])
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+
+	return http.DetectContentType(buf[:n]), nil
+}
+
+// acceptedEncodings returns the encodings accepted by the client, ignoring the ones with a zero quality value.
+func acceptedEncodings(values []string) []string {
+	var encodings []string
+	for _, value := range values {
+		for element := range strings.SplitSeq(value, ",") {
+			encoding, params, _ := strings.Cut(strings.TrimSpace(element), ";")
+
+			if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+				if quality, err := strconv.ParseFloat(q, 64); err == nil && quality == 0 {
+					continue
+				}
+			}
+
+			encodings = append(encodings, strings.ToLower(strings.TrimSpace(encoding)))
+		}
+	}
+
+	return encodings
+}
+
+func etag(info fs.FileInfo) string {
+	return fmt.Sprintf(`"%x-%x"`, info.ModTime().UnixNano(), info.Size())
+}
@@ -0,0 +1,349 @@
+package fileserver
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+)
+
+func TestNew(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "file.txt"), "foo")
+
+	_, err := New(dynamic.FileServer{}, []string{dir})
+	require.Error(t, err)
+
+	_, err = New(dynamic.FileServer{Root: filepath.Join(dir, "missing")}, []string{dir})
+	require.Error(t, err)
+
+	_, err = New(dynamic.FileServer{Root: filepath.Join(dir, "file.txt")}, []string{dir})
+	require.Error(t, err)
+
+	_, err = New(dynamic.FileServer{Root: dir}, nil)
+	require.Error(t, err)
+
+	handler, err := New(dynamic.FileServer{Root: dir}, []string{dir})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"index.html"}, handler.indexFiles)
+}
+
+func TestResolveRoot(t *testing.T) {
+	dir := t.TempDir()
+	allowed := filepath.Join(dir, "allowed")
+	other := filepath.Join(dir, "other")
+	require.NoError(t, os.MkdirAll(filepath.Join(allowed, "site"), 0o755))
+	require.NoError(t, os.MkdirAll(filepath.Join(other, "site"), 0o755))
+	require.NoError(t, os.Symlink(other, filepath.Join(allowed, "link")))
+
+	testCases := []struct {
+		desc         string
+		root         string
+		allowedRoots []string
+		expected     string
+	}{
+		{
+			desc:         "no allowed roots",
+			root:         allowed,
+			allowedRoots: nil,
+		},
+		{
+			desc:         "allowed root",
+			root:         allowed,
+			allowedRoots: []string{allowed},
+			expected:     allowed,
+		},
+		{
+			desc:         "subdirectory of an allowed root",
+			root:         filepath.Join(allowed, "site"),
+			allowedRoots: []string{allowed},
+			expected:     filepath.Join(allowed, "site"),
+		},
+		{
+			desc:         "relative to an allowed root",
+			root:         "site",
+			allowedRoots: []string{filepath.Join(dir, "missing"), allowed},
+			expected:     filepath.Join(allowed, "site"),
+		},
+		{
+			desc:         "outside of the allowed roots",
+			root:         filepath.Join(other, "site"),
+			allowedRoots: []string{allowed},
+		},
+		{
+			desc:         "relative path escaping the allowed roots",
+			root:         "../other",
+			allowedRoots: []string{allowed},
+		},
+		{
+			desc:         "symbolic link escaping the allowed roots",
+			root:         filepath.Join(allowed, "link"),
+			allowedRoots: []string{allowed},
+		},
+		{
+			desc:         "allowed root prefix",
+			root:         allowed + "-suffix",
+			allowedRoots: []string{allowed},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			root, err := resolveRoot(test.root, test.allowedRoots)
+			if test.expected == "" {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+
+			expected, err := filepath.EvalSymlinks(test.expected)
+			require.NoError(t, err)
+			assert.Equal(t, expected, root)
+		})
+	}
+}
+
+func TestHandler_ServeHTTP(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "index.html"), "<html>root</html>")
+	writeFile(t, filepath.Join(dir, "app.js"), "console.log('app');")
+	writeFile(t, filepath.Join(dir, "app.js.gz"), "gzip-content")
+	writeFile(t, filepath.Join(dir, "app.js.br"), "br-content")
+	writeFile(t, filepath.Join(dir, "docs", "index.htm"), "docs")
+	writeFile(t, filepath.Join(dir, "empty", "a.txt"), "a")
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "empty", "sub"), 0o755))
+
+	testCases := []struct {
+		desc            string
+		config          dynamic.FileServer
+		method          string
+		path            string
+		headers         map[string]string
+		expectedStatus  int
+		expectedBody    string
+		expectedHeaders map[string]string
+	}{
+		{
+			desc:           "file",
+			path:           "/app.js",
+			expectedStatus: http.StatusOK,
+			expectedBody:   "console.log('app');",
+			expectedHeaders: map[string]string{
+				"Content-Type": "text/javascript; charset=utf-8",
+			},
+		},
+		{
+			desc:           "index file",
+			path:           "/",
+			expectedStatus: http.StatusOK,
+			expectedBody:   "<html>root</html>",
+			expectedHeaders: map[string]string{
+				"Content-Type": "text/html; charset=utf-8",
+			},
+		},
+		{
+			desc:           "custom index files",
+			config:         dynamic.FileServer{IndexFiles: []string{"index.html", "index.htm"}},
+			path:           "/docs/",
+			expectedStatus: http.StatusOK,
+			expectedBody:   "docs",
+		},
+		{
+			desc:           "directory redirect",
+			path:           "/docs",
+			expectedStatus: http.StatusMovedPermanently,
+			expectedHeaders: map[string]string{
+				"Location": "docs/",
+			},
+		},
+		{
+			desc:           "path traversal",
+			path:           "/../../etc/passwd",
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			desc:           "not found",
+			path:           "/missing",
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			desc:           "SPA fallback",
+			config:         dynamic.FileServer{SPAFallback: true},
+			path:           "/some/client/route",
+			expectedStatus: http.StatusOK,
+			expectedBody:   "<html>root</html>",
+		},
+		{
+			desc:           "directory listing disabled",
+			path:           "/empty/",
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			desc:           "directory listing",
+			config:         dynamic.FileServer{Browse: true},
+			path:           "/empty/",
+			expectedStatus: http.StatusOK,
+			expectedBody:   "<!doctype html>\n<meta name=\"viewport\" content=\"width=device-width\">\n<pre>\n<a href=\"a.txt\">a.txt</a>\n<a href=\"sub/\">sub/</a>\n</pre>\n",
+		},
+		{
+			desc:           "method not allowed",
+			method:         http.MethodPost,
+			path:           "/app.js",
+			expectedStatus: http.StatusMethodNotAllowed,
+			expectedHeaders: map[string]string{
+				"Allow": "GET, HEAD",
+			},
+		},
+		{
+			desc:           "precompressed disabled",
+			path:           "/app.js",
+			headers:        map[string]string{"Accept-Encoding": "br, gzip"},
+			expectedStatus: http.StatusOK,
+			expectedBody:   "console.log('app');",
+		},
+		{
+			desc:           "precompressed brotli",
+			config:         dynamic.FileServer{Precompressed: true},
+			path:           "/app.js",
+			headers:        map[string]string{"Accept-Encoding": "gzip, br"},
+			expectedStatus: http.StatusOK,
+			expectedBody:   "br-content",
+			expectedHeaders: map[string]string{
+				"Content-Encoding": "br",
+				"Content-Type":     "text/javascript; charset=utf-8",
+				"Vary":             "Accept-Encoding",
+			},
+		},
+		{
+			desc:           "precompressed gzip",
+			config:         dynamic.FileServer{Precompressed: true},
+			path:           "/app.js",
+			headers:        map[string]string{"Accept-Encoding": "br;q=0, zstd, gzip"},
+			expectedStatus: http.StatusOK,
+			expectedBody:   "gzip-content",
+			expectedHeaders: map[string]string{
+				"Content-Encoding": "gzip",
+			},
+		},
+		{
+			desc:           "precompressed not accepted",
+			config:         dynamic.FileServer{Precompressed: true},
+			path:           "/app.js",
+			expectedStatus: http.StatusOK,
+			expectedBody:   "console.log('app');",
+			expectedHeaders: map[string]string{
+				"Content-Encoding": "",
+				"Vary":             "Accept-Encoding",
+			},
+		},
+		{
+			desc:           "range",
+			path:           "/app.js",
+			headers:        map[string]string{"Range": "bytes=0-6"},
+			expectedStatus: http.StatusPartialContent,
+			expectedBody:   "console",
+			expectedHeaders: map[string]string{
+				"Content-Range": "bytes 0-6/19",
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			config := test.config
+			config.Root = dir
+
+			handler, err := New(config, []string{dir})
+			require.NoError(t, err)
+
+			method := test.method
+			if method == "" {
+				method = http.MethodGet
+			}
+
+			req := httptest.NewRequest(method, "http://localhost"+test.path, nil)
+			for k, v := range test.headers {
+				req.Header.Set(k, v)
+			}
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			assert.Equal(t, test.expectedStatus, rec.Code)
+			if test.expectedBody != "" {
+				assert.Equal(t, test.expectedBody, rec.Body.String())
+			}
+			for k, v := range test.expectedHeaders {
+				assert.Equal(t, v, rec.Header().Get(k), k)
+			}
+		})
+	}
+}
+
+func TestHandler_ServeHTTP_conditional(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "file.txt"), "foo")
+
+	modTime := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	require.NoError(t, os.Chtimes(filepath.Join(dir, "file.txt"), modTime, modTime))
+
+	handler, err := New(dynamic.FileServer{Root: dir}, []string{dir})
+	require.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "http://localhost/file.txt", nil))
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, modTime.Format(http.TimeFormat), rec.Header().Get("Last-Modified"))
+
+	etag := rec.Header().Get("ETag")
+	require.NotEmpty(t, etag)
+
+	req := httptest.NewRequest(http.MethodGet, "http://localhost/file.txt", nil)
+	req.Header.Set("If-None-Match", etag)
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusNotModified, rec.Code)
+
+	req = httptest.NewRequest(http.MethodGet, "http://localhost/file.txt", nil)
+	req.Header.Set("If-Modified-Since", modTime.Format(http.TimeFormat))
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusNotModified, rec.Code)
+}
+
+func TestHandler_ServeHTTP_symlinkEscape(t *testing.T) {
+	outside := t.TempDir()
+	writeFile(t, filepath.Join(outside, "secret.txt"), "secret")
+
+	dir := t.TempDir()
+	require.NoError(t, os.Symlink(filepath.Join(outside, "secret.txt"), filepath.Join(dir, "secret.txt")))
+
+	handler, err := New(dynamic.FileServer{Root: dir}, []string{dir})
+	require.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "http://localhost/secret.txt", nil))
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func writeFile(t *testing.T, name, content string) {
+	t.Helper()
+
+	require.NoError(t, os.MkdirAll(filepath.Dir(name), 0o755))
+	require.NoError(t, os.WriteFile(name, []byte(content), 0o644))
+}
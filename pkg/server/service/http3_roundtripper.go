@@ -0,0 +1,350 @@
+package service
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/quic-go/quic-go"
+	"github.com/quic-go/quic-go/http3"
+	"github.com/rs/zerolog/log"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+	"golang.org/x/net/http/httpguts"
+)
+
+const (
+	// migrationCheckInterval is the interval at which the local address used to reach a backend server is checked,
+	// to migrate the QUIC connections when it changes.
+	migrationCheckInterval = 5 * time.Second
+	// migrationProbeTimeout is the maximum duration of the validation of a new network path.
+	migrationProbeTimeout = 5 * time.Second
+)
+
+// quicDialError is returned when a QUIC connection to a backend server cannot be established.
+type quicDialError struct {
+	err error
+}
+
+func (e *quicDialError) Error() string {
+	return fmt.Sprintf("establishing QUIC connection: %v", e.err)
+}
+
+func (e *quicDialError) Unwrap() error {
+	return e.err
+}
+
+// http3RoundTripper sends the requests to the HTTPS backend servers over HTTP/3.
+// The other requests, and the requests to the servers which cannot be reached over QUIC, are sent by the TCP round tripper.
+type http3RoundTripper struct {
+	http3 *http3.Transport
+	tcp   http.RoundTripper
+
+	dialTimeout         time.Duration
+	enable0RTT          bool
+	connectionMigration bool
+	disableTCPFallback  bool
+	fallbackDuration    time.Duration
+
+	brokenMu sync.Mutex
+	broken   map[string]time.Time
+}
+
+func newHTTP3RoundTripper(cfg *dynamic.ServersTransport, tlsConfig *tls.Config, tcp http.RoundTripper) *http3RoundTripper {
+	rt := &http3RoundTripper{
+		tcp:                 tcp,
+		enable0RTT:          cfg.HTTP3.Enable0RTT,
+		connectionMigration: cfg.HTTP3.ConnectionMigration,
+		disableTCPFallback:  cfg.HTTP3.DisableTCPFallback,
+		fallbackDuration:    time.Duration(cfg.HTTP3.FallbackDuration),
+		broken:              make(map[string]time.Time),
+	}
+
+	if rt.fallbackDuration <= 0 {
+		rt.fallbackDuration = time.Duration(dynamic.DefaultHTTP3FallbackDuration)
+	}
+
+	quicConfig := &quic.Config{}
+
+	// The QUIC idle timeout only comes from the forwarding timeouts,
+	// which also take precedence over the idleConnTimeout option for the TCP connections.
+	// Without forwarding timeouts, the QUIC default idle timeout applies.
+	if cfg.ForwardingTimeouts != nil {
+		rt.dialTimeout = time.Duration(cfg.ForwardingTimeouts.DialTimeout)
+		quicConfig.MaxIdleTimeout = time.Duration(cfg.ForwardingTimeouts.IdleConnTimeout)
+		quicConfig.KeepAlivePeriod = time.Duration(cfg.ForwardingTimeouts.ReadIdleTimeout)
+	}
+
+	if rt.enable0RTT {
+		// 0-RTT requires the resumption of a previous TLS session.
+		if tlsConfig == nil {
+			tlsConfig = &tls.Config{}
+		} else {
+			tlsConfig = tlsConfig.Clone()
+		}
+		tlsConfig.ClientSessionCache = tls.NewLRUClientSessionCache(0)
+	}
+
+	rt.http3 = &http3.Transport{
+		TLSClientConfig: tlsConfig,
+		QUICConfig:      quicConfig,
+		Dial:            rt.dial,
+	}
+
+	return rt
+}
+
+func (r *http3RoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	// Connection upgrades (e.g. WebSocket) are not supported over HTTP/3.
+	if req.URL.Scheme != "https" || httpguts.HeaderValuesContainsToken(req.Header["Connection"], "Upgrade") || r.isBroken(req.URL.Host) {
+		return r.tcp.RoundTrip(req)
+	}
+
+	outReq := *req
+
+	var body *fallbackBody
+	if req.Body != nil && req.Body != http.NoBody {
+		body = &fallbackBody{ReadCloser: req.Body}
+		outReq.Body = body
+	}
+
+	if r.enable0RTT && body == nil {
+		switch req.Method {
+		case http.MethodGet:
+			outReq.Method = http3.MethodGet0RTT
+		case http.MethodHead:
+			outReq.Method = http3.MethodHead0RTT
+		}
+	}
+
+	resp, err := r.http3.RoundTrip(&outReq)
+
+	var dialErr *quicDialError
+	if err == nil || r.disableTCPFallback || !errors.As(err, &dialErr) || (body != nil && body.wasRead()) {
+		return resp, err
+	}
+
+	r.markBroken(req.URL.Host)
+
+	log.Ctx(req.Context()).Debug().Err(err).Str("host", req.URL.Host).
+		Msgf("QUIC connection failed, falling back to TCP for %s", r.fallbackDuration)
+
+	return r.tcp.RoundTrip(req)
+}
+
+func (r *http3RoundTripper) isBroken(host string) bool {
+	r.brokenMu.Lock()
+	defer r.brokenMu.Unlock()
+
+	until, ok := r.broken[host]
+	if !ok {
+		return false
+	}
+
+	if time.Now().After(until) {
+		delete(r.broken, host)
+		return false
+	}
+
+	return true
+}
+
+func (r *http3RoundTripper) markBroken(host string) {
+	r.brokenMu.Lock()
+	defer r.brokenMu.Unlock()
+
+	r.broken[host] = time.Now().Add(r.fallbackDuration)
+}
+
+// dial establishes a QUIC connection on a dedicated UDP socket,
+// which is closed with the connection.
+func (r *http3RoundTripper) dial(ctx context.Context, addr string, tlsConfig *tls.Config, quicConfig *quic.Config) (*quic.Conn, error) {
+	if r.dialTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.dialTimeout)
+		defer cancel()
+	}
+
+	remoteAddr, err := resolveUDPAddr(ctx, addr)
+	if err != nil {
+		return nil, &quicDialError{err: err}
+	}
+
+	// When the connections are migrated, the socket is bound to the local address,
+	// so that a change of this address is detected.
+	var localAddr *net.UDPAddr
+	if r.connectionMigration {
+		localIP, err := localIPFor(remoteAddr)
+		if err != nil {
+			return nil, &quicDialError{err: err}
+		}
+		localAddr = &net.UDPAddr{IP: localIP}
+	}
+
+	transport, err := newQUICTransport(localAddr)
+	if err != nil {
+		return nil, &quicDialError{err: err}
+	}
+
+	conn, err := transport.DialEarly(ctx, remoteAddr, tlsConfig, quicConfig)
+	if err != nil {
+		closeQUICTransport(transport)
+		return nil, &quicDialError{err: err}
+	}
+
+	go func() {
+		transports := []*quic.Transport{transport}
+		if r.connectionMigration && localAddr != nil {
+			transports = migrate(conn, remoteAddr, localAddr.IP, transports)
+		}
+
+		<-conn.Context().Done()
+
+		for _, t := range transports {
+			closeQUICTransport(t)
+		}
+	}()
+
+	return conn, nil
+}
+
+// migrate migrates the connection to a new network path each time the local address used to reach the server changes,
+// until the connection is closed, and returns the transports used by the connection.
+func migrate(conn *quic.Conn, remoteAddr *net.UDPAddr, currentIP net.IP, transports []*quic.Transport) []*quic.Transport {
+	ticker := time.NewTicker(migrationCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-conn.Context().Done():
+			return transports
+		case <-ticker.C:
+		}
+
+		localIP, err := localIPFor(remoteAddr)
+		if err != nil || localIP.Equal(currentIP) {
+			continue
+		}
+
+		logger := log.With().Str("remoteAddr", remoteAddr.String()).Logger()
+
+		transport, err := newQUICTransport(&net.UDPAddr{IP: localIP})
+		if err != nil {
+			logger.Debug().Err(err).Msg("Unable to create QUIC transport for connection migration")
+			continue
+		}
+
+		if err := switchPath(conn, transport); err != nil {
+			logger.Debug().Err(err).Msgf("Unable to migrate QUIC connection to %s", localIP)
+			closeQUICTransport(transport)
+			continue
+		}
+
+		logger.Debug().Msgf("QUIC connection migrated from %s to %s", currentIP, localIP)
+
+		currentIP = localIP
+		transports = append(transports, transport)
+	}
+}
+
+func switchPath(conn *quic.Conn, transport *quic.Transport) error {
+	path, err := conn.AddPath(transport)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(conn.Context(), migrationProbeTimeout)
+	defer cancel()
+
+	if err := path.Probe(ctx); err != nil {
+		_ = path.Close()
+		return err
+	}
+
+	if err := path.Switch(); err != nil {
+		_ = path.Close()
+		return err
+	}
+
+	return nil
+}
+
+func newQUICTransport(localAddr *net.UDPAddr) (*quic.Transport, error) {
+	udpConn, err := net.ListenUDP("udp", localAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	return &quic.Transport{Conn: udpConn}, nil
+}
+
+func closeQUICTransport(transport *quic.Transport) {
+	_ = transport.Close()
+	_ = transport.Conn.Close()
+}
+
+func resolveUDPAddr(ctx context.Context, addr string) (*net.UDPAddr, error) {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	addrs, err := net.DefaultResolver.LookupNetIP(ctx, "ip", host)
+	if err != nil {
+		return nil, err
+	}
+	if len(addrs) == 0 {
+		return nil, fmt.Errorf("no address found for %s", host)
+	}
+
+	return net.ResolveUDPAddr("udp", net.JoinHostPort(addrs[0].Unmap().String(), port))
+}
+
+// localIPFor returns the local IP address used to reach the given address.
+// No packet is sent.
+func localIPFor(remoteAddr *net.UDPAddr) (net.IP, error) {
+	conn, err := net.DialUDP("udp", nil, remoteAddr)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	return conn.LocalAddr().(*net.UDPAddr).IP, nil
+}
+
+// fallbackBody is a request body which is not closed by the HTTP/3 transport until it has been read,
+// so that it can still be sent over TCP when the QUIC connection cannot be established.
+type fallbackBody struct {
+	io.ReadCloser
+
+	mu   sync.Mutex
+	read bool
+}
+
+func (b *fallbackBody) Read(p []byte) (int, error) {
+	b.mu.Lock()
+	b.read = true
+	b.mu.Unlock()
+
+	return b.ReadCloser.Read(p)
+}
+
+func (b *fallbackBody) Close() error {
+	if !b.wasRead() {
+		return nil
+	}
+
+	return b.ReadCloser.Close()
+}
+
+func (b *fallbackBody) wasRead() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.read
+}
@@ -0,0 +1,518 @@
+package mirror
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"net"
+	"net/http"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	gokitmetrics "github.com/go-kit/kit/metrics"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+	"github.com/traefik/traefik/v3/pkg/observability/logs"
+)
+
+// Results of the comparison of a mirror response with the main service response.
+const (
+	CompareMatch    = "match"
+	CompareMismatch = "mismatch"
+	CompareSkipped  = "skipped"
+)
+
+const (
+	// maxDifferences is the maximum number of differences reported for a mirror response.
+	maxDifferences = 20
+	// maxExcerptSize is the maximum size of the body excerpts reported in the differences.
+	maxExcerptSize = 256
+)
+
+// Comparator compares the mirror responses with the main service response,
+// and reports the mismatches through metrics and the logs.
+type Comparator struct {
+	serviceName     string
+	headers         []string
+	ignoreHeaders   map[string]struct{}
+	ignoreJSONPaths [][]string
+	maxBodySize     int64
+	diffsPercent    int
+	counter         gokitmetrics.Counter
+	logger          zerolog.Logger
+
+	lock       sync.Mutex
+	mismatches uint64
+	logged     uint64
+}
+
+// NewComparator creates a new Comparator for the given mirroring service.
+// The counter, if not nil, counts the comparisons by service, mirror, and result.
+// The differences are logged to the logger, if not nil, and to the logger from the context otherwise.
+func NewComparator(ctx context.Context, serviceName string, config dynamic.MirroringCompare, counter gokitmetrics.Counter, logger *zerolog.Logger) (*Comparator, error) {
+	maxBodySize := dynamic.MirroringCompareDefaultMaxBodySize
+	if config.MaxBodySize != nil {
+		maxBodySize = *config.MaxBodySize
+	}
+
+	diffsPercent := dynamic.MirroringCompareDefaultDiffsPercent
+	if config.DiffsPercent != nil {
+		diffsPercent = *config.DiffsPercent
+	}
+	if diffsPercent < 0 || diffsPercent > 100 {
+		return nil, errors.New("diffsPercent must be between 0 and 100")
+	}
+
+	var headers []string
+	for _, header := range config.Headers {
+		headers = append(headers, http.CanonicalHeaderKey(header))
+	}
+
+	ignoreHeaders := map[string]struct{}{}
+	for _, header := range config.IgnoreHeaders {
+		ignoreHeaders[http.CanonicalHeaderKey(header)] = struct{}{}
+	}
+
+	var ignoreJSONPaths [][]string
+	for _, path := range config.IgnoreJSONPaths {
+		segments, err := parseJSONPath(path)
+		if err != nil {
+			return nil, fmt.Errorf("parsing JSON path %q: %w", path, err)
+		}
+		ignoreJSONPaths = append(ignoreJSONPaths, segments)
+	}
+
+	diffsLogger := *log.Ctx(ctx)
+	if logger != nil {
+		diffsLogger = logger.With().Str(logs.ServiceName, serviceName).Logger()
+	}
+
+	return &Comparator{
+		serviceName:     serviceName,
+		headers:         headers,
+		ignoreHeaders:   ignoreHeaders,
+		ignoreJSONPaths: ignoreJSONPaths,
+		maxBodySize:     maxBodySize,
+		diffsPercent:    diffsPercent,
+		counter:         counter,
+		logger:          diffsLogger,
+	}, nil
+}
+
+// Difference is a difference between a mirror response and the main service response.
+type Difference struct {
+	// Field is the compared part of the responses: status, header.<name>, body, or body.<JSON path>.
+	Field   string `json:"field"`
+	Primary string `json:"primary"`
+	Mirror  string `json:"mirror"`
+}
+
+// capturedResponse holds the compared parts of a response.
+type capturedResponse struct {
+	status    int
+	header    http.Header
+	body      bytes.Buffer
+	truncated bool
+	hijacked  bool
+}
+
+func (c *Comparator) capture(rw http.ResponseWriter, resp *capturedResponse) http.ResponseWriter {
+	return &captureResponseWriter{ResponseWriter: rw, resp: resp, maxBodySize: c.maxBodySize}
+}
+
+// report compares the mirror response with the main service response, and reports the result.
+func (c *Comparator) report(mirrorName string, primary, mirror *capturedResponse, withMetrics bool) {
+	result, diffs := c.compare(primary, mirror)
+
+	if withMetrics && c.counter != nil {
+		c.counter.With("service", c.serviceName, "mirror", mirrorName, "result", result).Add(1)
+	}
+
+	if result != CompareMismatch || !c.sampleDiffs() {
+		return
+	}
+
+	c.logger.Info().
+		Str("mirror", mirrorName).
+		Int("primaryStatus", primary.statusCode()).
+		Int("mirrorStatus", mirror.statusCode()).
+		Interface("differences", diffs).
+		Msg("Mirror response mismatch")
+}
+
+func (c *Comparator) sampleDiffs() bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.mismatches++
+	if c.logged*100 >= c.mismatches*uint64(c.diffsPercent) {
+		return false
+	}
+
+	c.logged++
+	return true
+}
+
+// compare returns the result of the comparison of the responses, and their differences.
+func (c *Comparator) compare(primary, mirror *capturedResponse) (string, []Difference) {
+	if primary.hijacked || mirror.hijacked {
+		return CompareSkipped, nil
+	}
+
+	var diffs []Difference
+
+	if primary.statusCode() != mirror.statusCode() {
+		diffs = append(diffs, Difference{
+			Field:   "status",
+			Primary: strconv.Itoa(primary.statusCode()),
+			Mirror:  strconv.Itoa(mirror.statusCode()),
+		})
+	}
+
+	for _, name := range c.comparedHeaders(primary.header, mirror.header) {
+		primaryValue := strings.Join(primary.header.Values(name), ", ")
+		mirrorValue := strings.Join(mirror.header.Values(name), ", ")
+		if primaryValue != mirrorValue {
+			diffs = append(diffs, Difference{Field: "header." + name, Primary: primaryValue, Mirror: mirrorValue})
+		}
+	}
+
+	// The bodies larger than the limit are not compared.
+	if !primary.truncated && !mirror.truncated {
+		diffs = append(diffs, c.compareBodies(primary, mirror)...)
+	}
+
+	if len(diffs) > maxDifferences {
+		diffs = diffs[:maxDifferences]
+	}
+
+	if len(diffs) > 0 {
+		return CompareMismatch, diffs
+	}
+
+	return CompareMatch, nil
+}
+
+func (c *Comparator) comparedHeaders(primary, mirror http.Header) []string {
+	names := c.headers
+	if len(names) == 0 {
+		for name := range primary {
+			names = append(names, name)
+		}
+		for name := range mirror {
+			if _, ok := primary[name]; !ok {
+				names = append(names, name)
+			}
+		}
+		slices.Sort(names)
+	}
+
+	var compared []string
+	for _, name := range names {
+		if _, ok := c.ignoreHeaders[name]; ok {
+			continue
+		}
+
+		if len(c.headers) == 0 && name == "Date" {
+			continue
+		}
+
+		compared = append(compared, name)
+	}
+
+	return compared
+}
+
+func (c *Comparator) compareBodies(primary, mirror *capturedResponse) []Difference {
+	if bytes.Equal(primary.body.Bytes(), mirror.body.Bytes()) {
+		return nil
+	}
+
+	if isJSON(primary.header) && isJSON(mirror.header) {
+		primaryValue, errPrimary := decodeJSON(primary.body.Bytes())
+		mirrorValue, errMirror := decodeJSON(mirror.body.Bytes())
+		if errPrimary == nil && errMirror == nil {
+			for _, path := range c.ignoreJSONPaths {
+				primaryValue = removeJSONPath(primaryValue, path)
+				mirrorValue = removeJSONPath(mirrorValue, path)
+			}
+
+			var diffs []Difference
+			diffJSON("body", primaryValue, mirrorValue, &diffs)
+
+			return diffs
+		}
+	}
+
+	return []Difference{{
+		Field:   "body",
+		Primary: excerpt(primary.body.Bytes()),
+		Mirror:  excerpt(mirror.body.Bytes()),
+	}}
+}
+
+func (r *capturedResponse) statusCode() int {
+	if r.status == 0 {
+		// Nothing has been written, which results in an empty 200 response.
+		return http.StatusOK
+	}
+
+	return r.status
+}
+
+// parseJSONPath parses a dot-separated JSON path, where * matches any object field or array element.
+func parseJSONPath(path string) ([]string, error) {
+	path = strings.TrimPrefix(strings.TrimPrefix(path, "$"), ".")
+	if path == "" {
+		return nil, errors.New("empty path")
+	}
+
+	segments := strings.Split(path, ".")
+	if slices.Contains(segments, "") {
+		return nil, errors.New("empty path segment")
+	}
+
+	return segments, nil
+}
+
+// removeJSONPath removes the values matching the path from the decoded JSON value.
+// The matching array elements are replaced with null, to keep the positions of the other elements.
+func removeJSONPath(value any, path []string) any {
+	if len(path) == 0 {
+		return nil
+	}
+
+	segment, rest := path[0], path[1:]
+
+	switch v := value.(type) {
+	case map[string]any:
+		for key, child := range v {
+			if segment != "*" && segment != key {
+				continue
+			}
+
+			if len(rest) == 0 {
+				delete(v, key)
+				continue
+			}
+
+			v[key] = removeJSONPath(child, rest)
+		}
+
+	case []any:
+		for i, child := range v {
+			if segment != "*" && segment != strconv.Itoa(i) {
+				continue
+			}
+
+			v[i] = removeJSONPath(child, rest)
+		}
+	}
+
+	return value
+}
+
+// diffJSON appends the differences between the decoded JSON values, up to maxDifferences.
+func diffJSON(path string, primary, mirror any, diffs *[]Difference) {
+	if len(*diffs) >= maxDifferences {
+		return
+	}
+
+	switch p := primary.(type) {
+	case map[string]any:
+		m, ok := mirror.(map[string]any)
+		if !ok {
+			break
+		}
+
+		keys := make([]string, 0, len(p)+len(m))
+		for key := range p {
+			keys = append(keys, key)
+		}
+		for key := range m {
+			if _, exists := p[key]; !exists {
+				keys = append(keys, key)
+			}
+		}
+		slices.Sort(keys)
+
+		for _, key := range keys {
+			primaryChild, primaryOk := p[key]
+			mirrorChild, mirrorOk := m[key]
+
+			if primaryOk && mirrorOk {
+				diffJSON(path+"."+key, primaryChild, mirrorChild, diffs)
+				continue
+			}
+
+			appendJSONDifference(path+"."+key, primaryChild, primaryOk, mirrorChild, mirrorOk, diffs)
+		}
+
+		return
+
+	case []any:
+		m, ok := mirror.([]any)
+		if !ok {
+			break
+		}
+
+		for i := range max(len(p), len(m)) {
+			elementPath := path + "." + strconv.Itoa(i)
+
+			if i < len(p) && i < len(m) {
+				diffJSON(elementPath, p[i], m[i], diffs)
+				continue
+			}
+
+			var primaryChild, mirrorChild any
+			if i < len(p) {
+				primaryChild = p[i]
+			}
+			if i < len(m) {
+				mirrorChild = m[i]
+			}
+
+			appendJSONDifference(elementPath, primaryChild, i < len(p), mirrorChild, i < len(m), diffs)
+		}
+
+		return
+	}
+
+	if !reflect.DeepEqual(primary, mirror) {
+		appendJSONDifference(path, primary, true, mirror, true, diffs)
+	}
+}
+
+func appendJSONDifference(path string, primary any, primaryOk bool, mirror any, mirrorOk bool, diffs *[]Difference) {
+	if len(*diffs) >= maxDifferences {
+		return
+	}
+
+	*diffs = append(*diffs, Difference{
+		Field:   path,
+		Primary: encodeJSON(primary, primaryOk),
+		Mirror:  encodeJSON(mirror, mirrorOk),
+	})
+}
+
+func encodeJSON(value any, ok bool) string {
+	if !ok {
+		return "<missing>"
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+
+	return excerpt(data)
+}
+
+func decodeJSON(data []byte) (any, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+
+	return value, nil
+}
+
+func isJSON(header http.Header) bool {
+	mediaType, _, err := mime.ParseMediaType(header.Get("Content-Type"))
+	if err != nil {
+		return false
+	}
+
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+func excerpt(data []byte) string {
+	if len(data) <= maxExcerptSize {
+		return string(data)
+	}
+
+	return fmt.Sprintf("%s... (%d bytes)", data[:maxExcerptSize], len(data))
+}
+
+// captureResponseWriter captures the status, the headers, and the body, up to a size limit, of a response.
+type captureResponseWriter struct {
+	http.ResponseWriter
+
+	resp        *capturedResponse
+	maxBodySize int64
+	wroteHeader bool
+}
+
+func (c *captureResponseWriter) WriteHeader(code int) {
+	// The informational responses are not captured.
+	if !c.wroteHeader && code >= http.StatusOK {
+		c.wroteHeader = true
+		c.resp.status = code
+		c.resp.header = c.ResponseWriter.Header().Clone()
+	}
+
+	c.ResponseWriter.WriteHeader(code)
+}
+
+func (c *captureResponseWriter) Write(data []byte) (int, error) {
+	if !c.wroteHeader {
+		c.WriteHeader(http.StatusOK)
+	}
+
+	if !c.resp.truncated {
+		if c.maxBodySize >= 0 && int64(c.resp.body.Len()+len(data)) > c.maxBodySize {
+			c.resp.truncated = true
+			c.resp.body = bytes.Buffer{}
+		} else {
+			c.resp.body.Write(data)
+		}
+	}
+
+	return c.ResponseWriter.Write(data)
+}
+
+func (c *captureResponseWriter) Flush() {
+	_ = http.NewResponseController(c.ResponseWriter).Flush()
+}
+
+func (c *captureResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	conn, brw, err := http.NewResponseController(c.ResponseWriter).Hijack()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	c.resp.hijacked = true
+
+	return conn, brw, nil
+}
+
+func (c *captureResponseWriter) Unwrap() http.ResponseWriter {
+	return c.ResponseWriter
+}
+
+// discardResponseWriter is a response writer discarding the body,
+// which keeps the headers for them to be captured.
+type discardResponseWriter struct {
+	blackHoleResponseWriter
+
+	header http.Header
+}
+
+func newDiscardResponseWriter() *discardResponseWriter {
+	return &discardResponseWriter{header: make(http.Header)}
+}
+
+func (d *discardResponseWriter) Header() http.Header {
+	return d.header
+}
@@ -0,0 +1,284 @@
+package mirror
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	gokitmetrics "github.com/go-kit/kit/metrics"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/traefik/traefik/v3/pkg/config/dynamic"
+	"github.com/traefik/traefik/v3/pkg/middlewares/observability"
+	"github.com/traefik/traefik/v3/pkg/safe"
+)
+
+func TestNewComparator(t *testing.T) {
+	_, err := NewComparator(t.Context(), "foo", dynamic.MirroringCompare{IgnoreJSONPaths: []string{"a..b"}}, nil, nil)
+	require.Error(t, err)
+
+	_, err = NewComparator(t.Context(), "foo", dynamic.MirroringCompare{DiffsPercent: pointer(101)}, nil, nil)
+	require.Error(t, err)
+
+	comparator, err := NewComparator(t.Context(), "foo", dynamic.MirroringCompare{
+		Headers:         []string{"x-foo"},
+		IgnoreJSONPaths: []string{"$.items.*.id"},
+	}, nil, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"X-Foo"}, comparator.headers)
+	assert.Equal(t, [][]string{{"items", "*", "id"}}, comparator.ignoreJSONPaths)
+	assert.Equal(t, dynamic.MirroringCompareDefaultMaxBodySize, comparator.maxBodySize)
+	assert.Equal(t, dynamic.MirroringCompareDefaultDiffsPercent, comparator.diffsPercent)
+}
+
+func TestComparator_compare(t *testing.T) {
+	testCases := []struct {
+		desc           string
+		config         dynamic.MirroringCompare
+		primary        *capturedResponse
+		mirror         *capturedResponse
+		expectedResult string
+		expectedDiffs  []Difference
+	}{
+		{
+			desc:           "same responses",
+			primary:        newCapturedResponse(http.StatusOK, http.Header{"Content-Type": {"text/plain"}}, "foo"),
+			mirror:         newCapturedResponse(http.StatusOK, http.Header{"Content-Type": {"text/plain"}}, "foo"),
+			expectedResult: CompareMatch,
+		},
+		{
+			desc:           "nothing written",
+			primary:        &capturedResponse{},
+			mirror:         newCapturedResponse(http.StatusOK, nil, ""),
+			expectedResult: CompareMatch,
+		},
+		{
+			desc:           "different status",
+			primary:        newCapturedResponse(http.StatusOK, nil, ""),
+			mirror:         newCapturedResponse(http.StatusInternalServerError, nil, ""),
+			expectedResult: CompareMismatch,
+			expectedDiffs:  []Difference{{Field: "status", Primary: "200", Mirror: "500"}},
+		},
+		{
+			desc:           "different headers",
+			primary:        newCapturedResponse(http.StatusOK, http.Header{"Date": {"1"}, "X-Foo": {"a"}, "X-Bar": {"a"}}, ""),
+			mirror:         newCapturedResponse(http.StatusOK, http.Header{"Date": {"2"}, "X-Foo": {"b"}, "X-Baz": {"a"}}, ""),
+			expectedResult: CompareMismatch,
+			expectedDiffs: []Difference{
+				{Field: "header.X-Bar", Primary: "a"},
+				{Field: "header.X-Baz", Mirror: "a"},
+				{Field: "header.X-Foo", Primary: "a", Mirror: "b"},
+			},
+		},
+		{
+			desc:           "selected headers",
+			config:         dynamic.MirroringCompare{Headers: []string{"x-foo"}},
+			primary:        newCapturedResponse(http.StatusOK, http.Header{"X-Foo": {"a"}, "X-Bar": {"a"}}, ""),
+			mirror:         newCapturedResponse(http.StatusOK, http.Header{"X-Foo": {"a"}, "X-Bar": {"b"}}, ""),
+			expectedResult: CompareMatch,
+		},
+		{
+			desc:           "ignored headers",
+			config:         dynamic.MirroringCompare{IgnoreHeaders: []string{"x-bar"}},
+			primary:        newCapturedResponse(http.StatusOK, http.Header{"X-Foo": {"a"}, "X-Bar": {"a"}}, ""),
+			mirror:         newCapturedResponse(http.StatusOK, http.Header{"X-Foo": {"a"}, "X-Bar": {"b"}}, ""),
+			expectedResult: CompareMatch,
+		},
+		{
+			desc:           "different bodies",
+			primary:        newCapturedResponse(http.StatusOK, nil, "foo"),
+			mirror:         newCapturedResponse(http.StatusOK, nil, "bar"),
+			expectedResult: CompareMismatch,
+			expectedDiffs:  []Difference{{Field: "body", Primary: "foo", Mirror: "bar"}},
+		},
+		{
+			desc:           "different JSON bodies",
+			primary:        newJSONCapturedResponse(`{"a": 1, "b": [1, 2], "c": "foo"}`),
+			mirror:         newJSONCapturedResponse(`{"b": [1, 3, 4], "c": "foo", "d": true}`),
+			expectedResult: CompareMismatch,
+			expectedDiffs: []Difference{
+				{Field: "body.a", Primary: "1", Mirror: "<missing>"},
+				{Field: "body.b.1", Primary: "2", Mirror: "3"},
+				{Field: "body.b.2", Primary: "<missing>", Mirror: "4"},
+				{Field: "body.d", Primary: "<missing>", Mirror: "true"},
+			},
+		},
+		{
+			desc:           "equivalent JSON bodies",
+			primary:        newJSONCapturedResponse(`{"a": 1, "b": "foo"}`),
+			mirror:         newJSONCapturedResponse(`{"b":"foo","a":1}`),
+			expectedResult: CompareMatch,
+		},
+		{
+			desc:           "ignored JSON paths",
+			config:         dynamic.MirroringCompare{IgnoreJSONPaths: []string{"meta.requestId", "items.*.updatedAt"}},
+			primary:        newJSONCapturedResponse(`{"meta": {"requestId": "1"}, "items": [{"id": 1, "updatedAt": "a"}]}`),
+			mirror:         newJSONCapturedResponse(`{"meta": {"requestId": "2"}, "items": [{"id": 1, "updatedAt": "b"}]}`),
+			expectedResult: CompareMatch,
+		},
+		{
+			desc:           "truncated body",
+			primary:        &capturedResponse{status: http.StatusOK, truncated: true},
+			mirror:         newCapturedResponse(http.StatusOK, nil, "bar"),
+			expectedResult: CompareMatch,
+		},
+		{
+			desc:           "hijacked",
+			primary:        &capturedResponse{status: http.StatusSwitchingProtocols, hijacked: true},
+			mirror:         newCapturedResponse(http.StatusOK, nil, "bar"),
+			expectedResult: CompareSkipped,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			comparator, err := NewComparator(t.Context(), "foo", test.config, nil, nil)
+			require.NoError(t, err)
+
+			result, diffs := comparator.compare(test.primary, test.mirror)
+			assert.Equal(t, test.expectedResult, result)
+			assert.Equal(t, test.expectedDiffs, diffs)
+		})
+	}
+}
+
+func TestMirroring_compare(t *testing.T) {
+	handler := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.Header().Set("Content-Type", "application/json")
+		_, _ = rw.Write([]byte(`{"version": 1}`))
+	})
+
+	var logs bytes.Buffer
+	ctx := zerolog.New(&logs).With().Str("serviceName", "foo").Logger().WithContext(t.Context())
+	counter := &comparisonsCounter{}
+
+	comparator, err := NewComparator(ctx, "foo", dynamic.MirroringCompare{
+		MaxBodySize: pointer[int64](100),
+	}, counter, nil)
+	require.NoError(t, err)
+
+	pool := safe.NewPool(t.Context())
+	mirror := New(handler, pool, true, defaultMaxBodySize, nil)
+	mirror.SetComparator(comparator)
+
+	err = mirror.AddMirror("same", handler, 100)
+	require.NoError(t, err)
+
+	err = mirror.AddMirror("different", http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.Header().Set("Content-Type", "application/json")
+		_, _ = rw.Write([]byte(`{"version": 2}`))
+	}), 100)
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req = req.WithContext(observability.WithObservability(req.Context(), observability.Observability{MetricsEnabled: true}))
+
+	rec := httptest.NewRecorder()
+	mirror.ServeHTTP(rec, req)
+
+	pool.Stop()
+
+	assert.JSONEq(t, `{"version": 1}`, rec.Body.String())
+	assert.Equal(t, map[string]float64{
+		"service=foo,mirror=same,result=match":         1,
+		"service=foo,mirror=different,result=mismatch": 1,
+	}, counter.values)
+
+	var lines []map[string]any
+	scanner := bufio.NewScanner(&logs)
+	for scanner.Scan() {
+		var line map[string]any
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &line))
+		lines = append(lines, line)
+	}
+	require.NoError(t, scanner.Err())
+
+	require.Len(t, lines, 1)
+	assert.Equal(t, "foo", lines[0]["serviceName"])
+	assert.Equal(t, "different", lines[0]["mirror"])
+	assert.Equal(t, []any{map[string]any{"field": "body.version", "primary": "1", "mirror": "2"}}, lines[0]["differences"])
+}
+
+func TestComparator_report_logger(t *testing.T) {
+	var traefikLogs bytes.Buffer
+	ctx := zerolog.New(&traefikLogs).WithContext(t.Context())
+
+	var diffsLogs bytes.Buffer
+	logger := zerolog.New(&diffsLogs)
+
+	comparator, err := NewComparator(ctx, "foo", dynamic.MirroringCompare{}, nil, &logger)
+	require.NoError(t, err)
+
+	comparator.report("bar", newCapturedResponse(http.StatusOK, http.Header{}, ""), newCapturedResponse(http.StatusNotFound, http.Header{}, ""), false)
+
+	assert.Empty(t, traefikLogs.String())
+
+	var line map[string]any
+	require.NoError(t, json.Unmarshal(diffsLogs.Bytes(), &line))
+
+	assert.Equal(t, "foo", line["serviceName"])
+	assert.Equal(t, "bar", line["mirror"])
+	assert.Equal(t, []any{map[string]any{"field": "status", "primary": "200", "mirror": "404"}}, line["differences"])
+}
+
+func TestComparator_sampleDiffs(t *testing.T) {
+	comparator, err := NewComparator(t.Context(), "foo", dynamic.MirroringCompare{DiffsPercent: pointer(10)}, nil, nil)
+	require.NoError(t, err)
+
+	var logged int
+	for range 100 {
+		if comparator.sampleDiffs() {
+			logged++
+		}
+	}
+
+	assert.Equal(t, 10, logged)
+}
+
+func newCapturedResponse(status int, header http.Header, body string) *capturedResponse {
+	resp := &capturedResponse{status: status, header: header}
+	resp.body.WriteString(body)
+
+	return resp
+}
+
+func newJSONCapturedResponse(body string) *capturedResponse {
+	return newCapturedResponse(http.StatusOK, http.Header{"Content-Type": {"application/json; charset=utf-8"}}, body)
+}
+
+func pointer[T any](v T) *T { return &v }
+
+type comparisonsCounter struct {
+	lock   sync.Mutex
+	values map[string]float64
+	labels []string
+	parent *comparisonsCounter
+}
+
+func (c *comparisonsCounter) With(labelValues ...string) gokitmetrics.Counter {
+	return &comparisonsCounter{labels: labelValues, parent: c}
+}
+
+func (c *comparisonsCounter) Add(delta float64) {
+	var pairs []string
+	for i := 0; i+1 < len(c.labels); i += 2 {
+		pairs = append(pairs, c.labels[i]+"="+c.labels[i+1])
+	}
+
+	c.parent.lock.Lock()
+	defer c.parent.lock.Unlock()
+
+	if c.parent.values == nil {
+		c.parent.values = make(map[string]float64)
+	}
+	c.parent.values[strings.Join(pairs, ",")] += delta
+}
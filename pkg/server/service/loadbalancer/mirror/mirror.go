@@ -15,6 +15,7 @@ import (
 	"github.com/traefik/traefik/v3/pkg/config/dynamic"
 	"github.com/traefik/traefik/v3/pkg/healthcheck"
 	"github.com/traefik/traefik/v3/pkg/middlewares/accesslog"
+	"github.com/traefik/traefik/v3/pkg/middlewares/observability"
 	"github.com/traefik/traefik/v3/pkg/safe"
 )
 
@@ -24,6 +25,7 @@ type Mirroring struct {
 	mirrorHandlers []*mirrorHandler
 	rw             http.ResponseWriter
 	routinePool    *safe.Pool
+	comparator     *Comparator
 
 	mirrorBody       bool
 	maxBodySize      int64
@@ -48,6 +50,7 @@ func New(handler http.Handler, pool *safe.Pool, mirrorBody bool, maxBodySize int
 type mirrorHandler struct {
 	http.Handler
 
+	name    string
 	percent int
 
 	lock  sync.RWMutex
@@ -87,6 +90,12 @@ func (m *Mirroring) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
 		}
 	}
 
+	var primary *capturedResponse
+	if m.comparator != nil {
+		primary = &capturedResponse{}
+		rw = m.comparator.capture(rw, primary)
+	}
+
 	m.handler.ServeHTTP(rw, rr.Clone(req.Context()))
 
 	select {
@@ -97,6 +106,8 @@ func (m *Mirroring) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
 	default:
 	}
 
+	withMetrics := observability.MetricsEnabled(req.Context())
+
 	m.routinePool.GoCtx(func(_ context.Context) {
 		for _, handler := range mirrors {
 			// prepare request, update body from buffer
@@ -114,20 +125,35 @@ func (m *Mirroring) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
 			// which would trigger a cancellation of the ongoing mirrored requests.
 			// Therefore, we give a new, non-cancellable context  to each of the mirrored calls,
 			// so they can terminate by themselves.
-			handler.ServeHTTP(m.rw, r.WithContext(contextStopPropagation{ctx}))
+			r = r.WithContext(contextStopPropagation{ctx})
+
+			if m.comparator == nil {
+				handler.ServeHTTP(m.rw, r)
+				continue
+			}
+
+			resp := &capturedResponse{}
+			handler.ServeHTTP(m.comparator.capture(newDiscardResponseWriter(), resp), r)
+			m.comparator.report(handler.name, primary, resp, withMetrics)
 		}
 	})
 }
 
 // AddMirror adds an httpHandler to mirror to.
-func (m *Mirroring) AddMirror(handler http.Handler, percent int) error {
+func (m *Mirroring) AddMirror(name string, handler http.Handler, percent int) error {
 	if percent < 0 || percent > 100 {
 		return errors.New("percent must be between 0 and 100")
 	}
-	m.mirrorHandlers = append(m.mirrorHandlers, &mirrorHandler{Handler: handler, percent: percent})
+	m.mirrorHandlers = append(m.mirrorHandlers, &mirrorHandler{Handler: handler, name: name, percent: percent})
 	return nil
 }
 
+// SetComparator enables the comparison of the mirror responses with the main handler response.
+// Not thread safe.
+func (m *Mirroring) SetComparator(comparator *Comparator) {
+	m.comparator = comparator
+}
+
 // RegisterStatusUpdater adds fn to the list of hooks that are run when the
 // status of handler of the Mirroring changes.
 // Not thread safe.
@@ -160,10 +186,10 @@ func (m *Mirroring) inc() uint64 {
 	return m.total
 }
 
-func (m *Mirroring) getActiveMirrors() []http.Handler {
+func (m *Mirroring) getActiveMirrors() []*mirrorHandler {
 	total := m.inc()
 
-	var mirrors []http.Handler
+	var mirrors []*mirrorHandler
 	for _, handler := range m.mirrorHandlers {
 		handler.lock.Lock()
 		if handler.count*100 < total*uint64(handler.percent) {
@@ -21,12 +21,12 @@ func TestMirroringOn100(t *testing.T) {
 	})
 	pool := safe.NewPool(t.Context())
 	mirror := New(handler, pool, true, defaultMaxBodySize, nil)
-	err := mirror.AddMirror(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+	err := mirror.AddMirror("mirror", http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
 		atomic.AddInt32(&countMirror1, 1)
 	}), 10)
 	assert.NoError(t, err)
 
-	err = mirror.AddMirror(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+	err = mirror.AddMirror("mirror", http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
 		atomic.AddInt32(&countMirror2, 1)
 	}), 50)
 	assert.NoError(t, err)
@@ -50,12 +50,12 @@ func TestMirroringOn10(t *testing.T) {
 	})
 	pool := safe.NewPool(t.Context())
 	mirror := New(handler, pool, true, defaultMaxBodySize, nil)
-	err := mirror.AddMirror(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+	err := mirror.AddMirror("mirror", http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
 		atomic.AddInt32(&countMirror1, 1)
 	}), 10)
 	assert.NoError(t, err)
 
-	err = mirror.AddMirror(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+	err = mirror.AddMirror("mirror", http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
 		atomic.AddInt32(&countMirror2, 1)
 	}), 50)
 	assert.NoError(t, err)
@@ -74,16 +74,16 @@ func TestMirroringOn10(t *testing.T) {
 
 func TestInvalidPercent(t *testing.T) {
 	mirror := New(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {}), safe.NewPool(t.Context()), true, defaultMaxBodySize, nil)
-	err := mirror.AddMirror(nil, -1)
+	err := mirror.AddMirror("mirror", nil, -1)
 	assert.Error(t, err)
 
-	err = mirror.AddMirror(nil, 101)
+	err = mirror.AddMirror("mirror", nil, 101)
 	assert.Error(t, err)
 
-	err = mirror.AddMirror(nil, 100)
+	err = mirror.AddMirror("mirror", nil, 100)
 	assert.NoError(t, err)
 
-	err = mirror.AddMirror(nil, 0)
+	err = mirror.AddMirror("mirror", nil, 0)
 	assert.NoError(t, err)
 }
 
@@ -95,7 +95,7 @@ func TestHijack(t *testing.T) {
 	mirror := New(handler, pool, true, defaultMaxBodySize, nil)
 
 	var mirrorRequest bool
-	err := mirror.AddMirror(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+	err := mirror.AddMirror("mirror", http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
 		hijacker, ok := rw.(http.Hijacker)
 		assert.True(t, ok)
 
@@ -119,7 +119,7 @@ func TestFlush(t *testing.T) {
 	mirror := New(handler, pool, true, defaultMaxBodySize, nil)
 
 	var mirrorRequest bool
-	err := mirror.AddMirror(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+	err := mirror.AddMirror("mirror", http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
 		hijacker, ok := rw.(http.Flusher)
 		assert.True(t, ok)
 
@@ -156,7 +156,7 @@ func TestMirroringWithBody(t *testing.T) {
 	mirror := New(handler, pool, true, defaultMaxBodySize, nil)
 
 	for range numMirrors {
-		err := mirror.AddMirror(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		err := mirror.AddMirror("mirror", http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
 			assert.NotNil(t, r.Body)
 			bb, err := io.ReadAll(r.Body)
 			assert.NoError(t, err)
@@ -198,7 +198,7 @@ func TestMirroringWithIgnoredBody(t *testing.T) {
 	mirror := New(handler, pool, false, defaultMaxBodySize, nil)
 
 	for range numMirrors {
-		err := mirror.AddMirror(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		err := mirror.AddMirror("mirror", http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
 			assert.NotNil(t, r.Body)
 			bb, err := io.ReadAll(r.Body)
 			assert.NoError(t, err)
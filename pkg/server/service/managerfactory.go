@@ -6,6 +6,7 @@ import (
 	"github.com/gorilla/mux"
 	"github.com/rs/zerolog/log"
 	"github.com/traefik/traefik/v3/pkg/api"
+	"github.com/traefik/traefik/v3/pkg/api/auth"
 	"github.com/traefik/traefik/v3/pkg/api/dashboard"
 	"github.com/traefik/traefik/v3/pkg/config/runtime"
 	"github.com/traefik/traefik/v3/pkg/config/static"
@@ -26,13 +27,16 @@ type ManagerFactory struct {
 	dashboardHandler http.Handler
 	metricsHandler   http.Handler
 	pingHandler      http.Handler
+	readyHandler     http.Handler
 	acmeHTTPHandler  http.Handler
 
+	fileServerRoots []string
+
 	routinesPool *safe.Pool
 }
 
 // NewManagerFactory creates a new ManagerFactory.
-func NewManagerFactory(staticConfiguration static.Configuration, routinesPool *safe.Pool, observabilityMgr *middleware.ObservabilityMgr, transportManager *TransportManager, proxyBuilder ProxyBuilder, acmeHTTPHandler http.Handler) *ManagerFactory {
+func NewManagerFactory(staticConfiguration static.Configuration, routinesPool *safe.Pool, observabilityMgr *middleware.ObservabilityMgr, transportManager *TransportManager, proxyBuilder ProxyBuilder, acmeHTTPHandler http.Handler, supportDump api.SupportDumpSources) *ManagerFactory {
 	factory := &ManagerFactory{
 		observabilityMgr: observabilityMgr,
 		routinesPool:     routinesPool,
@@ -41,26 +45,34 @@ func NewManagerFactory(staticConfiguration static.Configuration, routinesPool *s
 		acmeHTTPHandler:  acmeHTTPHandler,
 	}
 
+	if staticConfiguration.FileServer != nil {
+		factory.fileServerRoots = staticConfiguration.FileServer.Roots
+	}
+
+	withAuth := newAPIAuth(staticConfiguration)
+
 	if staticConfiguration.API != nil {
-		apiRouterBuilder := api.NewBuilder(staticConfiguration)
+		apiRouterBuilder := api.NewBuilder(staticConfiguration, supportDump)
 
 		if staticConfiguration.API.Dashboard {
-			factory.dashboardHandler = dashboard.Handler{BasePath: staticConfiguration.API.BasePath}
+			factory.dashboardHandler = withAuth(dashboard.Handler{BasePath: staticConfiguration.API.BasePath})
 			factory.api = func(configuration *runtime.Configuration) http.Handler {
 				router := apiRouterBuilder(configuration).(*mux.Router)
 				if err := dashboard.Append(router, staticConfiguration.API.BasePath, nil); err != nil {
 					log.Error().Err(err).Msg("Error appending dashboard to API router")
 				}
 
-				return router
+				return withAuth(router)
 			}
 		} else {
-			factory.api = apiRouterBuilder
+			factory.api = func(configuration *runtime.Configuration) http.Handler {
+				return withAuth(apiRouterBuilder(configuration))
+			}
 		}
 	}
 
 	if staticConfiguration.Providers != nil && staticConfiguration.Providers.Rest != nil {
-		factory.restHandler = staticConfiguration.Providers.Rest.CreateRouter()
+		factory.restHandler = withAuth(auth.RequireWrite(staticConfiguration.Providers.Rest.CreateRouter()))
 	}
 
 	if staticConfiguration.Metrics != nil && staticConfiguration.Metrics.Prometheus != nil {
@@ -72,6 +84,7 @@ func NewManagerFactory(staticConfiguration static.Configuration, routinesPool *s
 	// and would break things elsewhere.
 	if staticConfiguration.Ping != nil {
 		factory.pingHandler = staticConfiguration.Ping
+		factory.readyHandler = staticConfiguration.Ping.Readiness()
 	}
 
 	return factory
@@ -84,6 +97,27 @@ func (f *ManagerFactory) Build(configuration *runtime.Configuration) *Manager {
 		apiHandler = f.api(configuration)
 	}
 
-	internalHandlers := NewInternalHandlers(apiHandler, f.restHandler, f.metricsHandler, f.pingHandler, f.dashboardHandler, f.acmeHTTPHandler)
-	return NewManager(configuration.Services, f.observabilityMgr, f.routinesPool, f.transportManager, f.proxyBuilder, internalHandlers)
+	internalHandlers := NewInternalHandlers(apiHandler, f.restHandler, f.metricsHandler, f.pingHandler, f.readyHandler, f.dashboardHandler, f.acmeHTTPHandler)
+	manager := NewManager(configuration.Services, f.observabilityMgr, f.routinesPool, f.transportManager, f.proxyBuilder, internalHandlers)
+	manager.SetFileServerRoots(f.fileServerRoots)
+
+	return manager
+}
+
+// newAPIAuth returns the function wrapping the API, dashboard and REST provider handlers with the API authentication,
+// which is a no-op when the authentication is not configured.
+func newAPIAuth(staticConfiguration static.Configuration) func(http.Handler) http.Handler {
+	if staticConfiguration.API == nil || staticConfiguration.API.Auth == nil {
+		return func(handler http.Handler) http.Handler { return handler }
+	}
+
+	authenticator, err := auth.New(staticConfiguration.API.Auth)
+	if err != nil {
+		// Should never happen because Traefik should not start if the configuration is invalid.
+		log.Error().Err(err).Msg("Invalid API authentication configuration, denying all the API requests")
+
+		authenticator, _ = auth.New(&auth.Config{})
+	}
+
+	return authenticator.Wrap
 }
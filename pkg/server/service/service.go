@@ -13,6 +13,7 @@ import (
 	"time"
 
 	"github.com/containous/alice"
+	gokitmetrics "github.com/go-kit/kit/metrics"
 	"github.com/rs/zerolog/log"
 	"github.com/traefik/traefik/v3/pkg/config/dynamic"
 	"github.com/traefik/traefik/v3/pkg/config/runtime"
@@ -28,6 +29,9 @@ import (
 	"github.com/traefik/traefik/v3/pkg/server/middleware"
 	"github.com/traefik/traefik/v3/pkg/server/provider"
 	"github.com/traefik/traefik/v3/pkg/server/recursion"
+	"github.com/traefik/traefik/v3/pkg/server/service/breaker"
+	"github.com/traefik/traefik/v3/pkg/server/service/drain"
+	"github.com/traefik/traefik/v3/pkg/server/service/fileserver"
 	"github.com/traefik/traefik/v3/pkg/server/service/loadbalancer/failover"
 	"github.com/traefik/traefik/v3/pkg/server/service/loadbalancer/hrw"
 	"github.com/traefik/traefik/v3/pkg/server/service/loadbalancer/leasttime"
@@ -65,6 +69,8 @@ type Manager struct {
 	healthCheckers         map[string]*healthcheck.ServiceHealthChecker
 	rand                   *rand.Rand // For the initial shuffling of load-balancers.
 	middlewareChainBuilder middlewareChainBuilder
+	drainManager           *drain.Manager
+	fileServerRoots        []string
 }
 
 // NewManager creates a new Manager.
@@ -87,6 +93,17 @@ func (m *Manager) SetMiddlewareChainBuilder(middlewareChainBuilder middlewareCha
 	m.middlewareChainBuilder = middlewareChainBuilder
 }
 
+// SetDrainManager sets the manager tracking the connections to the servers of the load-balancers,
+// to drain them once the servers are removed or marked unhealthy.
+func (m *Manager) SetDrainManager(drainManager *drain.Manager) {
+	m.drainManager = drainManager
+}
+
+// SetFileServerRoots sets the directories the file server services are allowed to serve files from.
+func (m *Manager) SetFileServerRoots(roots []string) {
+	m.fileServerRoots = roots
+}
+
 // BuildHTTP Creates a http.Handler for a service configuration.
 func (m *Manager) BuildHTTP(rootCtx context.Context, serviceName string) (http.Handler, error) {
 	serviceName = provider.GetQualifiedName(rootCtx, serviceName)
@@ -165,7 +182,7 @@ func (m *Manager) BuildHTTP(rootCtx context.Context, serviceName string) (http.H
 		}
 	case conf.Mirroring != nil:
 		var err error
-		lb, err = m.getMirrorServiceHandler(ctx, conf.Mirroring)
+		lb, err = m.getMirrorServiceHandler(ctx, serviceName, conf.Mirroring)
 		if err != nil {
 			conf.AddError(err, true)
 			return nil, err
@@ -177,6 +194,13 @@ func (m *Manager) BuildHTTP(rootCtx context.Context, serviceName string) (http.H
 			conf.AddError(err, true)
 			return nil, err
 		}
+	case conf.FileServer != nil:
+		var err error
+		lb, err = m.getFileServerServiceHandler(ctx, serviceName, conf.FileServer)
+		if err != nil {
+			conf.AddError(err, true)
+			return nil, err
+		}
 	default:
 		sErr := fmt.Errorf("the service %q does not have any type defined", serviceName)
 		conf.AddError(sErr, true)
@@ -261,7 +285,7 @@ func (m *Manager) getFailoverServiceHandler(ctx context.Context, serviceName str
 	return f, nil
 }
 
-func (m *Manager) getMirrorServiceHandler(ctx context.Context, config *dynamic.Mirroring) (http.Handler, error) {
+func (m *Manager) getMirrorServiceHandler(ctx context.Context, serviceName string, config *dynamic.Mirroring) (http.Handler, error) {
 	serviceHandler, err := m.BuildHTTP(ctx, config.Service)
 	if err != nil {
 		return nil, err
@@ -283,11 +307,25 @@ func (m *Manager) getMirrorServiceHandler(ctx context.Context, config *dynamic.M
 			return nil, err
 		}
 
-		err = handler.AddMirror(mirrorHandler, mirrorConfig.Percent)
+		err = handler.AddMirror(provider.GetQualifiedName(ctx, mirrorConfig.Name), mirrorHandler, mirrorConfig.Percent)
 		if err != nil {
 			return nil, err
 		}
 	}
+
+	if config.Compare != nil {
+		var counter gokitmetrics.Counter
+		if registry := m.observabilityMgr.MetricsRegistry(); registry != nil && registry.IsSvcEnabled() {
+			counter = registry.ServiceMirrorComparisonsCounter()
+		}
+
+		comparator, err := mirror.NewComparator(ctx, provider.GetQualifiedName(ctx, serviceName), *config.Compare, counter, m.observabilityMgr.MirrorComparisonLogger())
+		if err != nil {
+			return nil, fmt.Errorf("creating mirror responses comparator: %w", err)
+		}
+		handler.SetComparator(comparator)
+	}
+
 	return handler, nil
 }
 
@@ -403,6 +441,32 @@ func (m *Manager) getHRWServiceHandler(ctx context.Context, serviceName string,
 	return balancer, nil
 }
 
+func (m *Manager) getFileServerServiceHandler(ctx context.Context, serviceName string, config *dynamic.FileServer) (http.Handler, error) {
+	log.Ctx(ctx).Debug().Str("root", config.Root).Msg("Creating file server")
+
+	fileServer, err := fileserver.New(*config, m.fileServerRoots)
+	if err != nil {
+		return nil, fmt.Errorf("creating file server: %w", err)
+	}
+
+	qualifiedSvcName := provider.GetQualifiedName(ctx, serviceName)
+
+	// Access logs, metrics, and tracing middlewares are idempotent if the associated signal is disabled.
+	handler := accesslog.NewFieldHandler(fileServer, accesslog.ServiceName, qualifiedSvcName, accesslog.AddServiceFields)
+
+	metricsHandler := metricsMiddle.ServiceMetricsHandler(ctx, m.observabilityMgr.MetricsRegistry(), qualifiedSvcName)
+	metricsHandler = observability.WrapMiddleware(ctx, metricsHandler)
+
+	handler, err = alice.New().
+		Append(metricsHandler).
+		Then(handler)
+	if err != nil {
+		return nil, fmt.Errorf("error wrapping metrics handler: %w", err)
+	}
+
+	return observability.NewService(ctx, qualifiedSvcName, handler), nil
+}
+
 func (m *Manager) getLoadBalancerServiceHandler(ctx context.Context, serviceName string, info *runtime.ServiceInfo) (http.Handler, error) {
 	service := info.LoadBalancer
 
@@ -445,11 +509,36 @@ func (m *Manager) getLoadBalancerServiceHandler(ctx context.Context, serviceName
 		return nil, fmt.Errorf("unsupported load-balancer strategy %q", service.Strategy)
 	}
 
+	qualifiedSvcName := provider.GetQualifiedName(ctx, serviceName)
+
+	var drainer *drain.Service
+	if m.drainManager != nil {
+		drainer = m.drainManager.Service(qualifiedSvcName, time.Duration(service.DrainTimeout), info)
+	}
+
+	var statusSetter healthcheck.StatusSetter = lb
+	if drainer != nil && service.DrainTimeout > 0 {
+		statusSetter = drainer.WrapStatusSetter(lb)
+	}
+
+	var breakers *breaker.Breakers
+	if service.CircuitBreaker != nil {
+		var err error
+		breakers, err = breaker.New(serviceName, *service.CircuitBreaker, statusSetter, info, m.observabilityMgr.MetricsRegistry())
+		if err != nil {
+			return nil, err
+		}
+
+		// The health checks must not put back into the load-balancing a server whose breaker is open.
+		statusSetter = breakers
+	}
+
 	var passiveHealthChecker *healthcheck.PassiveServiceHealthChecker
 	if service.PassiveHealthCheck != nil {
 		passiveHealthChecker = healthcheck.NewPassiveHealthChecker(
 			serviceName,
-			lb,
+			statusSetter,
+			info,
 			service.PassiveHealthCheck.MaxFailedAttempts,
 			service.PassiveHealthCheck.FailureWindow,
 			service.HealthCheck != nil,
@@ -467,18 +556,30 @@ func (m *Manager) getLoadBalancerServiceHandler(ctx context.Context, serviceName
 		logger.Debug().Int(logs.ServerIndex, i).Str("URL", server.URL).
 			Msg("Creating server")
 
-		qualifiedSvcName := provider.GetQualifiedName(ctx, serviceName)
-
 		proxy, err := m.proxyBuilder.Build(service.ServersTransport, target, passHostHeader, server.PreservePath, flushInterval)
 		if err != nil {
 			return nil, fmt.Errorf("error building proxy for server URL %s: %w", server.URL, err)
 		}
 
+		if drainer != nil {
+			drainer.AddServer(server.URL)
+			if service.DrainTimeout > 0 {
+				proxy = drainer.WrapHTTP(server.URL, proxy)
+			}
+		}
+
 		if passiveHealthChecker != nil {
 			// If passive health check is enabled, we wrap the proxy with the passive health checker.
 			proxy = passiveHealthChecker.WrapHandler(ctx, proxy, target.String())
 		}
 
+		if breakers != nil {
+			proxy, err = breakers.WrapHandler(ctx, server.URL, target.String(), proxy)
+			if err != nil {
+				return nil, fmt.Errorf("error wrapping circuit breaker for server URL %s: %w", server.URL, err)
+			}
+		}
+
 		// The retry wrapping must be done just before the proxy handler,
 		// to make sure that the retry will not be triggered/disabled by
 		// middlewares in the chain.
@@ -519,7 +620,7 @@ func (m *Manager) getLoadBalancerServiceHandler(ctx context.Context, serviceName
 			ctx,
 			m.observabilityMgr.MetricsRegistry(),
 			service.HealthCheck,
-			lb,
+			statusSetter,
 			info,
 			roundTripper,
 			healthCheckTargets,
@@ -8,6 +8,8 @@ import (
 	"net/http/httptest"
 	"net/http/httptrace"
 	"net/textproto"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -82,6 +84,32 @@ func TestGetLoadBalancer(t *testing.T) {
 			fwd:         &forwarderMock{},
 			expectError: false,
 		},
+		{
+			desc:        "Succeeds when circuit breaker is set",
+			serviceName: "test",
+			service: &dynamic.ServersLoadBalancer{
+				Strategy: dynamic.BalancerStrategyWRR,
+				CircuitBreaker: &dynamic.ServerCircuitBreaker{
+					Expression:       "RequestCount() > 10 && NetworkErrorRatio() > 0.5",
+					FallbackDuration: ptypes.Duration(10 * time.Second),
+					TrialRequests:    1,
+				},
+			},
+			fwd:         &forwarderMock{},
+			expectError: false,
+		},
+		{
+			desc:        "Fails when circuit breaker expression is invalid",
+			serviceName: "test",
+			service: &dynamic.ServersLoadBalancer{
+				Strategy: dynamic.BalancerStrategyWRR,
+				CircuitBreaker: &dynamic.ServerCircuitBreaker{
+					Expression: "Foo() > 0.5",
+				},
+			},
+			fwd:         &forwarderMock{},
+			expectError: true,
+		},
 		{
 			desc:        "Fails when unsupported strategy is set",
 			serviceName: "test",
@@ -593,6 +621,40 @@ func TestManager_Build(t *testing.T) {
 	}
 }
 
+func TestManager_BuildFileServer(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "index.html"), []byte("maintenance"), 0o644))
+
+	services := map[string]*runtime.ServiceInfo{
+		"static@file": {
+			Service: &dynamic.Service{
+				FileServer: &dynamic.FileServer{Root: dir},
+			},
+		},
+		"missing@file": {
+			Service: &dynamic.Service{
+				FileServer: &dynamic.FileServer{Root: filepath.Join(dir, "missing")},
+			},
+		},
+	}
+
+	manager := NewManager(services, nil, nil, &transportManagerMock{}, nil)
+	manager.SetFileServerRoots([]string{dir})
+
+	handler, err := manager.BuildHTTP(t.Context(), "static@file")
+	require.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "http://localhost/", nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "maintenance", rec.Body.String())
+
+	_, err = manager.BuildHTTP(t.Context(), "missing@file")
+	require.Error(t, err)
+	assert.NotEmpty(t, services["missing@file"].Err)
+}
+
 func TestMultipleTypeOnBuildHTTP(t *testing.T) {
 	services := map[string]*runtime.ServiceInfo{
 		"test@file": {
@@ -15,6 +15,7 @@ import (
 	"github.com/traefik/traefik/v3/pkg/healthcheck"
 	"github.com/traefik/traefik/v3/pkg/observability/logs"
 	"github.com/traefik/traefik/v3/pkg/server/provider"
+	"github.com/traefik/traefik/v3/pkg/server/service/drain"
 	"github.com/traefik/traefik/v3/pkg/tcp"
 )
 
@@ -24,6 +25,7 @@ type Manager struct {
 	configs        map[string]*runtime.TCPServiceInfo
 	rand           *rand.Rand // For the initial shuffling of load-balancers.
 	healthCheckers map[string]*healthcheck.ServiceTCPHealthChecker
+	drainManager   *drain.Manager
 }
 
 // NewManager creates a new manager.
@@ -36,6 +38,12 @@ func NewManager(conf *runtime.Configuration, dialerManager *tcp.DialerManager) *
 	}
 }
 
+// SetDrainManager sets the manager tracking the connections to the servers of the load-balancers,
+// to drain them once the servers are removed or marked unhealthy.
+func (m *Manager) SetDrainManager(drainManager *drain.Manager) {
+	m.drainManager = drainManager
+}
+
 // BuildTCP Creates a tcp.Handler for a service configuration.
 func (m *Manager) BuildTCP(rootCtx context.Context, serviceName string) (tcp.Handler, error) {
 	serviceQualifiedName := provider.GetQualifiedName(rootCtx, serviceName)
@@ -70,6 +78,11 @@ func (m *Manager) BuildTCP(rootCtx context.Context, serviceName string) (tcp.Han
 			conf.LoadBalancer.ServersTransport = provider.GetQualifiedName(ctx, conf.LoadBalancer.ServersTransport)
 		}
 
+		var drainer *drain.Service
+		if m.drainManager != nil {
+			drainer = m.drainManager.Service(serviceQualifiedName, time.Duration(conf.LoadBalancer.DrainTimeout), conf)
+		}
+
 		uniqHealthCheckTargets := make(map[string]healthcheck.TCPHealthCheckTarget, len(conf.LoadBalancer.Servers))
 
 		for index, server := range shuffle(conf.LoadBalancer.Servers, m.rand) {
@@ -87,12 +100,20 @@ func (m *Manager) BuildTCP(rootCtx context.Context, serviceName string) (tcp.Han
 				return nil, err
 			}
 
-			handler, err := tcp.NewProxy(server.Address, dialer)
+			var handler tcp.Handler
+			handler, err = tcp.NewProxy(server.Address, dialer)
 			if err != nil {
 				srvLogger.Error().Err(err).Msg("Failed to create server")
 				continue
 			}
 
+			if drainer != nil {
+				drainer.AddServer(server.Address)
+				if conf.LoadBalancer.DrainTimeout > 0 {
+					handler = drainer.WrapTCP(server.Address, handler)
+				}
+			}
+
 			loadBalancer.Add(server.Address, tcp.NewFieldHandler(handler, map[string]string{
 				tcp.ServiceURL: fmt.Sprintf("%s://%s", func() string {
 					if server.TLS {
@@ -116,10 +137,15 @@ func (m *Manager) BuildTCP(rootCtx context.Context, serviceName string) (tcp.Han
 		}
 
 		if conf.LoadBalancer.HealthCheck != nil {
+			var statusSetter healthcheck.StatusSetter = loadBalancer
+			if drainer != nil && conf.LoadBalancer.DrainTimeout > 0 {
+				statusSetter = drainer.WrapStatusSetter(loadBalancer)
+			}
+
 			m.healthCheckers[serviceName] = healthcheck.NewServiceTCPHealthChecker(
 				ctx,
 				conf.LoadBalancer.HealthCheck,
-				loadBalancer,
+				statusSetter,
 				conf,
 				slices.Collect(maps.Values(uniqHealthCheckTargets)),
 				serviceQualifiedName)
@@ -327,25 +327,37 @@ func (t *TransportManager) createRoundTripper(cfg *dynamic.ServersTransport, tls
 		transport.DialContext = customDialContext(dialer, cfg.ForwardingTimeouts)
 	}
 
-	// Return directly HTTP/1.1 transport when HTTP/2 is disabled
-	if cfg.DisableHTTP2 {
-		return &kerberosRoundTripper{
-			OriginalRoundTripper: transport,
-			new: func() http.RoundTripper {
-				return transport.Clone()
-			},
-		}, nil
+	var rt http.RoundTripper = transport
+	newRT := func() http.RoundTripper {
+		return transport.Clone()
 	}
 
-	rt, err := newSmartRoundTripper(transport, cfg.ForwardingTimeouts)
-	if err != nil {
-		return nil, err
+	// Use directly HTTP/1.1 transport when HTTP/2 is disabled
+	if !cfg.DisableHTTP2 {
+		smartRT, err := newSmartRoundTripper(transport, cfg.ForwardingTimeouts)
+		if err != nil {
+			return nil, err
+		}
+
+		rt = smartRT
+		newRT = func() http.RoundTripper {
+			return smartRT.Clone()
+		}
 	}
+
+	// The connections authenticated with Kerberos or NTLM are kept on TCP,
+	// hence the HTTP/3 round tripper is only used as the original round tripper.
+	if cfg.HTTP3 != nil {
+		if cfg.Proxy != "" {
+			return nil, errors.New("HTTP/3 cannot be used with a network proxy")
+		}
+
+		rt = newHTTP3RoundTripper(cfg, tlsConfig, rt)
+	}
+
 	return &kerberosRoundTripper{
 		OriginalRoundTripper: rt,
-		new: func() http.RoundTripper {
-			return rt.Clone()
-		},
+		new:                  newRT,
 	}, nil
 }
 
@@ -17,6 +17,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/quic-go/quic-go/http3"
 	"github.com/rs/zerolog/log"
 	"github.com/spiffe/go-spiffe/v2/bundle/x509bundle"
 	"github.com/spiffe/go-spiffe/v2/spiffeid"
@@ -24,6 +25,7 @@ import (
 	"github.com/spiffe/go-spiffe/v2/svid/x509svid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	ptypes "github.com/traefik/paerser/types"
 	"github.com/traefik/traefik/v3/pkg/config/dynamic"
 	traefiktls "github.com/traefik/traefik/v3/pkg/tls"
 	"github.com/traefik/traefik/v3/pkg/types"
@@ -778,6 +780,150 @@ func TestDisableHTTP2(t *testing.T) {
 	}
 }
 
+func TestHTTP3(t *testing.T) {
+	testCases := []struct {
+		desc               string
+		serverHTTP3        bool
+		disableTCPFallback bool
+		expectedProto      string
+		expectedErr        bool
+	}{
+		{
+			desc:          "HTTP3 server",
+			serverHTTP3:   true,
+			expectedProto: "HTTP/3.0",
+		},
+		{
+			desc:          "fallback to TCP",
+			expectedProto: "HTTP/1.1",
+		},
+		{
+			desc:               "fallback to TCP disabled",
+			disableTCPFallback: true,
+			expectedErr:        true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			cert, err := tls.X509KeyPair(LocalhostCert, LocalhostKey)
+			require.NoError(t, err)
+
+			handler := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				rw.WriteHeader(http.StatusOK)
+			})
+
+			var serverURL string
+			if test.serverHTTP3 {
+				conn, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+				require.NoError(t, err)
+
+				srv := &http3.Server{
+					Handler:   handler,
+					TLSConfig: http3.ConfigureTLSConfig(&tls.Config{Certificates: []tls.Certificate{cert}}),
+				}
+				go func() { _ = srv.Serve(conn) }()
+
+				t.Cleanup(func() {
+					_ = srv.Close()
+					_ = conn.Close()
+				})
+
+				serverURL = "https://" + conn.LocalAddr().String()
+			} else {
+				srv := httptest.NewUnstartedServer(handler)
+				srv.TLS = &tls.Config{Certificates: []tls.Certificate{cert}}
+				srv.StartTLS()
+				t.Cleanup(srv.Close)
+
+				serverURL = srv.URL
+			}
+
+			transportManager := NewTransportManager(nil)
+
+			dynamicConf := map[string]*dynamic.ServersTransport{
+				"test": {
+					ServerName: "example.com",
+					RootCAs:    []types.FileOrContent{types.FileOrContent(LocalhostCert)},
+					ForwardingTimeouts: &dynamic.ForwardingTimeouts{
+						DialTimeout: ptypes.Duration(time.Second),
+					},
+					HTTP3: &dynamic.ServersTransportHTTP3{
+						DisableTCPFallback: test.disableTCPFallback,
+						FallbackDuration:   dynamic.DefaultHTTP3FallbackDuration,
+					},
+				},
+			}
+
+			transportManager.Update(dynamicConf)
+
+			tr, err := transportManager.GetRoundTripper("test")
+			require.NoError(t, err)
+
+			client := http.Client{Transport: tr}
+
+			resp, err := client.Get(serverURL)
+			if test.expectedErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+			assert.Equal(t, test.expectedProto, resp.Proto)
+		})
+	}
+}
+
+func TestHTTP3WithProxy(t *testing.T) {
+	transportManager := NewTransportManager(nil)
+
+	_, err := transportManager.createRoundTripper(&dynamic.ServersTransport{
+		Proxy: "socks5://127.0.0.1:1080",
+		HTTP3: &dynamic.ServersTransportHTTP3{},
+	}, nil)
+	require.Error(t, err)
+}
+
+func TestHTTP3IdleTimeout(t *testing.T) {
+	testCases := []struct {
+		desc            string
+		cfg             *dynamic.ServersTransport
+		expectedTimeout time.Duration
+	}{
+		{
+			desc: "forwarding timeouts",
+			cfg: &dynamic.ServersTransport{
+				IdleConnTimeout: ptypes.Duration(10 * time.Second),
+				ForwardingTimeouts: &dynamic.ForwardingTimeouts{
+					IdleConnTimeout: ptypes.Duration(20 * time.Second),
+				},
+				HTTP3: &dynamic.ServersTransportHTTP3{},
+			},
+			expectedTimeout: 20 * time.Second,
+		},
+		{
+			desc: "without forwarding timeouts",
+			cfg: &dynamic.ServersTransport{
+				IdleConnTimeout: ptypes.Duration(10 * time.Second),
+				HTTP3:           &dynamic.ServersTransportHTTP3{},
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			rt := newHTTP3RoundTripper(test.cfg, nil, http.DefaultTransport)
+
+			assert.Equal(t, test.expectedTimeout, rt.http3.QUICConfig.MaxIdleTimeout)
+		})
+	}
+}
+
 // fakeSpiffePKI simulates a SPIFFE aware PKI and allows generating multiple valid SVIDs.
 type fakeSpiffePKI struct {
 	caPrivateKey *rsa.PrivateKey
@@ -3,10 +3,14 @@ package server
 import (
 	"errors"
 	"net"
+	"sync"
 )
 
 type SocketActivation struct {
-	enabled   bool
+	enabled bool
+
+	// lock protects the sockets below, as the dynamic entry points are built while the server is running.
+	lock      sync.Mutex
 	listeners map[string]net.Listener
 	conns     map[string]net.PacketConn
 }
@@ -15,21 +19,35 @@ func (s *SocketActivation) isEnabled() bool {
 	return s.enabled
 }
 
+// getListener returns the TCP listener of the given entry point.
+// A listener can only be used once, so that a dynamic entry point which is re-created opens a new one.
 func (s *SocketActivation) getListener(name string) (net.Listener, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
 	listener, ok := s.listeners[name]
 	if !ok {
 		return nil, errors.New("unable to find socket activation TCP listener for entryPoint")
 	}
 
+	delete(s.listeners, name)
+
 	return listener, nil
 }
 
+// getConn returns the UDP listener of the given entry point.
+// A listener can only be used once, as for TCP listeners.
 func (s *SocketActivation) getConn(name string) (net.PacketConn, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
 	conn, ok := s.conns[name]
 	if !ok {
 		return nil, errors.New("unable to find socket activation UDP listener for entryPoint")
 	}
 
+	delete(s.conns, name)
+
 	return conn, nil
 }
 
@@ -4,6 +4,7 @@ package server
 
 import (
 	"net"
+	"os"
 
 	"github.com/coreos/go-systemd/v22/activation"
 	"github.com/rs/zerolog/log"
@@ -12,6 +13,11 @@ import (
 func populateSocketActivationListeners() *SocketActivation {
 	// We use Files api due to activation not providing method for get PacketConn with names
 	files := activation.Files(true)
+	if len(files) == 0 {
+		// The sockets can also be handed over by a previous Traefik process.
+		files = handoverFiles()
+	}
+
 	sa := &SocketActivation{enabled: false}
 	sa.listeners = make(map[string]net.Listener)
 	sa.conns = make(map[string]net.PacketConn)
@@ -20,25 +26,29 @@ func populateSocketActivationListeners() *SocketActivation {
 		sa.enabled = true
 
 		for _, f := range files {
-			if lc, err := net.FileListener(f); err == nil {
-				_, ok := sa.listeners[f.Name()]
-				if ok {
-					log.Error().Str("listenersName", f.Name()).Msg("Socket activation TCP listeners must have one and only one listener per name")
-				} else {
-					sa.listeners[f.Name()] = lc
-				}
-				f.Close()
-			} else if pc, err := net.FilePacketConn(f); err == nil {
-				_, ok := sa.conns[f.Name()]
-				if ok {
-					log.Error().Str("listenersName", f.Name()).Msg("Socket activation UDP listeners must have one and only one listener per name")
-				} else {
-					sa.conns[f.Name()] = pc
-				}
-				f.Close()
-			}
+			populateSocketActivationFile(sa, f)
 		}
 	}
 
 	return sa
 }
+
+func populateSocketActivationFile(sa *SocketActivation, f *os.File) {
+	if lc, err := net.FileListener(f); err == nil {
+		_, ok := sa.listeners[f.Name()]
+		if ok {
+			log.Error().Str("listenersName", f.Name()).Msg("Socket activation TCP listeners must have one and only one listener per name")
+		} else {
+			sa.listeners[f.Name()] = lc
+		}
+		f.Close()
+	} else if pc, err := net.FilePacketConn(f); err == nil {
+		_, ok := sa.conns[f.Name()]
+		if ok {
+			log.Error().Str("listenersName", f.Name()).Msg("Socket activation UDP listeners must have one and only one listener per name")
+		} else {
+			sa.conns[f.Name()] = pc
+		}
+		f.Close()
+	}
+}
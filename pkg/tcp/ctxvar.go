@@ -17,25 +17,27 @@ import (
 )
 
 const (
-	RequestClientAddr = "rc_client_addr"
-	RequestServerAddr = "rc_server_addr"
-	RequestTLSVersion = "rc_tls_version"
-	RequestTLSCipher  = "rc_tls_cipher"
-	RequestTLSSNI     = "rc_tls_sni"
-	RequestProtocol   = "rc_protocol"
-	ProxyClientAddr   = "pc_client_addr"
-	ProxyServerAddr   = "pc_server_addr"
-	ProxyTLSVersion   = "rc_tls_version"
-	ProxyTLSCipher    = "rc_tls_cipher"
-	ProxyTLSSNI       = "rc_tls_sni"
-	ProxyProtocol     = "pc_protocol"
-	ServiceURL        = "service_url"
-	ServiceName       = "service_name"
-	ServiceAddr       = "service_addr"
-	RouterName        = "router_name"
-	Timestamp         = "timestamp"
-	Status            = "status"
-	TraceID           = "trace_id"
+	RequestClientAddr   = "rc_client_addr"
+	RequestServerAddr   = "rc_server_addr"
+	RequestTLSVersion   = "rc_tls_version"
+	RequestTLSCipher    = "rc_tls_cipher"
+	RequestTLSSNI       = "rc_tls_sni"
+	RequestTLSALPN      = "rc_tls_alpn"
+	RequestProtocol     = "rc_protocol"
+	ProxyClientAddr     = "pc_client_addr"
+	ProxyServerAddr     = "pc_server_addr"
+	ProxyTLSVersion     = "rc_tls_version"
+	ProxyTLSCipher      = "rc_tls_cipher"
+	ProxyTLSSNI         = "rc_tls_sni"
+	ProxyProtocol       = "pc_protocol"
+	ProxyProtocolSource = "pp_source_addr"
+	ServiceURL          = "service_url"
+	ServiceName         = "service_name"
+	ServiceAddr         = "service_addr"
+	RouterName          = "router_name"
+	Timestamp           = "timestamp"
+	Status              = "status"
+	TraceID             = "trace_id"
 )
 
 func NewNextConn(conn WriteCloser) NextConn {
@@ -142,9 +144,8 @@ type FieldHandler struct {
 
 func (that *FieldHandler) ServeTCP(conn WriteCloser) {
 	that.h.ServeTCP(conn)
-	if next, ok := conn.(NextConn); ok {
-		contextProvider.Set(next.Context(), that.kvs(next.Context(), conn))
-	}
+	ctx := ContextOf(conn)
+	contextProvider.Set(ctx, that.kvs(ctx, conn))
 }
 
 func ProvideContext(cp ContextProvider) {
@@ -152,19 +153,33 @@ func ProvideContext(cp ContextProvider) {
 }
 
 func ContextVars(conn WriteCloser, kvs ...map[string]string) map[string]string {
-	next, ok := conn.(NextConn)
-	if !ok {
-		return map[string]string{}
-	}
+	ctx := ContextOf(conn)
 	for _, kv := range kvs {
-		contextProvider.Set(next.Context(), kv)
+		contextProvider.Set(ctx, kv)
 	}
-	if v := contextProvider.Get(next.Context()); nil != v {
+	if v := contextProvider.Get(ctx); nil != v {
 		return v
 	}
 	return map[string]string{}
 }
 
+// ContextOf returns the context carried by the given connection,
+// looking through the connections wrapping it (e.g. *tls.Conn).
+// It returns context.Background() if no context is found.
+func ContextOf(conn net.Conn) context.Context {
+	for conn != nil {
+		switch c := conn.(type) {
+		case interface{ Context() context.Context }:
+			return c.Context()
+		case interface{ NetConn() net.Conn }:
+			conn = c.NetConn()
+		default:
+			return context.Background()
+		}
+	}
+	return context.Background()
+}
+
 var contextProvider ContextProvider = new(dftContextProvider)
 
 type ContextProvider interface {
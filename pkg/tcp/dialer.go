@@ -21,6 +21,9 @@ import (
 	next "github.com/traefik/traefik/v3/pkg/server/dialer"
 	traefiktls "github.com/traefik/traefik/v3/pkg/tls"
 	"github.com/traefik/traefik/v3/pkg/types"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // Dialer is an interface to dial a network connection, with support for PROXY protocol and termination delay.
@@ -59,6 +62,11 @@ func (d tcpDialer) DialContext(ctx context.Context, network, addr string, client
 			_ = conn.Close()
 			return nil, fmt.Errorf("writing PROXY Protocol header: %w", err)
 		}
+
+		trace.SpanFromContext(ctx).SetAttributes(
+			attribute.Int("traefik.proxy_protocol.version", d.proxyProtocol.Version),
+			attribute.String("traefik.proxy_protocol.source", clientConn.RemoteAddr().String()),
+		)
 	}
 
 	return conn, nil
@@ -89,6 +97,9 @@ func (d tcpTLSDialer) DialContext(ctx context.Context, network, addr string, cli
 		return nil, fmt.Errorf("TLS handshake failed: %w", err)
 	}
 
+	state := tlsConn.ConnectionState()
+	trace.SpanFromContext(ctx).SetAttributes(semconv.TLSProtocolVersion(traefiktls.GetVersion(&state)))
+
 	return tlsConn, nil
 }
 
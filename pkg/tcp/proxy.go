@@ -1,15 +1,20 @@
 package tcp
 
 import (
+	"context"
 	"crypto/tls"
 	"errors"
 	"io"
 	"net"
+	"strconv"
 	"syscall"
 	"time"
 
 	"github.com/rs/zerolog/log"
 	traefiktls "github.com/traefik/traefik/v3/pkg/tls"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // Proxy forwards a TCP request to a TCP service.
@@ -36,9 +41,10 @@ func (p *Proxy) ServeTCP(conn WriteCloser) {
 	// needed because of e.g. server.trackedConnection
 	defer conn.Close()
 
-	connBackend, err := p.dialBackend(conn)
+	connBackend, err := p.dialBackend(ContextOf(conn), conn)
 	if err != nil {
 		log.Error().Err(err).Msg("Error while dialing backend")
+		ContextVars(conn, map[string]string{Status: err.Error()})
 		return
 	}
 
@@ -68,34 +74,62 @@ func (p *Proxy) ServeTCP(conn WriteCloser) {
 
 	<-errChan
 
+	dict := map[string]string{Status: "Y"}
+	if nil != err {
+		dict[Status] = err.Error()
+	}
 	if tc, ok := connBackend.(*tls.Conn); ok {
 		state := tc.ConnectionState()
-		dict := map[string]string{
-			ProxyTLSVersion: traefiktls.GetVersion(&state),
-			ProxyTLSCipher:  traefiktls.GetCipherName(&state),
-			ProxyTLSSNI:     state.ServerName,
-			ProxyProtocol:   "TLS",
-		}
-		if nil != err {
-			dict[Status] = err.Error()
-		} else {
-			dict[Status] = "Y"
-		}
-		ContextVars(conn, dict)
+		dict[ProxyTLSVersion] = traefiktls.GetVersion(&state)
+		dict[ProxyTLSCipher] = traefiktls.GetCipherName(&state)
+		dict[ProxyTLSSNI] = state.ServerName
+		dict[ProxyProtocol] = "TLS"
 	}
+	ContextVars(conn, dict)
 }
 
-func (p *Proxy) dialBackend(clientConn net.Conn) (WriteCloser, error) {
+func (p *Proxy) dialBackend(ctx context.Context, clientConn net.Conn) (WriteCloser, error) {
+	ctx, span := startDialSpan(ctx, p.address)
+	defer span.End()
+
 	// The clientConn is passed to the dialer so that it can use information from it if needed,
 	// to build a PROXY protocol header.
-	conn, err := p.dialer.Dial("tcp", p.address, clientConn)
+	conn, err := p.dialer.DialContext(ctx, "tcp", p.address, clientConn)
 	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
 		return nil, err
 	}
 
+	span.SetAttributes(semconv.NetworkLocalAddress(conn.LocalAddr().String()))
+
 	return conn.(WriteCloser), nil
 }
 
+// startDialSpan starts a client span for dialing the given backend address,
+// if the given context holds a span created by the Traefik tracer.
+// Otherwise, it returns a non-recording span.
+//
+//nolint:spancheck
+func startDialSpan(ctx context.Context, address string) (context.Context, trace.Span) {
+	if !trace.SpanContextFromContext(ctx).IsValid() {
+		return ctx, trace.SpanFromContext(context.Background())
+	}
+
+	tracer := trace.SpanFromContext(ctx).TracerProvider().Tracer("github.com/traefik/traefik")
+	ctx, span := tracer.Start(ctx, "TCP Dial", trace.WithSpanKind(trace.SpanKindClient))
+
+	span.SetAttributes(semconv.NetworkTransportTCP)
+	if host, port, err := net.SplitHostPort(address); err == nil {
+		span.SetAttributes(semconv.ServerAddress(host))
+		if p, err := strconv.Atoi(port); err == nil {
+			span.SetAttributes(semconv.ServerPort(p))
+		}
+	}
+
+	return ctx, span
+}
+
 func (p *Proxy) connCopy(dst, src WriteCloser, errCh chan error) {
 	_, err := io.Copy(dst, src)
 	errCh <- err
@@ -27,10 +27,15 @@ func (t *TLSHandler) ServeTCP(conn WriteCloser) {
 	}
 }
 
+// TLSServer returns a TLSHandler terminating the TLS connections with the given config,
+// and recording the TLS connection state in the context variables once handled by next.
 func TLSServer(next Handler, config *tls.Config, plugin map[string]any, forwarder Handler) Handler {
-	return NewFieldFnHandler(&TLSHandler{Next: next, Config: config, Plugin: plugin, Forwarder: forwarder}, func(ctx context.Context, conn WriteCloser) map[string]string {
+	next = NewFieldFnHandler(next, func(ctx context.Context, conn WriteCloser) map[string]string {
 		if tc, ok := conn.(*tls.Conn); ok {
 			state := tc.ConnectionState()
+			if !state.HandshakeComplete {
+				return map[string]string{}
+			}
 			return map[string]string{
 				RequestTLSVersion: traefiktls.GetVersion(&state),
 				RequestTLSCipher:  traefiktls.GetCipherName(&state),
@@ -40,4 +45,6 @@ func TLSServer(next Handler, config *tls.Config, plugin map[string]any, forwarde
 		}
 		return map[string]string{}
 	})
+
+	return &TLSHandler{Next: next, Config: config, Plugin: plugin, Forwarder: forwarder}
 }
@@ -93,20 +93,10 @@ func (o *ocspStapler) Upsert(key string, leaf, issuer *x509.Certificate) error {
 		return nil
 	}
 
-	var responders []string
-	for _, url := range leaf.OCSPServer {
-		if len(o.responderOverrides) > 0 {
-			if newURL, ok := o.responderOverrides[url]; ok {
-				url = newURL
-			}
-		}
-		responders = append(responders, url)
-	}
-
 	o.cache.Set(key, &ocspEntry{
 		leaf:       leaf,
 		issuer:     issuer,
-		responders: responders,
+		responders: ocspResponders(leaf, o.responderOverrides),
 	}, cache.NoExpiration)
 
 	return nil
@@ -147,62 +137,90 @@ func (o *ocspStapler) updateStaples(ctx context.Context) {
 	}
 }
 
-// obtainStaple obtains the OCSP stable for the given leaf certificate.
+// updateStaple obtains the OCSP staple for the given leaf certificate.
 func (o *ocspStapler) updateStaple(ctx context.Context, entry *ocspEntry) error {
-	ocspReq, err := ocsp.CreateRequest(entry.leaf, entry.issuer, nil)
+	ocspResBytes, ocspRes, err := fetchOCSPResponse(ctx, o.client, entry.leaf, entry.issuer, entry.responders)
+	if err != nil {
+		return err
+	}
+
+	entry.staple = ocspResBytes
+
+	// As per RFC 6960, the nextUpdate field is optional.
+	if ocspRes.NextUpdate.IsZero() {
+		// NextUpdate is not set, the staple should be updated on the next update.
+		entry.nextUpdate = time.Now()
+	} else {
+		entry.nextUpdate = ocspRes.ThisUpdate.Add(ocspRes.NextUpdate.Sub(ocspRes.ThisUpdate) / 2)
+	}
+
+	return nil
+}
+
+// ocspResponders returns the OCSP responders advertised by the given certificate,
+// replaced by their overrides if any.
+func ocspResponders(leaf *x509.Certificate, responderOverrides map[string]string) []string {
+	var responders []string
+	for _, url := range leaf.OCSPServer {
+		if len(responderOverrides) > 0 {
+			if newURL, ok := responderOverrides[url]; ok {
+				url = newURL
+			}
+		}
+		responders = append(responders, url)
+	}
+
+	return responders
+}
+
+// fetchOCSPResponse queries the given responders, in order, for the OCSP status of the leaf certificate,
+// and returns the first valid response.
+func fetchOCSPResponse(ctx context.Context, client *http.Client, leaf, issuer *x509.Certificate, responders []string) ([]byte, *ocsp.Response, error) {
+	ocspReq, err := ocsp.CreateRequest(leaf, issuer, nil)
 	if err != nil {
-		return fmt.Errorf("creating OCSP request: %w", err)
+		return nil, nil, fmt.Errorf("creating OCSP request: %w", err)
 	}
 
-	for _, responder := range entry.responders {
+	for _, responder := range responders {
 		logger := log.With().Str("responder", responder).Logger()
 
 		req, err := http.NewRequestWithContext(ctx, http.MethodPost, responder, bytes.NewReader(ocspReq))
 		if err != nil {
-			return fmt.Errorf("creating OCSP request: %w", err)
+			return nil, nil, fmt.Errorf("creating OCSP request: %w", err)
 		}
 
 		req.Header.Set("Content-Type", "application/ocsp-request")
 
-		res, err := o.client.Do(req)
+		res, err := client.Do(req)
 		if err != nil && ctx.Err() != nil {
-			return ctx.Err()
+			return nil, nil, ctx.Err()
 		}
 		if err != nil {
 			logger.Debug().Err(err).Msg("Unable to obtain OCSP response")
 			continue
 		}
-		defer res.Body.Close()
+
+		ocspResBytes, err := io.ReadAll(res.Body)
+		_ = res.Body.Close()
 
 		if res.StatusCode/100 != 2 {
 			logger.Debug().Msgf("Unable to obtain OCSP response due to status code: %d", res.StatusCode)
 			continue
 		}
 
-		ocspResBytes, err := io.ReadAll(res.Body)
 		if err != nil {
 			logger.Debug().Err(err).Msg("Unable to read OCSP response bytes")
 			continue
 		}
 
-		ocspRes, err := ocsp.ParseResponseForCert(ocspResBytes, entry.leaf, entry.issuer)
+		ocspRes, err := ocsp.ParseResponseForCert(ocspResBytes, leaf, issuer)
 		if err != nil {
 			logger.Debug().Err(err).Msg("Unable to parse OCSP response")
 			continue
 		}
 
-		entry.staple = ocspResBytes
-
-		// As per RFC 6960, the nextUpdate field is optional.
-		if ocspRes.NextUpdate.IsZero() {
-			// NextUpdate is not set, the staple should be updated on the next update.
-			entry.nextUpdate = time.Now()
-		} else {
-			entry.nextUpdate = ocspRes.ThisUpdate.Add(ocspRes.NextUpdate.Sub(ocspRes.ThisUpdate) / 2)
-		}
-
-		return nil
+		return ocspResBytes, ocspRes, nil
 	}
 
-	return errors.New("no OCSP staple obtained from any responders")
+	return nil, nil, errors.New("no OCSP response obtained from any responders")
 }
@@ -0,0 +1,515 @@
+package tls
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	gokitmetrics "github.com/go-kit/kit/metrics"
+	"github.com/patrickmn/go-cache"
+	"github.com/rs/zerolog/log"
+	"github.com/traefik/traefik/v3/pkg/types"
+	"golang.org/x/crypto/ocsp"
+)
+
+const (
+	defaultCRLRefreshInterval = time.Hour
+	crlRefreshTick            = time.Minute
+
+	// ocspCheckTimeout bounds the time spent querying OCSP responders during a handshake.
+	ocspCheckTimeout = 5 * time.Second
+	// ocspFailureCacheDuration is how long an undetermined OCSP status is cached,
+	// to avoid querying unreachable responders on each handshake.
+	ocspFailureCacheDuration = time.Minute
+)
+
+// Revocation check methods and results, used as metric labels.
+const (
+	revocationMethodCRL  = "crl"
+	revocationMethodOCSP = "ocsp"
+
+	revocationStatusGood    = "good"
+	revocationStatusRevoked = "revoked"
+	revocationStatusUnknown = "unknown"
+)
+
+// crl is a parsed certificate revocation list.
+type crl struct {
+	list    *x509.RevocationList
+	revoked map[string]struct{}
+
+	// signatures caches the result of the signature verification against a given issuer (hash).
+	signatures sync.Map
+}
+
+// signedBy reports whether the revocation list has been issued by the given certificate.
+func (c *crl) signedBy(issuer *x509.Certificate) bool {
+	if !bytes.Equal(c.list.RawIssuer, issuer.RawSubject) {
+		return false
+	}
+
+	key := hashRawCert(issuer.Raw)
+	if valid, ok := c.signatures.Load(key); ok {
+		return valid.(bool)
+	}
+
+	valid := c.list.CheckSignatureFrom(issuer) == nil
+	c.signatures.Store(key, valid)
+
+	return valid
+}
+
+// crlSource is a certificate revocation list location, either a file (path or content) or a URL.
+type crlSource struct {
+	file types.FileOrContent
+	url  string
+
+	lock        sync.RWMutex
+	interval    time.Duration
+	nextRefresh time.Time
+	crls        []*crl
+}
+
+func (s *crlSource) String() string {
+	if s.url != "" {
+		return s.url
+	}
+	if s.file.IsPath() {
+		return s.file.String()
+	}
+	return "content"
+}
+
+func (s *crlSource) get() []*crl {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	return s.crls
+}
+
+func (s *crlSource) set(crls []*crl) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.crls = crls
+	s.nextRefresh = time.Now().Add(s.interval)
+}
+
+func (s *crlSource) due(now time.Time) bool {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	return !now.Before(s.nextRefresh)
+}
+
+// setInterval sets the refresh interval, rescheduling the next refresh accordingly.
+func (s *crlSource) setInterval(interval time.Duration) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if !s.nextRefresh.IsZero() {
+		s.nextRefresh = s.nextRefresh.Add(interval - s.interval)
+	}
+	s.interval = interval
+}
+
+// revocationChecker checks the revocation status of client certificates,
+// against certificate revocation lists and OCSP responders.
+type revocationChecker struct {
+	client             *http.Client
+	responderOverrides map[string]string
+	ocspCache          *cache.Cache
+	checksCounter      gokitmetrics.Counter
+
+	lock    sync.RWMutex
+	sources map[string]*crlSource
+
+	forceUpdates chan struct{}
+}
+
+func newRevocationChecker(responderOverrides map[string]string) *revocationChecker {
+	return &revocationChecker{
+		client:             &http.Client{Timeout: 10 * time.Second},
+		responderOverrides: responderOverrides,
+		ocspCache:          cache.New(defaultCacheDuration, 5*time.Minute),
+		sources:            map[string]*crlSource{},
+		forceUpdates:       make(chan struct{}, 1),
+	}
+}
+
+// Run refreshes the certificate revocation lists when they are due.
+func (r *revocationChecker) Run(ctx context.Context) {
+	ticker := time.NewTicker(crlRefreshTick)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case <-r.forceUpdates:
+			r.refreshCRLs(ctx)
+
+		case <-ticker.C:
+			r.refreshCRLs(ctx)
+		}
+	}
+}
+
+// Update sets the certificate revocation lists to maintain from the given TLS options.
+// The lists already known are kept, and the new local ones are loaded right away,
+// while the new remote ones are downloaded in the background.
+func (r *revocationChecker) Update(ctx context.Context, configs map[string]Options) {
+	intervals := make(map[string]time.Duration)
+	sources := make(map[string]*crlSource)
+
+	for _, option := range configs {
+		revocation := option.ClientAuth.Revocation
+		if revocation == nil {
+			continue
+		}
+
+		interval := time.Duration(revocation.RefreshInterval)
+		if interval <= 0 {
+			interval = defaultCRLRefreshInterval
+		}
+
+		for _, file := range revocation.CRLFiles {
+			key := crlSourceKey(file, "")
+			sources[key] = &crlSource{file: file}
+			intervals[key] = minInterval(intervals[key], interval)
+		}
+		for _, crlURL := range revocation.CRLURLs {
+			key := crlSourceKey("", crlURL)
+			sources[key] = &crlSource{url: crlURL}
+			intervals[key] = minInterval(intervals[key], interval)
+		}
+	}
+
+	r.lock.Lock()
+	for key, source := range sources {
+		if existing, ok := r.sources[key]; ok {
+			sources[key] = existing
+			existing.setInterval(intervals[key])
+			continue
+		}
+
+		source.interval = intervals[key]
+		if source.url == "" {
+			r.refreshCRL(ctx, source)
+		}
+	}
+	r.sources = sources
+	r.lock.Unlock()
+
+	r.ForceUpdates()
+}
+
+// ForceUpdates triggers the refresh of the certificate revocation lists in the background.
+func (r *revocationChecker) ForceUpdates() {
+	select {
+	case r.forceUpdates <- struct{}{}:
+	default:
+	}
+}
+
+func (r *revocationChecker) refreshCRLs(ctx context.Context) {
+	r.lock.RLock()
+	sources := make([]*crlSource, 0, len(r.sources))
+	for _, source := range r.sources {
+		sources = append(sources, source)
+	}
+	r.lock.RUnlock()
+
+	now := time.Now()
+	for _, source := range sources {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+		}
+
+		if source.due(now) {
+			r.refreshCRL(ctx, source)
+		}
+	}
+}
+
+// refreshCRL (re)loads the given source, keeping the previous lists on failure.
+// On failure, the source is retried on the next tick.
+func (r *revocationChecker) refreshCRL(ctx context.Context, source *crlSource) {
+	crls, err := r.loadCRL(ctx, source)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msgf("Unable to load certificate revocation list %s", source)
+		return
+	}
+
+	source.set(crls)
+}
+
+func (r *revocationChecker) loadCRL(ctx context.Context, source *crlSource) ([]*crl, error) {
+	if source.url == "" {
+		data, err := source.file.Read()
+		if err != nil {
+			return nil, err
+		}
+
+		return parseCRLs(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, source.url, http.NoBody)
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+
+	res, err := r.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("downloading: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode/100 != 2 {
+		return nil, fmt.Errorf("unexpected status code: %d", res.StatusCode)
+	}
+
+	data, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading body: %w", err)
+	}
+
+	return parseCRLs(data)
+}
+
+// parseCRLs parses the certificate revocation lists contained in PEM encoded data,
+// or the single one contained in DER encoded data.
+func parseCRLs(data []byte) ([]*crl, error) {
+	var ders [][]byte
+
+	rest := data
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+
+		if block.Type == "X509 CRL" {
+			ders = append(ders, block.Bytes)
+		}
+	}
+
+	if len(ders) == 0 {
+		ders = [][]byte{data}
+	}
+
+	var crls []*crl
+	for _, der := range ders {
+		list, err := x509.ParseRevocationList(der)
+		if err != nil {
+			return nil, fmt.Errorf("parsing certificate revocation list: %w", err)
+		}
+
+		revoked := make(map[string]struct{}, len(list.RevokedCertificateEntries))
+		for _, entry := range list.RevokedCertificateEntries {
+			revoked[entry.SerialNumber.String()] = struct{}{}
+		}
+
+		crls = append(crls, &crl{list: list, revoked: revoked})
+	}
+
+	return crls, nil
+}
+
+// VerifyConnection returns a tls.Config VerifyConnection callback,
+// checking the revocation status of the verified client certificate.
+// The intermediate certificates of the chain are only rejected when they are known to be revoked,
+// as the configured revocation sources usually do not cover them.
+func (r *revocationChecker) VerifyConnection(revocation *Revocation) func(tls.ConnectionState) error {
+	var keys []string
+	for _, file := range revocation.CRLFiles {
+		keys = append(keys, crlSourceKey(file, ""))
+	}
+	for _, crlURL := range revocation.CRLURLs {
+		keys = append(keys, crlSourceKey("", crlURL))
+	}
+
+	return func(state tls.ConnectionState) error {
+		if len(state.VerifiedChains) == 0 {
+			return nil
+		}
+
+		chain := state.VerifiedChains[0]
+		if len(chain) < 2 {
+			return nil
+		}
+
+		if err := r.check(revocation, keys, chain[0], chain[1], true); err != nil {
+			return err
+		}
+
+		for i := 1; i < len(chain)-1; i++ {
+			if err := r.check(revocation, keys, chain[i], chain[i+1], false); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
+// check checks the revocation status of the certificate.
+// When strict is false, only a revoked status is an error, whatever the soft-fail policy.
+func (r *revocationChecker) check(revocation *Revocation, keys []string, cert, issuer *x509.Certificate, strict bool) error {
+	var statuses []string
+
+	if len(keys) > 0 {
+		status := r.checkCRL(keys, cert, issuer)
+		r.record(revocationMethodCRL, status)
+		statuses = append(statuses, status)
+	}
+
+	if revocation.OCSP {
+		status := r.checkOCSP(cert, issuer)
+		r.record(revocationMethodOCSP, status)
+		statuses = append(statuses, status)
+	}
+
+	var good bool
+	for _, status := range statuses {
+		switch status {
+		case revocationStatusRevoked:
+			return fmt.Errorf("certificate %q (serial %s) is revoked", cert.Subject, cert.SerialNumber)
+		case revocationStatusGood:
+			good = true
+		}
+	}
+
+	if good || revocation.SoftFail || !strict {
+		return nil
+	}
+
+	return fmt.Errorf("unable to determine the revocation status of certificate %q (serial %s)", cert.Subject, cert.SerialNumber)
+}
+
+// checkCRL checks the certificate against the fresh revocation lists issued by its issuer.
+func (r *revocationChecker) checkCRL(keys []string, cert, issuer *x509.Certificate) string {
+	r.lock.RLock()
+	sources := make([]*crlSource, 0, len(keys))
+	for _, key := range keys {
+		if source, ok := r.sources[key]; ok {
+			sources = append(sources, source)
+		}
+	}
+	r.lock.RUnlock()
+
+	now := time.Now()
+	status := revocationStatusUnknown
+	for _, source := range sources {
+		for _, c := range source.get() {
+			if !c.list.NextUpdate.IsZero() && now.After(c.list.NextUpdate) {
+				continue
+			}
+
+			if !c.signedBy(issuer) {
+				continue
+			}
+
+			if _, ok := c.revoked[cert.SerialNumber.String()]; ok {
+				return revocationStatusRevoked
+			}
+
+			status = revocationStatusGood
+		}
+	}
+
+	return status
+}
+
+// checkOCSP checks the certificate against the OCSP responders it advertises, caching the responses.
+func (r *revocationChecker) checkOCSP(cert, issuer *x509.Certificate) string {
+	key := hashRawCert(cert.Raw)
+	if status, ok := r.ocspCache.Get(key); ok {
+		return status.(string)
+	}
+
+	responders := ocspResponders(cert, r.responderOverrides)
+	if len(responders) == 0 {
+		return revocationStatusUnknown
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), ocspCheckTimeout)
+	defer cancel()
+
+	_, res, err := fetchOCSPResponse(ctx, r.client, cert, issuer, responders)
+	if err != nil {
+		log.Debug().Err(err).Msgf("Unable to check the OCSP status of certificate %q", cert.Subject)
+		r.ocspCache.Set(key, revocationStatusUnknown, ocspFailureCacheDuration)
+		return revocationStatusUnknown
+	}
+
+	var status string
+	switch res.Status {
+	case ocsp.Good:
+		status = revocationStatusGood
+	case ocsp.Revoked:
+		status = revocationStatusRevoked
+	default:
+		status = revocationStatusUnknown
+	}
+
+	ttl := defaultCacheDuration
+	if status != revocationStatusRevoked && !res.NextUpdate.IsZero() {
+		ttl = time.Until(res.NextUpdate)
+	}
+	if ttl > 0 {
+		r.ocspCache.Set(key, status, ttl)
+	}
+
+	return status
+}
+
+func (r *revocationChecker) record(method, status string) {
+	if r.checksCounter == nil {
+		return
+	}
+
+	r.checksCounter.With("method", method, "result", status).Add(1)
+}
+
+func crlSourceKey(file types.FileOrContent, crlURL string) string {
+	if crlURL != "" {
+		return "url:" + crlURL
+	}
+	return "file:" + string(file)
+}
+
+func minInterval(current, interval time.Duration) time.Duration {
+	if current == 0 {
+		return interval
+	}
+	return min(current, interval)
+}
+
+func validateRevocation(revocation *Revocation) error {
+	if len(revocation.CRLFiles) == 0 && len(revocation.CRLURLs) == 0 && !revocation.OCSP {
+		return errors.New("no CRL defined and OCSP disabled")
+	}
+
+	for _, rawURL := range revocation.CRLURLs {
+		u, err := url.Parse(rawURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+			return fmt.Errorf("invalid CRL URL %q", rawURL)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,481 @@
+package tls
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ptypes "github.com/traefik/paerser/types"
+	"github.com/traefik/traefik/v3/pkg/types"
+	"golang.org/x/crypto/ocsp"
+)
+
+func TestRevocationChecker_CRL(t *testing.T) {
+	leafCert, err := tls.X509KeyPair([]byte(certWithOCSPServer), []byte(certKey))
+	require.NoError(t, err)
+
+	issuerCert, err := tls.X509KeyPair([]byte(caCert), []byte(caKey))
+	require.NoError(t, err)
+
+	revokedCRL := createCRL(t, issuerCert, leafCert.Leaf.SerialNumber)
+	emptyCRL := createCRL(t, issuerCert)
+	// The foreign list has the same issuer name, but it is not signed by the certificate issuer.
+	foreignCRL := createCRL(t, createCA(t, issuerCert.Leaf.Subject), leafCert.Leaf.SerialNumber)
+
+	testCases := []struct {
+		desc        string
+		revocation  *Revocation
+		expectError bool
+	}{
+		{
+			desc:        "revoked certificate",
+			revocation:  &Revocation{CRLFiles: []types.FileOrContent{types.FileOrContent(revokedCRL)}},
+			expectError: true,
+		},
+		{
+			desc:       "certificate not revoked",
+			revocation: &Revocation{CRLFiles: []types.FileOrContent{types.FileOrContent(emptyCRL)}},
+		},
+		{
+			desc: "revoked in one of the lists",
+			revocation: &Revocation{CRLFiles: []types.FileOrContent{
+				types.FileOrContent(emptyCRL),
+				types.FileOrContent(revokedCRL),
+			}},
+			expectError: true,
+		},
+		{
+			desc:        "list with an invalid signature",
+			revocation:  &Revocation{CRLFiles: []types.FileOrContent{types.FileOrContent(foreignCRL)}},
+			expectError: true,
+		},
+		{
+			desc: "list with an invalid signature with soft fail",
+			revocation: &Revocation{
+				CRLFiles: []types.FileOrContent{types.FileOrContent(foreignCRL)},
+				SoftFail: true,
+			},
+		},
+		{
+			desc: "revoked certificate with soft fail",
+			revocation: &Revocation{
+				CRLFiles: []types.FileOrContent{types.FileOrContent(revokedCRL)},
+				SoftFail: true,
+			},
+			expectError: true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			checker := newRevocationChecker(nil)
+			checker.Update(t.Context(), map[string]Options{
+				"foo": {ClientAuth: ClientAuth{Revocation: test.revocation}},
+			})
+
+			verify := checker.VerifyConnection(test.revocation)
+			err := verify(tls.ConnectionState{
+				VerifiedChains: [][]*x509.Certificate{{leafCert.Leaf, issuerCert.Leaf}},
+			})
+
+			if test.expectError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestRevocationChecker_CRLURL(t *testing.T) {
+	leafCert, err := tls.X509KeyPair([]byte(certWithOCSPServer), []byte(certKey))
+	require.NoError(t, err)
+
+	issuerCert, err := tls.X509KeyPair([]byte(caCert), []byte(caKey))
+	require.NoError(t, err)
+
+	revokedCRL := createCRL(t, issuerCert, leafCert.Leaf.SerialNumber)
+
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, _ *http.Request) {
+		calls++
+		_, _ = rw.Write(revokedCRL)
+	}))
+	t.Cleanup(server.Close)
+
+	revocation := &Revocation{
+		CRLURLs:         []string{server.URL},
+		RefreshInterval: ptypes.Duration(time.Hour),
+	}
+
+	checker := newRevocationChecker(nil)
+	checker.Update(t.Context(), map[string]Options{
+		"foo": {ClientAuth: ClientAuth{Revocation: revocation}},
+	})
+
+	verify := checker.VerifyConnection(revocation)
+	state := tls.ConnectionState{
+		VerifiedChains: [][]*x509.Certificate{{leafCert.Leaf, issuerCert.Leaf}},
+	}
+
+	// The list has not been downloaded yet.
+	err = verify(state)
+	require.ErrorContains(t, err, "unable to determine the revocation status")
+
+	checker.refreshCRLs(t.Context())
+	assert.Equal(t, 1, calls)
+
+	err = verify(state)
+	require.ErrorContains(t, err, "is revoked")
+
+	// The list is not due for a refresh.
+	checker.refreshCRLs(t.Context())
+	assert.Equal(t, 1, calls)
+
+	// The list is kept across updates.
+	checker.Update(t.Context(), map[string]Options{
+		"bar": {ClientAuth: ClientAuth{Revocation: revocation}},
+	})
+	checker.refreshCRLs(t.Context())
+	assert.Equal(t, 1, calls)
+
+	err = verify(state)
+	require.ErrorContains(t, err, "is revoked")
+}
+
+func TestRevocationChecker_OCSP(t *testing.T) {
+	leafCert, err := tls.X509KeyPair([]byte(certWithOCSPServer), []byte(certKey))
+	require.NoError(t, err)
+
+	issuerCert, err := tls.X509KeyPair([]byte(caCert), []byte(caKey))
+	require.NoError(t, err)
+
+	testCases := []struct {
+		desc        string
+		status      int
+		softFail    bool
+		notOK       bool
+		expectError bool
+	}{
+		{
+			desc:   "good certificate",
+			status: ocsp.Good,
+		},
+		{
+			desc:        "revoked certificate",
+			status:      ocsp.Revoked,
+			expectError: true,
+		},
+		{
+			desc:        "unknown certificate",
+			status:      ocsp.Unknown,
+			expectError: true,
+		},
+		{
+			desc:     "unknown certificate with soft fail",
+			status:   ocsp.Unknown,
+			softFail: true,
+		},
+		{
+			desc:        "unavailable responder",
+			notOK:       true,
+			expectError: true,
+		},
+		{
+			desc:     "unavailable responder with soft fail",
+			notOK:    true,
+			softFail: true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			ocspResponse, err := ocsp.CreateResponse(issuerCert.Leaf, issuerCert.Leaf, ocsp.Response{
+				Status:       test.status,
+				SerialNumber: leafCert.Leaf.SerialNumber,
+				ThisUpdate:   time.Now(),
+				NextUpdate:   time.Now().Add(time.Hour),
+				RevokedAt:    time.Now(),
+			}, issuerCert.PrivateKey.(crypto.Signer))
+			require.NoError(t, err)
+
+			var calls int
+			responder := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, _ *http.Request) {
+				calls++
+				if test.notOK {
+					rw.WriteHeader(http.StatusServiceUnavailable)
+					return
+				}
+
+				rw.Header().Set("Content-Type", "application/ocsp-response")
+				_, _ = rw.Write(ocspResponse)
+			}))
+			t.Cleanup(responder.Close)
+
+			checker := newRevocationChecker(map[string]string{
+				leafCert.Leaf.OCSPServer[0]: responder.URL,
+			})
+
+			verify := checker.VerifyConnection(&Revocation{OCSP: true, SoftFail: test.softFail})
+			state := tls.ConnectionState{
+				VerifiedChains: [][]*x509.Certificate{{leafCert.Leaf, issuerCert.Leaf}},
+			}
+
+			for range 2 {
+				err = verify(state)
+				if test.expectError {
+					assert.Error(t, err)
+				} else {
+					assert.NoError(t, err)
+				}
+			}
+
+			// The second check is served from the cache.
+			assert.Equal(t, 1, calls)
+		})
+	}
+}
+
+func TestRevocationChecker_intermediateCA(t *testing.T) {
+	rootCert := createCA(t, pkix.Name{CommonName: "root"})
+	intermediateCert := createCert(t, pkix.Name{CommonName: "intermediate"}, big.NewInt(2), true, rootCert)
+	leafCert := createCert(t, pkix.Name{CommonName: "leaf"}, big.NewInt(3), false, intermediateCert)
+
+	testCases := []struct {
+		desc        string
+		revocation  *Revocation
+		expectError bool
+	}{
+		{
+			desc: "only the leaf list is configured",
+			revocation: &Revocation{CRLFiles: []types.FileOrContent{
+				types.FileOrContent(createCRL(t, intermediateCert)),
+			}},
+		},
+		{
+			desc: "revoked leaf certificate",
+			revocation: &Revocation{CRLFiles: []types.FileOrContent{
+				types.FileOrContent(createCRL(t, intermediateCert, leafCert.Leaf.SerialNumber)),
+			}},
+			expectError: true,
+		},
+		{
+			desc: "revoked intermediate certificate",
+			revocation: &Revocation{CRLFiles: []types.FileOrContent{
+				types.FileOrContent(createCRL(t, intermediateCert)),
+				types.FileOrContent(createCRL(t, rootCert, intermediateCert.Leaf.SerialNumber)),
+			}},
+			expectError: true,
+		},
+		{
+			desc: "only the intermediate list is configured",
+			revocation: &Revocation{CRLFiles: []types.FileOrContent{
+				types.FileOrContent(createCRL(t, rootCert)),
+			}},
+			expectError: true,
+		},
+		{
+			desc: "only the intermediate list is configured with soft fail",
+			revocation: &Revocation{
+				CRLFiles: []types.FileOrContent{types.FileOrContent(createCRL(t, rootCert))},
+				SoftFail: true,
+			},
+		},
+		{
+			desc: "OCSP without responders for the intermediate certificate",
+			revocation: &Revocation{
+				CRLFiles: []types.FileOrContent{types.FileOrContent(createCRL(t, intermediateCert))},
+				OCSP:     true,
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			checker := newRevocationChecker(nil)
+			checker.Update(t.Context(), map[string]Options{
+				"foo": {ClientAuth: ClientAuth{Revocation: test.revocation}},
+			})
+
+			verify := checker.VerifyConnection(test.revocation)
+			err := verify(tls.ConnectionState{
+				VerifiedChains: [][]*x509.Certificate{{leafCert.Leaf, intermediateCert.Leaf, rootCert.Leaf}},
+			})
+
+			if test.expectError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestRevocationChecker_noVerifiedChains(t *testing.T) {
+	checker := newRevocationChecker(nil)
+
+	verify := checker.VerifyConnection(&Revocation{OCSP: true})
+
+	assert.NoError(t, verify(tls.ConnectionState{}))
+}
+
+func TestBuildTLSConfig_revocation(t *testing.T) {
+	testCases := []struct {
+		desc        string
+		clientAuth  ClientAuth
+		expectError bool
+	}{
+		{
+			desc: "valid revocation",
+			clientAuth: ClientAuth{
+				CAFiles:    []types.FileOrContent{caCert},
+				Revocation: &Revocation{CRLURLs: []string{"https://crl.example.com/ca.crl"}, OCSP: true},
+			},
+		},
+		{
+			desc: "missing CA files",
+			clientAuth: ClientAuth{
+				Revocation: &Revocation{OCSP: true},
+			},
+			expectError: true,
+		},
+		{
+			desc: "no CRL and OCSP disabled",
+			clientAuth: ClientAuth{
+				CAFiles:    []types.FileOrContent{caCert},
+				Revocation: &Revocation{SoftFail: true},
+			},
+			expectError: true,
+		},
+		{
+			desc: "invalid CRL URL",
+			clientAuth: ClientAuth{
+				CAFiles:    []types.FileOrContent{caCert},
+				Revocation: &Revocation{CRLURLs: []string{"ftp://crl.example.com/ca.crl"}},
+			},
+			expectError: true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := buildTLSConfig(Options{ClientAuth: test.clientAuth})
+			if test.expectError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestManager_Get_revocation(t *testing.T) {
+	tlsManager := NewManager(nil)
+	tlsManager.UpdateConfigs(context.Background(), nil, map[string]Options{
+		"foo": {
+			ClientAuth: ClientAuth{
+				CAFiles:    []types.FileOrContent{caCert},
+				Revocation: &Revocation{OCSP: true},
+			},
+		},
+		"bar": {
+			ClientAuth: ClientAuth{
+				CAFiles: []types.FileOrContent{caCert},
+			},
+		},
+	}, nil)
+
+	config, err := tlsManager.Get(DefaultTLSStoreName, "foo")
+	require.NoError(t, err)
+	assert.NotNil(t, config.VerifyConnection)
+
+	config, err = tlsManager.Get(DefaultTLSStoreName, "bar")
+	require.NoError(t, err)
+	assert.Nil(t, config.VerifyConnection)
+}
+
+// createCRL creates a PEM encoded certificate revocation list, issued by the given certificate, revoking the given serials.
+func createCRL(t *testing.T, issuer tls.Certificate, serials ...*big.Int) []byte {
+	t.Helper()
+
+	var entries []x509.RevocationListEntry
+	for _, serial := range serials {
+		entries = append(entries, x509.RevocationListEntry{
+			SerialNumber:   serial,
+			RevocationTime: time.Now().Add(-time.Hour),
+		})
+	}
+
+	der, err := x509.CreateRevocationList(nil, &x509.RevocationList{
+		Number:                    big.NewInt(1),
+		ThisUpdate:                time.Now().Add(-time.Hour),
+		NextUpdate:                time.Now().Add(time.Hour),
+		RevokedCertificateEntries: entries,
+	}, issuer.Leaf, issuer.PrivateKey.(crypto.Signer))
+	require.NoError(t, err)
+
+	return pem.EncodeToMemory(&pem.Block{Type: "X509 CRL", Bytes: der})
+}
+
+// createCA creates a self-signed CA certificate with the given subject.
+func createCA(t *testing.T, subject pkix.Name) tls.Certificate {
+	t.Helper()
+
+	return createCert(t, subject, big.NewInt(1), true, tls.Certificate{})
+}
+
+// createCert creates a certificate with the given subject and serial, issued by the given certificate,
+// or self-signed when the issuer is empty.
+func createCert(t *testing.T, subject pkix.Name, serial *big.Int, isCA bool, issuer tls.Certificate) tls.Certificate {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               subject,
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  isCA,
+	}
+	if isCA {
+		template.KeyUsage = x509.KeyUsageCertSign | x509.KeyUsageCRLSign
+	}
+
+	parent, signer := template, crypto.Signer(key)
+	if issuer.Leaf != nil {
+		parent, signer = issuer.Leaf, issuer.PrivateKey.(crypto.Signer)
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, key.Public(), signer)
+	require.NoError(t, err)
+
+	leaf, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key, Leaf: leaf}
+}
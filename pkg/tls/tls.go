@@ -1,6 +1,9 @@
 package tls
 
-import "github.com/traefik/traefik/v3/pkg/types"
+import (
+	ptypes "github.com/traefik/paerser/types"
+	"github.com/traefik/traefik/v3/pkg/types"
+)
 
 const certificateHeader = "-----BEGIN CERTIFICATE-----\n"
 
@@ -36,6 +39,24 @@ type ClientAuth struct {
 	// ClientAuthType defines the client authentication type to apply.
 	// The available values are: "NoClientCert", "RequestClientCert", "VerifyClientCertIfGiven" and "RequireAndVerifyClientCert".
 	ClientAuthType string `json:"clientAuthType,omitempty" toml:"clientAuthType,omitempty" yaml:"clientAuthType,omitempty" export:"true"`
+	// Revocation defines the revocation checks applied to the verified client certificates.
+	Revocation *Revocation `json:"revocation,omitempty" toml:"revocation,omitempty" yaml:"revocation,omitempty" export:"true"`
+}
+
+// +k8s:deepcopy-gen=true
+
+// Revocation defines how the revocation status of client certificates is checked.
+type Revocation struct {
+	// CRLFiles defines the certificate revocation lists (path or content) to check the client certificates against.
+	CRLFiles []types.FileOrContent `json:"crlFiles,omitempty" toml:"crlFiles,omitempty" yaml:"crlFiles,omitempty"`
+	// CRLURLs defines the URLs from which the certificate revocation lists are downloaded.
+	CRLURLs []string `json:"crlURLs,omitempty" toml:"crlURLs,omitempty" yaml:"crlURLs,omitempty" export:"true"`
+	// RefreshInterval defines how often the certificate revocation lists are reloaded.
+	RefreshInterval ptypes.Duration `json:"refreshInterval,omitempty" toml:"refreshInterval,omitempty" yaml:"refreshInterval,omitempty" export:"true"`
+	// OCSP enables checking the client certificates against the OCSP responders they advertise.
+	OCSP bool `json:"ocsp,omitempty" toml:"ocsp,omitempty" yaml:"ocsp,omitempty" export:"true"`
+	// SoftFail accepts the client certificates whose revocation status cannot be determined.
+	SoftFail bool `json:"softFail,omitempty" toml:"softFail,omitempty" yaml:"softFail,omitempty" export:"true"`
 }
 
 // +k8s:deepcopy-gen=true
@@ -1,6 +1,7 @@
 package tls
 
 import (
+	"cmp"
 	"context"
 	"crypto/tls"
 	"crypto/x509"
@@ -11,11 +12,14 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/go-acme/lego/v4/challenge/dns01"
 	"github.com/go-acme/lego/v4/challenge/tlsalpn01"
+	gokitmetrics "github.com/go-kit/kit/metrics"
 	"github.com/rs/zerolog/log"
 	"github.com/traefik/traefik/v3/pkg/observability/logs"
+	"github.com/traefik/traefik/v3/pkg/safe"
 	"github.com/traefik/traefik/v3/pkg/tls/generate"
 	"github.com/traefik/traefik/v3/pkg/types"
 )
@@ -62,6 +66,8 @@ type Manager struct {
 	// It would likely have been a Configuration listener but this implies that certs are re-parsed.
 	// But this would probably have impact on resource consumption.
 	ocspStapler *ocspStapler
+
+	revocationChecker *revocationChecker
 }
 
 // NewManager creates a new Manager.
@@ -73,17 +79,31 @@ func NewManager(ocspConfig *OCSPConfig) *Manager {
 		},
 	}
 
+	var responderOverrides map[string]string
 	if ocspConfig != nil {
-		manager.ocspStapler = newOCSPStapler(ocspConfig.ResponderOverrides)
+		responderOverrides = ocspConfig.ResponderOverrides
+		manager.ocspStapler = newOCSPStapler(responderOverrides)
 	}
 
+	manager.revocationChecker = newRevocationChecker(responderOverrides)
+
 	return manager
 }
 
+// SetRevocationChecksCounter sets the counter of the client certificate revocation checks.
+// It must be called before the Manager starts serving TLS configurations.
+func (m *Manager) SetRevocationChecksCounter(counter gokitmetrics.Counter) {
+	m.revocationChecker.checksCounter = counter
+}
+
 func (m *Manager) Run(ctx context.Context) {
 	if m.ocspStapler != nil {
-		m.ocspStapler.Run(ctx)
+		safe.Go(func() {
+			m.ocspStapler.Run(ctx)
+		})
 	}
+
+	m.revocationChecker.Run(ctx)
 }
 
 // UpdateConfigs updates the TLS* configuration options.
@@ -100,6 +120,8 @@ func (m *Manager) UpdateConfigs(ctx context.Context, stores map[string]Store, co
 		}
 	}
 
+	m.revocationChecker.Update(ctx, configs)
+
 	m.storesConfig = stores
 	m.certs = certs
 
@@ -238,6 +260,10 @@ func (m *Manager) Get(storeName, configName string) (*tls.Config, error) {
 		return nil, fmt.Errorf("building TLS config: %w", err)
 	}
 
+	if config.ClientAuth.Revocation != nil {
+		tlsConfig.VerifyConnection = m.revocationChecker.VerifyConnection(config.ClientAuth.Revocation)
+	}
+
 	// Option also can bundle store
 	store := m.getStore(configName)
 	if nil == store {
@@ -338,6 +364,80 @@ func (m *Manager) GetServerCertificates() []*x509.Certificate {
 	return certificates
 }
 
+// CertificateInfo describes a certificate of a TLS store.
+type CertificateInfo struct {
+	Store        string    `json:"store" export:"true"`
+	Default      bool      `json:"default,omitempty" export:"true"`
+	CommonName   string    `json:"commonName,omitempty" export:"true"`
+	SANs         []string  `json:"sans,omitempty" export:"true"`
+	Issuer       string    `json:"issuer,omitempty" export:"true"`
+	SerialNumber string    `json:"serialNumber,omitempty" export:"true"`
+	NotBefore    time.Time `json:"notBefore" export:"true"`
+	NotAfter     time.Time `json:"notAfter" export:"true"`
+}
+
+// Certificates returns the inventory of the certificates of all the TLS stores, including their default certificates.
+func (m *Manager) Certificates() []CertificateInfo {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	var certificates []CertificateInfo
+	for storeName, store := range m.stores {
+		if store == nil {
+			continue
+		}
+
+		if store.DynamicCerts != nil && store.DynamicCerts.Get() != nil {
+			for _, cert := range store.DynamicCerts.Get().(map[string]*CertificateData) {
+				if info, ok := newCertificateInfo(storeName, cert); ok {
+					certificates = append(certificates, info)
+				}
+			}
+		}
+
+		if info, ok := newCertificateInfo(storeName, store.DefaultCertificate); ok {
+			info.Default = true
+			certificates = append(certificates, info)
+		}
+	}
+
+	slices.SortFunc(certificates, func(a, b CertificateInfo) int {
+		return cmp.Or(
+			cmp.Compare(a.Store, b.Store),
+			a.NotAfter.Compare(b.NotAfter),
+			cmp.Compare(a.SerialNumber, b.SerialNumber),
+		)
+	})
+
+	return certificates
+}
+
+func newCertificateInfo(storeName string, cert *CertificateData) (CertificateInfo, bool) {
+	if cert == nil || cert.Certificate == nil || len(cert.Certificate.Certificate) == 0 {
+		return CertificateInfo{}, false
+	}
+
+	x509Cert, err := x509.ParseCertificate(cert.Certificate.Certificate[0])
+	if err != nil {
+		return CertificateInfo{}, false
+	}
+
+	sans := slices.Clone(x509Cert.DNSNames)
+	for _, ip := range x509Cert.IPAddresses {
+		sans = append(sans, ip.String())
+	}
+
+	return CertificateInfo{
+		Store:        storeName,
+		CommonName:   x509Cert.Subject.CommonName,
+		SANs:         sans,
+		Issuer:       x509Cert.Issuer.String(),
+		SerialNumber: x509Cert.SerialNumber.String(),
+		NotBefore:    x509Cert.NotBefore,
+		NotAfter:     x509Cert.NotAfter,
+	}, true
+}
+
 // GetStore gets the certificate store of a given name.
 func (m *Manager) GetStore(storeName string) *CertificateStore {
 	m.lock.RLock()
@@ -480,6 +580,16 @@ func buildTLSConfig(tlsOption Options) (*tls.Config, error) {
 		}
 	}
 
+	if tlsOption.ClientAuth.Revocation != nil {
+		if conf.ClientCAs == nil {
+			return nil, errors.New("invalid revocation: CAFiles is required")
+		}
+
+		if err := validateRevocation(tlsOption.ClientAuth.Revocation); err != nil {
+			return nil, fmt.Errorf("invalid revocation: %w", err)
+		}
+	}
+
 	// Set the minimum TLS version if set in the config
 	if minConst, exists := MinVersion[tlsOption.MinVersion]; exists {
 		conf.MinVersion = minConst
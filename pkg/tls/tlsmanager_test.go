@@ -455,3 +455,31 @@ func TestManager_Get_DefaultValues(t *testing.T) {
 		tls.TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305_SHA256,
 	}, config.CipherSuites)
 }
+
+func TestManager_Certificates(t *testing.T) {
+	tlsManager := NewManager(nil)
+	tlsManager.UpdateConfigs(t.Context(), nil, nil, []*CertAndStores{{
+		Certificate: Certificate{
+			CertFile: localhostCert,
+			KeyFile:  localhostKey,
+		},
+	}})
+
+	var dynamicCerts []CertificateInfo
+	for _, cert := range tlsManager.Certificates() {
+		if !cert.Default {
+			dynamicCerts = append(dynamicCerts, cert)
+			continue
+		}
+
+		// The generated default certificate.
+		assert.Equal(t, DefaultTLSStoreName, cert.Store)
+		assert.Equal(t, "TRAEFIK DEFAULT CERT", cert.CommonName)
+	}
+
+	require.Len(t, dynamicCerts, 1)
+	assert.Equal(t, DefaultTLSStoreName, dynamicCerts[0].Store)
+	assert.Equal(t, []string{"example.com", "127.0.0.1", "::1"}, dynamicCerts[0].SANs)
+	assert.Equal(t, "O=Acme Co", dynamicCerts[0].Issuer)
+	assert.Equal(t, time.Date(2084, time.January, 29, 16, 0, 0, 0, time.UTC), dynamicCerts[0].NotAfter)
+}
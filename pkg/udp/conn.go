@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"net"
+	"os"
 	"sync"
 	"time"
 )
@@ -92,6 +93,12 @@ func (l *Listener) Addr() net.Addr {
 	return l.pConn.LocalAddr()
 }
 
+// File returns a copy of the underlying socket file.
+// It is used to hand over the socket to another process.
+func (l *Listener) File() (*os.File, error) {
+	return l.pConn.File()
+}
+
 // Close closes the listener.
 // It is like Shutdown with a zero graceTimeout.
 func (l *Listener) Close() error {